@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/davidterranova/contacts/internal/ports"
+	"github.com/spf13/pflag"
+)
+
+type dbConfig struct {
+	driver string
+	dsn    string
+}
+
+func (c *dbConfig) register(flags *pflag.FlagSet) {
+	flags.StringVar(&c.driver, "db-driver", ports.DriverSQLite, "sql database driver (sqlite3|postgres)")
+	flags.StringVar(&c.dsn, "db-dsn", "contacts.db", "sql database data source name")
+}
+
+func (c *dbConfig) open(ctx context.Context) (*sql.DB, error) {
+	return ports.OpenSQL(ctx, c.driver, c.dsn)
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	ihttp "github.com/davidterranova/contacts/internal/adapters/http"
+	"github.com/davidterranova/contacts/pkg/vcard"
+	"github.com/spf13/cobra"
+)
+
+const (
+	formatCSV   = "csv"
+	formatVCard = "vcard"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "imports contacts from a csv or vcard file through the http api",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runImport,
+}
+
+var importFlags struct {
+	format        string
+	url           string
+	username      string
+	password      string
+	addressBookId string
+	mapping       map[string]string
+	dryRun        bool
+	timeout       time.Duration
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	contentTypes := map[string]string{
+		formatCSV:   "text/csv",
+		formatVCard: vcard.MediaType,
+	}
+	contentType, ok := contentTypes[importFlags.format]
+	if !ok {
+		return fmt.Errorf("invalid format %q, expected %s or %s", importFlags.format, formatCSV, formatVCard)
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	query := url.Values{"format": {importFlags.format}}
+	if importFlags.dryRun {
+		query.Set("dry_run", "true")
+	}
+	if importFlags.addressBookId != "" {
+		query.Set("address_book_id", importFlags.addressBookId)
+	}
+	if len(importFlags.mapping) > 0 {
+		mapping, err := json.Marshal(importFlags.mapping)
+		if err != nil {
+			return err
+		}
+		query.Set("mapping", string(mapping))
+	}
+
+	endpoint, err := url.JoinPath(importFlags.url, "/v1/contacts/import")
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(cmd.Context(), http.MethodPost, endpoint+"?"+query.Encode(), file)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.SetBasicAuth(importFlags.username, importFlags.password)
+
+	client := http.Client{Timeout: importFlags.timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// the errors are reported by the api, not by the usage of the command
+	cmd.SilenceUsage = true
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Message string `json:"message"`
+			Error   string `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+		return fmt.Errorf("import failed with status %d: %s: %s", resp.StatusCode, apiErr.Message, apiErr.Error)
+	}
+
+	var report ihttp.ImportReport
+	err = json.NewDecoder(resp.Body).Decode(&report)
+	if err != nil {
+		return fmt.Errorf("failed to decode import report: %w", err)
+	}
+
+	err = printImportReport(report)
+	if err != nil {
+		return err
+	}
+	if report.Failed > 0 {
+		return fmt.Errorf("%d contacts failed to import", report.Failed)
+	}
+
+	return nil
+}
+
+// printImportReport lists the skipped and failed contacts followed by the count of each status
+func printImportReport(report ihttp.ImportReport) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if report.Skipped+report.Failed > 0 {
+		position := "CARD"
+		if importFlags.format == formatCSV {
+			position = "ROW"
+		}
+		fmt.Fprintf(w, "%s\tSTATUS\tREASON\n", position)
+	}
+	for _, result := range report.Results {
+		if result.Reason == "" {
+			continue
+		}
+
+		position := result.Card
+		if importFlags.format == formatCSV {
+			position = result.Row
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", position, result.Status, result.Reason)
+	}
+	err := w.Flush()
+	if err != nil {
+		return err
+	}
+
+	if importFlags.dryRun {
+		fmt.Printf("dry run: %d valid, %d skipped, %d failed\n", report.Valid, report.Skipped, report.Failed)
+		return nil
+	}
+	fmt.Printf("%d created, %d skipped, %d failed\n", report.Created, report.Skipped, report.Failed)
+
+	return nil
+}
+
+func init() {
+	importCmd.Flags().StringVar(&importFlags.format, "format", formatCSV, "format of the imported file (csv|vcard)")
+	importCmd.Flags().StringVar(&importFlags.url, "url", "http://localhost:8080", "url of the contacts http api")
+	importCmd.Flags().StringVar(&importFlags.username, "username", "", "basic auth username")
+	importCmd.Flags().StringVar(&importFlags.password, "password", "", "basic auth password")
+	importCmd.Flags().StringVar(&importFlags.addressBookId, "address-book-id", "", "imports the contacts in this address book")
+	importCmd.Flags().StringToStringVar(&importFlags.mapping, "mapping", nil, "csv header columns to contact fields (first_name|last_name|email|phone), e.g. \"Given Name=first_name,Mobile=phone\"")
+	importCmd.Flags().BoolVar(&importFlags.dryRun, "dry-run", false, "checks the contacts without creating them")
+	importCmd.Flags().DurationVar(&importFlags.timeout, "timeout", time.Minute, "timeout of the import request")
+
+	rootCmd.AddCommand(importCmd)
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/davidterranova/contacts/internal/ports"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var migrateDB dbConfig
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "manages the sql database schema",
+}
+
+var migrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "applies all pending migrations",
+	RunE:  runMigrateUp,
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:   "down",
+	Short: "reverts the last applied migrations",
+	RunE:  runMigrateDown,
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "prints the migrations status",
+	RunE:  runMigrateStatus,
+}
+
+var migrateDownSteps int
+
+func migrator(cmd *cobra.Command) (*ports.Migrator, func() error, error) {
+	db, err := migrateDB.open(cmd.Context())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m, err := ports.NewMigrator(db, migrateDB.driver)
+	if err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+
+	return m, db.Close, nil
+}
+
+// migrateUp applies the pending migrations of an already opened database
+func migrateUp(ctx context.Context, db *sql.DB, driver string) error {
+	m, err := ports.NewMigrator(db, driver)
+	if err != nil {
+		return err
+	}
+
+	migrations, err := m.Up(ctx)
+	for _, migration := range migrations {
+		log.Info().Int("version", migration.Version).Str("name", migration.Name).Msg("applied migration")
+	}
+
+	return err
+}
+
+func runMigrateUp(cmd *cobra.Command, args []string) error {
+	m, closeDB, err := migrator(cmd)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	migrations, err := m.Up(cmd.Context())
+	for _, migration := range migrations {
+		fmt.Printf("applied %04d_%s\n", migration.Version, migration.Name)
+	}
+	if err != nil {
+		return err
+	}
+	if len(migrations) == 0 {
+		fmt.Println("no pending migration")
+	}
+
+	return nil
+}
+
+func runMigrateDown(cmd *cobra.Command, args []string) error {
+	m, closeDB, err := migrator(cmd)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	migrations, err := m.Down(cmd.Context(), migrateDownSteps)
+	for _, migration := range migrations {
+		fmt.Printf("reverted %04d_%s\n", migration.Version, migration.Name)
+	}
+	if err != nil {
+		return err
+	}
+	if len(migrations) == 0 {
+		fmt.Println("no applied migration")
+	}
+
+	return nil
+}
+
+func runMigrateStatus(cmd *cobra.Command, args []string) error {
+	m, closeDB, err := migrator(cmd)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	statuses, err := m.Status(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format("2006-01-02T15:04:05Z")
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+	}
+
+	return w.Flush()
+}
+
+func init() {
+	migrateDB.register(migrateCmd.PersistentFlags())
+	migrateDownCmd.Flags().IntVar(&migrateDownSteps, "steps", 1, "number of migrations to revert")
+
+	migrateCmd.AddCommand(migrateUpCmd, migrateDownCmd, migrateStatusCmd)
+	rootCmd.AddCommand(migrateCmd)
+}
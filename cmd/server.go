@@ -2,19 +2,24 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/davidterranova/contacts/internal"
 	"github.com/davidterranova/contacts/internal/adapters/graphql"
 	lgrpc "github.com/davidterranova/contacts/internal/adapters/grpc"
+	"github.com/davidterranova/contacts/internal/domain"
 
 	ihttp "github.com/davidterranova/contacts/internal/adapters/http"
 	"github.com/davidterranova/contacts/internal/ports"
+	"github.com/davidterranova/contacts/internal/usecase"
 	"github.com/davidterranova/contacts/pkg/xgrpc"
 	"github.com/davidterranova/contacts/pkg/xhttp"
 	"github.com/gorilla/mux"
@@ -23,21 +28,81 @@ import (
 	"google.golang.org/grpc"
 )
 
+const (
+	storeMemory = "memory"
+	storeFile   = "file"
+	storeSQL    = "sql"
+)
+
 var serverCmd = &cobra.Command{
 	Use:   "server",
 	Short: "starts contacts server",
 	Run:   runServer,
 }
 
+var serverFlags struct {
+	store         string
+	dataDir       string
+	snapshotEvery int
+	db            dbConfig
+	autoMigrate   bool
+	uniqueEmail   bool
+	uniquePhone   bool
+
+	trashRetention time.Duration
+	purgeInterval  time.Duration
+
+	eventsInterval    time.Duration
+	eventsBatchSize   int
+	eventsMaxAttempts int
+
+	webhookInterval    time.Duration
+	webhookBatchSize   int
+	webhookMaxAttempts int
+	webhookBackoff     time.Duration
+	webhookMaxBackoff  time.Duration
+	webhookTimeout     time.Duration
+
+	emailMXCheck         bool
+	emailResolverTimeout time.Duration
+	disposableDomains    string
+
+	graphqlComplexityLimit int
+}
+
 func runServer(cmd *cobra.Command, args []string) {
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer cancel()
 
-	app := internal.New(ports.NewInMemoryContactRepository())
+	stores, err := openStores(ctx)
+	if err != nil {
+		log.Fatal().Err(err).Str("store", serverFlags.store).Msg("failed to open contacts store")
+	}
+	defer func() {
+		err := stores.close()
+		if err != nil {
+			log.Error().Err(err).Msg("failed to close contacts store")
+		}
+	}()
+
+	emails, err := emailVerifier()
+	if err != nil {
+		log.Fatal().Err(err).Str("disposable-domains", serverFlags.disposableDomains).Msg("failed to load disposable domains")
+	}
+
+	bus := ports.NewInProcessEventBus()
+	bus.Subscribe(logEvent)
+
+	app := internal.New(stores.contacts, stores.books, stores.audit, stores.schemas, stores.groups, stores.outbox, bus, stores.webhooks, ports.NewHTTPWebhookSender(serverFlags.webhookTimeout), emails)
+	bus.Subscribe(app.EnqueueWebhookDeliveries)
+	bus.Subscribe(app.NotifyContactWatchers)
 
 	go gqlAPIServer(ctx, app)
 	go httpAPIServer(ctx, app)
 	go grpcServer(ctx, app)
+	go trashPurger(ctx, app)
+	go eventRelay(ctx, app)
+	go webhookDispatcher(ctx, app)
 
 	signalCh := make(chan os.Signal, 1)
 	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
@@ -48,6 +113,137 @@ func runServer(cmd *cobra.Command, args []string) {
 	}
 }
 
+// stores are the repositories backed by the configured store, the contact repository is also the events outbox
+type stores struct {
+	contacts usecase.ContactRepository
+	books    usecase.AddressBookRepository
+	audit    usecase.AuditRepository
+	schemas  usecase.ContactSchemaRepository
+	groups   usecase.GroupRepository
+	outbox   usecase.EventOutbox
+	webhooks usecase.WebhookRepository
+	close    func() error
+}
+
+// contactConstraints are the uniqueness constraints the contact repository enforces
+func contactConstraints() []ports.ContactConstraint {
+	var constraints []ports.ContactConstraint
+	if serverFlags.uniqueEmail {
+		constraints = append(constraints, ports.WithUniqueEmail())
+	}
+	if serverFlags.uniquePhone {
+		constraints = append(constraints, ports.WithUniquePhone())
+	}
+
+	return constraints
+}
+
+// emailVerifier rejects the emails of the disposable domains file if any, and checks the mail servers of the email
+// domains when enabled
+func emailVerifier() (usecase.EmailVerifier, error) {
+	var resolver usecase.DomainResolver
+	if serverFlags.emailMXCheck {
+		resolver = ports.NewDNSDomainResolver(serverFlags.emailResolverTimeout)
+	}
+	if serverFlags.disposableDomains == "" {
+		return usecase.NewEmailVerifier(resolver, nil), nil
+	}
+
+	domains, err := ports.LoadDisposableDomains(serverFlags.disposableDomains)
+	if err != nil {
+		return usecase.EmailVerifier{}, err
+	}
+
+	return usecase.NewEmailVerifier(resolver, domains), nil
+}
+
+func openStores(ctx context.Context) (*stores, error) {
+	switch serverFlags.store {
+	case storeMemory:
+		contacts := ports.NewInMemoryContactRepository(contactConstraints()...)
+		return &stores{
+			contacts: contacts,
+			books:    ports.NewInMemoryAddressBookRepository(contacts),
+			audit:    ports.NewInMemoryAuditRepository(contacts),
+			schemas:  ports.NewInMemoryContactSchemaRepository(),
+			groups:   ports.NewInMemoryGroupRepository(contacts),
+			outbox:   contacts,
+			webhooks: ports.NewInMemoryWebhookRepository(),
+			close:    func() error { return nil },
+		}, nil
+	case storeFile:
+		store, err := ports.OpenFileStore(serverFlags.dataDir, ports.WithSnapshotEvery(serverFlags.snapshotEvery))
+		if err != nil {
+			return nil, err
+		}
+		contacts := ports.NewFileContactRepository(store, contactConstraints()...)
+		return &stores{
+			contacts: contacts,
+			books:    ports.NewFileAddressBookRepository(store),
+			audit:    ports.NewFileAuditRepository(store),
+			schemas:  ports.NewFileContactSchemaRepository(store),
+			groups:   ports.NewFileGroupRepository(store),
+			outbox:   contacts,
+			webhooks: ports.NewFileWebhookRepository(store),
+			close:    store.Close,
+		}, nil
+	case storeSQL:
+		db, err := serverFlags.db.open(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if serverFlags.autoMigrate {
+			err = migrateUp(ctx, db, serverFlags.db.driver)
+			if err != nil {
+				db.Close()
+				return nil, err
+			}
+		}
+		contacts, err := ports.NewSQLContactRepository(db, serverFlags.db.driver, contactConstraints()...)
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+		books, err := ports.NewSQLAddressBookRepository(db, serverFlags.db.driver)
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+		audit, err := ports.NewSQLAuditRepository(db, serverFlags.db.driver)
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+		schemas, err := ports.NewSQLContactSchemaRepository(db, serverFlags.db.driver)
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+		groups, err := ports.NewSQLGroupRepository(db, serverFlags.db.driver)
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+		webhooks, err := ports.NewSQLWebhookRepository(db, serverFlags.db.driver)
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+		return &stores{
+			contacts: contacts,
+			books:    books,
+			audit:    audit,
+			schemas:  schemas,
+			groups:   groups,
+			outbox:   contacts,
+			webhooks: webhooks,
+			close:    db.Close,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown store %q", serverFlags.store)
+	}
+}
+
 func httpAPIServer(ctx context.Context, app *internal.App) {
 	router := ihttp.New(
 		app,
@@ -65,10 +261,12 @@ func gqlAPIServer(ctx context.Context, app *internal.App) {
 	srv := handler.NewDefaultServer(
 		graphql.NewExecutableSchema(
 			graphql.Config{
-				Resolvers: graphql.NewResolver(app),
+				Resolvers:  graphql.NewResolver(app),
+				Complexity: graphql.NewComplexity(),
 			},
 		),
 	)
+	srv.Use(extension.FixedComplexityLimit(serverFlags.graphqlComplexityLimit))
 	root := mux.NewRouter()
 	root.Handle(
 		"/query",
@@ -95,6 +293,9 @@ func grpcServer(ctx context.Context, app *internal.App) {
 		grpc.UnaryInterceptor(
 			xgrpc.GrantAnyFn(),
 		),
+		grpc.StreamInterceptor(
+			xgrpc.GrantAnyStreamFn(),
+		),
 	}
 	grpcServer := grpc.NewServer(opts...)
 	lgrpc.RegisterContactsServer(grpcServer, lgrpc.NewHandler(app))
@@ -105,6 +306,109 @@ func grpcServer(ctx context.Context, app *internal.App) {
 	}
 }
 
+// trashPurger permanently removes the contacts kept in the trash for longer than the retention period
+func trashPurger(ctx context.Context, app *internal.App) {
+	ticker := time.NewTicker(serverFlags.purgeInterval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := app.PurgeTrash(ctx, usecase.CmdPurgeTrash{Retention: serverFlags.trashRetention})
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("failed to purge trash")
+		} else if purged > 0 {
+			log.Ctx(ctx).Info().Int("purged", purged).Msg("purged trash")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// eventRelay publishes the events waiting in the contacts outbox
+func eventRelay(ctx context.Context, app *internal.App) {
+	ticker := time.NewTicker(serverFlags.eventsInterval)
+	defer ticker.Stop()
+
+	for {
+		_, err := app.PublishEvents(ctx, usecase.CmdPublishEvents{BatchSize: serverFlags.eventsBatchSize, MaxAttempts: serverFlags.eventsMaxAttempts})
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("failed to publish contact events")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// webhookDispatcher attempts the due webhook deliveries
+func webhookDispatcher(ctx context.Context, app *internal.App) {
+	ticker := time.NewTicker(serverFlags.webhookInterval)
+	defer ticker.Stop()
+
+	cmd := usecase.CmdDeliverWebhooks{
+		BatchSize:   serverFlags.webhookBatchSize,
+		MaxAttempts: serverFlags.webhookMaxAttempts,
+		Backoff:     serverFlags.webhookBackoff,
+		MaxBackoff:  serverFlags.webhookMaxBackoff,
+	}
+	for {
+		_, err := app.DeliverWebhooks(ctx, cmd)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("failed to deliver webhooks")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// logEvent is the default subscriber of the event bus
+func logEvent(ctx context.Context, event domain.Event) error {
+	log.Ctx(ctx).Info().
+		Str("event", event.EventName()).
+		Str("event_id", event.EventId().String()).
+		Str("contact_id", event.AggregateId().String()).
+		Time("occurred_at", event.OccurredAt()).
+		Msg("contact event")
+
+	return nil
+}
+
 func init() {
+	serverCmd.Flags().StringVar(&serverFlags.store, "store", storeMemory, "contacts storage backend (memory|file|sql)")
+	serverCmd.Flags().StringVar(&serverFlags.dataDir, "data-dir", "data", "data directory of the file store")
+	serverCmd.Flags().IntVar(&serverFlags.snapshotEvery, "snapshot-every", ports.DefaultSnapshotEvery, "number of write-ahead log records between file store snapshots")
+	serverFlags.db.register(serverCmd.Flags())
+	serverCmd.Flags().BoolVar(&serverFlags.autoMigrate, "auto-migrate", false, "applies pending sql migrations on startup")
+	serverCmd.Flags().BoolVar(&serverFlags.uniqueEmail, "unique-email", false, "rejects contacts whose email is already used by another contact of their owner")
+	serverCmd.Flags().BoolVar(&serverFlags.uniquePhone, "unique-phone", false, "rejects contacts whose phone is already used by another contact of their owner")
+	serverCmd.Flags().DurationVar(&serverFlags.trashRetention, "trash-retention", 30*24*time.Hour, "how long deleted contacts are kept in the trash before being purged")
+	serverCmd.Flags().DurationVar(&serverFlags.purgeInterval, "purge-interval", time.Hour, "interval between trash purges")
+	serverCmd.Flags().DurationVar(&serverFlags.eventsInterval, "events-interval", time.Second, "interval between contact events publications")
+	serverCmd.Flags().IntVar(&serverFlags.eventsBatchSize, "events-batch-size", 100, "number of contact events read at once from the outbox")
+	serverCmd.Flags().IntVar(&serverFlags.eventsMaxAttempts, "events-max-attempts", 8, "number of failed publications after which a contact event is dead")
+
+	serverCmd.Flags().DurationVar(&serverFlags.webhookInterval, "webhook-interval", 5*time.Second, "interval between webhook deliveries")
+	serverCmd.Flags().IntVar(&serverFlags.webhookBatchSize, "webhook-batch-size", 100, "number of webhook deliveries attempted at once")
+	serverCmd.Flags().IntVar(&serverFlags.webhookMaxAttempts, "webhook-max-attempts", 8, "number of failed attempts after which a webhook delivery is dead")
+	serverCmd.Flags().DurationVar(&serverFlags.webhookBackoff, "webhook-backoff", 30*time.Second, "delay before retrying a failed webhook delivery, doubled on every attempt")
+	serverCmd.Flags().DurationVar(&serverFlags.webhookMaxBackoff, "webhook-max-backoff", time.Hour, "maximum delay between webhook delivery attempts")
+	serverCmd.Flags().DurationVar(&serverFlags.webhookTimeout, "webhook-timeout", 10*time.Second, "timeout of a webhook delivery attempt")
+
+	serverCmd.Flags().BoolVar(&serverFlags.emailMXCheck, "email-mx-check", false, "checks that the email domains have a mail server, setting the contacts email status")
+	serverCmd.Flags().DurationVar(&serverFlags.emailResolverTimeout, "email-resolver-timeout", 2*time.Second, "timeout of the email domain lookups")
+	serverCmd.Flags().StringVar(&serverFlags.disposableDomains, "disposable-domains", "", "file of the disposable email domains to reject, one per line")
+
+	serverCmd.Flags().IntVar(&serverFlags.graphqlComplexityLimit, "graphql-complexity-limit", 10000, "maximum complexity of a graphQL query, the lists of contacts multiplying the complexity of their fields")
+
 	rootCmd.AddCommand(serverCmd)
 }
@@ -0,0 +1,40 @@
+package graphql
+
+import (
+	"github.com/davidterranova/contacts/internal/adapters/graphql/model"
+	"github.com/davidterranova/contacts/internal/usecase"
+)
+
+// relationshipsComplexity is the number of relationships a contact is expected to have, the relationships multiplying
+// the complexity of their selection so that deep traversals reach the complexity limit
+const relationshipsComplexity = 10
+
+// NewComplexity returns the complexity of the fields resolving lists of contacts, the other fields costing 1 plus the
+// complexity of their selection
+func NewComplexity() ComplexityRoot {
+	var complexity ComplexityRoot
+	complexity.Contact.Relationships = func(childComplexity int) int {
+		return relationshipsComplexity * childComplexity
+	}
+	complexity.Contact.RelatedBy = func(childComplexity int) int {
+		return relationshipsComplexity * childComplexity
+	}
+	complexity.Query.ListContacts = func(childComplexity int, _ *model.ContactFilter, _ *model.ContactSort, first *int, _ *string) int {
+		return pageComplexity(childComplexity, first)
+	}
+	complexity.Query.ListTrash = func(childComplexity int, _ *model.ContactFilter, _ *model.ContactSort, first *int, _ *string) int {
+		return pageComplexity(childComplexity, first)
+	}
+
+	return complexity
+}
+
+// pageComplexity multiplies the complexity of the selection of a page by its size
+func pageComplexity(childComplexity int, first *int) int {
+	size := usecase.DefaultPageSize
+	if first != nil && *first > 0 {
+		size = *first
+	}
+
+	return size * childComplexity
+}
@@ -2,19 +2,923 @@
 
 package model
 
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type Actor struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+type AddressBook struct {
+	ID        string    `json:"id"`
+	CreatedAt string    `json:"createdAt"`
+	UpdatedAt string    `json:"updatedAt"`
+	CreatedBy string    `json:"createdBy"`
+	Name      string    `json:"name"`
+	Members   []*Member `json:"members"`
+}
+
+type AuditEntry struct {
+	ID      string         `json:"id"`
+	Actor   *Actor         `json:"actor"`
+	Action  AuditAction    `json:"action"`
+	At      string         `json:"at"`
+	Changes []*FieldChange `json:"changes"`
+}
+
+// BatchError is the error the mutation of the single contact would fail with
+type BatchError struct {
+	Message string `json:"message"`
+	Code    string `json:"code"`
+}
+
+type BatchResult struct {
+	Status BatchStatus `json:"status"`
+	// contact is set once applied
+	Contact *Contact    `json:"contact,omitempty"`
+	Error   *BatchError `json:"error,omitempty"`
+}
+
 type Contact struct {
 	ID        string `json:"id"`
 	CreatedAt string `json:"createdAt"`
 	UpdatedAt string `json:"updatedAt"`
+	// version is incremented on every update
+	Version   int    `json:"version"`
 	FirstName string `json:"firstName"`
 	LastName  string `json:"lastName"`
-	Phone     string `json:"phone"`
-	Email     string `json:"email"`
+	// phone and email are the primary ones of phones and emails
+	Phone string `json:"phone"`
+	Email string `json:"email"`
+	// emailStatus is the deliverability of the primary email
+	EmailStatus EmailStatus      `json:"emailStatus"`
+	Emails      []*EmailAddress  `json:"emails"`
+	Phones      []*PhoneNumber   `json:"phones"`
+	Addresses   []*PostalAddress `json:"addresses"`
+	// customFields are numbers for number fields and strings for the other types
+	CustomFields map[string]interface{} `json:"customFields"`
+	Shares       []*Share               `json:"shares"`
+	// addressBookId is null for personal contacts
+	AddressBookID *string `json:"addressBookId,omitempty"`
+	// groupIds are the groups of the contact, sorted
+	GroupIds []string `json:"groupIds"`
+	// deletedAt is set while the contact is in the trash
+	DeletedAt *string `json:"deletedAt,omitempty"`
+	// history lists the changes made to the contact from the oldest to the most recent
+	History []*AuditEntry `json:"history"`
+	// relationships link the contact to other contacts, their contact is the related one and can be traversed in turn
+	Relationships []*Relationship `json:"relationships"`
+	// relatedBy are the relationships of other contacts to the contact, their contact is the relating one
+	RelatedBy []*Relationship `json:"relatedBy"`
+}
+
+// ContactChange notifies a change of a contact, along with the contact as it is when the change is sent
+type ContactChange struct {
+	Event      ContactEvent `json:"event"`
+	OccurredAt string       `json:"occurredAt"`
+	Contact    *Contact     `json:"contact"`
+}
+
+type ContactConnection struct {
+	Edges    []*ContactEdge `json:"edges"`
+	PageInfo *PageInfo      `json:"pageInfo"`
+}
+
+type ContactDeletion struct {
+	ID      string `json:"id"`
+	Version *int   `json:"version,omitempty"`
+}
+
+type ContactEdge struct {
+	Cursor string   `json:"cursor"`
+	Node   *Contact `json:"node"`
+}
+
+type ContactFilter struct {
+	AddressBookID *string `json:"addressBookId,omitempty"`
+	// groupId restricts the list to the contacts of a group
+	GroupID      *string             `json:"groupId,omitempty"`
+	Search       *string             `json:"search,omitempty"`
+	FirstName    *StringMatch        `json:"firstName,omitempty"`
+	LastName     *StringMatch        `json:"lastName,omitempty"`
+	Email        *StringMatch        `json:"email,omitempty"`
+	Phone        *StringMatch        `json:"phone,omitempty"`
+	CustomFields []*CustomFieldMatch `json:"customFields,omitempty"`
+	CreatedAt    *DateRange          `json:"createdAt,omitempty"`
+	UpdatedAt    *DateRange          `json:"updatedAt,omitempty"`
+}
+
+type ContactSchema struct {
+	OwnerID string `json:"ownerId"`
+	// updatedAt is null until the user defines custom fields
+	UpdatedAt *string        `json:"updatedAt,omitempty"`
+	Fields    []*CustomField `json:"fields"`
+	// defaultPhoneRegion is the region the phones of the user contacts are read in when they have no international prefix, null when none
+	DefaultPhoneRegion *string `json:"defaultPhoneRegion,omitempty"`
+}
+
+type ContactSort struct {
+	Field     ContactSortField `json:"field"`
+	Direction *SortDirection   `json:"direction,omitempty"`
+}
+
+type ContactUpdate struct {
+	ID      string      `json:"id"`
+	Input   *NewContact `json:"input"`
+	Version *int        `json:"version,omitempty"`
+}
+
+// CustomField is a custom field definition, min and max bound numbers and the length of strings and urls
+type CustomField struct {
+	Name     string    `json:"name"`
+	Type     FieldType `json:"type"`
+	Required bool      `json:"required"`
+	Min      *float64  `json:"min,omitempty"`
+	Max      *float64  `json:"max,omitempty"`
+	// pattern is a regular expression string values must match
+	Pattern string `json:"pattern"`
+	// options are the values allowed by enum fields
+	Options []string `json:"options"`
+}
+
+type CustomFieldInput struct {
+	Name     string    `json:"name"`
+	Type     FieldType `json:"type"`
+	Required *bool     `json:"required,omitempty"`
+	Min      *float64  `json:"min,omitempty"`
+	Max      *float64  `json:"max,omitempty"`
+	Pattern  *string   `json:"pattern,omitempty"`
+	Options  []string  `json:"options,omitempty"`
+}
+
+type CustomFieldMatch struct {
+	Name  string     `json:"name"`
+	Value string     `json:"value"`
+	Mode  *MatchMode `json:"mode,omitempty"`
+}
+
+type DateRange struct {
+	From *string `json:"from,omitempty"`
+	To   *string `json:"to,omitempty"`
+}
+
+type EmailAddress struct {
+	Label   Label  `json:"label"`
+	Address string `json:"address"`
+	Primary bool   `json:"primary"`
+}
+
+// a single email, phone or postal address of a list is primary, the first one is when none is
+type EmailAddressInput struct {
+	Label   *Label `json:"label,omitempty"`
+	Address string `json:"address"`
+	Primary *bool  `json:"primary,omitempty"`
+}
+
+// FieldChange holds the values of a field before and after a change, empty when the field is not set
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// Group tags contacts for its creator, it is only visible to them
+type Group struct {
+	ID        string `json:"id"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"updatedAt"`
+	CreatedBy string `json:"createdBy"`
+	Name      string `json:"name"`
+}
+
+type Member struct {
+	UserID  string `json:"userId"`
+	Role    Role   `json:"role"`
+	AddedAt string `json:"addedAt"`
+}
+
+type NewAddressBook struct {
+	Name string `json:"name"`
 }
 
 type NewContact struct {
 	FirstName string `json:"firstName"`
 	LastName  string `json:"lastName"`
-	Phone     string `json:"phone"`
-	Email     string `json:"email"`
+	// phone and email are made primary, they can be left empty when phones and emails are set
+	Phone string `json:"phone"`
+	Email string `json:"email"`
+	// emails, phones and addresses replace the lists of the contact on update when they are not empty
+	Emails    []*EmailAddressInput  `json:"emails,omitempty"`
+	Phones    []*PhoneNumberInput   `json:"phones,omitempty"`
+	Addresses []*PostalAddressInput `json:"addresses,omitempty"`
+	// customFields are checked against the contact schema of the creator, a null value removes the field on update
+	CustomFields map[string]interface{} `json:"customFields,omitempty"`
+	// addressBookId creates the contact in an address book, it is ignored on update
+	AddressBookID *string `json:"addressBookId,omitempty"`
+	// phoneRegion is the ISO 3166-1 alpha-2 code of the region phones without international prefix are read in, the default phone region of the contact creator when empty
+	PhoneRegion string `json:"phoneRegion"`
+}
+
+type NewGroup struct {
+	Name string `json:"name"`
+}
+
+type PageInfo struct {
+	HasNextPage bool    `json:"hasNextPage"`
+	EndCursor   *string `json:"endCursor,omitempty"`
+}
+
+type PhoneNumber struct {
+	Label Label `json:"label"`
+	// number is in E.164 format
+	Number  string `json:"number"`
+	Primary bool   `json:"primary"`
+	// national and international are the formats of the number, national and type are null for the numbers of the country codes without numbering plan
+	National      *string    `json:"national,omitempty"`
+	International *string    `json:"international,omitempty"`
+	Type          *PhoneType `json:"type,omitempty"`
+}
+
+type PhoneNumberInput struct {
+	Label   *Label `json:"label,omitempty"`
+	Number  string `json:"number"`
+	Primary *bool  `json:"primary,omitempty"`
+}
+
+type PostalAddress struct {
+	Label      Label  `json:"label"`
+	Street     string `json:"street"`
+	Locality   string `json:"locality"`
+	Region     string `json:"region"`
+	PostalCode string `json:"postalCode"`
+	// country is an ISO 3166-1 alpha-2 code
+	Country string `json:"country"`
+	Primary bool   `json:"primary"`
+}
+
+type PostalAddressInput struct {
+	Label      *Label  `json:"label,omitempty"`
+	Street     string  `json:"street"`
+	Locality   string  `json:"locality"`
+	Region     *string `json:"region,omitempty"`
+	PostalCode *string `json:"postalCode,omitempty"`
+	Country    *string `json:"country,omitempty"`
+	Primary    *bool   `json:"primary,omitempty"`
+}
+
+// Relationship is a directional link between two contacts, the contacts the user cannot read are left out
+type Relationship struct {
+	Type      RelationshipType `json:"type"`
+	OnDelete  OnDelete         `json:"onDelete"`
+	CreatedAt string           `json:"createdAt"`
+	Contact   *Contact         `json:"contact"`
+}
+
+type Share struct {
+	UserID     string     `json:"userId"`
+	Permission Permission `json:"permission"`
+	GrantedAt  string     `json:"grantedAt"`
+}
+
+type StringMatch struct {
+	Value string     `json:"value"`
+	Mode  *MatchMode `json:"mode,omitempty"`
+}
+
+type AuditAction string
+
+const (
+	AuditActionCreate   AuditAction = "CREATE"
+	AuditActionUpdate   AuditAction = "UPDATE"
+	AuditActionDelete   AuditAction = "DELETE"
+	AuditActionRestore  AuditAction = "RESTORE"
+	AuditActionMerge    AuditAction = "MERGE"
+	AuditActionShare    AuditAction = "SHARE"
+	AuditActionUnshare  AuditAction = "UNSHARE"
+	AuditActionGroup    AuditAction = "GROUP"
+	AuditActionUngroup  AuditAction = "UNGROUP"
+	AuditActionRelate   AuditAction = "RELATE"
+	AuditActionUnrelate AuditAction = "UNRELATE"
+)
+
+var AllAuditAction = []AuditAction{
+	AuditActionCreate,
+	AuditActionUpdate,
+	AuditActionDelete,
+	AuditActionRestore,
+	AuditActionMerge,
+	AuditActionShare,
+	AuditActionUnshare,
+	AuditActionGroup,
+	AuditActionUngroup,
+	AuditActionRelate,
+	AuditActionUnrelate,
+}
+
+func (e AuditAction) IsValid() bool {
+	switch e {
+	case AuditActionCreate, AuditActionUpdate, AuditActionDelete, AuditActionRestore, AuditActionMerge, AuditActionShare, AuditActionUnshare, AuditActionGroup, AuditActionUngroup, AuditActionRelate, AuditActionUnrelate:
+		return true
+	}
+	return false
+}
+
+func (e AuditAction) String() string {
+	return string(e)
+}
+
+func (e *AuditAction) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AuditAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AuditAction", str)
+	}
+	return nil
+}
+
+func (e AuditAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type BatchStatus string
+
+const (
+	BatchStatusApplied BatchStatus = "APPLIED"
+	BatchStatusFailed  BatchStatus = "FAILED"
+	// ABORTED contacts of an atomic batch are valid but were not applied because another one failed
+	BatchStatusAborted BatchStatus = "ABORTED"
+)
+
+var AllBatchStatus = []BatchStatus{
+	BatchStatusApplied,
+	BatchStatusFailed,
+	BatchStatusAborted,
+}
+
+func (e BatchStatus) IsValid() bool {
+	switch e {
+	case BatchStatusApplied, BatchStatusFailed, BatchStatusAborted:
+		return true
+	}
+	return false
+}
+
+func (e BatchStatus) String() string {
+	return string(e)
+}
+
+func (e *BatchStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = BatchStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid BatchStatus", str)
+	}
+	return nil
+}
+
+func (e BatchStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ContactEvent string
+
+const (
+	ContactEventCreated ContactEvent = "CREATED"
+	ContactEventUpdated ContactEvent = "UPDATED"
+	ContactEventDeleted ContactEvent = "DELETED"
+)
+
+var AllContactEvent = []ContactEvent{
+	ContactEventCreated,
+	ContactEventUpdated,
+	ContactEventDeleted,
+}
+
+func (e ContactEvent) IsValid() bool {
+	switch e {
+	case ContactEventCreated, ContactEventUpdated, ContactEventDeleted:
+		return true
+	}
+	return false
+}
+
+func (e ContactEvent) String() string {
+	return string(e)
+}
+
+func (e *ContactEvent) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ContactEvent(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ContactEvent", str)
+	}
+	return nil
+}
+
+func (e ContactEvent) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ContactSortField string
+
+const (
+	ContactSortFieldLastName  ContactSortField = "LAST_NAME"
+	ContactSortFieldFirstName ContactSortField = "FIRST_NAME"
+	ContactSortFieldCreatedAt ContactSortField = "CREATED_AT"
+	ContactSortFieldUpdatedAt ContactSortField = "UPDATED_AT"
+)
+
+var AllContactSortField = []ContactSortField{
+	ContactSortFieldLastName,
+	ContactSortFieldFirstName,
+	ContactSortFieldCreatedAt,
+	ContactSortFieldUpdatedAt,
+}
+
+func (e ContactSortField) IsValid() bool {
+	switch e {
+	case ContactSortFieldLastName, ContactSortFieldFirstName, ContactSortFieldCreatedAt, ContactSortFieldUpdatedAt:
+		return true
+	}
+	return false
+}
+
+func (e ContactSortField) String() string {
+	return string(e)
+}
+
+func (e *ContactSortField) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ContactSortField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ContactSortField", str)
+	}
+	return nil
+}
+
+func (e ContactSortField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type EmailStatus string
+
+const (
+	EmailStatusUnverified  EmailStatus = "UNVERIFIED"
+	EmailStatusDeliverable EmailStatus = "DELIVERABLE"
+	// UNDELIVERABLE emails have a domain without mail server
+	EmailStatusUndeliverable EmailStatus = "UNDELIVERABLE"
+	// UNKNOWN emails could not be checked, their domain lookup failed
+	EmailStatusUnknown EmailStatus = "UNKNOWN"
+)
+
+var AllEmailStatus = []EmailStatus{
+	EmailStatusUnverified,
+	EmailStatusDeliverable,
+	EmailStatusUndeliverable,
+	EmailStatusUnknown,
+}
+
+func (e EmailStatus) IsValid() bool {
+	switch e {
+	case EmailStatusUnverified, EmailStatusDeliverable, EmailStatusUndeliverable, EmailStatusUnknown:
+		return true
+	}
+	return false
+}
+
+func (e EmailStatus) String() string {
+	return string(e)
+}
+
+func (e *EmailStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = EmailStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid EmailStatus", str)
+	}
+	return nil
+}
+
+func (e EmailStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type FieldType string
+
+const (
+	FieldTypeString FieldType = "STRING"
+	FieldTypeNumber FieldType = "NUMBER"
+	FieldTypeDate   FieldType = "DATE"
+	FieldTypeEnum   FieldType = "ENUM"
+	FieldTypeURL    FieldType = "URL"
+)
+
+var AllFieldType = []FieldType{
+	FieldTypeString,
+	FieldTypeNumber,
+	FieldTypeDate,
+	FieldTypeEnum,
+	FieldTypeURL,
+}
+
+func (e FieldType) IsValid() bool {
+	switch e {
+	case FieldTypeString, FieldTypeNumber, FieldTypeDate, FieldTypeEnum, FieldTypeURL:
+		return true
+	}
+	return false
+}
+
+func (e FieldType) String() string {
+	return string(e)
+}
+
+func (e *FieldType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FieldType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FieldType", str)
+	}
+	return nil
+}
+
+func (e FieldType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type Label string
+
+const (
+	LabelWork   Label = "WORK"
+	LabelHome   Label = "HOME"
+	LabelMobile Label = "MOBILE"
+	LabelOther  Label = "OTHER"
+)
+
+var AllLabel = []Label{
+	LabelWork,
+	LabelHome,
+	LabelMobile,
+	LabelOther,
+}
+
+func (e Label) IsValid() bool {
+	switch e {
+	case LabelWork, LabelHome, LabelMobile, LabelOther:
+		return true
+	}
+	return false
+}
+
+func (e Label) String() string {
+	return string(e)
+}
+
+func (e *Label) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Label(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Label", str)
+	}
+	return nil
+}
+
+func (e Label) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type MatchMode string
+
+const (
+	MatchModeExact  MatchMode = "EXACT"
+	MatchModePrefix MatchMode = "PREFIX"
+)
+
+var AllMatchMode = []MatchMode{
+	MatchModeExact,
+	MatchModePrefix,
+}
+
+func (e MatchMode) IsValid() bool {
+	switch e {
+	case MatchModeExact, MatchModePrefix:
+		return true
+	}
+	return false
+}
+
+func (e MatchMode) String() string {
+	return string(e)
+}
+
+func (e *MatchMode) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MatchMode(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MatchMode", str)
+	}
+	return nil
+}
+
+func (e MatchMode) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// OnDelete tells what happens to the relating contact when the related contact is deleted
+type OnDelete string
+
+const (
+	// CASCADE deletes the relating contact as well
+	OnDeleteCascade OnDelete = "CASCADE"
+	// RESTRICT prevents the deletion with a RESTRICTED error
+	OnDeleteRestrict OnDelete = "RESTRICT"
+	// NULLIFY removes the relationship
+	OnDeleteNullify OnDelete = "NULLIFY"
+)
+
+var AllOnDelete = []OnDelete{
+	OnDeleteCascade,
+	OnDeleteRestrict,
+	OnDeleteNullify,
+}
+
+func (e OnDelete) IsValid() bool {
+	switch e {
+	case OnDeleteCascade, OnDeleteRestrict, OnDeleteNullify:
+		return true
+	}
+	return false
+}
+
+func (e OnDelete) String() string {
+	return string(e)
+}
+
+func (e *OnDelete) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OnDelete(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OnDelete", str)
+	}
+	return nil
+}
+
+func (e OnDelete) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type Permission string
+
+const (
+	PermissionRead  Permission = "READ"
+	PermissionWrite Permission = "WRITE"
+)
+
+var AllPermission = []Permission{
+	PermissionRead,
+	PermissionWrite,
+}
+
+func (e Permission) IsValid() bool {
+	switch e {
+	case PermissionRead, PermissionWrite:
+		return true
+	}
+	return false
+}
+
+func (e Permission) String() string {
+	return string(e)
+}
+
+func (e *Permission) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Permission(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Permission", str)
+	}
+	return nil
+}
+
+func (e Permission) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type PhoneType string
+
+const (
+	PhoneTypeMobile PhoneType = "MOBILE"
+	PhoneTypeFixed  PhoneType = "FIXED"
+	// FIXED_OR_MOBILE numbers belong to regions not telling mobile and fixed lines apart
+	PhoneTypeFixedOrMobile PhoneType = "FIXED_OR_MOBILE"
+	PhoneTypeTollFree      PhoneType = "TOLL_FREE"
+)
+
+var AllPhoneType = []PhoneType{
+	PhoneTypeMobile,
+	PhoneTypeFixed,
+	PhoneTypeFixedOrMobile,
+	PhoneTypeTollFree,
+}
+
+func (e PhoneType) IsValid() bool {
+	switch e {
+	case PhoneTypeMobile, PhoneTypeFixed, PhoneTypeFixedOrMobile, PhoneTypeTollFree:
+		return true
+	}
+	return false
+}
+
+func (e PhoneType) String() string {
+	return string(e)
+}
+
+func (e *PhoneType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PhoneType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PhoneType", str)
+	}
+	return nil
+}
+
+func (e PhoneType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type RelationshipType string
+
+const (
+	RelationshipTypeOrganization RelationshipType = "ORGANIZATION"
+	RelationshipTypeManager      RelationshipType = "MANAGER"
+	RelationshipTypeSpouse       RelationshipType = "SPOUSE"
+	RelationshipTypeAssistant    RelationshipType = "ASSISTANT"
+)
+
+var AllRelationshipType = []RelationshipType{
+	RelationshipTypeOrganization,
+	RelationshipTypeManager,
+	RelationshipTypeSpouse,
+	RelationshipTypeAssistant,
+}
+
+func (e RelationshipType) IsValid() bool {
+	switch e {
+	case RelationshipTypeOrganization, RelationshipTypeManager, RelationshipTypeSpouse, RelationshipTypeAssistant:
+		return true
+	}
+	return false
+}
+
+func (e RelationshipType) String() string {
+	return string(e)
+}
+
+func (e *RelationshipType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = RelationshipType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid RelationshipType", str)
+	}
+	return nil
+}
+
+func (e RelationshipType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type Role string
+
+const (
+	RoleOwner  Role = "OWNER"
+	RoleEditor Role = "EDITOR"
+	RoleViewer Role = "VIEWER"
+)
+
+var AllRole = []Role{
+	RoleOwner,
+	RoleEditor,
+	RoleViewer,
+}
+
+func (e Role) IsValid() bool {
+	switch e {
+	case RoleOwner, RoleEditor, RoleViewer:
+		return true
+	}
+	return false
+}
+
+func (e Role) String() string {
+	return string(e)
+}
+
+func (e *Role) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Role(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Role", str)
+	}
+	return nil
+}
+
+func (e Role) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type SortDirection string
+
+const (
+	SortDirectionAsc  SortDirection = "ASC"
+	SortDirectionDesc SortDirection = "DESC"
+)
+
+var AllSortDirection = []SortDirection{
+	SortDirectionAsc,
+	SortDirectionDesc,
+}
+
+func (e SortDirection) IsValid() bool {
+	switch e {
+	case SortDirectionAsc, SortDirectionDesc:
+		return true
+	}
+	return false
+}
+
+func (e SortDirection) String() string {
+	return string(e)
+}
+
+func (e *SortDirection) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SortDirection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SortDirection", str)
+	}
+	return nil
+}
+
+func (e SortDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
 }
@@ -2,10 +2,22 @@ package graphql
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
 
 	"github.com/davidterranova/contacts/internal/adapters/graphql/model"
 	"github.com/davidterranova/contacts/internal/domain"
 	"github.com/davidterranova/contacts/internal/usecase"
+	"github.com/davidterranova/contacts/pkg/auth"
+	"github.com/davidterranova/contacts/pkg/phone"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
 )
 
 // This file will not be regenerated automatically.
@@ -13,10 +25,41 @@ import (
 // It serves as dependency injection for your app, add any dependencies you require here.
 
 type App interface {
-	ListContacts(ctx context.Context, query usecase.QueryListContact) ([]*domain.Contact, error)
+	ListContacts(ctx context.Context, query usecase.QueryListContact) (*usecase.ContactPage, error)
+	GetContact(ctx context.Context, query usecase.QueryGetContact) (*domain.Contact, error)
 	CreateContact(ctx context.Context, cmd usecase.CmdCreateContact) (*domain.Contact, error)
 	UpdateContact(ctx context.Context, cmd usecase.CmdUpdateContact) (*domain.Contact, error)
 	DeleteContact(ctx context.Context, cmd usecase.CmdDeleteContact) error
+	RestoreContact(ctx context.Context, cmd usecase.CmdRestoreContact) (*domain.Contact, error)
+	ContactHistory(ctx context.Context, query usecase.QueryContactHistory) ([]*domain.AuditEntry, error)
+	ShareContact(ctx context.Context, cmd usecase.CmdShareContact) (*domain.Contact, error)
+	UnshareContact(ctx context.Context, cmd usecase.CmdUnshareContact) (*domain.Contact, error)
+	WatchContacts(ctx context.Context, query usecase.QueryWatchContacts) (<-chan usecase.ContactChange, error)
+	BatchContacts(ctx context.Context, cmd usecase.CmdBatchContacts) (usecase.BatchResults, error)
+	ContactRelationships(ctx context.Context, query usecase.QueryContactRelationships) (*usecase.ContactRelationships, error)
+	RelateContacts(ctx context.Context, cmd usecase.CmdRelateContacts) (*domain.Contact, error)
+	UnrelateContacts(ctx context.Context, cmd usecase.CmdUnrelateContacts) (*domain.Contact, error)
+
+	GetContactSchema(ctx context.Context, query usecase.QueryGetContactSchema) (*domain.ContactSchema, error)
+	SetContactSchema(ctx context.Context, cmd usecase.CmdSetContactSchema) (*domain.ContactSchema, error)
+
+	ListAddressBooks(ctx context.Context, query usecase.QueryListAddressBooks) ([]*domain.AddressBook, error)
+	GetAddressBook(ctx context.Context, query usecase.QueryGetAddressBook) (*domain.AddressBook, error)
+	CreateAddressBook(ctx context.Context, cmd usecase.CmdCreateAddressBook) (*domain.AddressBook, error)
+	UpdateAddressBook(ctx context.Context, cmd usecase.CmdUpdateAddressBook) (*domain.AddressBook, error)
+	DeleteAddressBook(ctx context.Context, cmd usecase.CmdDeleteAddressBook) error
+	SetAddressBookMember(ctx context.Context, cmd usecase.CmdSetAddressBookMember) (*domain.AddressBook, error)
+	RemoveAddressBookMember(ctx context.Context, cmd usecase.CmdRemoveAddressBookMember) (*domain.AddressBook, error)
+
+	ListGroups(ctx context.Context, query usecase.QueryListGroups) ([]*domain.Group, error)
+	GetGroup(ctx context.Context, query usecase.QueryGetGroup) (*domain.Group, error)
+	CreateGroup(ctx context.Context, cmd usecase.CmdCreateGroup) (*domain.Group, error)
+	UpdateGroup(ctx context.Context, cmd usecase.CmdUpdateGroup) (*domain.Group, error)
+	DeleteGroup(ctx context.Context, cmd usecase.CmdDeleteGroup) error
+	AddGroupContact(ctx context.Context, cmd usecase.CmdAddGroupContact) (*domain.Contact, error)
+	RemoveGroupContact(ctx context.Context, cmd usecase.CmdRemoveGroupContact) (*domain.Contact, error)
+	DeleteGroupContacts(ctx context.Context, cmd usecase.CmdDeleteGroupContacts) (usecase.BatchResults, error)
+	ShareGroupContacts(ctx context.Context, cmd usecase.CmdShareGroupContacts) ([]usecase.BatchResult, error)
 }
 
 type Resolver struct {
@@ -30,12 +73,12 @@ func NewResolver(app App) *Resolver {
 }
 
 func (r *Resolver) ListContacts(ctx context.Context) ([]*model.Contact, error) {
-	contacts, err := r.app.ListContacts(ctx, usecase.QueryListContact{})
+	page, err := r.app.ListContacts(ctx, usecase.QueryListContact{})
 	if err != nil {
 		return nil, err
 	}
 
-	return toGQLContacts(contacts), nil
+	return toGQLContacts(page.Contacts), nil
 }
 
 func (r *Resolver) CreateContact(ctx context.Context, input model.NewContact) (*model.Contact, error) {
@@ -44,6 +87,9 @@ func (r *Resolver) CreateContact(ctx context.Context, input model.NewContact) (*
 		LastName:  input.LastName,
 		Email:     input.Email,
 		Phone:     input.Phone,
+		Emails:    toCmdEmails(input.Emails),
+		Phones:    toCmdPhones(input.Phones),
+		Addresses: toCmdAddresses(input.Addresses),
 	})
 	if err != nil {
 		return nil, err
@@ -52,16 +98,506 @@ func (r *Resolver) CreateContact(ctx context.Context, input model.NewContact) (*
 	return toGQLContact(contact), nil
 }
 
+// toGQLError exposes use case errors with a machine readable extensions code
+// listContacts serves both the contacts and the trash listings
+func (r *Resolver) listContacts(ctx context.Context, filter *model.ContactFilter, sort *model.ContactSort, first *int, after *string, trash bool) (*model.ContactConnection, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:list failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	query, err := toQueryListContact(filter)
+	if err != nil {
+		return nil, err
+	}
+	query.Requester = user
+	query.Trash = trash
+	query.Sort = toDomainSort(sort)
+	if first != nil {
+		query.Limit = *first
+	}
+	if after != nil {
+		query.Cursor = *after
+	}
+
+	page, err := r.app.ListContacts(ctx, query)
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return toGQLContactConnection(page), nil
+}
+
+// contactRelationships serves both sides of the relationships of a contact, each related contact may be traversed in turn
+func (r *Resolver) contactRelationships(ctx context.Context, contact *model.Contact) (*usecase.ContactRelationships, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:relationships failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	relationships, err := r.app.ContactRelationships(ctx, usecase.QueryContactRelationships{
+		Requester: user,
+		ContactId: contact.ID,
+	})
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return relationships, nil
+}
+
+func toGQLError(ctx context.Context, err error) error {
+	return &gqlerror.Error{
+		Path:       graphql.GetPath(ctx),
+		Message:    err.Error(),
+		Extensions: map[string]interface{}{"code": errorCode(err)},
+	}
+}
+
+func errorCode(err error) string {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidCommand):
+		return "BAD_USER_INPUT"
+	case errors.Is(err, usecase.ErrNotFound):
+		return "NOT_FOUND"
+	case errors.Is(err, usecase.ErrForbidden):
+		return "FORBIDDEN"
+	case errors.Is(err, usecase.ErrConflict):
+		return "CONFLICT"
+	case errors.Is(err, usecase.ErrAlreadyExists):
+		return "ALREADY_EXISTS"
+	case errors.Is(err, usecase.ErrRestricted):
+		return "RESTRICTED"
+	default:
+		return "INTERNAL"
+	}
+}
+
+func toQueryListContact(filter *model.ContactFilter) (usecase.QueryListContact, error) {
+	var (
+		query usecase.QueryListContact
+		err   error
+	)
+	if filter == nil {
+		return query, nil
+	}
+
+	query.AddressBookId = stringValue(filter.AddressBookID)
+	query.GroupId = stringValue(filter.GroupID)
+	query.Search = stringValue(filter.Search)
+	query.FirstName = toDomainFieldMatch(filter.FirstName)
+	query.LastName = toDomainFieldMatch(filter.LastName)
+	query.Email = toDomainFieldMatch(filter.Email)
+	query.Phone = toDomainFieldMatch(filter.Phone)
+	for _, match := range filter.CustomFields {
+		if query.CustomFields == nil {
+			query.CustomFields = map[string]domain.FieldMatch{}
+		}
+		query.CustomFields[match.Name] = toDomainFieldMatch(&model.StringMatch{Value: match.Value, Mode: match.Mode})
+	}
+
+	query.CreatedAt, err = toDomainTimeRange(filter.CreatedAt)
+	if err != nil {
+		return query, err
+	}
+
+	query.UpdatedAt, err = toDomainTimeRange(filter.UpdatedAt)
+	if err != nil {
+		return query, err
+	}
+
+	return query, nil
+}
+
+func toDomainFieldMatch(match *model.StringMatch) domain.FieldMatch {
+	if match == nil {
+		return domain.FieldMatch{}
+	}
+
+	mode := domain.MatchExact
+	if match.Mode != nil && *match.Mode == model.MatchModePrefix {
+		mode = domain.MatchPrefix
+	}
+
+	return domain.FieldMatch{Value: match.Value, Mode: mode}
+}
+
+func toDomainTimeRange(dateRange *model.DateRange) (domain.TimeRange, error) {
+	var timeRange domain.TimeRange
+	if dateRange == nil {
+		return timeRange, nil
+	}
+
+	for _, bound := range []struct {
+		value *string
+		time  **time.Time
+	}{
+		{dateRange.From, &timeRange.From},
+		{dateRange.To, &timeRange.To},
+	} {
+		if bound.value == nil {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, *bound.value)
+		if err != nil {
+			return timeRange, fmt.Errorf("invalid date %q: %w", *bound.value, err)
+		}
+		*bound.time = &t
+	}
+
+	return timeRange, nil
+}
+
+func toDomainSort(sort *model.ContactSort) domain.Sort {
+	if sort == nil {
+		return domain.Sort{}
+	}
+
+	fields := map[model.ContactSortField]domain.SortField{
+		model.ContactSortFieldLastName:  domain.SortByLastName,
+		model.ContactSortFieldFirstName: domain.SortByFirstName,
+		model.ContactSortFieldCreatedAt: domain.SortByCreatedAt,
+		model.ContactSortFieldUpdatedAt: domain.SortByUpdatedAt,
+	}
+
+	return domain.Sort{
+		Field: fields[sort.Field],
+		Desc:  sort.Direction != nil && *sort.Direction == model.SortDirectionDesc,
+	}
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+func boolValue(b *bool) bool {
+	if b == nil {
+		return false
+	}
+
+	return *b
+}
+
+func intValue(i *int) int {
+	if i == nil {
+		return 0
+	}
+
+	return *i
+}
+
 func toGQLContact(contact *domain.Contact) *model.Contact {
-	return &model.Contact{
-		ID:        contact.Id.String(),
-		CreatedAt: contact.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt: contact.UpdatedAt.Format("2006-01-02T15:04:05Z"),
-		FirstName: contact.FirstName,
-		LastName:  contact.LastName,
-		Email:     contact.Email,
-		Phone:     contact.Phone,
+	gqlContact := &model.Contact{
+		ID:           contact.Id.String(),
+		CreatedAt:    contact.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:    contact.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		Version:      contact.Version,
+		FirstName:    contact.FirstName,
+		LastName:     contact.LastName,
+		Email:        contact.Email,
+		EmailStatus:  model.EmailStatus(strings.ToUpper(string(contact.EmailStatus.OrUnverified()))),
+		Phone:        contact.Phone,
+		Emails:       toGQLEmails(contact.EmailAddresses()),
+		Phones:       toGQLPhones(contact.PhoneNumbers()),
+		Addresses:    toGQLAddresses(contact.Addresses),
+		CustomFields: toGQLCustomFields(contact.CustomFields),
+		Shares:       toGQLShares(contact.Grants),
+		GroupIds:     toGQLGroupIds(contact.GroupIds),
+	}
+	if contact.AddressBookId != uuid.Nil {
+		addressBookId := contact.AddressBookId.String()
+		gqlContact.AddressBookID = &addressBookId
+	}
+	if contact.DeletedAt != nil {
+		deletedAt := contact.DeletedAt.Format("2006-01-02T15:04:05Z")
+		gqlContact.DeletedAt = &deletedAt
 	}
+
+	return gqlContact
+}
+
+func toGQLRelationships(related []usecase.RelatedContact) []*model.Relationship {
+	var relationships = make([]*model.Relationship, 0, len(related))
+	for _, r := range related {
+		relationships = append(relationships, &model.Relationship{
+			Type:      model.RelationshipType(strings.ToUpper(string(r.Relationship.Type))),
+			OnDelete:  model.OnDelete(strings.ToUpper(string(r.Relationship.OnDelete))),
+			CreatedAt: r.Relationship.CreatedAt.Format("2006-01-02T15:04:05Z"),
+			Contact:   toGQLContact(r.Contact),
+		})
+	}
+
+	return relationships
+}
+
+func toDomainRelationshipType(relationshipType model.RelationshipType) domain.RelationshipType {
+	return domain.RelationshipType(strings.ToLower(relationshipType.String()))
+}
+
+func toDomainOnDelete(onDelete model.OnDelete) domain.OnDelete {
+	return domain.OnDelete(strings.ToLower(onDelete.String()))
+}
+
+func toGQLAuditEntries(entries []*domain.AuditEntry) []*model.AuditEntry {
+	var gqlEntries = make([]*model.AuditEntry, 0, len(entries))
+	for _, entry := range entries {
+		changes := make([]*model.FieldChange, 0, len(entry.Changes))
+		for _, change := range entry.Changes {
+			changes = append(changes, &model.FieldChange{
+				Field:  change.Field,
+				Before: change.Before,
+				After:  change.After,
+			})
+		}
+
+		gqlEntries = append(gqlEntries, &model.AuditEntry{
+			ID: entry.Id.String(),
+			Actor: &model.Actor{
+				ID:   entry.Actor.Id().String(),
+				Type: string(entry.Actor.Type()),
+			},
+			Action:  model.AuditAction(strings.ToUpper(string(entry.Action))),
+			At:      entry.At.Format("2006-01-02T15:04:05Z"),
+			Changes: changes,
+		})
+	}
+
+	return gqlEntries
+}
+
+func toGQLContactChange(change usecase.ContactChange) *model.ContactChange {
+	return &model.ContactChange{
+		Event:      model.ContactEvent(strings.ToUpper(strings.TrimPrefix(change.Event, "contact."))),
+		OccurredAt: change.OccurredAt.Format("2006-01-02T15:04:05Z"),
+		Contact:    toGQLContact(change.Contact),
+	}
+}
+
+func toGQLBatchResults(results []usecase.BatchResult) []*model.BatchResult {
+	statuses := map[usecase.BatchStatus]model.BatchStatus{
+		usecase.BatchStatusApplied: model.BatchStatusApplied,
+		usecase.BatchStatusFailed:  model.BatchStatusFailed,
+		usecase.BatchStatusAborted: model.BatchStatusAborted,
+	}
+
+	gqlResults := make([]*model.BatchResult, 0, len(results))
+	for _, result := range results {
+		gqlResult := &model.BatchResult{Status: statuses[result.Status]}
+		if result.Contact != nil {
+			gqlResult.Contact = toGQLContact(result.Contact)
+		}
+		if result.Err != nil {
+			gqlResult.Error = &model.BatchError{Message: result.Err.Error(), Code: errorCode(result.Err)}
+		}
+		gqlResults = append(gqlResults, gqlResult)
+	}
+
+	return gqlResults
+}
+
+func toGQLEmails(emails []domain.EmailAddress) []*model.EmailAddress {
+	var gqlEmails = make([]*model.EmailAddress, 0, len(emails))
+	for _, email := range emails {
+		gqlEmails = append(gqlEmails, &model.EmailAddress{Label: toGQLLabel(email.Label), Address: email.Address, Primary: email.Primary})
+	}
+
+	return gqlEmails
+}
+
+func toGQLPhones(phones []domain.PhoneNumber) []*model.PhoneNumber {
+	var gqlPhones = make([]*model.PhoneNumber, 0, len(phones))
+	for _, p := range phones {
+		gqlPhone := &model.PhoneNumber{Label: toGQLLabel(p.Label), Number: p.Number, Primary: p.Primary}
+		if n, err := phone.Parse(p.Number, ""); err == nil {
+			international := n.International()
+			gqlPhone.International = &international
+			if national := n.National(); national != "" {
+				gqlPhone.National = &national
+			}
+			if n.Type != phone.TypeUnknown {
+				phoneType := model.PhoneType(strings.ToUpper(string(n.Type)))
+				gqlPhone.Type = &phoneType
+			}
+		}
+		gqlPhones = append(gqlPhones, gqlPhone)
+	}
+
+	return gqlPhones
+}
+
+func toGQLAddresses(addresses []domain.PostalAddress) []*model.PostalAddress {
+	var gqlAddresses = make([]*model.PostalAddress, 0, len(addresses))
+	for _, address := range addresses {
+		gqlAddresses = append(gqlAddresses, &model.PostalAddress{
+			Label:      toGQLLabel(address.Label),
+			Street:     address.Street,
+			Locality:   address.Locality,
+			Region:     address.Region,
+			PostalCode: address.PostalCode,
+			Country:    address.Country,
+			Primary:    address.Primary,
+		})
+	}
+
+	return gqlAddresses
+}
+
+func toGQLLabel(label domain.Label) model.Label {
+	return model.Label(strings.ToUpper(string(label)))
+}
+
+// toDomainLabel keeps an empty label empty, the use cases default it
+func toDomainLabel(label *model.Label) string {
+	if label == nil {
+		return ""
+	}
+
+	return strings.ToLower(string(*label))
+}
+
+func toCmdEmails(emails []*model.EmailAddressInput) []usecase.ContactEmail {
+	var cmdEmails []usecase.ContactEmail
+	for _, email := range emails {
+		cmdEmails = append(cmdEmails, usecase.ContactEmail{Label: toDomainLabel(email.Label), Address: email.Address, Primary: boolValue(email.Primary)})
+	}
+
+	return cmdEmails
+}
+
+func toCmdPhones(phones []*model.PhoneNumberInput) []usecase.ContactPhone {
+	var cmdPhones []usecase.ContactPhone
+	for _, p := range phones {
+		cmdPhones = append(cmdPhones, usecase.ContactPhone{Label: toDomainLabel(p.Label), Number: p.Number, Primary: boolValue(p.Primary)})
+	}
+
+	return cmdPhones
+}
+
+func toCmdAddresses(addresses []*model.PostalAddressInput) []usecase.ContactAddress {
+	var cmdAddresses []usecase.ContactAddress
+	for _, address := range addresses {
+		cmdAddresses = append(cmdAddresses, usecase.ContactAddress{
+			Label:      toDomainLabel(address.Label),
+			Street:     address.Street,
+			Locality:   address.Locality,
+			Region:     stringValue(address.Region),
+			PostalCode: stringValue(address.PostalCode),
+			Country:    stringValue(address.Country),
+			Primary:    boolValue(address.Primary),
+		})
+	}
+
+	return cmdAddresses
+}
+
+func toGQLCustomFields(fields map[string]domain.CustomValue) map[string]any {
+	var gqlFields = make(map[string]any, len(fields))
+	for name, value := range fields {
+		gqlFields[name] = value.Value()
+	}
+
+	return gqlFields
+}
+
+// toCmdCustomFields converts the numbers to float64, variables hold them as json.Number and literals as int64 or float64
+func toCmdCustomFields(fields map[string]any) map[string]any {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	values := make(map[string]any, len(fields))
+	for name, value := range fields {
+		switch v := value.(type) {
+		case json.Number:
+			number, err := v.Float64()
+			if err != nil {
+				values[name] = v.String()
+				continue
+			}
+			values[name] = number
+		case int64:
+			values[name] = float64(v)
+		case int:
+			values[name] = float64(v)
+		default:
+			values[name] = value
+		}
+	}
+
+	return values
+}
+
+func toGQLContactSchema(schema *domain.ContactSchema) *model.ContactSchema {
+	gqlSchema := &model.ContactSchema{
+		OwnerID: schema.OwnerId.String(),
+		Fields:  make([]*model.CustomField, 0, len(schema.Fields)),
+	}
+	if !schema.UpdatedAt.IsZero() {
+		updatedAt := schema.UpdatedAt.Format("2006-01-02T15:04:05Z")
+		gqlSchema.UpdatedAt = &updatedAt
+	}
+	if schema.DefaultPhoneRegion != "" {
+		gqlSchema.DefaultPhoneRegion = &schema.DefaultPhoneRegion
+	}
+	for _, field := range schema.Fields {
+		options := field.Options
+		if options == nil {
+			options = []string{}
+		}
+		gqlSchema.Fields = append(gqlSchema.Fields, &model.CustomField{
+			Name:     field.Name,
+			Type:     model.FieldType(strings.ToUpper(string(field.Type))),
+			Required: field.Required,
+			Min:      field.Min,
+			Max:      field.Max,
+			Pattern:  field.Pattern,
+			Options:  options,
+		})
+	}
+
+	return gqlSchema
+}
+
+func toCmdCustomFieldDefinitions(fields []*model.CustomFieldInput) []usecase.CustomField {
+	var cmdFields = make([]usecase.CustomField, 0, len(fields))
+	for _, field := range fields {
+		cmdFields = append(cmdFields, usecase.CustomField{
+			Name:     field.Name,
+			Type:     strings.ToLower(string(field.Type)),
+			Required: boolValue(field.Required),
+			Min:      field.Min,
+			Max:      field.Max,
+			Pattern:  stringValue(field.Pattern),
+			Options:  field.Options,
+		})
+	}
+
+	return cmdFields
+}
+
+func toGQLShares(grants []domain.Grant) []*model.Share {
+	var shares = make([]*model.Share, 0, len(grants))
+	for _, grant := range grants {
+		permission := model.PermissionRead
+		if grant.Permission == domain.PermissionWrite {
+			permission = model.PermissionWrite
+		}
+
+		shares = append(shares, &model.Share{
+			UserID:     grant.UserId.String(),
+			Permission: permission,
+			GrantedAt:  grant.GrantedAt.Format("2006-01-02T15:04:05Z"),
+		})
+	}
+
+	return shares
 }
 
 func toGQLContacts(contacts []*domain.Contact) []*model.Contact {
@@ -72,3 +608,79 @@ func toGQLContacts(contacts []*domain.Contact) []*model.Contact {
 
 	return gqlContacts
 }
+
+func toGQLContactConnection(page *usecase.ContactPage) *model.ContactConnection {
+	connection := &model.ContactConnection{
+		Edges:    make([]*model.ContactEdge, 0, len(page.Contacts)),
+		PageInfo: &model.PageInfo{HasNextPage: page.NextCursor != ""},
+	}
+	for _, contact := range page.Contacts {
+		connection.Edges = append(connection.Edges, &model.ContactEdge{
+			Cursor: page.Cursor(contact),
+			Node:   toGQLContact(contact),
+		})
+	}
+
+	if len(connection.Edges) > 0 {
+		connection.PageInfo.EndCursor = &connection.Edges[len(connection.Edges)-1].Cursor
+	}
+
+	return connection
+}
+
+func toGQLAddressBook(addressBook *domain.AddressBook) *model.AddressBook {
+	var members = make([]*model.Member, 0, len(addressBook.Members))
+	for _, member := range addressBook.Members {
+		members = append(members, &model.Member{
+			UserID:  member.UserId.String(),
+			Role:    model.Role(strings.ToUpper(string(member.Role))),
+			AddedAt: member.AddedAt.Format("2006-01-02T15:04:05Z"),
+		})
+	}
+
+	return &model.AddressBook{
+		ID:        addressBook.Id.String(),
+		CreatedAt: addressBook.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt: addressBook.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		CreatedBy: addressBook.CreatedBy.String(),
+		Name:      addressBook.Name,
+		Members:   members,
+	}
+}
+
+func toGQLAddressBooks(addressBooks []*domain.AddressBook) []*model.AddressBook {
+	var gqlAddressBooks = make([]*model.AddressBook, 0, len(addressBooks))
+	for _, addressBook := range addressBooks {
+		gqlAddressBooks = append(gqlAddressBooks, toGQLAddressBook(addressBook))
+	}
+
+	return gqlAddressBooks
+}
+
+func toGQLGroup(group *domain.Group) *model.Group {
+	return &model.Group{
+		ID:        group.Id.String(),
+		CreatedAt: group.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt: group.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		CreatedBy: group.CreatedBy.String(),
+		Name:      group.Name,
+	}
+}
+
+func toGQLGroups(groups []*domain.Group) []*model.Group {
+	var gqlGroups = make([]*model.Group, 0, len(groups))
+	for _, group := range groups {
+		gqlGroups = append(gqlGroups, toGQLGroup(group))
+	}
+
+	return gqlGroups
+}
+
+func toGQLGroupIds(groupIds []uuid.UUID) []string {
+	var ids = make([]string, 0, len(groupIds))
+	for _, id := range groupIds {
+		ids = append(ids, id.String())
+	}
+
+	return ids
+}
@@ -6,6 +6,7 @@ package graphql
 
 import (
 	"context"
+	"strings"
 
 	"github.com/davidterranova/contacts/internal/adapters/graphql/model"
 	"github.com/davidterranova/contacts/internal/usecase"
@@ -13,6 +14,48 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// History is the resolver for the history field.
+func (r *contactResolver) History(ctx context.Context, obj *model.Contact) ([]*model.AuditEntry, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:history failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	entries, err := r.app.ContactHistory(
+		ctx,
+		usecase.QueryContactHistory{
+			Requester: user,
+			ContactId: obj.ID,
+		},
+	)
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return toGQLAuditEntries(entries), nil
+}
+
+// Relationships is the resolver for the relationships field.
+func (r *contactResolver) Relationships(ctx context.Context, obj *model.Contact) ([]*model.Relationship, error) {
+	relationships, err := r.contactRelationships(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGQLRelationships(relationships.Outgoing), nil
+}
+
+// RelatedBy is the resolver for the relatedBy field.
+func (r *contactResolver) RelatedBy(ctx context.Context, obj *model.Contact) ([]*model.Relationship, error) {
+	relationships, err := r.contactRelationships(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGQLRelationships(relationships.Incoming), nil
+}
+
 // CreateContact is the resolver for the createContact field.
 func (r *mutationResolver) CreateContact(ctx context.Context, input model.NewContact) (*model.Contact, error) {
 	user, err := auth.UserFromContext(ctx)
@@ -24,22 +67,28 @@ func (r *mutationResolver) CreateContact(ctx context.Context, input model.NewCon
 	contact, err := r.app.CreateContact(
 		ctx,
 		usecase.CmdCreateContact{
-			CreatedBy: user,
-			FirstName: input.FirstName,
-			LastName:  input.LastName,
-			Email:     input.Email,
-			Phone:     input.Phone,
+			CreatedBy:     user,
+			AddressBookId: stringValue(input.AddressBookID),
+			FirstName:     input.FirstName,
+			LastName:      input.LastName,
+			Email:         input.Email,
+			Phone:         input.Phone,
+			Emails:        toCmdEmails(input.Emails),
+			Phones:        toCmdPhones(input.Phones),
+			Addresses:     toCmdAddresses(input.Addresses),
+			CustomFields:  toCmdCustomFields(input.CustomFields),
+			PhoneRegion:   input.PhoneRegion,
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, toGQLError(ctx, err)
 	}
 
 	return toGQLContact(contact), nil
 }
 
 // UpdateContact is the resolver for the updateContact field.
-func (r *mutationResolver) UpdateContact(ctx context.Context, id string, input model.NewContact) (*model.Contact, error) {
+func (r *mutationResolver) UpdateContact(ctx context.Context, id string, input model.NewContact, version *int) (*model.Contact, error) {
 	user, err := auth.UserFromContext(ctx)
 	if err != nil {
 		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:update failed to get user from context")
@@ -49,23 +98,29 @@ func (r *mutationResolver) UpdateContact(ctx context.Context, id string, input m
 	contact, err := r.app.UpdateContact(
 		ctx,
 		usecase.CmdUpdateContact{
-			Updater:   user,
-			ContactId: id,
-			FirstName: input.FirstName,
-			LastName:  input.LastName,
-			Email:     input.Email,
-			Phone:     input.Phone,
+			Updater:      user,
+			ContactId:    id,
+			FirstName:    input.FirstName,
+			LastName:     input.LastName,
+			Email:        input.Email,
+			Phone:        input.Phone,
+			Emails:       toCmdEmails(input.Emails),
+			Phones:       toCmdPhones(input.Phones),
+			Addresses:    toCmdAddresses(input.Addresses),
+			CustomFields: toCmdCustomFields(input.CustomFields),
+			PhoneRegion:  input.PhoneRegion,
+			Version:      intValue(version),
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, toGQLError(ctx, err)
 	}
 
 	return toGQLContact(contact), nil
 }
 
 // DeleteContact is the resolver for the deleteContact field.
-func (r *mutationResolver) DeleteContact(ctx context.Context, id string) (*model.Contact, error) {
+func (r *mutationResolver) DeleteContact(ctx context.Context, id string, version *int) (*model.Contact, error) {
 	user, err := auth.UserFromContext(ctx)
 	if err != nil {
 		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:delete failed to get user from context")
@@ -77,38 +132,696 @@ func (r *mutationResolver) DeleteContact(ctx context.Context, id string) (*model
 		usecase.CmdDeleteContact{
 			ContactId: id,
 			Deleter:   user,
+			Version:   intValue(version),
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, toGQLError(ctx, err)
 	}
 
-	return &model.Contact{ID: id}, nil
+	return &model.Contact{ID: id, Shares: []*model.Share{}}, nil
 }
 
-// ListContacts is the resolver for the listContacts field.
-func (r *queryResolver) ListContacts(ctx context.Context) ([]*model.Contact, error) {
+// CreateContacts is the resolver for the createContacts field.
+func (r *mutationResolver) CreateContacts(ctx context.Context, input []*model.NewContact, atomic *bool) ([]*model.BatchResult, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:batch_create failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	cmd := usecase.CmdBatchContacts{
+		Requester: user,
+		Atomic:    boolValue(atomic),
+		Create:    make([]usecase.CmdCreateContact, 0, len(input)),
+	}
+	for _, contact := range input {
+		cmd.Create = append(cmd.Create, usecase.CmdCreateContact{
+			AddressBookId: stringValue(contact.AddressBookID),
+			FirstName:     contact.FirstName,
+			LastName:      contact.LastName,
+			Email:         contact.Email,
+			Phone:         contact.Phone,
+			Emails:        toCmdEmails(contact.Emails),
+			Phones:        toCmdPhones(contact.Phones),
+			Addresses:     toCmdAddresses(contact.Addresses),
+			CustomFields:  toCmdCustomFields(contact.CustomFields),
+			PhoneRegion:   contact.PhoneRegion,
+		})
+	}
+
+	results, err := r.app.BatchContacts(ctx, cmd)
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return toGQLBatchResults(results.Create), nil
+}
+
+// UpdateContacts is the resolver for the updateContacts field.
+func (r *mutationResolver) UpdateContacts(ctx context.Context, input []*model.ContactUpdate, atomic *bool) ([]*model.BatchResult, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:batch_update failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	cmd := usecase.CmdBatchContacts{
+		Requester: user,
+		Atomic:    boolValue(atomic),
+		Update:    make([]usecase.CmdUpdateContact, 0, len(input)),
+	}
+	for _, update := range input {
+		cmd.Update = append(cmd.Update, usecase.CmdUpdateContact{
+			ContactId:    update.ID,
+			FirstName:    update.Input.FirstName,
+			LastName:     update.Input.LastName,
+			Email:        update.Input.Email,
+			Phone:        update.Input.Phone,
+			Emails:       toCmdEmails(update.Input.Emails),
+			Phones:       toCmdPhones(update.Input.Phones),
+			Addresses:    toCmdAddresses(update.Input.Addresses),
+			CustomFields: toCmdCustomFields(update.Input.CustomFields),
+			PhoneRegion:  update.Input.PhoneRegion,
+			Version:      intValue(update.Version),
+		})
+	}
+
+	results, err := r.app.BatchContacts(ctx, cmd)
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return toGQLBatchResults(results.Update), nil
+}
+
+// DeleteContacts is the resolver for the deleteContacts field.
+func (r *mutationResolver) DeleteContacts(ctx context.Context, input []*model.ContactDeletion, atomic *bool) ([]*model.BatchResult, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:batch_delete failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	cmd := usecase.CmdBatchContacts{
+		Requester: user,
+		Atomic:    boolValue(atomic),
+		Delete:    make([]usecase.CmdDeleteContact, 0, len(input)),
+	}
+	for _, deletion := range input {
+		cmd.Delete = append(cmd.Delete, usecase.CmdDeleteContact{
+			ContactId: deletion.ID,
+			Version:   intValue(deletion.Version),
+		})
+	}
+
+	results, err := r.app.BatchContacts(ctx, cmd)
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return toGQLBatchResults(results.Delete), nil
+}
+
+// RestoreContact is the resolver for the restoreContact field.
+func (r *mutationResolver) RestoreContact(ctx context.Context, id string) (*model.Contact, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:restore failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	contact, err := r.app.RestoreContact(
+		ctx,
+		usecase.CmdRestoreContact{
+			Restorer:  user,
+			ContactId: id,
+		},
+	)
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return toGQLContact(contact), nil
+}
+
+// ShareContact is the resolver for the shareContact field.
+func (r *mutationResolver) ShareContact(ctx context.Context, id string, userID string, permission model.Permission) (*model.Contact, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:share failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	contact, err := r.app.ShareContact(
+		ctx,
+		usecase.CmdShareContact{
+			Sharer:     user,
+			ContactId:  id,
+			UserId:     userID,
+			Permission: strings.ToLower(permission.String()),
+		},
+	)
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return toGQLContact(contact), nil
+}
+
+// UnshareContact is the resolver for the unshareContact field.
+func (r *mutationResolver) UnshareContact(ctx context.Context, id string, userID string) (*model.Contact, error) {
 	user, err := auth.UserFromContext(ctx)
 	if err != nil {
-		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:list failed to get user from context")
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:unshare failed to get user from context")
 		return nil, auth.ErrUnauthorized
 	}
 
-	contacts, err := r.app.ListContacts(ctx, usecase.QueryListContact{
-		CreatedBy: user,
+	contact, err := r.app.UnshareContact(
+		ctx,
+		usecase.CmdUnshareContact{
+			Sharer:    user,
+			ContactId: id,
+			UserId:    userID,
+		},
+	)
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return toGQLContact(contact), nil
+}
+
+// CreateAddressBook is the resolver for the createAddressBook field.
+func (r *mutationResolver) CreateAddressBook(ctx context.Context, input model.NewAddressBook) (*model.AddressBook, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("address_books:create failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	addressBook, err := r.app.CreateAddressBook(
+		ctx,
+		usecase.CmdCreateAddressBook{
+			Creator: user,
+			Name:    input.Name,
+		},
+	)
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return toGQLAddressBook(addressBook), nil
+}
+
+// UpdateAddressBook is the resolver for the updateAddressBook field.
+func (r *mutationResolver) UpdateAddressBook(ctx context.Context, id string, input model.NewAddressBook) (*model.AddressBook, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("address_books:update failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	addressBook, err := r.app.UpdateAddressBook(
+		ctx,
+		usecase.CmdUpdateAddressBook{
+			Updater:       user,
+			AddressBookId: id,
+			Name:          input.Name,
+		},
+	)
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return toGQLAddressBook(addressBook), nil
+}
+
+// DeleteAddressBook is the resolver for the deleteAddressBook field.
+func (r *mutationResolver) DeleteAddressBook(ctx context.Context, id string) (*model.AddressBook, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("address_books:delete failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	err = r.app.DeleteAddressBook(
+		ctx,
+		usecase.CmdDeleteAddressBook{
+			Deleter:       user,
+			AddressBookId: id,
+		},
+	)
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return &model.AddressBook{ID: id, Members: []*model.Member{}}, nil
+}
+
+// SetAddressBookMember is the resolver for the setAddressBookMember field.
+func (r *mutationResolver) SetAddressBookMember(ctx context.Context, id string, userID string, role model.Role) (*model.AddressBook, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("address_books:set_member failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	addressBook, err := r.app.SetAddressBookMember(
+		ctx,
+		usecase.CmdSetAddressBookMember{
+			Updater:       user,
+			AddressBookId: id,
+			UserId:        userID,
+			Role:          strings.ToLower(role.String()),
+		},
+	)
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return toGQLAddressBook(addressBook), nil
+}
+
+// RemoveAddressBookMember is the resolver for the removeAddressBookMember field.
+func (r *mutationResolver) RemoveAddressBookMember(ctx context.Context, id string, userID string) (*model.AddressBook, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("address_books:remove_member failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	addressBook, err := r.app.RemoveAddressBookMember(
+		ctx,
+		usecase.CmdRemoveAddressBookMember{
+			Updater:       user,
+			AddressBookId: id,
+			UserId:        userID,
+		},
+	)
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return toGQLAddressBook(addressBook), nil
+}
+
+// SetContactSchema is the resolver for the setContactSchema field.
+func (r *mutationResolver) SetContactSchema(ctx context.Context, fields []*model.CustomFieldInput, defaultPhoneRegion *string) (*model.ContactSchema, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("contact_schema:set failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	schema, err := r.app.SetContactSchema(ctx, usecase.CmdSetContactSchema{
+		Owner:              user,
+		Fields:             toCmdCustomFieldDefinitions(fields),
+		DefaultPhoneRegion: stringValue(defaultPhoneRegion),
 	})
 	if err != nil {
-		return nil, err
+		return nil, toGQLError(ctx, err)
+	}
+
+	return toGQLContactSchema(schema), nil
+}
+
+// CreateGroup is the resolver for the createGroup field.
+func (r *mutationResolver) CreateGroup(ctx context.Context, input model.NewGroup) (*model.Group, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:create failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	group, err := r.app.CreateGroup(
+		ctx,
+		usecase.CmdCreateGroup{
+			Creator: user,
+			Name:    input.Name,
+		},
+	)
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return toGQLGroup(group), nil
+}
+
+// UpdateGroup is the resolver for the updateGroup field.
+func (r *mutationResolver) UpdateGroup(ctx context.Context, id string, input model.NewGroup) (*model.Group, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:update failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	group, err := r.app.UpdateGroup(
+		ctx,
+		usecase.CmdUpdateGroup{
+			Updater: user,
+			GroupId: id,
+			Name:    input.Name,
+		},
+	)
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return toGQLGroup(group), nil
+}
+
+// DeleteGroup is the resolver for the deleteGroup field.
+func (r *mutationResolver) DeleteGroup(ctx context.Context, id string) (*model.Group, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:delete failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	err = r.app.DeleteGroup(
+		ctx,
+		usecase.CmdDeleteGroup{
+			Deleter: user,
+			GroupId: id,
+		},
+	)
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return &model.Group{ID: id}, nil
+}
+
+// AddGroupContact is the resolver for the addGroupContact field.
+func (r *mutationResolver) AddGroupContact(ctx context.Context, id string, contactID string) (*model.Contact, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:add_contact failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	contact, err := r.app.AddGroupContact(
+		ctx,
+		usecase.CmdAddGroupContact{
+			Requester: user,
+			GroupId:   id,
+			ContactId: contactID,
+		},
+	)
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return toGQLContact(contact), nil
+}
+
+// RemoveGroupContact is the resolver for the removeGroupContact field.
+func (r *mutationResolver) RemoveGroupContact(ctx context.Context, id string, contactID string) (*model.Contact, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:remove_contact failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	contact, err := r.app.RemoveGroupContact(
+		ctx,
+		usecase.CmdRemoveGroupContact{
+			Requester: user,
+			GroupId:   id,
+			ContactId: contactID,
+		},
+	)
+	if err != nil {
+		return nil, toGQLError(ctx, err)
 	}
 
-	return toGQLContacts(contacts), nil
+	return toGQLContact(contact), nil
 }
 
+// DeleteGroupContacts is the resolver for the deleteGroupContacts field.
+func (r *mutationResolver) DeleteGroupContacts(ctx context.Context, id string, atomic *bool) ([]*model.BatchResult, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:delete_contacts failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	results, err := r.app.DeleteGroupContacts(
+		ctx,
+		usecase.CmdDeleteGroupContacts{
+			Deleter: user,
+			GroupId: id,
+			Atomic:  boolValue(atomic),
+		},
+	)
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return toGQLBatchResults(results.Delete), nil
+}
+
+// ShareGroupContacts is the resolver for the shareGroupContacts field.
+func (r *mutationResolver) ShareGroupContacts(ctx context.Context, id string, userID string, permission model.Permission) ([]*model.BatchResult, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:share_contacts failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	results, err := r.app.ShareGroupContacts(
+		ctx,
+		usecase.CmdShareGroupContacts{
+			Sharer:     user,
+			GroupId:    id,
+			UserId:     userID,
+			Permission: strings.ToLower(permission.String()),
+		},
+	)
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return toGQLBatchResults(results), nil
+}
+
+// RelateContacts is the resolver for the relateContacts field.
+func (r *mutationResolver) RelateContacts(ctx context.Context, id string, relatedID string, typeArg model.RelationshipType, onDelete *model.OnDelete) (*model.Contact, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:relate failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	cmd := usecase.CmdRelateContacts{
+		Requester: user,
+		ContactId: id,
+		RelatedId: relatedID,
+		Type:      toDomainRelationshipType(typeArg),
+	}
+	if onDelete != nil {
+		cmd.OnDelete = toDomainOnDelete(*onDelete)
+	}
+
+	contact, err := r.app.RelateContacts(ctx, cmd)
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return toGQLContact(contact), nil
+}
+
+// UnrelateContacts is the resolver for the unrelateContacts field.
+func (r *mutationResolver) UnrelateContacts(ctx context.Context, id string, relatedID string, typeArg model.RelationshipType) (*model.Contact, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:unrelate failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	contact, err := r.app.UnrelateContacts(
+		ctx,
+		usecase.CmdUnrelateContacts{
+			Requester: user,
+			ContactId: id,
+			RelatedId: relatedID,
+			Type:      toDomainRelationshipType(typeArg),
+		},
+	)
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return toGQLContact(contact), nil
+}
+
+// Contact is the resolver for the contact field.
+func (r *queryResolver) Contact(ctx context.Context, id string) (*model.Contact, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:get failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	contact, err := r.app.GetContact(
+		ctx,
+		usecase.QueryGetContact{
+			Requester: user,
+			ContactId: id,
+		},
+	)
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return toGQLContact(contact), nil
+}
+
+// ListContacts is the resolver for the listContacts field.
+func (r *queryResolver) ListContacts(ctx context.Context, filter *model.ContactFilter, sort *model.ContactSort, first *int, after *string) (*model.ContactConnection, error) {
+	return r.listContacts(ctx, filter, sort, first, after, false)
+}
+
+// ListTrash is the resolver for the listTrash field.
+func (r *queryResolver) ListTrash(ctx context.Context, filter *model.ContactFilter, sort *model.ContactSort, first *int, after *string) (*model.ContactConnection, error) {
+	return r.listContacts(ctx, filter, sort, first, after, true)
+}
+
+// AddressBook is the resolver for the addressBook field.
+func (r *queryResolver) AddressBook(ctx context.Context, id string) (*model.AddressBook, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("address_books:get failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	addressBook, err := r.app.GetAddressBook(
+		ctx,
+		usecase.QueryGetAddressBook{
+			Requester:     user,
+			AddressBookId: id,
+		},
+	)
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return toGQLAddressBook(addressBook), nil
+}
+
+// AddressBooks is the resolver for the addressBooks field.
+func (r *queryResolver) AddressBooks(ctx context.Context) ([]*model.AddressBook, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("address_books:list failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	addressBooks, err := r.app.ListAddressBooks(ctx, usecase.QueryListAddressBooks{Member: user})
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return toGQLAddressBooks(addressBooks), nil
+}
+
+// ContactSchema is the resolver for the contactSchema field.
+func (r *queryResolver) ContactSchema(ctx context.Context) (*model.ContactSchema, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("contact_schema:get failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	schema, err := r.app.GetContactSchema(ctx, usecase.QueryGetContactSchema{Owner: user})
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return toGQLContactSchema(schema), nil
+}
+
+// Group is the resolver for the group field.
+func (r *queryResolver) Group(ctx context.Context, id string) (*model.Group, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:get failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	group, err := r.app.GetGroup(
+		ctx,
+		usecase.QueryGetGroup{
+			Requester: user,
+			GroupId:   id,
+		},
+	)
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return toGQLGroup(group), nil
+}
+
+// Groups is the resolver for the groups field.
+func (r *queryResolver) Groups(ctx context.Context) ([]*model.Group, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:list failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	groups, err := r.app.ListGroups(ctx, usecase.QueryListGroups{Owner: user})
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	return toGQLGroups(groups), nil
+}
+
+// ContactChanged is the resolver for the contactChanged field.
+func (r *subscriptionResolver) ContactChanged(ctx context.Context) (<-chan *model.ContactChange, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:watch failed to get user from context")
+		return nil, auth.ErrUnauthorized
+	}
+
+	changes, err := r.app.WatchContacts(ctx, usecase.QueryWatchContacts{Watcher: user})
+	if err != nil {
+		return nil, toGQLError(ctx, err)
+	}
+
+	gqlChanges := make(chan *model.ContactChange)
+	go func() {
+		// the subscription ends once the use case closes the changes
+		defer close(gqlChanges)
+		for change := range changes {
+			select {
+			case gqlChanges <- toGQLContactChange(change):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return gqlChanges, nil
+}
+
+// Contact returns ContactResolver implementation.
+func (r *Resolver) Contact() ContactResolver { return &contactResolver{r} }
+
 // Mutation returns MutationResolver implementation.
 func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
 
 // Query returns QueryResolver implementation.
 func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
 
+// Subscription returns SubscriptionResolver implementation.
+func (r *Resolver) Subscription() SubscriptionResolver { return &subscriptionResolver{r} }
+
+type contactResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type subscriptionResolver struct{ *Resolver }
@@ -0,0 +1,214 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/internal/usecase"
+	"github.com/davidterranova/contacts/pkg/auth"
+	"github.com/rs/zerolog/log"
+)
+
+func (h *Handler) ListAddressBooks(ctx context.Context, req *ListAddressBooksRequest) (*ListAddressBooksResponse, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("address_books:list failed to get user from context")
+		return nil, err
+	}
+
+	addressBooks, err := h.app.ListAddressBooks(ctx, usecase.QueryListAddressBooks{Member: user})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	var pbAddressBooks = make([]*AddressBook, 0, len(addressBooks))
+	for _, addressBook := range addressBooks {
+		pbAddressBooks = append(pbAddressBooks, toPBAddressBook(addressBook))
+	}
+
+	return &ListAddressBooksResponse{
+		AddressBooks: pbAddressBooks,
+	}, nil
+}
+
+func (h *Handler) GetAddressBook(ctx context.Context, req *GetAddressBookRequest) (*GetAddressBookResponse, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("address_books:get failed to get user from context")
+		return nil, err
+	}
+
+	addressBook, err := h.app.GetAddressBook(
+		ctx,
+		usecase.QueryGetAddressBook{
+			Requester:     user,
+			AddressBookId: req.Id,
+		},
+	)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &GetAddressBookResponse{
+		AddressBook: toPBAddressBook(addressBook),
+	}, nil
+}
+
+func (h *Handler) CreateAddressBook(ctx context.Context, req *CreateAddressBookRequest) (*CreateAddressBookResponse, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("address_books:create failed to get user from context")
+		return nil, err
+	}
+
+	addressBook, err := h.app.CreateAddressBook(
+		ctx,
+		usecase.CmdCreateAddressBook{
+			Creator: user,
+			Name:    req.Name,
+		},
+	)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &CreateAddressBookResponse{
+		AddressBook: toPBAddressBook(addressBook),
+	}, nil
+}
+
+func (h *Handler) UpdateAddressBook(ctx context.Context, req *UpdateAddressBookRequest) (*UpdateAddressBookResponse, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("address_books:update failed to get user from context")
+		return nil, err
+	}
+
+	addressBook, err := h.app.UpdateAddressBook(
+		ctx,
+		usecase.CmdUpdateAddressBook{
+			Updater:       user,
+			AddressBookId: req.Id,
+			Name:          req.Name,
+		},
+	)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &UpdateAddressBookResponse{
+		AddressBook: toPBAddressBook(addressBook),
+	}, nil
+}
+
+func (h *Handler) DeleteAddressBook(ctx context.Context, req *DeleteAddressBookRequest) (*DeleteAddressBookResponse, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("address_books:delete failed to get user from context")
+		return nil, err
+	}
+
+	err = h.app.DeleteAddressBook(
+		ctx,
+		usecase.CmdDeleteAddressBook{
+			Deleter:       user,
+			AddressBookId: req.Id,
+		},
+	)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &DeleteAddressBookResponse{}, nil
+}
+
+func (h *Handler) SetAddressBookMember(ctx context.Context, req *SetAddressBookMemberRequest) (*SetAddressBookMemberResponse, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("address_books:set_member failed to get user from context")
+		return nil, err
+	}
+
+	addressBook, err := h.app.SetAddressBookMember(
+		ctx,
+		usecase.CmdSetAddressBookMember{
+			Updater:       user,
+			AddressBookId: req.Id,
+			UserId:        req.UserId,
+			Role:          string(toDomainRole(req.Role)),
+		},
+	)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &SetAddressBookMemberResponse{
+		AddressBook: toPBAddressBook(addressBook),
+	}, nil
+}
+
+func (h *Handler) RemoveAddressBookMember(ctx context.Context, req *RemoveAddressBookMemberRequest) (*RemoveAddressBookMemberResponse, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("address_books:remove_member failed to get user from context")
+		return nil, err
+	}
+
+	addressBook, err := h.app.RemoveAddressBookMember(
+		ctx,
+		usecase.CmdRemoveAddressBookMember{
+			Updater:       user,
+			AddressBookId: req.Id,
+			UserId:        req.UserId,
+		},
+	)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &RemoveAddressBookMemberResponse{
+		AddressBook: toPBAddressBook(addressBook),
+	}, nil
+}
+
+func toPBAddressBook(addressBook *domain.AddressBook) *AddressBook {
+	var members = make([]*Member, 0, len(addressBook.Members))
+	for _, member := range addressBook.Members {
+		members = append(members, &Member{
+			UserId:  member.UserId.String(),
+			Role:    toPBRole(member.Role),
+			AddedAt: member.AddedAt.Format(layout),
+		})
+	}
+
+	return &AddressBook{
+		Id:        addressBook.Id.String(),
+		CreatedAt: addressBook.CreatedAt.Format(layout),
+		UpdatedAt: addressBook.UpdatedAt.Format(layout),
+		CreatedBy: addressBook.CreatedBy.String(),
+		Name:      addressBook.Name,
+		Members:   members,
+	}
+}
+
+func toPBRole(role domain.Role) Role {
+	switch role {
+	case domain.RoleOwner:
+		return Role_ROLE_OWNER
+	case domain.RoleEditor:
+		return Role_ROLE_EDITOR
+	default:
+		return Role_ROLE_VIEWER
+	}
+}
+
+func toDomainRole(role Role) domain.Role {
+	switch role {
+	case Role_ROLE_OWNER:
+		return domain.RoleOwner
+	case Role_ROLE_EDITOR:
+		return domain.RoleEditor
+	default:
+		return domain.RoleViewer
+	}
+}
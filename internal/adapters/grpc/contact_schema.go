@@ -0,0 +1,108 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/internal/usecase"
+	"github.com/davidterranova/contacts/pkg/auth"
+	"github.com/rs/zerolog/log"
+)
+
+func (h *Handler) GetContactSchema(ctx context.Context, req *GetContactSchemaRequest) (*GetContactSchemaResponse, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("contact_schema:get failed to get user from context")
+		return nil, err
+	}
+
+	schema, err := h.app.GetContactSchema(ctx, usecase.QueryGetContactSchema{Owner: user})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &GetContactSchemaResponse{
+		Schema: toPBContactSchema(schema),
+	}, nil
+}
+
+func (h *Handler) SetContactSchema(ctx context.Context, req *SetContactSchemaRequest) (*SetContactSchemaResponse, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("contact_schema:set failed to get user from context")
+		return nil, err
+	}
+
+	fields := make([]usecase.CustomField, 0, len(req.Fields))
+	for _, field := range req.Fields {
+		fields = append(fields, usecase.CustomField{
+			Name:     field.Name,
+			Type:     toDomainFieldType(field.Type),
+			Required: field.Required,
+			Min:      field.Min,
+			Max:      field.Max,
+			Pattern:  field.Pattern,
+			Options:  field.Options,
+		})
+	}
+
+	schema, err := h.app.SetContactSchema(ctx, usecase.CmdSetContactSchema{
+		Owner:              user,
+		Fields:             fields,
+		DefaultPhoneRegion: req.DefaultPhoneRegion,
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &SetContactSchemaResponse{
+		Schema: toPBContactSchema(schema),
+	}, nil
+}
+
+var fieldTypes = map[domain.FieldType]FieldType{
+	domain.FieldTypeString: FieldType_FIELD_TYPE_STRING,
+	domain.FieldTypeNumber: FieldType_FIELD_TYPE_NUMBER,
+	domain.FieldTypeDate:   FieldType_FIELD_TYPE_DATE,
+	domain.FieldTypeEnum:   FieldType_FIELD_TYPE_ENUM,
+	domain.FieldTypeURL:    FieldType_FIELD_TYPE_URL,
+}
+
+func toPBContactSchema(schema *domain.ContactSchema) *ContactSchema {
+	pbSchema := &ContactSchema{
+		OwnerId:            schema.OwnerId.String(),
+		Fields:             make([]*CustomField, 0, len(schema.Fields)),
+		DefaultPhoneRegion: schema.DefaultPhoneRegion,
+	}
+	if !schema.UpdatedAt.IsZero() {
+		pbSchema.UpdatedAt = schema.UpdatedAt.Format(layout)
+	}
+	for _, field := range schema.Fields {
+		pbSchema.Fields = append(pbSchema.Fields, &CustomField{
+			Name:     field.Name,
+			Type:     fieldTypes[field.Type],
+			Required: field.Required,
+			Min:      field.Min,
+			Max:      field.Max,
+			Pattern:  field.Pattern,
+			Options:  field.Options,
+		})
+	}
+
+	return pbSchema
+}
+
+func toDomainFieldType(fieldType FieldType) string {
+	switch fieldType {
+	case FieldType_FIELD_TYPE_NUMBER:
+		return string(domain.FieldTypeNumber)
+	case FieldType_FIELD_TYPE_DATE:
+		return string(domain.FieldTypeDate)
+	case FieldType_FIELD_TYPE_ENUM:
+		return string(domain.FieldTypeEnum)
+	case FieldType_FIELD_TYPE_URL:
+		return string(domain.FieldTypeURL)
+	default:
+		return string(domain.FieldTypeString)
+	}
+}
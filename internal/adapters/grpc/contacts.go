@@ -2,20 +2,55 @@ package grpc
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/davidterranova/contacts/internal/domain"
 	"github.com/davidterranova/contacts/internal/usecase"
 	"github.com/davidterranova/contacts/pkg/auth"
+	"github.com/davidterranova/contacts/pkg/phone"
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 const layout = "2006-01-02T15:04:05Z"
 
 type App interface {
-	ListContacts(ctx context.Context, query usecase.QueryListContact) ([]*domain.Contact, error)
+	ListContacts(ctx context.Context, query usecase.QueryListContact) (*usecase.ContactPage, error)
+	GetContact(ctx context.Context, query usecase.QueryGetContact) (*domain.Contact, error)
 	CreateContact(ctx context.Context, cmd usecase.CmdCreateContact) (*domain.Contact, error)
 	UpdateContact(ctx context.Context, cmd usecase.CmdUpdateContact) (*domain.Contact, error)
 	DeleteContact(ctx context.Context, cmd usecase.CmdDeleteContact) error
+	RestoreContact(ctx context.Context, cmd usecase.CmdRestoreContact) (*domain.Contact, error)
+	ContactHistory(ctx context.Context, query usecase.QueryContactHistory) ([]*domain.AuditEntry, error)
+	ShareContact(ctx context.Context, cmd usecase.CmdShareContact) (*domain.Contact, error)
+	UnshareContact(ctx context.Context, cmd usecase.CmdUnshareContact) (*domain.Contact, error)
+	WatchContacts(ctx context.Context, query usecase.QueryWatchContacts) (<-chan usecase.ContactChange, error)
+	BatchContacts(ctx context.Context, cmd usecase.CmdBatchContacts) (usecase.BatchResults, error)
+
+	GetContactSchema(ctx context.Context, query usecase.QueryGetContactSchema) (*domain.ContactSchema, error)
+	SetContactSchema(ctx context.Context, cmd usecase.CmdSetContactSchema) (*domain.ContactSchema, error)
+
+	ListAddressBooks(ctx context.Context, query usecase.QueryListAddressBooks) ([]*domain.AddressBook, error)
+	GetAddressBook(ctx context.Context, query usecase.QueryGetAddressBook) (*domain.AddressBook, error)
+	CreateAddressBook(ctx context.Context, cmd usecase.CmdCreateAddressBook) (*domain.AddressBook, error)
+	UpdateAddressBook(ctx context.Context, cmd usecase.CmdUpdateAddressBook) (*domain.AddressBook, error)
+	DeleteAddressBook(ctx context.Context, cmd usecase.CmdDeleteAddressBook) error
+	SetAddressBookMember(ctx context.Context, cmd usecase.CmdSetAddressBookMember) (*domain.AddressBook, error)
+	RemoveAddressBookMember(ctx context.Context, cmd usecase.CmdRemoveAddressBookMember) (*domain.AddressBook, error)
+
+	ListGroups(ctx context.Context, query usecase.QueryListGroups) ([]*domain.Group, error)
+	GetGroup(ctx context.Context, query usecase.QueryGetGroup) (*domain.Group, error)
+	CreateGroup(ctx context.Context, cmd usecase.CmdCreateGroup) (*domain.Group, error)
+	UpdateGroup(ctx context.Context, cmd usecase.CmdUpdateGroup) (*domain.Group, error)
+	DeleteGroup(ctx context.Context, cmd usecase.CmdDeleteGroup) error
+	AddGroupContact(ctx context.Context, cmd usecase.CmdAddGroupContact) (*domain.Contact, error)
+	RemoveGroupContact(ctx context.Context, cmd usecase.CmdRemoveGroupContact) (*domain.Contact, error)
+	DeleteGroupContacts(ctx context.Context, cmd usecase.CmdDeleteGroupContacts) (usecase.BatchResults, error)
+	ShareGroupContacts(ctx context.Context, cmd usecase.CmdShareGroupContacts) ([]usecase.BatchResult, error)
 }
 
 type Handler struct {
@@ -29,21 +64,58 @@ func NewHandler(app App) *Handler {
 }
 
 func (h *Handler) ListContacts(ctx context.Context, req *ListContactsRequest) (*ListContactsResponse, error) {
+	return h.listContacts(ctx, req, false)
+}
+
+func (h *Handler) ListTrash(ctx context.Context, req *ListContactsRequest) (*ListContactsResponse, error) {
+	return h.listContacts(ctx, req, true)
+}
+
+func (h *Handler) listContacts(ctx context.Context, req *ListContactsRequest, trash bool) (*ListContactsResponse, error) {
 	user, err := auth.UserFromContext(ctx)
 	if err != nil {
 		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:list failed to get user from context")
 		return nil, err
 	}
 
-	contacts, err := h.app.ListContacts(ctx, usecase.QueryListContact{
-		CreatedBy: user,
-	})
+	query, err := toQueryListContact(req)
 	if err != nil {
-		return nil, err
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	query.Requester = user
+	query.Trash = trash
+
+	page, err := h.app.ListContacts(ctx, query)
+	if err != nil {
+		return nil, toStatusError(err)
 	}
 
 	return &ListContactsResponse{
-		Contacts: toPBContactList(contacts...),
+		Contacts:      toPBContactList(page.Contacts...),
+		NextPageToken: page.NextCursor,
+	}, nil
+}
+
+func (h *Handler) GetContact(ctx context.Context, req *GetContactRequest) (*GetContactResponse, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:get failed to get user from context")
+		return nil, err
+	}
+
+	contact, err := h.app.GetContact(
+		ctx,
+		usecase.QueryGetContact{
+			Requester: user,
+			ContactId: req.Id,
+		},
+	)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &GetContactResponse{
+		Contact: toPBContact(contact),
 	}, nil
 }
 
@@ -57,15 +129,21 @@ func (h *Handler) CreateContact(ctx context.Context, req *CreateContactRequest)
 	contact, err := h.app.CreateContact(
 		ctx,
 		usecase.CmdCreateContact{
-			CreatedBy: user,
-			FirstName: req.FirstName,
-			LastName:  req.LastName,
-			Email:     req.Email,
-			Phone:     req.Phone,
+			CreatedBy:     user,
+			AddressBookId: req.AddressBookId,
+			FirstName:     req.FirstName,
+			LastName:      req.LastName,
+			Email:         req.Email,
+			Phone:         req.Phone,
+			Emails:        toCmdEmails(req.Emails),
+			Phones:        toCmdPhones(req.Phones),
+			Addresses:     toCmdAddresses(req.Addresses),
+			CustomFields:  toCmdCustomFields(req.CustomFields),
+			PhoneRegion:   req.PhoneRegion,
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	return &CreateContactResponse{
@@ -83,16 +161,22 @@ func (h *Handler) UpdateContact(ctx context.Context, req *UpdateContactRequest)
 	contact, err := h.app.UpdateContact(
 		ctx,
 		usecase.CmdUpdateContact{
-			Updater:   user,
-			ContactId: req.Id,
-			FirstName: req.FirstName,
-			LastName:  req.LastName,
-			Email:     req.Email,
-			Phone:     req.Phone,
+			Updater:      user,
+			ContactId:    req.Id,
+			FirstName:    req.FirstName,
+			LastName:     req.LastName,
+			Email:        req.Email,
+			Phone:        req.Phone,
+			Emails:       toCmdEmails(req.Emails),
+			Phones:       toCmdPhones(req.Phones),
+			Addresses:    toCmdAddresses(req.Addresses),
+			CustomFields: toCmdCustomFields(req.CustomFields),
+			PhoneRegion:  req.PhoneRegion,
+			Version:      int(req.Version),
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	return &UpdateContactResponse{
@@ -112,24 +196,522 @@ func (h *Handler) DeleteContact(ctx context.Context, req *DeleteContactRequest)
 		usecase.CmdDeleteContact{
 			Deleter:   user,
 			ContactId: req.Id,
+			Version:   int(req.Version),
+		},
+	)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &DeleteContactResponse{}, nil
+}
+
+func (h *Handler) BatchCreateContacts(ctx context.Context, req *BatchCreateContactsRequest) (*BatchContactsResponse, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:batch_create failed to get user from context")
+		return nil, err
+	}
+
+	cmd := usecase.CmdBatchContacts{
+		Requester: user,
+		Atomic:    req.Atomic,
+		Create:    make([]usecase.CmdCreateContact, 0, len(req.Contacts)),
+	}
+	for _, contact := range req.Contacts {
+		cmd.Create = append(cmd.Create, usecase.CmdCreateContact{
+			AddressBookId: contact.AddressBookId,
+			FirstName:     contact.FirstName,
+			LastName:      contact.LastName,
+			Email:         contact.Email,
+			Phone:         contact.Phone,
+			Emails:        toCmdEmails(contact.Emails),
+			Phones:        toCmdPhones(contact.Phones),
+			Addresses:     toCmdAddresses(contact.Addresses),
+			CustomFields:  toCmdCustomFields(contact.CustomFields),
+			PhoneRegion:   contact.PhoneRegion,
+		})
+	}
+
+	results, err := h.app.BatchContacts(ctx, cmd)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &BatchContactsResponse{
+		Results: toPBBatchResults(results.Create),
+	}, nil
+}
+
+func (h *Handler) BatchDeleteContacts(ctx context.Context, req *BatchDeleteContactsRequest) (*BatchContactsResponse, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:batch_delete failed to get user from context")
+		return nil, err
+	}
+
+	cmd := usecase.CmdBatchContacts{
+		Requester: user,
+		Atomic:    req.Atomic,
+		Delete:    make([]usecase.CmdDeleteContact, 0, len(req.Contacts)),
+	}
+	for _, contact := range req.Contacts {
+		cmd.Delete = append(cmd.Delete, usecase.CmdDeleteContact{
+			ContactId: contact.Id,
+			Version:   int(contact.Version),
+		})
+	}
+
+	results, err := h.app.BatchContacts(ctx, cmd)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &BatchContactsResponse{
+		Results: toPBBatchResults(results.Delete),
+	}, nil
+}
+
+func (h *Handler) RestoreContact(ctx context.Context, req *RestoreContactRequest) (*RestoreContactResponse, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:restore failed to get user from context")
+		return nil, err
+	}
+
+	contact, err := h.app.RestoreContact(
+		ctx,
+		usecase.CmdRestoreContact{
+			Restorer:  user,
+			ContactId: req.Id,
 		},
 	)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
 
-	return nil, err
+	return &RestoreContactResponse{
+		Contact: toPBContact(contact),
+	}, nil
+}
+
+func (h *Handler) ContactHistory(ctx context.Context, req *ContactHistoryRequest) (*ContactHistoryResponse, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:history failed to get user from context")
+		return nil, err
+	}
+
+	entries, err := h.app.ContactHistory(
+		ctx,
+		usecase.QueryContactHistory{
+			Requester: user,
+			ContactId: req.Id,
+		},
+	)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &ContactHistoryResponse{
+		Entries: toPBAuditEntries(entries),
+	}, nil
+}
+
+func (h *Handler) ShareContact(ctx context.Context, req *ShareContactRequest) (*ShareContactResponse, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:share failed to get user from context")
+		return nil, err
+	}
+
+	contact, err := h.app.ShareContact(
+		ctx,
+		usecase.CmdShareContact{
+			Sharer:     user,
+			ContactId:  req.Id,
+			UserId:     req.UserId,
+			Permission: string(toDomainPermission(req.Permission)),
+		},
+	)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &ShareContactResponse{
+		Contact: toPBContact(contact),
+	}, nil
+}
+
+func (h *Handler) UnshareContact(ctx context.Context, req *UnshareContactRequest) (*UnshareContactResponse, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:unshare failed to get user from context")
+		return nil, err
+	}
+
+	contact, err := h.app.UnshareContact(
+		ctx,
+		usecase.CmdUnshareContact{
+			Sharer:    user,
+			ContactId: req.Id,
+			UserId:    req.UserId,
+		},
+	)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &UnshareContactResponse{
+		Contact: toPBContact(contact),
+	}, nil
+}
+
+func (h *Handler) WatchContacts(req *WatchContactsRequest, stream Contacts_WatchContactsServer) error {
+	ctx := stream.Context()
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:watch failed to get user from context")
+		return err
+	}
+
+	changes, err := h.app.WatchContacts(ctx, usecase.QueryWatchContacts{Watcher: user})
+	if err != nil {
+		return toStatusError(err)
+	}
+
+	for change := range changes {
+		err := stream.Send(&ContactChange{
+			Event:      change.Event,
+			OccurredAt: change.OccurredAt.Format(layout),
+			Contact:    toPBContact(change.Contact),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// changes are closed once the client cancels the stream, or when it lags behind
+	if ctx.Err() != nil {
+		return nil
+	}
+	return status.Error(codes.Unavailable, "contact changes lagged behind, contacts must be reloaded")
 }
 
 func (h *Handler) mustEmbedUnimplementedContactsServer() {}
 
+func toQueryListContact(req *ListContactsRequest) (usecase.QueryListContact, error) {
+	query := usecase.QueryListContact{
+		AddressBookId: req.AddressBookId,
+		GroupId:       req.GroupId,
+		Search:        req.Search,
+		FirstName:     toDomainFieldMatch(req.FirstName),
+		LastName:      toDomainFieldMatch(req.LastName),
+		Email:         toDomainFieldMatch(req.Email),
+		Phone:         toDomainFieldMatch(req.Phone),
+		Sort: domain.Sort{
+			Field: toDomainSortField(req.SortBy),
+			Desc:  req.Descending,
+		},
+		Limit:  int(req.PageSize),
+		Cursor: req.PageToken,
+	}
+	for name, match := range req.CustomFields {
+		if query.CustomFields == nil {
+			query.CustomFields = map[string]domain.FieldMatch{}
+		}
+		query.CustomFields[name] = toDomainFieldMatch(match)
+	}
+
+	bounds := []struct {
+		value string
+		time  **time.Time
+	}{
+		{req.CreatedAfter, &query.CreatedAt.From},
+		{req.CreatedBefore, &query.CreatedAt.To},
+		{req.UpdatedAfter, &query.UpdatedAt.From},
+		{req.UpdatedBefore, &query.UpdatedAt.To},
+	}
+	for _, bound := range bounds {
+		if bound.value == "" {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, bound.value)
+		if err != nil {
+			return query, fmt.Errorf("invalid date %q: %w", bound.value, err)
+		}
+		*bound.time = &t
+	}
+
+	return query, nil
+}
+
+func toDomainFieldMatch(match *StringMatch) domain.FieldMatch {
+	if match == nil {
+		return domain.FieldMatch{}
+	}
+
+	mode := domain.MatchExact
+	if match.Mode == MatchMode_MATCH_MODE_PREFIX {
+		mode = domain.MatchPrefix
+	}
+
+	return domain.FieldMatch{Value: match.Value, Mode: mode}
+}
+
+func toDomainSortField(field SortField) domain.SortField {
+	switch field {
+	case SortField_SORT_FIELD_UPDATED_AT:
+		return domain.SortByUpdatedAt
+	case SortField_SORT_FIELD_LAST_NAME:
+		return domain.SortByLastName
+	case SortField_SORT_FIELD_FIRST_NAME:
+		return domain.SortByFirstName
+	default:
+		return domain.SortByCreatedAt
+	}
+}
+
 func toPBContact(contact *domain.Contact) *Contact {
-	return &Contact{
-		Id:        contact.Id.String(),
-		CreatedAt: contact.CreatedAt.Format(layout),
-		UpdatedAt: contact.UpdatedAt.Format(layout),
-		FirstName: contact.FirstName,
-		LastName:  contact.LastName,
-		Email:     contact.Email,
-		Phone:     contact.Phone,
+	pbContact := &Contact{
+		Id:           contact.Id.String(),
+		CreatedAt:    contact.CreatedAt.Format(layout),
+		UpdatedAt:    contact.UpdatedAt.Format(layout),
+		FirstName:    contact.FirstName,
+		LastName:     contact.LastName,
+		Email:        contact.Email,
+		Phone:        contact.Phone,
+		Emails:       toPBEmails(contact.EmailAddresses()),
+		Phones:       toPBPhones(contact.PhoneNumbers()),
+		Addresses:    toPBAddresses(contact.Addresses),
+		CustomFields: toPBCustomFields(contact.CustomFields),
+		Shares:       toPBShares(contact.Grants),
+		GroupIds:     toPBGroupIds(contact.GroupIds),
+		Version:      int64(contact.Version),
+		EmailStatus:  emailStatuses[contact.EmailStatus],
+	}
+	if contact.AddressBookId != uuid.Nil {
+		pbContact.AddressBookId = contact.AddressBookId.String()
+	}
+	if contact.DeletedAt != nil {
+		pbContact.DeletedAt = contact.DeletedAt.Format(layout)
+	}
+
+	return pbContact
+}
+
+func toPBBatchResults(results []usecase.BatchResult) []*BatchResult {
+	statuses := map[usecase.BatchStatus]BatchStatus{
+		usecase.BatchStatusApplied: BatchStatus_BATCH_STATUS_APPLIED,
+		usecase.BatchStatusFailed:  BatchStatus_BATCH_STATUS_FAILED,
+		usecase.BatchStatusAborted: BatchStatus_BATCH_STATUS_ABORTED,
 	}
+
+	pbResults := make([]*BatchResult, 0, len(results))
+	for _, result := range results {
+		pbResult := &BatchResult{Status: statuses[result.Status]}
+		if result.Contact != nil {
+			pbResult.Contact = toPBContact(result.Contact)
+		}
+		if result.Err != nil {
+			st := status.Convert(toStatusError(result.Err))
+			pbResult.Code = int32(st.Code())
+			pbResult.Error = st.Message()
+		}
+		pbResults = append(pbResults, pbResult)
+	}
+
+	return pbResults
+}
+
+func toPBShares(grants []domain.Grant) []*Share {
+	var shares = make([]*Share, 0, len(grants))
+	for _, grant := range grants {
+		permission := Permission_PERMISSION_READ
+		if grant.Permission == domain.PermissionWrite {
+			permission = Permission_PERMISSION_WRITE
+		}
+
+		shares = append(shares, &Share{
+			UserId:     grant.UserId.String(),
+			Permission: permission,
+			GrantedAt:  grant.GrantedAt.Format(layout),
+		})
+	}
+
+	return shares
+}
+
+var labels = map[domain.Label]Label{
+	domain.LabelOther:  Label_LABEL_OTHER,
+	domain.LabelWork:   Label_LABEL_WORK,
+	domain.LabelHome:   Label_LABEL_HOME,
+	domain.LabelMobile: Label_LABEL_MOBILE,
+}
+
+func toPBEmails(emails []domain.EmailAddress) []*EmailAddress {
+	var pbEmails = make([]*EmailAddress, 0, len(emails))
+	for _, email := range emails {
+		pbEmails = append(pbEmails, &EmailAddress{Label: labels[email.Label], Address: email.Address, Primary: email.Primary})
+	}
+
+	return pbEmails
+}
+
+// emailStatuses are the statuses of the primary emails, unset statuses being unverified ones
+var emailStatuses = map[domain.EmailStatus]EmailStatus{
+	domain.EmailStatusDeliverable:   EmailStatus_EMAIL_STATUS_DELIVERABLE,
+	domain.EmailStatusUndeliverable: EmailStatus_EMAIL_STATUS_UNDELIVERABLE,
+	domain.EmailStatusUnknown:       EmailStatus_EMAIL_STATUS_UNKNOWN,
+}
+
+var phoneTypes = map[phone.Type]PhoneType{
+	phone.TypeMobile:        PhoneType_PHONE_TYPE_MOBILE,
+	phone.TypeFixed:         PhoneType_PHONE_TYPE_FIXED,
+	phone.TypeFixedOrMobile: PhoneType_PHONE_TYPE_FIXED_OR_MOBILE,
+	phone.TypeTollFree:      PhoneType_PHONE_TYPE_TOLL_FREE,
+}
+
+func toPBPhones(phones []domain.PhoneNumber) []*PhoneNumber {
+	var pbPhones = make([]*PhoneNumber, 0, len(phones))
+	for _, p := range phones {
+		pbPhone := &PhoneNumber{Label: labels[p.Label], Number: p.Number, Primary: p.Primary}
+		if n, err := phone.Parse(p.Number, ""); err == nil {
+			pbPhone.National = n.National()
+			pbPhone.International = n.International()
+			pbPhone.Type = phoneTypes[n.Type]
+		}
+		pbPhones = append(pbPhones, pbPhone)
+	}
+
+	return pbPhones
+}
+
+func toPBAddresses(addresses []domain.PostalAddress) []*PostalAddress {
+	var pbAddresses = make([]*PostalAddress, 0, len(addresses))
+	for _, address := range addresses {
+		pbAddresses = append(pbAddresses, &PostalAddress{
+			Label:      labels[address.Label],
+			Street:     address.Street,
+			Locality:   address.Locality,
+			Region:     address.Region,
+			PostalCode: address.PostalCode,
+			Country:    address.Country,
+			Primary:    address.Primary,
+		})
+	}
+
+	return pbAddresses
+}
+
+func toDomainLabel(label Label) string {
+	switch label {
+	case Label_LABEL_WORK:
+		return string(domain.LabelWork)
+	case Label_LABEL_HOME:
+		return string(domain.LabelHome)
+	case Label_LABEL_MOBILE:
+		return string(domain.LabelMobile)
+	default:
+		return string(domain.LabelOther)
+	}
+}
+
+func toCmdEmails(emails []*EmailAddress) []usecase.ContactEmail {
+	var cmdEmails []usecase.ContactEmail
+	for _, email := range emails {
+		cmdEmails = append(cmdEmails, usecase.ContactEmail{Label: toDomainLabel(email.Label), Address: email.Address, Primary: email.Primary})
+	}
+
+	return cmdEmails
+}
+
+func toCmdPhones(phones []*PhoneNumber) []usecase.ContactPhone {
+	var cmdPhones []usecase.ContactPhone
+	for _, p := range phones {
+		cmdPhones = append(cmdPhones, usecase.ContactPhone{Label: toDomainLabel(p.Label), Number: p.Number, Primary: p.Primary})
+	}
+
+	return cmdPhones
+}
+
+func toCmdAddresses(addresses []*PostalAddress) []usecase.ContactAddress {
+	var cmdAddresses []usecase.ContactAddress
+	for _, address := range addresses {
+		cmdAddresses = append(cmdAddresses, usecase.ContactAddress{
+			Label:      toDomainLabel(address.Label),
+			Street:     address.Street,
+			Locality:   address.Locality,
+			Region:     address.Region,
+			PostalCode: address.PostalCode,
+			Country:    address.Country,
+			Primary:    address.Primary,
+		})
+	}
+
+	return cmdAddresses
+}
+
+func toPBCustomFields(fields map[string]domain.CustomValue) map[string]*structpb.Value {
+	var pbFields = make(map[string]*structpb.Value, len(fields))
+	for name, value := range fields {
+		if value.Type == domain.FieldTypeNumber {
+			pbFields[name] = structpb.NewNumberValue(value.Number)
+		} else {
+			pbFields[name] = structpb.NewStringValue(value.Text)
+		}
+	}
+
+	return pbFields
+}
+
+// toCmdCustomFields keeps null values, they remove the fields on update
+func toCmdCustomFields(fields map[string]*structpb.Value) map[string]any {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	values := make(map[string]any, len(fields))
+	for name, value := range fields {
+		values[name] = value.AsInterface()
+	}
+
+	return values
+}
+
+func toPBAuditEntries(entries []*domain.AuditEntry) []*AuditEntry {
+	var pbEntries = make([]*AuditEntry, 0, len(entries))
+	for _, entry := range entries {
+		changes := make([]*FieldChange, 0, len(entry.Changes))
+		for _, change := range entry.Changes {
+			changes = append(changes, &FieldChange{
+				Field:  change.Field,
+				Before: change.Before,
+				After:  change.After,
+			})
+		}
+
+		pbEntries = append(pbEntries, &AuditEntry{
+			Id:        entry.Id.String(),
+			ContactId: entry.ContactId.String(),
+			ActorId:   entry.Actor.Id().String(),
+			ActorType: string(entry.Actor.Type()),
+			Action:    string(entry.Action),
+			At:        entry.At.Format(layout),
+			Changes:   changes,
+		})
+	}
+
+	return pbEntries
+}
+
+func toDomainPermission(permission Permission) domain.Permission {
+	if permission == Permission_PERMISSION_WRITE {
+		return domain.PermissionWrite
+	}
+
+	return domain.PermissionRead
 }
 
 func toPBContactList(contacts ...*domain.Contact) []*Contact {
@@ -23,9 +23,45 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ContactsClient interface {
 	ListContacts(ctx context.Context, in *ListContactsRequest, opts ...grpc.CallOption) (*ListContactsResponse, error)
+	GetContact(ctx context.Context, in *GetContactRequest, opts ...grpc.CallOption) (*GetContactResponse, error)
 	CreateContact(ctx context.Context, in *CreateContactRequest, opts ...grpc.CallOption) (*CreateContactResponse, error)
 	DeleteContact(ctx context.Context, in *DeleteContactRequest, opts ...grpc.CallOption) (*DeleteContactResponse, error)
+	// BatchCreateContacts and BatchDeleteContacts answer the result of every contact in the order of the request,
+	// an atomic batch is either applied entirely or reports the contacts that failed and aborts the others
+	BatchCreateContacts(ctx context.Context, in *BatchCreateContactsRequest, opts ...grpc.CallOption) (*BatchContactsResponse, error)
+	BatchDeleteContacts(ctx context.Context, in *BatchDeleteContactsRequest, opts ...grpc.CallOption) (*BatchContactsResponse, error)
+	// ListTrash lists the deleted contacts with the same filters as ListContacts
+	ListTrash(ctx context.Context, in *ListContactsRequest, opts ...grpc.CallOption) (*ListContactsResponse, error)
+	RestoreContact(ctx context.Context, in *RestoreContactRequest, opts ...grpc.CallOption) (*RestoreContactResponse, error)
+	// ContactHistory lists the audit entries of a contact from the oldest to the most recent
+	ContactHistory(ctx context.Context, in *ContactHistoryRequest, opts ...grpc.CallOption) (*ContactHistoryResponse, error)
 	UpdateContact(ctx context.Context, in *UpdateContactRequest, opts ...grpc.CallOption) (*UpdateContactResponse, error)
+	ShareContact(ctx context.Context, in *ShareContactRequest, opts ...grpc.CallOption) (*ShareContactResponse, error)
+	UnshareContact(ctx context.Context, in *UnshareContactRequest, opts ...grpc.CallOption) (*UnshareContactResponse, error)
+	// WatchContacts streams the changes of the contacts the user can read until the client cancels it.
+	// The stream fails with UNAVAILABLE when the client lags behind, it should reload the contacts and watch again.
+	WatchContacts(ctx context.Context, in *WatchContactsRequest, opts ...grpc.CallOption) (Contacts_WatchContactsClient, error)
+	ListAddressBooks(ctx context.Context, in *ListAddressBooksRequest, opts ...grpc.CallOption) (*ListAddressBooksResponse, error)
+	GetAddressBook(ctx context.Context, in *GetAddressBookRequest, opts ...grpc.CallOption) (*GetAddressBookResponse, error)
+	CreateAddressBook(ctx context.Context, in *CreateAddressBookRequest, opts ...grpc.CallOption) (*CreateAddressBookResponse, error)
+	UpdateAddressBook(ctx context.Context, in *UpdateAddressBookRequest, opts ...grpc.CallOption) (*UpdateAddressBookResponse, error)
+	DeleteAddressBook(ctx context.Context, in *DeleteAddressBookRequest, opts ...grpc.CallOption) (*DeleteAddressBookResponse, error)
+	SetAddressBookMember(ctx context.Context, in *SetAddressBookMemberRequest, opts ...grpc.CallOption) (*SetAddressBookMemberResponse, error)
+	RemoveAddressBookMember(ctx context.Context, in *RemoveAddressBookMemberRequest, opts ...grpc.CallOption) (*RemoveAddressBookMemberResponse, error)
+	// GetContactSchema and SetContactSchema manage the custom fields of the contacts created by the user
+	GetContactSchema(ctx context.Context, in *GetContactSchemaRequest, opts ...grpc.CallOption) (*GetContactSchemaResponse, error)
+	SetContactSchema(ctx context.Context, in *SetContactSchemaRequest, opts ...grpc.CallOption) (*SetContactSchemaResponse, error)
+	// groups are only visible to their creator, deleting a group keeps its contacts
+	ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error)
+	GetGroup(ctx context.Context, in *GetGroupRequest, opts ...grpc.CallOption) (*GetGroupResponse, error)
+	CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*CreateGroupResponse, error)
+	UpdateGroup(ctx context.Context, in *UpdateGroupRequest, opts ...grpc.CallOption) (*UpdateGroupResponse, error)
+	DeleteGroup(ctx context.Context, in *DeleteGroupRequest, opts ...grpc.CallOption) (*DeleteGroupResponse, error)
+	AddGroupContact(ctx context.Context, in *AddGroupContactRequest, opts ...grpc.CallOption) (*AddGroupContactResponse, error)
+	RemoveGroupContact(ctx context.Context, in *RemoveGroupContactRequest, opts ...grpc.CallOption) (*RemoveGroupContactResponse, error)
+	// DeleteGroupContacts and ShareGroupContacts answer the result of every contact of the group, oldest first
+	DeleteGroupContacts(ctx context.Context, in *DeleteGroupContactsRequest, opts ...grpc.CallOption) (*BatchContactsResponse, error)
+	ShareGroupContacts(ctx context.Context, in *ShareGroupContactsRequest, opts ...grpc.CallOption) (*BatchContactsResponse, error)
 }
 
 type contactsClient struct {
@@ -45,6 +81,15 @@ func (c *contactsClient) ListContacts(ctx context.Context, in *ListContactsReque
 	return out, nil
 }
 
+func (c *contactsClient) GetContact(ctx context.Context, in *GetContactRequest, opts ...grpc.CallOption) (*GetContactResponse, error) {
+	out := new(GetContactResponse)
+	err := c.cc.Invoke(ctx, "/grpc.Contacts/GetContact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *contactsClient) CreateContact(ctx context.Context, in *CreateContactRequest, opts ...grpc.CallOption) (*CreateContactResponse, error) {
 	out := new(CreateContactResponse)
 	err := c.cc.Invoke(ctx, "/grpc.Contacts/CreateContact", in, out, opts...)
@@ -63,6 +108,51 @@ func (c *contactsClient) DeleteContact(ctx context.Context, in *DeleteContactReq
 	return out, nil
 }
 
+func (c *contactsClient) BatchCreateContacts(ctx context.Context, in *BatchCreateContactsRequest, opts ...grpc.CallOption) (*BatchContactsResponse, error) {
+	out := new(BatchContactsResponse)
+	err := c.cc.Invoke(ctx, "/grpc.Contacts/BatchCreateContacts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsClient) BatchDeleteContacts(ctx context.Context, in *BatchDeleteContactsRequest, opts ...grpc.CallOption) (*BatchContactsResponse, error) {
+	out := new(BatchContactsResponse)
+	err := c.cc.Invoke(ctx, "/grpc.Contacts/BatchDeleteContacts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsClient) ListTrash(ctx context.Context, in *ListContactsRequest, opts ...grpc.CallOption) (*ListContactsResponse, error) {
+	out := new(ListContactsResponse)
+	err := c.cc.Invoke(ctx, "/grpc.Contacts/ListTrash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsClient) RestoreContact(ctx context.Context, in *RestoreContactRequest, opts ...grpc.CallOption) (*RestoreContactResponse, error) {
+	out := new(RestoreContactResponse)
+	err := c.cc.Invoke(ctx, "/grpc.Contacts/RestoreContact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsClient) ContactHistory(ctx context.Context, in *ContactHistoryRequest, opts ...grpc.CallOption) (*ContactHistoryResponse, error) {
+	out := new(ContactHistoryResponse)
+	err := c.cc.Invoke(ctx, "/grpc.Contacts/ContactHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *contactsClient) UpdateContact(ctx context.Context, in *UpdateContactRequest, opts ...grpc.CallOption) (*UpdateContactResponse, error) {
 	out := new(UpdateContactResponse)
 	err := c.cc.Invoke(ctx, "/grpc.Contacts/UpdateContact", in, out, opts...)
@@ -72,14 +162,262 @@ func (c *contactsClient) UpdateContact(ctx context.Context, in *UpdateContactReq
 	return out, nil
 }
 
+func (c *contactsClient) ShareContact(ctx context.Context, in *ShareContactRequest, opts ...grpc.CallOption) (*ShareContactResponse, error) {
+	out := new(ShareContactResponse)
+	err := c.cc.Invoke(ctx, "/grpc.Contacts/ShareContact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsClient) UnshareContact(ctx context.Context, in *UnshareContactRequest, opts ...grpc.CallOption) (*UnshareContactResponse, error) {
+	out := new(UnshareContactResponse)
+	err := c.cc.Invoke(ctx, "/grpc.Contacts/UnshareContact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsClient) WatchContacts(ctx context.Context, in *WatchContactsRequest, opts ...grpc.CallOption) (Contacts_WatchContactsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Contacts_ServiceDesc.Streams[0], "/grpc.Contacts/WatchContacts", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &contactsWatchContactsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Contacts_WatchContactsClient interface {
+	Recv() (*ContactChange, error)
+	grpc.ClientStream
+}
+
+type contactsWatchContactsClient struct {
+	grpc.ClientStream
+}
+
+func (x *contactsWatchContactsClient) Recv() (*ContactChange, error) {
+	m := new(ContactChange)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *contactsClient) ListAddressBooks(ctx context.Context, in *ListAddressBooksRequest, opts ...grpc.CallOption) (*ListAddressBooksResponse, error) {
+	out := new(ListAddressBooksResponse)
+	err := c.cc.Invoke(ctx, "/grpc.Contacts/ListAddressBooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsClient) GetAddressBook(ctx context.Context, in *GetAddressBookRequest, opts ...grpc.CallOption) (*GetAddressBookResponse, error) {
+	out := new(GetAddressBookResponse)
+	err := c.cc.Invoke(ctx, "/grpc.Contacts/GetAddressBook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsClient) CreateAddressBook(ctx context.Context, in *CreateAddressBookRequest, opts ...grpc.CallOption) (*CreateAddressBookResponse, error) {
+	out := new(CreateAddressBookResponse)
+	err := c.cc.Invoke(ctx, "/grpc.Contacts/CreateAddressBook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsClient) UpdateAddressBook(ctx context.Context, in *UpdateAddressBookRequest, opts ...grpc.CallOption) (*UpdateAddressBookResponse, error) {
+	out := new(UpdateAddressBookResponse)
+	err := c.cc.Invoke(ctx, "/grpc.Contacts/UpdateAddressBook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsClient) DeleteAddressBook(ctx context.Context, in *DeleteAddressBookRequest, opts ...grpc.CallOption) (*DeleteAddressBookResponse, error) {
+	out := new(DeleteAddressBookResponse)
+	err := c.cc.Invoke(ctx, "/grpc.Contacts/DeleteAddressBook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsClient) SetAddressBookMember(ctx context.Context, in *SetAddressBookMemberRequest, opts ...grpc.CallOption) (*SetAddressBookMemberResponse, error) {
+	out := new(SetAddressBookMemberResponse)
+	err := c.cc.Invoke(ctx, "/grpc.Contacts/SetAddressBookMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsClient) RemoveAddressBookMember(ctx context.Context, in *RemoveAddressBookMemberRequest, opts ...grpc.CallOption) (*RemoveAddressBookMemberResponse, error) {
+	out := new(RemoveAddressBookMemberResponse)
+	err := c.cc.Invoke(ctx, "/grpc.Contacts/RemoveAddressBookMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsClient) GetContactSchema(ctx context.Context, in *GetContactSchemaRequest, opts ...grpc.CallOption) (*GetContactSchemaResponse, error) {
+	out := new(GetContactSchemaResponse)
+	err := c.cc.Invoke(ctx, "/grpc.Contacts/GetContactSchema", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsClient) SetContactSchema(ctx context.Context, in *SetContactSchemaRequest, opts ...grpc.CallOption) (*SetContactSchemaResponse, error) {
+	out := new(SetContactSchemaResponse)
+	err := c.cc.Invoke(ctx, "/grpc.Contacts/SetContactSchema", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsClient) ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error) {
+	out := new(ListGroupsResponse)
+	err := c.cc.Invoke(ctx, "/grpc.Contacts/ListGroups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsClient) GetGroup(ctx context.Context, in *GetGroupRequest, opts ...grpc.CallOption) (*GetGroupResponse, error) {
+	out := new(GetGroupResponse)
+	err := c.cc.Invoke(ctx, "/grpc.Contacts/GetGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsClient) CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*CreateGroupResponse, error) {
+	out := new(CreateGroupResponse)
+	err := c.cc.Invoke(ctx, "/grpc.Contacts/CreateGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsClient) UpdateGroup(ctx context.Context, in *UpdateGroupRequest, opts ...grpc.CallOption) (*UpdateGroupResponse, error) {
+	out := new(UpdateGroupResponse)
+	err := c.cc.Invoke(ctx, "/grpc.Contacts/UpdateGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsClient) DeleteGroup(ctx context.Context, in *DeleteGroupRequest, opts ...grpc.CallOption) (*DeleteGroupResponse, error) {
+	out := new(DeleteGroupResponse)
+	err := c.cc.Invoke(ctx, "/grpc.Contacts/DeleteGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsClient) AddGroupContact(ctx context.Context, in *AddGroupContactRequest, opts ...grpc.CallOption) (*AddGroupContactResponse, error) {
+	out := new(AddGroupContactResponse)
+	err := c.cc.Invoke(ctx, "/grpc.Contacts/AddGroupContact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsClient) RemoveGroupContact(ctx context.Context, in *RemoveGroupContactRequest, opts ...grpc.CallOption) (*RemoveGroupContactResponse, error) {
+	out := new(RemoveGroupContactResponse)
+	err := c.cc.Invoke(ctx, "/grpc.Contacts/RemoveGroupContact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsClient) DeleteGroupContacts(ctx context.Context, in *DeleteGroupContactsRequest, opts ...grpc.CallOption) (*BatchContactsResponse, error) {
+	out := new(BatchContactsResponse)
+	err := c.cc.Invoke(ctx, "/grpc.Contacts/DeleteGroupContacts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contactsClient) ShareGroupContacts(ctx context.Context, in *ShareGroupContactsRequest, opts ...grpc.CallOption) (*BatchContactsResponse, error) {
+	out := new(BatchContactsResponse)
+	err := c.cc.Invoke(ctx, "/grpc.Contacts/ShareGroupContacts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ContactsServer is the server API for Contacts service.
 // All implementations must embed UnimplementedContactsServer
 // for forward compatibility
 type ContactsServer interface {
 	ListContacts(context.Context, *ListContactsRequest) (*ListContactsResponse, error)
+	GetContact(context.Context, *GetContactRequest) (*GetContactResponse, error)
 	CreateContact(context.Context, *CreateContactRequest) (*CreateContactResponse, error)
 	DeleteContact(context.Context, *DeleteContactRequest) (*DeleteContactResponse, error)
+	// BatchCreateContacts and BatchDeleteContacts answer the result of every contact in the order of the request,
+	// an atomic batch is either applied entirely or reports the contacts that failed and aborts the others
+	BatchCreateContacts(context.Context, *BatchCreateContactsRequest) (*BatchContactsResponse, error)
+	BatchDeleteContacts(context.Context, *BatchDeleteContactsRequest) (*BatchContactsResponse, error)
+	// ListTrash lists the deleted contacts with the same filters as ListContacts
+	ListTrash(context.Context, *ListContactsRequest) (*ListContactsResponse, error)
+	RestoreContact(context.Context, *RestoreContactRequest) (*RestoreContactResponse, error)
+	// ContactHistory lists the audit entries of a contact from the oldest to the most recent
+	ContactHistory(context.Context, *ContactHistoryRequest) (*ContactHistoryResponse, error)
 	UpdateContact(context.Context, *UpdateContactRequest) (*UpdateContactResponse, error)
+	ShareContact(context.Context, *ShareContactRequest) (*ShareContactResponse, error)
+	UnshareContact(context.Context, *UnshareContactRequest) (*UnshareContactResponse, error)
+	// WatchContacts streams the changes of the contacts the user can read until the client cancels it.
+	// The stream fails with UNAVAILABLE when the client lags behind, it should reload the contacts and watch again.
+	WatchContacts(*WatchContactsRequest, Contacts_WatchContactsServer) error
+	ListAddressBooks(context.Context, *ListAddressBooksRequest) (*ListAddressBooksResponse, error)
+	GetAddressBook(context.Context, *GetAddressBookRequest) (*GetAddressBookResponse, error)
+	CreateAddressBook(context.Context, *CreateAddressBookRequest) (*CreateAddressBookResponse, error)
+	UpdateAddressBook(context.Context, *UpdateAddressBookRequest) (*UpdateAddressBookResponse, error)
+	DeleteAddressBook(context.Context, *DeleteAddressBookRequest) (*DeleteAddressBookResponse, error)
+	SetAddressBookMember(context.Context, *SetAddressBookMemberRequest) (*SetAddressBookMemberResponse, error)
+	RemoveAddressBookMember(context.Context, *RemoveAddressBookMemberRequest) (*RemoveAddressBookMemberResponse, error)
+	// GetContactSchema and SetContactSchema manage the custom fields of the contacts created by the user
+	GetContactSchema(context.Context, *GetContactSchemaRequest) (*GetContactSchemaResponse, error)
+	SetContactSchema(context.Context, *SetContactSchemaRequest) (*SetContactSchemaResponse, error)
+	// groups are only visible to their creator, deleting a group keeps its contacts
+	ListGroups(context.Context, *ListGroupsRequest) (*ListGroupsResponse, error)
+	GetGroup(context.Context, *GetGroupRequest) (*GetGroupResponse, error)
+	CreateGroup(context.Context, *CreateGroupRequest) (*CreateGroupResponse, error)
+	UpdateGroup(context.Context, *UpdateGroupRequest) (*UpdateGroupResponse, error)
+	DeleteGroup(context.Context, *DeleteGroupRequest) (*DeleteGroupResponse, error)
+	AddGroupContact(context.Context, *AddGroupContactRequest) (*AddGroupContactResponse, error)
+	RemoveGroupContact(context.Context, *RemoveGroupContactRequest) (*RemoveGroupContactResponse, error)
+	// DeleteGroupContacts and ShareGroupContacts answer the result of every contact of the group, oldest first
+	DeleteGroupContacts(context.Context, *DeleteGroupContactsRequest) (*BatchContactsResponse, error)
+	ShareGroupContacts(context.Context, *ShareGroupContactsRequest) (*BatchContactsResponse, error)
 	mustEmbedUnimplementedContactsServer()
 }
 
@@ -90,15 +428,96 @@ type UnimplementedContactsServer struct {
 func (UnimplementedContactsServer) ListContacts(context.Context, *ListContactsRequest) (*ListContactsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListContacts not implemented")
 }
+func (UnimplementedContactsServer) GetContact(context.Context, *GetContactRequest) (*GetContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContact not implemented")
+}
 func (UnimplementedContactsServer) CreateContact(context.Context, *CreateContactRequest) (*CreateContactResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateContact not implemented")
 }
 func (UnimplementedContactsServer) DeleteContact(context.Context, *DeleteContactRequest) (*DeleteContactResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteContact not implemented")
 }
+func (UnimplementedContactsServer) BatchCreateContacts(context.Context, *BatchCreateContactsRequest) (*BatchContactsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateContacts not implemented")
+}
+func (UnimplementedContactsServer) BatchDeleteContacts(context.Context, *BatchDeleteContactsRequest) (*BatchContactsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeleteContacts not implemented")
+}
+func (UnimplementedContactsServer) ListTrash(context.Context, *ListContactsRequest) (*ListContactsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrash not implemented")
+}
+func (UnimplementedContactsServer) RestoreContact(context.Context, *RestoreContactRequest) (*RestoreContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreContact not implemented")
+}
+func (UnimplementedContactsServer) ContactHistory(context.Context, *ContactHistoryRequest) (*ContactHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ContactHistory not implemented")
+}
 func (UnimplementedContactsServer) UpdateContact(context.Context, *UpdateContactRequest) (*UpdateContactResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateContact not implemented")
 }
+func (UnimplementedContactsServer) ShareContact(context.Context, *ShareContactRequest) (*ShareContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareContact not implemented")
+}
+func (UnimplementedContactsServer) UnshareContact(context.Context, *UnshareContactRequest) (*UnshareContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnshareContact not implemented")
+}
+func (UnimplementedContactsServer) WatchContacts(*WatchContactsRequest, Contacts_WatchContactsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchContacts not implemented")
+}
+func (UnimplementedContactsServer) ListAddressBooks(context.Context, *ListAddressBooksRequest) (*ListAddressBooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAddressBooks not implemented")
+}
+func (UnimplementedContactsServer) GetAddressBook(context.Context, *GetAddressBookRequest) (*GetAddressBookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAddressBook not implemented")
+}
+func (UnimplementedContactsServer) CreateAddressBook(context.Context, *CreateAddressBookRequest) (*CreateAddressBookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAddressBook not implemented")
+}
+func (UnimplementedContactsServer) UpdateAddressBook(context.Context, *UpdateAddressBookRequest) (*UpdateAddressBookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAddressBook not implemented")
+}
+func (UnimplementedContactsServer) DeleteAddressBook(context.Context, *DeleteAddressBookRequest) (*DeleteAddressBookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAddressBook not implemented")
+}
+func (UnimplementedContactsServer) SetAddressBookMember(context.Context, *SetAddressBookMemberRequest) (*SetAddressBookMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAddressBookMember not implemented")
+}
+func (UnimplementedContactsServer) RemoveAddressBookMember(context.Context, *RemoveAddressBookMemberRequest) (*RemoveAddressBookMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveAddressBookMember not implemented")
+}
+func (UnimplementedContactsServer) GetContactSchema(context.Context, *GetContactSchemaRequest) (*GetContactSchemaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContactSchema not implemented")
+}
+func (UnimplementedContactsServer) SetContactSchema(context.Context, *SetContactSchemaRequest) (*SetContactSchemaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetContactSchema not implemented")
+}
+func (UnimplementedContactsServer) ListGroups(context.Context, *ListGroupsRequest) (*ListGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGroups not implemented")
+}
+func (UnimplementedContactsServer) GetGroup(context.Context, *GetGroupRequest) (*GetGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGroup not implemented")
+}
+func (UnimplementedContactsServer) CreateGroup(context.Context, *CreateGroupRequest) (*CreateGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGroup not implemented")
+}
+func (UnimplementedContactsServer) UpdateGroup(context.Context, *UpdateGroupRequest) (*UpdateGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateGroup not implemented")
+}
+func (UnimplementedContactsServer) DeleteGroup(context.Context, *DeleteGroupRequest) (*DeleteGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteGroup not implemented")
+}
+func (UnimplementedContactsServer) AddGroupContact(context.Context, *AddGroupContactRequest) (*AddGroupContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddGroupContact not implemented")
+}
+func (UnimplementedContactsServer) RemoveGroupContact(context.Context, *RemoveGroupContactRequest) (*RemoveGroupContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveGroupContact not implemented")
+}
+func (UnimplementedContactsServer) DeleteGroupContacts(context.Context, *DeleteGroupContactsRequest) (*BatchContactsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteGroupContacts not implemented")
+}
+func (UnimplementedContactsServer) ShareGroupContacts(context.Context, *ShareGroupContactsRequest) (*BatchContactsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareGroupContacts not implemented")
+}
 func (UnimplementedContactsServer) mustEmbedUnimplementedContactsServer() {}
 
 // UnsafeContactsServer may be embedded to opt out of forward compatibility for this service.
@@ -130,6 +549,24 @@ func _Contacts_ListContacts_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Contacts_GetContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServer).GetContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.Contacts/GetContact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServer).GetContact(ctx, req.(*GetContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Contacts_CreateContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateContactRequest)
 	if err := dec(in); err != nil {
@@ -166,48 +603,629 @@ func _Contacts_DeleteContact_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Contacts_UpdateContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateContactRequest)
+func _Contacts_BatchCreateContacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateContactsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ContactsServer).UpdateContact(ctx, in)
+		return srv.(ContactsServer).BatchCreateContacts(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/grpc.Contacts/UpdateContact",
+		FullMethod: "/grpc.Contacts/BatchCreateContacts",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ContactsServer).UpdateContact(ctx, req.(*UpdateContactRequest))
+		return srv.(ContactsServer).BatchCreateContacts(ctx, req.(*BatchCreateContactsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// Contacts_ServiceDesc is the grpc.ServiceDesc for Contacts service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var Contacts_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "grpc.Contacts",
-	HandlerType: (*ContactsServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "ListContacts",
-			Handler:    _Contacts_ListContacts_Handler,
-		},
-		{
-			MethodName: "CreateContact",
-			Handler:    _Contacts_CreateContact_Handler,
-		},
-		{
-			MethodName: "DeleteContact",
-			Handler:    _Contacts_DeleteContact_Handler,
-		},
-		{
-			MethodName: "UpdateContact",
-			Handler:    _Contacts_UpdateContact_Handler,
+func _Contacts_BatchDeleteContacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteContactsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServer).BatchDeleteContacts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.Contacts/BatchDeleteContacts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServer).BatchDeleteContacts(ctx, req.(*BatchDeleteContactsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contacts_ListTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListContactsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServer).ListTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.Contacts/ListTrash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServer).ListTrash(ctx, req.(*ListContactsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contacts_RestoreContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServer).RestoreContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.Contacts/RestoreContact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServer).RestoreContact(ctx, req.(*RestoreContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contacts_ContactHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContactHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServer).ContactHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.Contacts/ContactHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServer).ContactHistory(ctx, req.(*ContactHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contacts_UpdateContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServer).UpdateContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.Contacts/UpdateContact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServer).UpdateContact(ctx, req.(*UpdateContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contacts_ShareContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServer).ShareContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.Contacts/ShareContact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServer).ShareContact(ctx, req.(*ShareContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contacts_UnshareContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnshareContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServer).UnshareContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.Contacts/UnshareContact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServer).UnshareContact(ctx, req.(*UnshareContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contacts_WatchContacts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchContactsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ContactsServer).WatchContacts(m, &contactsWatchContactsServer{stream})
+}
+
+type Contacts_WatchContactsServer interface {
+	Send(*ContactChange) error
+	grpc.ServerStream
+}
+
+type contactsWatchContactsServer struct {
+	grpc.ServerStream
+}
+
+func (x *contactsWatchContactsServer) Send(m *ContactChange) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Contacts_ListAddressBooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAddressBooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServer).ListAddressBooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.Contacts/ListAddressBooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServer).ListAddressBooks(ctx, req.(*ListAddressBooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contacts_GetAddressBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAddressBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServer).GetAddressBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.Contacts/GetAddressBook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServer).GetAddressBook(ctx, req.(*GetAddressBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contacts_CreateAddressBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAddressBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServer).CreateAddressBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.Contacts/CreateAddressBook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServer).CreateAddressBook(ctx, req.(*CreateAddressBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contacts_UpdateAddressBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAddressBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServer).UpdateAddressBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.Contacts/UpdateAddressBook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServer).UpdateAddressBook(ctx, req.(*UpdateAddressBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contacts_DeleteAddressBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAddressBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServer).DeleteAddressBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.Contacts/DeleteAddressBook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServer).DeleteAddressBook(ctx, req.(*DeleteAddressBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contacts_SetAddressBookMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAddressBookMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServer).SetAddressBookMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.Contacts/SetAddressBookMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServer).SetAddressBookMember(ctx, req.(*SetAddressBookMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contacts_RemoveAddressBookMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveAddressBookMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServer).RemoveAddressBookMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.Contacts/RemoveAddressBookMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServer).RemoveAddressBookMember(ctx, req.(*RemoveAddressBookMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contacts_GetContactSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContactSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServer).GetContactSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.Contacts/GetContactSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServer).GetContactSchema(ctx, req.(*GetContactSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contacts_SetContactSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetContactSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServer).SetContactSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.Contacts/SetContactSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServer).SetContactSchema(ctx, req.(*SetContactSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contacts_ListGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServer).ListGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.Contacts/ListGroups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServer).ListGroups(ctx, req.(*ListGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contacts_GetGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServer).GetGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.Contacts/GetGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServer).GetGroup(ctx, req.(*GetGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contacts_CreateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServer).CreateGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.Contacts/CreateGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServer).CreateGroup(ctx, req.(*CreateGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contacts_UpdateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServer).UpdateGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.Contacts/UpdateGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServer).UpdateGroup(ctx, req.(*UpdateGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contacts_DeleteGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServer).DeleteGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.Contacts/DeleteGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServer).DeleteGroup(ctx, req.(*DeleteGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contacts_AddGroupContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddGroupContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServer).AddGroupContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.Contacts/AddGroupContact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServer).AddGroupContact(ctx, req.(*AddGroupContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contacts_RemoveGroupContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveGroupContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServer).RemoveGroupContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.Contacts/RemoveGroupContact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServer).RemoveGroupContact(ctx, req.(*RemoveGroupContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contacts_DeleteGroupContacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGroupContactsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServer).DeleteGroupContacts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.Contacts/DeleteGroupContacts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServer).DeleteGroupContacts(ctx, req.(*DeleteGroupContactsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contacts_ShareGroupContacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareGroupContactsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactsServer).ShareGroupContacts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.Contacts/ShareGroupContacts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactsServer).ShareGroupContacts(ctx, req.(*ShareGroupContactsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Contacts_ServiceDesc is the grpc.ServiceDesc for Contacts service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Contacts_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "grpc.Contacts",
+	HandlerType: (*ContactsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListContacts",
+			Handler:    _Contacts_ListContacts_Handler,
+		},
+		{
+			MethodName: "GetContact",
+			Handler:    _Contacts_GetContact_Handler,
+		},
+		{
+			MethodName: "CreateContact",
+			Handler:    _Contacts_CreateContact_Handler,
+		},
+		{
+			MethodName: "DeleteContact",
+			Handler:    _Contacts_DeleteContact_Handler,
+		},
+		{
+			MethodName: "BatchCreateContacts",
+			Handler:    _Contacts_BatchCreateContacts_Handler,
+		},
+		{
+			MethodName: "BatchDeleteContacts",
+			Handler:    _Contacts_BatchDeleteContacts_Handler,
+		},
+		{
+			MethodName: "ListTrash",
+			Handler:    _Contacts_ListTrash_Handler,
+		},
+		{
+			MethodName: "RestoreContact",
+			Handler:    _Contacts_RestoreContact_Handler,
+		},
+		{
+			MethodName: "ContactHistory",
+			Handler:    _Contacts_ContactHistory_Handler,
+		},
+		{
+			MethodName: "UpdateContact",
+			Handler:    _Contacts_UpdateContact_Handler,
+		},
+		{
+			MethodName: "ShareContact",
+			Handler:    _Contacts_ShareContact_Handler,
+		},
+		{
+			MethodName: "UnshareContact",
+			Handler:    _Contacts_UnshareContact_Handler,
+		},
+		{
+			MethodName: "ListAddressBooks",
+			Handler:    _Contacts_ListAddressBooks_Handler,
+		},
+		{
+			MethodName: "GetAddressBook",
+			Handler:    _Contacts_GetAddressBook_Handler,
+		},
+		{
+			MethodName: "CreateAddressBook",
+			Handler:    _Contacts_CreateAddressBook_Handler,
+		},
+		{
+			MethodName: "UpdateAddressBook",
+			Handler:    _Contacts_UpdateAddressBook_Handler,
+		},
+		{
+			MethodName: "DeleteAddressBook",
+			Handler:    _Contacts_DeleteAddressBook_Handler,
+		},
+		{
+			MethodName: "SetAddressBookMember",
+			Handler:    _Contacts_SetAddressBookMember_Handler,
+		},
+		{
+			MethodName: "RemoveAddressBookMember",
+			Handler:    _Contacts_RemoveAddressBookMember_Handler,
+		},
+		{
+			MethodName: "GetContactSchema",
+			Handler:    _Contacts_GetContactSchema_Handler,
+		},
+		{
+			MethodName: "SetContactSchema",
+			Handler:    _Contacts_SetContactSchema_Handler,
+		},
+		{
+			MethodName: "ListGroups",
+			Handler:    _Contacts_ListGroups_Handler,
+		},
+		{
+			MethodName: "GetGroup",
+			Handler:    _Contacts_GetGroup_Handler,
+		},
+		{
+			MethodName: "CreateGroup",
+			Handler:    _Contacts_CreateGroup_Handler,
+		},
+		{
+			MethodName: "UpdateGroup",
+			Handler:    _Contacts_UpdateGroup_Handler,
+		},
+		{
+			MethodName: "DeleteGroup",
+			Handler:    _Contacts_DeleteGroup_Handler,
+		},
+		{
+			MethodName: "AddGroupContact",
+			Handler:    _Contacts_AddGroupContact_Handler,
+		},
+		{
+			MethodName: "RemoveGroupContact",
+			Handler:    _Contacts_RemoveGroupContact_Handler,
+		},
+		{
+			MethodName: "DeleteGroupContacts",
+			Handler:    _Contacts_DeleteGroupContacts_Handler,
+		},
+		{
+			MethodName: "ShareGroupContacts",
+			Handler:    _Contacts_ShareGroupContacts_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchContacts",
+			Handler:       _Contacts_WatchContacts_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/adapters/grpc/contacts.proto",
 }
@@ -0,0 +1,29 @@
+package grpc
+
+import (
+	"errors"
+
+	"github.com/davidterranova/contacts/internal/usecase"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatusError maps use case errors to gRPC status codes
+func toStatusError(err error) error {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidCommand):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, usecase.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, usecase.ErrForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, usecase.ErrConflict):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, usecase.ErrAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, usecase.ErrRestricted):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,240 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/internal/usecase"
+	"github.com/davidterranova/contacts/pkg/auth"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+func (h *Handler) ListGroups(ctx context.Context, req *ListGroupsRequest) (*ListGroupsResponse, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:list failed to get user from context")
+		return nil, err
+	}
+
+	groups, err := h.app.ListGroups(ctx, usecase.QueryListGroups{Owner: user})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	var pbGroups = make([]*Group, 0, len(groups))
+	for _, group := range groups {
+		pbGroups = append(pbGroups, toPBGroup(group))
+	}
+
+	return &ListGroupsResponse{
+		Groups: pbGroups,
+	}, nil
+}
+
+func (h *Handler) GetGroup(ctx context.Context, req *GetGroupRequest) (*GetGroupResponse, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:get failed to get user from context")
+		return nil, err
+	}
+
+	group, err := h.app.GetGroup(
+		ctx,
+		usecase.QueryGetGroup{
+			Requester: user,
+			GroupId:   req.Id,
+		},
+	)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &GetGroupResponse{
+		Group: toPBGroup(group),
+	}, nil
+}
+
+func (h *Handler) CreateGroup(ctx context.Context, req *CreateGroupRequest) (*CreateGroupResponse, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:create failed to get user from context")
+		return nil, err
+	}
+
+	group, err := h.app.CreateGroup(
+		ctx,
+		usecase.CmdCreateGroup{
+			Creator: user,
+			Name:    req.Name,
+		},
+	)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &CreateGroupResponse{
+		Group: toPBGroup(group),
+	}, nil
+}
+
+func (h *Handler) UpdateGroup(ctx context.Context, req *UpdateGroupRequest) (*UpdateGroupResponse, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:update failed to get user from context")
+		return nil, err
+	}
+
+	group, err := h.app.UpdateGroup(
+		ctx,
+		usecase.CmdUpdateGroup{
+			Updater: user,
+			GroupId: req.Id,
+			Name:    req.Name,
+		},
+	)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &UpdateGroupResponse{
+		Group: toPBGroup(group),
+	}, nil
+}
+
+func (h *Handler) DeleteGroup(ctx context.Context, req *DeleteGroupRequest) (*DeleteGroupResponse, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:delete failed to get user from context")
+		return nil, err
+	}
+
+	err = h.app.DeleteGroup(
+		ctx,
+		usecase.CmdDeleteGroup{
+			Deleter: user,
+			GroupId: req.Id,
+		},
+	)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &DeleteGroupResponse{}, nil
+}
+
+func (h *Handler) AddGroupContact(ctx context.Context, req *AddGroupContactRequest) (*AddGroupContactResponse, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:add_contact failed to get user from context")
+		return nil, err
+	}
+
+	contact, err := h.app.AddGroupContact(
+		ctx,
+		usecase.CmdAddGroupContact{
+			Requester: user,
+			GroupId:   req.Id,
+			ContactId: req.ContactId,
+		},
+	)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &AddGroupContactResponse{
+		Contact: toPBContact(contact),
+	}, nil
+}
+
+func (h *Handler) RemoveGroupContact(ctx context.Context, req *RemoveGroupContactRequest) (*RemoveGroupContactResponse, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:remove_contact failed to get user from context")
+		return nil, err
+	}
+
+	contact, err := h.app.RemoveGroupContact(
+		ctx,
+		usecase.CmdRemoveGroupContact{
+			Requester: user,
+			GroupId:   req.Id,
+			ContactId: req.ContactId,
+		},
+	)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &RemoveGroupContactResponse{
+		Contact: toPBContact(contact),
+	}, nil
+}
+
+func (h *Handler) DeleteGroupContacts(ctx context.Context, req *DeleteGroupContactsRequest) (*BatchContactsResponse, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:delete_contacts failed to get user from context")
+		return nil, err
+	}
+
+	results, err := h.app.DeleteGroupContacts(
+		ctx,
+		usecase.CmdDeleteGroupContacts{
+			Deleter: user,
+			GroupId: req.Id,
+			Atomic:  req.Atomic,
+		},
+	)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &BatchContactsResponse{
+		Results: toPBBatchResults(results.Delete),
+	}, nil
+}
+
+func (h *Handler) ShareGroupContacts(ctx context.Context, req *ShareGroupContactsRequest) (*BatchContactsResponse, error) {
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:share_contacts failed to get user from context")
+		return nil, err
+	}
+
+	results, err := h.app.ShareGroupContacts(
+		ctx,
+		usecase.CmdShareGroupContacts{
+			Sharer:     user,
+			GroupId:    req.Id,
+			UserId:     req.UserId,
+			Permission: string(toDomainPermission(req.Permission)),
+		},
+	)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &BatchContactsResponse{
+		Results: toPBBatchResults(results),
+	}, nil
+}
+
+func toPBGroup(group *domain.Group) *Group {
+	return &Group{
+		Id:        group.Id.String(),
+		CreatedAt: group.CreatedAt.Format(layout),
+		UpdatedAt: group.UpdatedAt.Format(layout),
+		CreatedBy: group.CreatedBy.String(),
+		Name:      group.Name,
+	}
+}
+
+func toPBGroupIds(groupIds []uuid.UUID) []string {
+	var ids = make([]string, 0, len(groupIds))
+	for _, id := range groupIds {
+		ids = append(ids, id.String())
+	}
+
+	return ids
+}
@@ -0,0 +1,220 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/davidterranova/contacts/internal/usecase"
+	"github.com/davidterranova/contacts/pkg/auth"
+	"github.com/davidterranova/contacts/pkg/xhttp"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+)
+
+type AddressBookHandler struct {
+	app App
+}
+
+func NewAddressBookHandler(app App) *AddressBookHandler {
+	return &AddressBookHandler{
+		app: app,
+	}
+}
+
+func (h *AddressBookHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("address_books:list failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	addressBooks, err := h.app.ListAddressBooks(ctx, usecase.QueryListAddressBooks{Member: user})
+	if err != nil {
+		writeAddressBookError(ctx, w, err, "address_books:list failed to list address books")
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, fromDomainAddressBookList(addressBooks))
+}
+
+func (h *AddressBookHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("address_books:get failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	addressBook, err := h.app.GetAddressBook(ctx, usecase.QueryGetAddressBook{
+		Requester:     user,
+		AddressBookId: mux.Vars(r)[pathAddressBookId],
+	})
+	if err != nil {
+		writeAddressBookError(ctx, w, err, "address_books:get failed to get address book")
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, fromDomainAddressBook(addressBook))
+}
+
+type addressBookRequest struct {
+	Name string `json:"name"`
+}
+
+func (h *AddressBookHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req addressBookRequest
+	ctx := r.Context()
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("address_books:create failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("address_books:create failed to decode request")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "failed to decode request", err)
+		return
+	}
+
+	addressBook, err := h.app.CreateAddressBook(ctx, usecase.CmdCreateAddressBook{
+		Creator: user,
+		Name:    req.Name,
+	})
+	if err != nil {
+		writeAddressBookError(ctx, w, err, "address_books:create failed to create address book")
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusCreated, fromDomainAddressBook(addressBook))
+}
+
+func (h *AddressBookHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req addressBookRequest
+	ctx := r.Context()
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("address_books:update failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("address_books:update failed to decode request")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "failed to decode request", err)
+		return
+	}
+
+	addressBook, err := h.app.UpdateAddressBook(ctx, usecase.CmdUpdateAddressBook{
+		Updater:       user,
+		AddressBookId: mux.Vars(r)[pathAddressBookId],
+		Name:          req.Name,
+	})
+	if err != nil {
+		writeAddressBookError(ctx, w, err, "address_books:update failed to update address book")
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, fromDomainAddressBook(addressBook))
+}
+
+func (h *AddressBookHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("address_books:delete failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	err = h.app.DeleteAddressBook(ctx, usecase.CmdDeleteAddressBook{
+		Deleter:       user,
+		AddressBookId: mux.Vars(r)[pathAddressBookId],
+	})
+	if err != nil {
+		writeAddressBookError(ctx, w, err, "address_books:delete failed to delete address book")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type setMemberRequest struct {
+	Role string `json:"role"`
+}
+
+func (h *AddressBookHandler) SetMember(w http.ResponseWriter, r *http.Request) {
+	var req setMemberRequest
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("address_books:set_member failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("address_books:set_member failed to decode request")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "failed to decode request", err)
+		return
+	}
+
+	addressBook, err := h.app.SetAddressBookMember(ctx, usecase.CmdSetAddressBookMember{
+		Updater:       user,
+		AddressBookId: vars[pathAddressBookId],
+		UserId:        vars[pathUserId],
+		Role:          req.Role,
+	})
+	if err != nil {
+		writeAddressBookError(ctx, w, err, "address_books:set_member failed to update address book members")
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, fromDomainAddressBook(addressBook))
+}
+
+func (h *AddressBookHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("address_books:remove_member failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	addressBook, err := h.app.RemoveAddressBookMember(ctx, usecase.CmdRemoveAddressBookMember{
+		Updater:       user,
+		AddressBookId: vars[pathAddressBookId],
+		UserId:        vars[pathUserId],
+	})
+	if err != nil {
+		writeAddressBookError(ctx, w, err, "address_books:remove_member failed to update address book members")
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, fromDomainAddressBook(addressBook))
+}
+
+func writeAddressBookError(ctx context.Context, w http.ResponseWriter, err error, msg string) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidCommand):
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "address book validation failed", err)
+	case errors.Is(err, usecase.ErrNotFound):
+		xhttp.WriteError(ctx, w, http.StatusNotFound, "address book or member not found", err)
+	case errors.Is(err, usecase.ErrForbidden):
+		xhttp.WriteError(ctx, w, http.StatusForbidden, "address book access forbidden", err)
+	default:
+		log.Ctx(ctx).Warn().Err(err).Msg(msg)
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to handle address book", err)
+	}
+}
@@ -0,0 +1,49 @@
+package http
+
+import (
+	"github.com/davidterranova/contacts/internal/domain"
+)
+
+type AddressBook struct {
+	Id        string   `json:"id"`
+	CreatedBy string   `json:"created_by"`
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+	Name      string   `json:"name"`
+	Members   []Member `json:"members"`
+}
+
+type Member struct {
+	UserId  string `json:"user_id"`
+	Role    string `json:"role"`
+	AddedAt string `json:"added_at"`
+}
+
+func fromDomainAddressBook(b *domain.AddressBook) *AddressBook {
+	var members = make([]Member, 0, len(b.Members))
+	for _, m := range b.Members {
+		members = append(members, Member{
+			UserId:  m.UserId.String(),
+			Role:    string(m.Role),
+			AddedAt: m.AddedAt.Format("2006-01-02T15:04:05Z"),
+		})
+	}
+
+	return &AddressBook{
+		Id:        b.Id.String(),
+		CreatedBy: b.CreatedBy.String(),
+		CreatedAt: b.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt: b.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		Name:      b.Name,
+		Members:   members,
+	}
+}
+
+func fromDomainAddressBookList(addressBooks []*domain.AddressBook) []*AddressBook {
+	var list = make([]*AddressBook, 0, len(addressBooks))
+	for _, b := range addressBooks {
+		list = append(list, fromDomainAddressBook(b))
+	}
+
+	return list
+}
@@ -0,0 +1,246 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/internal/usecase"
+	"github.com/davidterranova/contacts/pkg/user"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/steinfletcher/apitest"
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+)
+
+func TestListAddressBooks(t *testing.T) {
+	t.Parallel()
+
+	authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+	container := testContainer(t)
+	container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+	container.app.EXPECT().
+		ListAddressBooks(gomock.Any(), usecase.QueryListAddressBooks{Member: authorizedUser}).
+		Times(1).
+		Return([]*domain.AddressBook{domain.NewAddressBook(authorizedUser.Id(), "family")}, nil)
+
+	apitest.New().
+		Report(apitest.SequenceDiagram()).
+		Handler(container.handler).
+		Get("/v1/address-books").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(jsonpath.Len("$", 1)).
+		Assert(jsonpath.Equal("$[0].name", "family")).
+		Assert(jsonpath.Equal("$[0].members[0].role", "owner")).
+		End()
+}
+
+func TestCreateAddressBook(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name                   string
+		returnedAppAddressBook *domain.AddressBook
+		returnedAppErr         error
+		expectedStatus         int
+	}{
+		{
+			name:                   "ok",
+			returnedAppAddressBook: domain.NewAddressBook(uuid.New(), "family"),
+			expectedStatus:         http.StatusCreated,
+		},
+		{
+			name:           "bad request",
+			returnedAppErr: usecase.ErrInvalidCommand,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "internal server error",
+			returnedAppErr: usecase.ErrInternal,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+			container := testContainer(t)
+			container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+			container.app.EXPECT().
+				CreateAddressBook(gomock.Any(), usecase.CmdCreateAddressBook{Creator: authorizedUser, Name: "family"}).
+				Times(1).
+				Return(c.returnedAppAddressBook, c.returnedAppErr)
+
+			apitest.New().
+				Report(apitest.SequenceDiagram()).
+				Handler(container.handler).
+				Post("/v1/address-books").
+				JSON(`{"name": "family"}`).
+				Expect(t).
+				Status(c.expectedStatus).
+				End()
+		})
+	}
+}
+
+func TestGetAddressBook(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name                   string
+		returnedAppAddressBook *domain.AddressBook
+		returnedAppErr         error
+		expectedStatus         int
+	}{
+		{
+			name:                   "ok",
+			returnedAppAddressBook: domain.NewAddressBook(uuid.New(), "family"),
+			expectedStatus:         http.StatusOK,
+		},
+		{
+			name:           "not found",
+			returnedAppErr: usecase.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "forbidden",
+			returnedAppErr: usecase.ErrForbidden,
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+			addressBookId := uuid.NewString()
+			container := testContainer(t)
+			container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+			container.app.EXPECT().
+				GetAddressBook(gomock.Any(), usecase.QueryGetAddressBook{Requester: authorizedUser, AddressBookId: addressBookId}).
+				Times(1).
+				Return(c.returnedAppAddressBook, c.returnedAppErr)
+
+			apitest.New().
+				Report(apitest.SequenceDiagram()).
+				Handler(container.handler).
+				Getf("/v1/address-books/%s", addressBookId).
+				Expect(t).
+				Status(c.expectedStatus).
+				End()
+		})
+	}
+}
+
+func TestDeleteAddressBook(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name           string
+		returnedAppErr error
+		expectedStatus int
+	}{
+		{
+			name:           "ok",
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "address book still owns contacts",
+			returnedAppErr: usecase.ErrInvalidCommand,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "forbidden",
+			returnedAppErr: usecase.ErrForbidden,
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+			addressBookId := uuid.NewString()
+			container := testContainer(t)
+			container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+			container.app.EXPECT().
+				DeleteAddressBook(gomock.Any(), usecase.CmdDeleteAddressBook{Deleter: authorizedUser, AddressBookId: addressBookId}).
+				Times(1).
+				Return(c.returnedAppErr)
+
+			apitest.New().
+				Report(apitest.SequenceDiagram()).
+				Handler(container.handler).
+				Deletef("/v1/address-books/%s", addressBookId).
+				Expect(t).
+				Status(c.expectedStatus).
+				End()
+		})
+	}
+}
+
+func TestSetAddressBookMember(t *testing.T) {
+	t.Parallel()
+
+	authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+	memberId := uuid.New()
+	addressBook := domain.NewAddressBook(authorizedUser.Id(), "family")
+	_ = addressBook.SetMember(memberId, domain.RoleEditor)
+
+	container := testContainer(t)
+	container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+	container.app.EXPECT().
+		SetAddressBookMember(gomock.Any(), usecase.CmdSetAddressBookMember{
+			Updater:       authorizedUser,
+			AddressBookId: addressBook.Id.String(),
+			UserId:        memberId.String(),
+			Role:          "editor",
+		}).
+		Times(1).
+		Return(addressBook, nil)
+
+	apitest.New().
+		Report(apitest.SequenceDiagram()).
+		Handler(container.handler).
+		Putf("/v1/address-books/%s/members/%s", addressBook.Id, memberId).
+		JSON(`{"role": "editor"}`).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(jsonpath.Len("$.members", 2)).
+		Assert(jsonpath.Equal("$.members[1].role", "editor")).
+		End()
+}
+
+func TestRemoveAddressBookMember(t *testing.T) {
+	t.Parallel()
+
+	authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+	addressBookId, memberId := uuid.NewString(), uuid.NewString()
+
+	container := testContainer(t)
+	container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+	container.app.EXPECT().
+		RemoveAddressBookMember(gomock.Any(), usecase.CmdRemoveAddressBookMember{
+			Updater:       authorizedUser,
+			AddressBookId: addressBookId,
+			UserId:        memberId,
+		}).
+		Times(1).
+		Return(nil, usecase.ErrNotFound)
+
+	apitest.New().
+		Report(apitest.SequenceDiagram()).
+		Handler(container.handler).
+		Deletef("/v1/address-books/%s/members/%s", addressBookId, memberId).
+		Expect(t).
+		Status(http.StatusNotFound).
+		End()
+}
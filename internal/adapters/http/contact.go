@@ -5,21 +5,69 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/davidterranova/contacts/internal/domain"
 	"github.com/davidterranova/contacts/internal/usecase"
 	"github.com/davidterranova/contacts/pkg/auth"
+	"github.com/davidterranova/contacts/pkg/vcard"
 	"github.com/davidterranova/contacts/pkg/xhttp"
 	"github.com/gorilla/mux"
 	"github.com/rs/zerolog/log"
 )
 
 type App interface {
-	ListContacts(ctx context.Context, query usecase.QueryListContact) ([]*domain.Contact, error)
+	ListContacts(ctx context.Context, query usecase.QueryListContact) (*usecase.ContactPage, error)
+	GetContact(ctx context.Context, query usecase.QueryGetContact) (*domain.Contact, error)
 	CreateContact(ctx context.Context, cmd usecase.CmdCreateContact) (*domain.Contact, error)
 	UpdateContact(ctx context.Context, cmd usecase.CmdUpdateContact) (*domain.Contact, error)
 	DeleteContact(ctx context.Context, cmd usecase.CmdDeleteContact) error
+	RestoreContact(ctx context.Context, cmd usecase.CmdRestoreContact) (*domain.Contact, error)
+	ContactHistory(ctx context.Context, query usecase.QueryContactHistory) ([]*domain.AuditEntry, error)
+	ShareContact(ctx context.Context, cmd usecase.CmdShareContact) (*domain.Contact, error)
+	UnshareContact(ctx context.Context, cmd usecase.CmdUnshareContact) (*domain.Contact, error)
+	ImportContacts(ctx context.Context, cmd usecase.CmdImportContacts) ([]usecase.ImportResult, error)
+	BatchContacts(ctx context.Context, cmd usecase.CmdBatchContacts) (usecase.BatchResults, error)
+	FindDuplicates(ctx context.Context, query usecase.QueryFindDuplicates) ([]usecase.Duplicate, error)
+	MergeContacts(ctx context.Context, cmd usecase.CmdMergeContacts) (*domain.Contact, error)
+	WatchContacts(ctx context.Context, query usecase.QueryWatchContacts) (<-chan usecase.ContactChange, error)
+	ContactRelationships(ctx context.Context, query usecase.QueryContactRelationships) (*usecase.ContactRelationships, error)
+	RelateContacts(ctx context.Context, cmd usecase.CmdRelateContacts) (*domain.Contact, error)
+	UnrelateContacts(ctx context.Context, cmd usecase.CmdUnrelateContacts) (*domain.Contact, error)
+
+	GetContactSchema(ctx context.Context, query usecase.QueryGetContactSchema) (*domain.ContactSchema, error)
+	SetContactSchema(ctx context.Context, cmd usecase.CmdSetContactSchema) (*domain.ContactSchema, error)
+
+	ListAddressBooks(ctx context.Context, query usecase.QueryListAddressBooks) ([]*domain.AddressBook, error)
+	GetAddressBook(ctx context.Context, query usecase.QueryGetAddressBook) (*domain.AddressBook, error)
+	CreateAddressBook(ctx context.Context, cmd usecase.CmdCreateAddressBook) (*domain.AddressBook, error)
+	UpdateAddressBook(ctx context.Context, cmd usecase.CmdUpdateAddressBook) (*domain.AddressBook, error)
+	DeleteAddressBook(ctx context.Context, cmd usecase.CmdDeleteAddressBook) error
+	SetAddressBookMember(ctx context.Context, cmd usecase.CmdSetAddressBookMember) (*domain.AddressBook, error)
+	RemoveAddressBookMember(ctx context.Context, cmd usecase.CmdRemoveAddressBookMember) (*domain.AddressBook, error)
+
+	ListGroups(ctx context.Context, query usecase.QueryListGroups) ([]*domain.Group, error)
+	GetGroup(ctx context.Context, query usecase.QueryGetGroup) (*domain.Group, error)
+	CreateGroup(ctx context.Context, cmd usecase.CmdCreateGroup) (*domain.Group, error)
+	UpdateGroup(ctx context.Context, cmd usecase.CmdUpdateGroup) (*domain.Group, error)
+	DeleteGroup(ctx context.Context, cmd usecase.CmdDeleteGroup) error
+	AddGroupContact(ctx context.Context, cmd usecase.CmdAddGroupContact) (*domain.Contact, error)
+	RemoveGroupContact(ctx context.Context, cmd usecase.CmdRemoveGroupContact) (*domain.Contact, error)
+	DeleteGroupContacts(ctx context.Context, cmd usecase.CmdDeleteGroupContacts) (usecase.BatchResults, error)
+	ShareGroupContacts(ctx context.Context, cmd usecase.CmdShareGroupContacts) ([]usecase.BatchResult, error)
+
+	ListWebhooks(ctx context.Context, query usecase.QueryListWebhooks) ([]*domain.Webhook, error)
+	GetWebhook(ctx context.Context, query usecase.QueryGetWebhook) (*domain.Webhook, error)
+	CreateWebhook(ctx context.Context, cmd usecase.CmdCreateWebhook) (*domain.Webhook, error)
+	UpdateWebhook(ctx context.Context, cmd usecase.CmdUpdateWebhook) (*domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, cmd usecase.CmdDeleteWebhook) error
+	ListWebhookDeliveries(ctx context.Context, query usecase.QueryListWebhookDeliveries) ([]*domain.Delivery, error)
 }
 
 type ContactHandler struct {
@@ -33,6 +81,15 @@ func NewContactHandler(app App) *ContactHandler {
 }
 
 func (h *ContactHandler) List(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, false)
+}
+
+// ListTrash lists the deleted contacts, it accepts the same query parameters as List
+func (h *ContactHandler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, true)
+}
+
+func (h *ContactHandler) list(w http.ResponseWriter, r *http.Request, trash bool) {
 	ctx := r.Context()
 	user, err := auth.UserFromContext(ctx)
 	if err != nil {
@@ -41,24 +98,264 @@ func (h *ContactHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	contacts, err := h.app.ListContacts(ctx, usecase.QueryListContact{
-		CreatedBy: user,
-	})
+	query, err := listContactQuery(r)
 	if err != nil {
-		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:list failed to list contacts")
-		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to list contacts", err)
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid query parameters", err)
 		return
 	}
+	query.Requester = user
+	query.Trash = trash
 
-	toReturnContacts := fromDomainList(contacts)
+	page, err := h.app.ListContacts(ctx, query)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid query parameters", err)
+		case errors.Is(err, usecase.ErrForbidden):
+			xhttp.WriteError(ctx, w, http.StatusForbidden, "address book access forbidden", err)
+		default:
+			log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:list failed to list contacts")
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to list contacts", err)
+		}
+		return
+	}
+
+	toReturnContacts := fromDomainPage(page)
 	xhttp.WriteObject(ctx, w, http.StatusOK, toReturnContacts)
 }
 
+// listContactQuery reads the list filters from the query string:
+// q searches in all fields, <field> and <field>_prefix match a field exactly or by prefix,
+// created_after, created_before, updated_after and updated_before are RFC3339 dates,
+// custom_fields.<name> and custom_fields.<name>_prefix match a custom field value,
+// sort is a field name prefixed by - for descending order, limit and cursor select the page,
+// address_book_id restricts the list to an address book and group_id to the contacts of a group
+func listContactQuery(r *http.Request) (usecase.QueryListContact, error) {
+	var (
+		values = r.URL.Query()
+		query  = usecase.QueryListContact{
+			AddressBookId: values.Get("address_book_id"),
+			GroupId:       values.Get("group_id"),
+			Search:        values.Get("q"),
+			Sort:          sortParam(values.Get("sort")),
+			Cursor:        values.Get("cursor"),
+		}
+		err error
+	)
+
+	if limit := values.Get("limit"); limit != "" {
+		query.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			return query, fmt.Errorf("invalid limit: %w", err)
+		}
+	}
+
+	fieldMatches := []struct {
+		param string
+		match *domain.FieldMatch
+	}{
+		{"first_name", &query.FirstName},
+		{"last_name", &query.LastName},
+		{"email", &query.Email},
+		{"phone", &query.Phone},
+	}
+	for _, f := range fieldMatches {
+		*f.match, err = fieldMatchParam(values, f.param)
+		if err != nil {
+			return query, err
+		}
+	}
+
+	for name := range customFieldParams(values) {
+		match, err := fieldMatchParam(values, customFieldParam+name)
+		if err != nil {
+			return query, err
+		}
+		if query.CustomFields == nil {
+			query.CustomFields = map[string]domain.FieldMatch{}
+		}
+		query.CustomFields[name] = match
+	}
+
+	timeRanges := []struct {
+		after     string
+		before    string
+		timeRange *domain.TimeRange
+	}{
+		{"created_after", "created_before", &query.CreatedAt},
+		{"updated_after", "updated_before", &query.UpdatedAt},
+	}
+	for _, t := range timeRanges {
+		t.timeRange.From, err = timeParam(values, t.after)
+		if err != nil {
+			return query, err
+		}
+		t.timeRange.To, err = timeParam(values, t.before)
+		if err != nil {
+			return query, err
+		}
+	}
+
+	return query, nil
+}
+
+func fieldMatchParam(values url.Values, param string) (domain.FieldMatch, error) {
+	exact, prefix := values.Get(param), values.Get(param+"_prefix")
+	switch {
+	case exact != "" && prefix != "":
+		return domain.FieldMatch{}, fmt.Errorf("%s and %s_prefix are mutually exclusive", param, param)
+	case prefix != "":
+		return domain.FieldMatch{Value: prefix, Mode: domain.MatchPrefix}, nil
+	default:
+		return domain.FieldMatch{Value: exact, Mode: domain.MatchExact}, nil
+	}
+}
+
+const customFieldParam = "custom_fields."
+
+// customFieldParams returns the names of the custom fields matched by the query string
+func customFieldParams(values url.Values) map[string]bool {
+	names := map[string]bool{}
+	for param := range values {
+		if name, ok := strings.CutPrefix(param, customFieldParam); ok {
+			names[strings.TrimSuffix(name, "_prefix")] = true
+		}
+	}
+
+	return names
+}
+
+func sortParam(value string) domain.Sort {
+	field, desc := strings.CutPrefix(value, "-")
+
+	return domain.Sort{Field: domain.SortField(field), Desc: desc}
+}
+
+func timeParam(values url.Values, param string) (*time.Time, error) {
+	value := values.Get(param)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", param, err)
+	}
+
+	return &t, nil
+}
+
+// writeContact writes the contact with its version as ETag
+func writeContact(ctx context.Context, w http.ResponseWriter, status int, contact *domain.Contact) {
+	w.Header().Set("ETag", strconv.Quote(strconv.Itoa(contact.Version)))
+	xhttp.WriteObject(ctx, w, status, fromDomain(contact))
+}
+
+// ifMatchVersion reads the expected contact version from the If-Match header,
+// it is 0 when the header is missing or "*" so that any version matches
+func ifMatchVersion(r *http.Request) (int, error) {
+	value := r.Header.Get("If-Match")
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+
+	etag, err := strconv.Unquote(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid If-Match header %s: %w", value, err)
+	}
+	version, err := strconv.Atoi(etag)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("invalid If-Match header %s: not a contact version", value)
+	}
+
+	return version, nil
+}
+
+func (h *ContactHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	contactId := mux.Vars(r)[pathContactId]
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:get failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	contact, err := h.app.GetContact(ctx, usecase.QueryGetContact{
+		Requester: user,
+		ContactId: contactId,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid contact id", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "contact not found", err)
+		case errors.Is(err, usecase.ErrForbidden):
+			xhttp.WriteError(ctx, w, http.StatusForbidden, "contact access forbidden", err)
+		default:
+			log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:get failed to get contact")
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get contact", err)
+		}
+		return
+	}
+
+	writeContact(ctx, w, http.StatusOK, contact)
+}
+
 type createContactRequest struct {
-	FirstName string `json:"first_name" validate:"required"`
-	LastName  string `json:"last_name" validate:"required"`
-	Email     string `json:"email" validate:"required,email"`
-	Phone     string `json:"phone" validate:"required"`
+	AddressBookId string `json:"address_book_id"`
+	FirstName     string `json:"first_name" validate:"required"`
+	LastName      string `json:"last_name" validate:"required"`
+	Email         string `json:"email" validate:"required_without=Emails"`
+	Phone         string `json:"phone" validate:"required_without=Phones"`
+	// CustomFields are checked against the contact schema of the user
+	CustomFields map[string]any `json:"custom_fields"`
+	contactDetailsRequest
+}
+
+// contactDetailsRequest holds the lists of a contact, the single email and phone become primary when both are given
+type contactDetailsRequest struct {
+	Emails    []EmailAddress  `json:"emails"`
+	Phones    []PhoneNumber   `json:"phones"`
+	Addresses []PostalAddress `json:"addresses"`
+	// PhoneRegion is the region phones without international prefix are read in, the default one of the user when empty
+	PhoneRegion string `json:"phone_region"`
+}
+
+func (r contactDetailsRequest) emails() []usecase.ContactEmail {
+	var emails []usecase.ContactEmail
+	for _, e := range r.Emails {
+		emails = append(emails, usecase.ContactEmail{Label: e.Label, Address: e.Address, Primary: e.Primary})
+	}
+
+	return emails
+}
+
+func (r contactDetailsRequest) phones() []usecase.ContactPhone {
+	var phones []usecase.ContactPhone
+	for _, p := range r.Phones {
+		phones = append(phones, usecase.ContactPhone{Label: p.Label, Number: p.Number, Primary: p.Primary})
+	}
+
+	return phones
+}
+
+func (r contactDetailsRequest) addresses() []usecase.ContactAddress {
+	var addresses []usecase.ContactAddress
+	for _, a := range r.Addresses {
+		addresses = append(addresses, usecase.ContactAddress{
+			Label:      a.Label,
+			Street:     a.Street,
+			Locality:   a.Locality,
+			Region:     a.Region,
+			PostalCode: a.PostalCode,
+			Country:    a.Country,
+			Primary:    a.Primary,
+		})
+	}
+
+	return addresses
 }
 
 func (h *ContactHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -81,17 +378,29 @@ func (h *ContactHandler) Create(w http.ResponseWriter, r *http.Request) {
 	contact, err := h.app.CreateContact(
 		ctx,
 		usecase.CmdCreateContact{
-			CreatedBy: user,
-			FirstName: req.FirstName,
-			LastName:  req.LastName,
-			Email:     req.Email,
-			Phone:     req.Phone,
+			CreatedBy:     user,
+			AddressBookId: req.AddressBookId,
+			FirstName:     req.FirstName,
+			LastName:      req.LastName,
+			Email:         req.Email,
+			Phone:         req.Phone,
+			Emails:        req.emails(),
+			Phones:        req.phones(),
+			Addresses:     req.addresses(),
+			PhoneRegion:   req.PhoneRegion,
+			CustomFields:  req.CustomFields,
 		},
 	)
 	if err != nil {
 		switch {
 		case errors.Is(err, usecase.ErrInvalidCommand):
 			xhttp.WriteError(ctx, w, http.StatusBadRequest, "contact validation failed", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "address book not found", err)
+		case errors.Is(err, usecase.ErrForbidden):
+			xhttp.WriteError(ctx, w, http.StatusForbidden, "address book access forbidden", err)
+		case errors.Is(err, usecase.ErrAlreadyExists):
+			xhttp.WriteError(ctx, w, http.StatusConflict, "email or phone already used by another contact", err)
 		default:
 			log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:update failed to update contact")
 			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to update contact", err)
@@ -99,8 +408,7 @@ func (h *ContactHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	toReturnContact := fromDomain(contact)
-	xhttp.WriteObject(ctx, w, http.StatusCreated, toReturnContact)
+	writeContact(ctx, w, http.StatusCreated, contact)
 }
 
 type updateContactRequest struct {
@@ -108,6 +416,9 @@ type updateContactRequest struct {
 	LastName  string `json:"last_name"`
 	Email     string `json:"email"`
 	Phone     string `json:"phone"`
+	// CustomFields are set on the contact, a null value removes the field
+	CustomFields map[string]any `json:"custom_fields"`
+	contactDetailsRequest
 }
 
 func (h *ContactHandler) Update(w http.ResponseWriter, r *http.Request) {
@@ -128,15 +439,27 @@ func (h *ContactHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid precondition", err)
+		return
+	}
+
 	contact, err := h.app.UpdateContact(
 		ctx,
 		usecase.CmdUpdateContact{
-			Updater:   user,
-			ContactId: contactId,
-			FirstName: req.FirstName,
-			LastName:  req.LastName,
-			Email:     req.Email,
-			Phone:     req.Phone,
+			Updater:      user,
+			ContactId:    contactId,
+			FirstName:    req.FirstName,
+			LastName:     req.LastName,
+			Email:        req.Email,
+			Phone:        req.Phone,
+			Emails:       req.emails(),
+			Phones:       req.phones(),
+			Addresses:    req.addresses(),
+			PhoneRegion:  req.PhoneRegion,
+			CustomFields: req.CustomFields,
+			Version:      version,
 		},
 	)
 	if err != nil {
@@ -145,6 +468,12 @@ func (h *ContactHandler) Update(w http.ResponseWriter, r *http.Request) {
 			xhttp.WriteError(ctx, w, http.StatusBadRequest, "contact validation failed", err)
 		case errors.Is(err, usecase.ErrNotFound):
 			xhttp.WriteError(ctx, w, http.StatusNotFound, "contact not found", err)
+		case errors.Is(err, usecase.ErrForbidden):
+			xhttp.WriteError(ctx, w, http.StatusForbidden, "contact access forbidden", err)
+		case errors.Is(err, usecase.ErrConflict):
+			xhttp.WriteError(ctx, w, http.StatusPreconditionFailed, "contact was modified", err)
+		case errors.Is(err, usecase.ErrAlreadyExists):
+			xhttp.WriteError(ctx, w, http.StatusConflict, "email or phone already used by another contact", err)
 		default:
 			log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:update failed to update contact")
 			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to update contact", err)
@@ -152,8 +481,7 @@ func (h *ContactHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	toReturnContact := fromDomain(contact)
-	xhttp.WriteObject(ctx, w, http.StatusOK, toReturnContact)
+	writeContact(ctx, w, http.StatusOK, contact)
 }
 
 func (h *ContactHandler) Delete(w http.ResponseWriter, r *http.Request) {
@@ -166,9 +494,16 @@ func (h *ContactHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid precondition", err)
+		return
+	}
+
 	err = h.app.DeleteContact(ctx, usecase.CmdDeleteContact{
 		Deleter:   user,
 		ContactId: contactId,
+		Version:   version,
 	})
 	if err != nil {
 		switch {
@@ -176,6 +511,12 @@ func (h *ContactHandler) Delete(w http.ResponseWriter, r *http.Request) {
 			xhttp.WriteError(ctx, w, http.StatusBadRequest, "contact validation failed", err)
 		case errors.Is(err, usecase.ErrNotFound):
 			xhttp.WriteError(ctx, w, http.StatusNotFound, "contact not found", err)
+		case errors.Is(err, usecase.ErrForbidden):
+			xhttp.WriteError(ctx, w, http.StatusForbidden, "contact access forbidden", err)
+		case errors.Is(err, usecase.ErrConflict):
+			xhttp.WriteError(ctx, w, http.StatusPreconditionFailed, "contact was modified", err)
+		case errors.Is(err, usecase.ErrRestricted):
+			xhttp.WriteError(ctx, w, http.StatusConflict, "contact deletion restricted by a relationship", err)
 		default:
 			log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:update failed to update contact")
 			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to update contact", err)
@@ -185,3 +526,673 @@ func (h *ContactHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+func (h *ContactHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	contactId := mux.Vars(r)[pathContactId]
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:restore failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	contact, err := h.app.RestoreContact(ctx, usecase.CmdRestoreContact{
+		Restorer:  user,
+		ContactId: contactId,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "contact is not in the trash", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "contact not found", err)
+		case errors.Is(err, usecase.ErrForbidden):
+			xhttp.WriteError(ctx, w, http.StatusForbidden, "contact access forbidden", err)
+		case errors.Is(err, usecase.ErrAlreadyExists):
+			xhttp.WriteError(ctx, w, http.StatusConflict, "email or phone already used by another contact", err)
+		default:
+			log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:restore failed to restore contact")
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to restore contact", err)
+		}
+		return
+	}
+
+	writeContact(ctx, w, http.StatusOK, contact)
+}
+
+func (h *ContactHandler) History(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	contactId := mux.Vars(r)[pathContactId]
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:history failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	entries, err := h.app.ContactHistory(ctx, usecase.QueryContactHistory{
+		Requester: user,
+		ContactId: contactId,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid contact id", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "contact not found", err)
+		case errors.Is(err, usecase.ErrForbidden):
+			xhttp.WriteError(ctx, w, http.StatusForbidden, "contact access forbidden", err)
+		default:
+			log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:history failed to get contact history")
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get contact history", err)
+		}
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, fromDomainAuditEntries(entries))
+}
+
+// Duplicates reports the pairs of contacts which may be the same person, address_book_id restricts the search
+// to an address book and name_similarity, between 0 and 1, is the minimum similarity of the names
+func (h *ContactHandler) Duplicates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:duplicates failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	query := usecase.QueryFindDuplicates{
+		Requester:     user,
+		AddressBookId: r.URL.Query().Get("address_book_id"),
+	}
+	if similarity := r.URL.Query().Get("name_similarity"); similarity != "" {
+		query.NameSimilarity, err = strconv.ParseFloat(similarity, 64)
+		if err != nil {
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid query parameters", fmt.Errorf("invalid name_similarity: %w", err))
+			return
+		}
+	}
+
+	duplicates, err := h.app.FindDuplicates(ctx, query)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid query parameters", err)
+		case errors.Is(err, usecase.ErrForbidden):
+			xhttp.WriteError(ctx, w, http.StatusForbidden, "address book access forbidden", err)
+		default:
+			log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:duplicates failed to find duplicates")
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to find duplicates", err)
+		}
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, fromDomainDuplicates(duplicates))
+}
+
+type mergeContactsRequest struct {
+	DuplicateId string `json:"duplicate_id"`
+	// Rules choose the value of first_name, last_name, email and phone: fill_empty, survivor or duplicate
+	Rules map[string]usecase.MergeRule `json:"rules"`
+}
+
+// Merge merges the duplicate into the contact of the path which is returned, the duplicate is moved to the trash.
+// The If-Match header applies to the surviving contact.
+func (h *ContactHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	var req mergeContactsRequest
+	ctx := r.Context()
+	contactId := mux.Vars(r)[pathContactId]
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:merge failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:merge failed to decode request")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "failed to decode request", err)
+		return
+	}
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid precondition", err)
+		return
+	}
+
+	contact, err := h.app.MergeContacts(ctx, usecase.CmdMergeContacts{
+		Merger:      user,
+		SurvivorId:  contactId,
+		DuplicateId: req.DuplicateId,
+		Rules:       req.Rules,
+		Version:     version,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "merge validation failed", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "contact not found", err)
+		case errors.Is(err, usecase.ErrForbidden):
+			xhttp.WriteError(ctx, w, http.StatusForbidden, "contact access forbidden", err)
+		case errors.Is(err, usecase.ErrConflict):
+			xhttp.WriteError(ctx, w, http.StatusPreconditionFailed, "contact was modified", err)
+		case errors.Is(err, usecase.ErrAlreadyExists):
+			xhttp.WriteError(ctx, w, http.StatusConflict, "email or phone already used by another contact", err)
+		default:
+			log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:merge failed to merge contacts")
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to merge contacts", err)
+		}
+		return
+	}
+
+	writeContact(ctx, w, http.StatusOK, contact)
+}
+
+const (
+	// maxImportSize is the maximum size of an imported vCard file
+	maxImportSize = 5 << 20
+	// maxBatchSize is the maximum size of a batch request body
+	maxBatchSize = 5 << 20
+	// exportPageSize is the number of contacts listed at once while exporting
+	exportPageSize = 200
+
+	importFormatVCard = "vcard"
+	importFormatCSV   = "csv"
+)
+
+// VCard renders a contact as a vCard, the version query parameter selects 3.0 or 4.0, the default
+func (h *ContactHandler) VCard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	contactId := mux.Vars(r)[pathContactId]
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:vcard failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	version, err := vcardVersion(r)
+	if err != nil {
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid query parameters", err)
+		return
+	}
+
+	contact, err := h.app.GetContact(ctx, usecase.QueryGetContact{
+		Requester: user,
+		ContactId: contactId,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid contact id", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "contact not found", err)
+		case errors.Is(err, usecase.ErrForbidden):
+			xhttp.WriteError(ctx, w, http.StatusForbidden, "contact access forbidden", err)
+		default:
+			log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:vcard failed to get contact")
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get contact", err)
+		}
+		return
+	}
+
+	writeVCards(ctx, w, contact.Id.String()+".vcf", version, []*domain.Contact{contact})
+}
+
+// ExportVCard renders all the contacts matching the List query parameters as vCards, the version query parameter
+// selects 3.0 or 4.0, the default
+func (h *ContactHandler) ExportVCard(w http.ResponseWriter, r *http.Request) {
+	version, err := vcardVersion(r)
+	if err != nil {
+		xhttp.WriteError(r.Context(), w, http.StatusBadRequest, "invalid query parameters", err)
+		return
+	}
+
+	contacts, ok := h.export(w, r)
+	if !ok {
+		return
+	}
+
+	writeVCards(r.Context(), w, "contacts.vcf", version, contacts)
+}
+
+// ExportCSV renders all the contacts matching the List query parameters as a csv file with a header row
+func (h *ContactHandler) ExportCSV(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	contacts, ok := h.export(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="contacts.csv"`)
+	w.WriteHeader(http.StatusOK)
+
+	err := writeCSV(w, contacts)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:export failed to write csv")
+	}
+}
+
+// export lists all the pages of contacts matching the List query parameters, limit and cursor are ignored.
+// It writes the error response when the contacts cannot be listed.
+func (h *ContactHandler) export(w http.ResponseWriter, r *http.Request) ([]*domain.Contact, bool) {
+	ctx := r.Context()
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:export failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return nil, false
+	}
+
+	query, err := listContactQuery(r)
+	if err != nil {
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid query parameters", err)
+		return nil, false
+	}
+	query.Requester = user
+	query.Limit = exportPageSize
+	query.Cursor = ""
+
+	var contacts []*domain.Contact
+	for {
+		page, err := h.app.ListContacts(ctx, query)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrInvalidCommand):
+				xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid query parameters", err)
+			case errors.Is(err, usecase.ErrForbidden):
+				xhttp.WriteError(ctx, w, http.StatusForbidden, "address book access forbidden", err)
+			default:
+				log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:export failed to list contacts")
+				xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to list contacts", err)
+			}
+			return nil, false
+		}
+
+		contacts = append(contacts, page.Contacts...)
+		if page.NextCursor == "" {
+			return contacts, true
+		}
+		query.Cursor = page.NextCursor
+	}
+}
+
+// Import creates a contact from every card of a vCard file or every row of a csv file, selected by the format
+// query parameter. The file is sent as the request body or as the file field of a multipart form.
+// The csv columns are read according to the mapping parameter, a JSON object mapping header columns to contact fields.
+// The address_book_id query parameter imports the contacts in an address book, and dry_run only checks them.
+func (h *ContactHandler) Import(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:import failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	values := r.URL.Query()
+	cmd := usecase.CmdImportContacts{
+		Importer:      user,
+		AddressBookId: values.Get("address_book_id"),
+	}
+	if dryRun := values.Get("dry_run"); dryRun != "" {
+		cmd.DryRun, err = strconv.ParseBool(dryRun)
+		if err != nil {
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid query parameters", fmt.Errorf("invalid dry_run: %w", err))
+			return
+		}
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	var (
+		file    io.Reader = r.Body
+		mapping           = values.Get("mapping")
+	)
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		part, _, err := r.FormFile("file")
+		if err != nil {
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "failed to read import file", err)
+			return
+		}
+		defer part.Close()
+		file = part
+		mapping = r.FormValue("mapping")
+	}
+
+	format := values.Get("format")
+	switch format {
+	case "", importFormatVCard:
+		format = importFormatVCard
+		cards, err := vcard.Decode(file)
+		if err != nil {
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "failed to decode vcard file", err)
+			return
+		}
+		for _, card := range cards {
+			if card.Err != nil {
+				cmd.Contacts = append(cmd.Contacts, usecase.ImportedContact{Err: card.Err})
+				continue
+			}
+			cmd.Contacts = append(cmd.Contacts, fromVCard(card.Card))
+		}
+	case importFormatCSV:
+		columns := map[string]string{}
+		if mapping != "" {
+			err = json.Unmarshal([]byte(mapping), &columns)
+			if err != nil {
+				xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid csv mapping", err)
+				return
+			}
+		}
+		cmd.Contacts, err = fromCSV(file, columns)
+		if err != nil {
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "failed to decode csv file", err)
+			return
+		}
+	default:
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid query parameters", fmt.Errorf("invalid format %q, expected %s or %s", format, importFormatVCard, importFormatCSV))
+		return
+	}
+
+	results, err := h.app.ImportContacts(ctx, cmd)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid import", err)
+		case errors.Is(err, usecase.ErrNotFound):
+			xhttp.WriteError(ctx, w, http.StatusNotFound, "address book not found", err)
+		case errors.Is(err, usecase.ErrForbidden):
+			xhttp.WriteError(ctx, w, http.StatusForbidden, "address book access forbidden", err)
+		default:
+			log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:import failed to import contacts")
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to import contacts", err)
+		}
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, fromDomainImportResults(results, format))
+}
+
+type batchContactsRequest struct {
+	Atomic bool                   `json:"atomic"`
+	Create []createContactRequest `json:"create"`
+	Update []batchUpdateRequest   `json:"update"`
+	Delete []batchDeleteRequest   `json:"delete"`
+}
+
+type batchUpdateRequest struct {
+	Id string `json:"id"`
+	// Version is the expected contact version like the If-Match header of single updates, 0 skips the check
+	Version int `json:"version"`
+	updateContactRequest
+}
+
+type batchDeleteRequest struct {
+	Id      string `json:"id"`
+	Version int    `json:"version"`
+}
+
+// Batch answers 422 when an atomic batch is not applied, the report tells which commands failed
+func (h *ContactHandler) Batch(w http.ResponseWriter, r *http.Request) {
+	var req batchContactsRequest
+	ctx := r.Context()
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:batch failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	err = json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBatchSize)).Decode(&req)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:batch failed to decode request")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "failed to decode request", err)
+		return
+	}
+
+	cmd := usecase.CmdBatchContacts{
+		Requester: user,
+		Atomic:    req.Atomic,
+		Create:    make([]usecase.CmdCreateContact, 0, len(req.Create)),
+		Update:    make([]usecase.CmdUpdateContact, 0, len(req.Update)),
+		Delete:    make([]usecase.CmdDeleteContact, 0, len(req.Delete)),
+	}
+	for _, create := range req.Create {
+		cmd.Create = append(cmd.Create, usecase.CmdCreateContact{
+			AddressBookId: create.AddressBookId,
+			FirstName:     create.FirstName,
+			LastName:      create.LastName,
+			Email:         create.Email,
+			Phone:         create.Phone,
+			Emails:        create.emails(),
+			Phones:        create.phones(),
+			Addresses:     create.addresses(),
+			PhoneRegion:   create.PhoneRegion,
+			CustomFields:  create.CustomFields,
+		})
+	}
+	for _, update := range req.Update {
+		cmd.Update = append(cmd.Update, usecase.CmdUpdateContact{
+			ContactId:    update.Id,
+			FirstName:    update.FirstName,
+			LastName:     update.LastName,
+			Email:        update.Email,
+			Phone:        update.Phone,
+			Emails:       update.emails(),
+			Phones:       update.phones(),
+			Addresses:    update.addresses(),
+			PhoneRegion:  update.PhoneRegion,
+			CustomFields: update.CustomFields,
+			Version:      update.Version,
+		})
+	}
+	for _, del := range req.Delete {
+		cmd.Delete = append(cmd.Delete, usecase.CmdDeleteContact{ContactId: del.Id, Version: del.Version})
+	}
+
+	results, err := h.app.BatchContacts(ctx, cmd)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid batch", err)
+		default:
+			log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:batch failed to apply batch")
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to apply batch", err)
+		}
+		return
+	}
+
+	status := http.StatusOK
+	if req.Atomic && !results.Applied() {
+		status = http.StatusUnprocessableEntity
+	}
+	xhttp.WriteObject(ctx, w, status, fromDomainBatchResults(results))
+}
+
+func vcardVersion(r *http.Request) (string, error) {
+	switch version := r.URL.Query().Get("version"); version {
+	case "", vcard.Version4:
+		return vcard.Version4, nil
+	case vcard.Version3:
+		return vcard.Version3, nil
+	default:
+		return "", fmt.Errorf("invalid version %q, expected %s or %s", version, vcard.Version3, vcard.Version4)
+	}
+}
+
+func writeVCards(ctx context.Context, w http.ResponseWriter, filename string, version string, contacts []*domain.Contact) {
+	w.Header().Set("Content-Type", vcard.MediaType+"; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	encoder := vcard.NewEncoder(w)
+	for _, contact := range contacts {
+		err := encoder.Encode(toVCard(contact, version))
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:export failed to write vcard")
+			return
+		}
+	}
+}
+
+// eventReset tells an event stream client that the changes it missed are lost and that it must reload the contacts
+const eventReset = "reset"
+
+// Events streams the changes of the contacts the user can read as server-sent events.
+// A client reconnecting with the Last-Event-ID header, or the last_event_id query parameter,
+// first receives the changes it missed, or a reset event when they are no longer available.
+func (h *ContactHandler) Events(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:events failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	lastEventId := r.Header.Get("Last-Event-ID")
+	if lastEventId == "" {
+		lastEventId = r.URL.Query().Get("last_event_id")
+	}
+
+	reset := false
+	changes, err := h.app.WatchContacts(ctx, usecase.QueryWatchContacts{Watcher: user, After: lastEventId})
+	if lastEventId != "" && errors.Is(err, usecase.ErrNotFound) {
+		reset = true
+		changes, err = h.app.WatchContacts(ctx, usecase.QueryWatchContacts{Watcher: user})
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidCommand):
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid last event id", err)
+		default:
+			log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:events failed to watch contacts")
+			xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to watch contacts", err)
+		}
+		return
+	}
+
+	stream, err := xhttp.NewEventStream(w)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("user_contacts:events failed to start event stream")
+		return
+	}
+	if reset {
+		err = stream.Event("", eventReset, []byte("{}"))
+		if err != nil {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(xhttp.DefaultHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			err = stream.Heartbeat()
+		case change, ok := <-changes:
+			if !ok {
+				// the client lagged behind, it reconnects and resumes from its last event
+				return
+			}
+			err = writeContactChange(stream, change)
+		}
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).Msg("user_contacts:events failed to write event")
+			return
+		}
+	}
+}
+
+func writeContactChange(stream *xhttp.EventStream, change usecase.ContactChange) error {
+	data, err := json.Marshal(fromDomainChange(change))
+	if err != nil {
+		return err
+	}
+
+	return stream.Event(change.EventId.String(), change.Event, data)
+}
+
+type shareContactRequest struct {
+	UserId     string `json:"user_id"`
+	Permission string `json:"permission"`
+}
+
+func (h *ContactHandler) Share(w http.ResponseWriter, r *http.Request) {
+	var req shareContactRequest
+	ctx := r.Context()
+	contactId := mux.Vars(r)[pathContactId]
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:share failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:share failed to decode request")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "failed to decode request", err)
+		return
+	}
+
+	contact, err := h.app.ShareContact(ctx, usecase.CmdShareContact{
+		Sharer:     user,
+		ContactId:  contactId,
+		UserId:     req.UserId,
+		Permission: req.Permission,
+	})
+	if err != nil {
+		writeShareError(ctx, w, err)
+		return
+	}
+
+	writeContact(ctx, w, http.StatusOK, contact)
+}
+
+func (h *ContactHandler) Unshare(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:unshare failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	contact, err := h.app.UnshareContact(ctx, usecase.CmdUnshareContact{
+		Sharer:    user,
+		ContactId: vars[pathContactId],
+		UserId:    vars[pathUserId],
+	})
+	if err != nil {
+		writeShareError(ctx, w, err)
+		return
+	}
+
+	writeContact(ctx, w, http.StatusOK, contact)
+}
+
+func writeShareError(ctx context.Context, w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidCommand):
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "share validation failed", err)
+	case errors.Is(err, usecase.ErrNotFound):
+		xhttp.WriteError(ctx, w, http.StatusNotFound, "contact or share not found", err)
+	case errors.Is(err, usecase.ErrForbidden):
+		xhttp.WriteError(ctx, w, http.StatusForbidden, "contact access forbidden", err)
+	default:
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:share failed to update contact shares")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to update contact shares", err)
+	}
+}
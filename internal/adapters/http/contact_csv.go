@@ -0,0 +1,377 @@
+package http
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/internal/usecase"
+	"github.com/google/uuid"
+)
+
+const (
+	csvFirstName = "first_name"
+	csvLastName  = "last_name"
+	csvEmail     = "email"
+	csvPhone     = "phone"
+)
+
+// csvMaxValues is the number of emails, phones and postal addresses a contact may have, see usecase.CmdCreateContact
+const csvMaxValues = 20
+
+// csvIndexedField matches the indexed columns of the emails, phones and postal addresses, numbered from 1 like
+// email_1, email_1_label or address_1_street
+var csvIndexedField = regexp.MustCompile(`^(email|phone|address)_([0-9]+)(?:_(label|street|locality|region|postal_code|country))?$`)
+
+// csvAddressParts are the columns of a postal address following its index
+var csvAddressParts = []string{"street", "locality", "region", "postal_code", "country", "label"}
+
+// csvExportHeader lists the exported columns, the contact fields use the names expected by the default import mapping.
+// Email and phone are the primary ones, followed by as many indexed columns as the contacts have values, primary first.
+var csvExportHeader = []string{"id", "address_book_id", "created_at", "updated_at", csvFirstName, csvLastName, csvEmail, csvPhone}
+
+func writeCSV(w io.Writer, contacts []*domain.Contact) error {
+	var emails, phones, addresses int
+	for _, c := range contacts {
+		emails = maxInt(emails, len(c.EmailAddresses()))
+		phones = maxInt(phones, len(c.PhoneNumbers()))
+		addresses = maxInt(addresses, len(c.Addresses))
+	}
+
+	header := append([]string{}, csvExportHeader...)
+	for i := 1; i <= emails; i++ {
+		header = append(header, fmt.Sprintf("email_%d", i), fmt.Sprintf("email_%d_label", i))
+	}
+	for i := 1; i <= phones; i++ {
+		header = append(header, fmt.Sprintf("phone_%d", i), fmt.Sprintf("phone_%d_label", i))
+	}
+	for i := 1; i <= addresses; i++ {
+		for _, part := range csvAddressParts {
+			header = append(header, fmt.Sprintf("address_%d_%s", i, part))
+		}
+	}
+
+	writer := csv.NewWriter(w)
+	err := writer.Write(header)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range contacts {
+		addressBookId := ""
+		if c.AddressBookId != uuid.Nil {
+			addressBookId = c.AddressBookId.String()
+		}
+
+		row := []string{
+			c.Id.String(),
+			addressBookId,
+			c.CreatedAt.UTC().Format(time.RFC3339),
+			c.UpdatedAt.UTC().Format(time.RFC3339),
+			escapeCSVFormula(c.FirstName),
+			escapeCSVFormula(c.LastName),
+			escapeCSVFormula(c.Email),
+			escapeCSVFormula(c.Phone),
+		}
+
+		values := make([]string, 2*emails)
+		for i, email := range primaryFirst(c.EmailAddresses(), func(e domain.EmailAddress) bool { return e.Primary }) {
+			values[2*i], values[2*i+1] = escapeCSVFormula(email.Address), string(email.Label)
+		}
+		row = append(row, values...)
+
+		values = make([]string, 2*phones)
+		for i, phone := range primaryFirst(c.PhoneNumbers(), func(p domain.PhoneNumber) bool { return p.Primary }) {
+			values[2*i], values[2*i+1] = escapeCSVFormula(phone.Number), string(phone.Label)
+		}
+		row = append(row, values...)
+
+		values = make([]string, len(csvAddressParts)*addresses)
+		for i, address := range primaryFirst(c.Addresses, func(a domain.PostalAddress) bool { return a.Primary }) {
+			copy(values[len(csvAddressParts)*i:], []string{
+				escapeCSVFormula(address.Street),
+				escapeCSVFormula(address.Locality),
+				escapeCSVFormula(address.Region),
+				escapeCSVFormula(address.PostalCode),
+				address.Country,
+				string(address.Label),
+			})
+		}
+		row = append(row, values...)
+
+		err := writer.Write(row)
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// primaryFirst returns the values with the primary one first, the others keeping their order
+func primaryFirst[T any](values []T, primary func(v T) bool) []T {
+	sorted := make([]T, 0, len(values))
+	for _, v := range values {
+		if primary(v) {
+			sorted = append(sorted, v)
+		}
+	}
+	for _, v := range values {
+		if !primary(v) {
+			sorted = append(sorted, v)
+		}
+	}
+
+	return sorted
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+// csvFormulaPrefixes are the first characters making spreadsheets evaluate a cell as a formula
+const csvFormulaPrefixes = "=+-@\t\r"
+
+// escapeCSVFormula prefixes the cells read as formulas by spreadsheets with a quote, which they display as text,
+// https://owasp.org/www-community/attacks/CSV_Injection
+func escapeCSVFormula(cell string) string {
+	if cell != "" && strings.ContainsRune(csvFormulaPrefixes, rune(cell[0])) {
+		return "'" + cell
+	}
+
+	return cell
+}
+
+// unescapeCSVFormula removes the quote added by escapeCSVFormula so that exported files are imported unchanged
+func unescapeCSVFormula(cell string) string {
+	if len(cell) > 1 && cell[0] == '\'' && strings.ContainsRune(csvFormulaPrefixes, rune(cell[1])) {
+		return cell[1:]
+	}
+
+	return cell
+}
+
+// fromCSV reads a contact from every row following the header, mapping maps header columns to contact fields.
+// Without mapping, the columns named like a field, ignoring case, spaces and dashes, are read.
+func fromCSV(r io.Reader, mapping map[string]string) ([]usecase.ImportedContact, error) {
+	reader := csv.NewReader(r)
+	// rows missing trailing columns are reported by the validation rather than failing the whole file
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("missing csv header")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// spreadsheets often start their csv files with a byte order mark
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+
+	columns, err := csvColumns(header, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	var contacts []usecase.ImportedContact
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return contacts, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var contact usecase.ImportedContact
+		for i, value := range row {
+			if field, ok := columns[i]; ok {
+				setCSVField(&contact, field, strings.TrimSpace(unescapeCSVFormula(value)))
+			}
+		}
+		contacts = append(contacts, withCSVValues(contact))
+	}
+}
+
+// setCSVField sets the field of the contact read from a column, the indexed values are added as needed
+func setCSVField(contact *usecase.ImportedContact, field string, value string) {
+	switch field {
+	case csvFirstName:
+		contact.FirstName = value
+		return
+	case csvLastName:
+		contact.LastName = value
+		return
+	case csvEmail:
+		contact.Email = value
+		return
+	case csvPhone:
+		contact.Phone = phoneSeparators.Replace(value)
+		return
+	}
+
+	match := csvIndexedField.FindStringSubmatch(field)
+	index, _ := strconv.Atoi(match[2])
+	index--
+	switch match[1] {
+	case "email":
+		for len(contact.Emails) <= index {
+			contact.Emails = append(contact.Emails, usecase.ContactEmail{})
+		}
+		if match[3] == "label" {
+			contact.Emails[index].Label = csvLabel(value)
+		} else {
+			contact.Emails[index].Address = value
+		}
+	case "phone":
+		for len(contact.Phones) <= index {
+			contact.Phones = append(contact.Phones, usecase.ContactPhone{})
+		}
+		if match[3] == "label" {
+			contact.Phones[index].Label = csvLabel(value)
+		} else {
+			contact.Phones[index].Number = phoneSeparators.Replace(value)
+		}
+	case "address":
+		for len(contact.Addresses) <= index {
+			contact.Addresses = append(contact.Addresses, usecase.ContactAddress{})
+		}
+		address := &contact.Addresses[index]
+		switch match[3] {
+		case "street":
+			address.Street = value
+		case "locality":
+			address.Locality = value
+		case "region":
+			address.Region = value
+		case "postal_code":
+			address.PostalCode = value
+		case "country":
+			address.Country = strings.ToUpper(value)
+		case "label":
+			address.Label = csvLabel(value)
+		}
+	}
+}
+
+// withCSVValues removes the emails, phones and postal addresses whose columns are empty, the rows of a file having
+// as many columns as the contact with the most values, and makes the first remaining ones primary like the export
+func withCSVValues(contact usecase.ImportedContact) usecase.ImportedContact {
+	emails, phones, addresses := contact.Emails, contact.Phones, contact.Addresses
+	contact.Emails, contact.Phones, contact.Addresses = nil, nil, nil
+
+	for _, email := range emails {
+		if email.Address != "" {
+			contact.Emails = append(contact.Emails, email)
+		}
+	}
+	for _, phone := range phones {
+		if phone.Number != "" {
+			contact.Phones = append(contact.Phones, phone)
+		}
+	}
+	for _, address := range addresses {
+		if address.Street+address.Locality+address.Region+address.PostalCode+address.Country != "" {
+			contact.Addresses = append(contact.Addresses, address)
+		}
+	}
+
+	if len(contact.Emails) > 0 {
+		contact.Emails[0].Primary = true
+	}
+	if len(contact.Phones) > 0 {
+		contact.Phones[0].Primary = true
+	}
+	if len(contact.Addresses) > 0 {
+		contact.Addresses[0].Primary = true
+	}
+
+	return contact
+}
+
+// csvLabel returns the label of an email, phone or postal address, empty meaning other for unknown labels
+func csvLabel(label string) string {
+	switch label = strings.ToLower(label); domain.Label(label) {
+	case domain.LabelWork, domain.LabelHome, domain.LabelMobile, domain.LabelOther:
+		return label
+	}
+
+	return ""
+}
+
+// csvColumns returns the contact field read from each column index
+func csvColumns(header []string, mapping map[string]string) (map[int]string, error) {
+	columns := make(map[int]string, len(header))
+	if len(mapping) == 0 {
+		for i, column := range header {
+			if field := normalizeColumn(column); isCSVField(field) {
+				columns[i] = field
+			}
+		}
+		return columns, nil
+	}
+
+	indexes := make(map[string]int, len(header))
+	for i, column := range header {
+		if _, ok := indexes[column]; !ok {
+			indexes[column] = i
+		}
+	}
+
+	mapped := make(map[string]string, len(mapping))
+	for column, field := range mapping {
+		if !isCSVField(field) {
+			return nil, fmt.Errorf("column %q is mapped to unknown field %q", column, field)
+		}
+		if other, ok := mapped[field]; ok {
+			return nil, fmt.Errorf("columns %q and %q are both mapped to %q", other, column, field)
+		}
+		index, ok := indexes[column]
+		if !ok {
+			return nil, fmt.Errorf("mapped column %q is missing from the csv header", column)
+		}
+
+		mapped[field] = column
+		columns[index] = field
+	}
+
+	return columns, nil
+}
+
+func isCSVField(field string) bool {
+	if field == csvFirstName || field == csvLastName || field == csvEmail || field == csvPhone {
+		return true
+	}
+
+	match := csvIndexedField.FindStringSubmatch(field)
+	if match == nil {
+		return false
+	}
+	index, err := strconv.Atoi(match[2])
+	if err != nil || index < 1 || index > csvMaxValues {
+		return false
+	}
+
+	// emails and phones only have a label besides their value, postal addresses have no value of their own
+	if match[1] == "address" {
+		return match[3] != ""
+	}
+	return match[3] == "" || match[3] == "label"
+}
+
+func normalizeColumn(column string) string {
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(column)))
+}
@@ -1,28 +1,297 @@
 package http
 
-import "github.com/davidterranova/contacts/internal/domain"
+import (
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/internal/usecase"
+	"github.com/davidterranova/contacts/pkg/phone"
+	"github.com/google/uuid"
+)
 
+// Contact is presented with its primary email and phone along with the lists, for the clients knowing a single one
 type Contact struct {
-	Id        string `json:"id"`
-	CreatedBy string `json:"created_by"`
+	Id            string          `json:"id"`
+	CreatedBy     string          `json:"created_by"`
+	CreatedAt     string          `json:"created_at"`
+	UpdatedAt     string          `json:"updated_at"`
+	Version       int             `json:"version"`
+	AddressBookId string          `json:"address_book_id,omitempty"`
+	FirstName     string          `json:"first_name"`
+	LastName      string          `json:"last_name"`
+	Email         string          `json:"email"`
+	EmailStatus   string          `json:"email_status"`
+	Phone         string          `json:"phone"`
+	Emails        []EmailAddress  `json:"emails"`
+	Phones        []PhoneNumber   `json:"phones"`
+	Addresses     []PostalAddress `json:"addresses"`
+	CustomFields  map[string]any  `json:"custom_fields"`
+	Shares        []Share         `json:"shares"`
+	GroupIds      []string        `json:"group_ids"`
+	Relationships []Relationship  `json:"relationships"`
+	DeletedAt     string          `json:"deleted_at,omitempty"`
+}
+
+// Relationship links the contact to the related contact
+type Relationship struct {
+	Type      string `json:"type"`
+	ContactId string `json:"contact_id"`
+	OnDelete  string `json:"on_delete"`
 	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
-	Email     string `json:"email"`
-	Phone     string `json:"phone"`
+}
+
+// RelatedContact is the contact at the other end of a relationship
+type RelatedContact struct {
+	Type      string   `json:"type"`
+	OnDelete  string   `json:"on_delete"`
+	CreatedAt string   `json:"created_at"`
+	Contact   *Contact `json:"contact"`
+}
+
+// ContactRelationships are the relationships of the contact to other contacts and the ones of other contacts to it
+type ContactRelationships struct {
+	Outgoing []RelatedContact `json:"outgoing"`
+	Incoming []RelatedContact `json:"incoming"`
+}
+
+type EmailAddress struct {
+	Label   string `json:"label"`
+	Address string `json:"address"`
+	Primary bool   `json:"primary"`
+}
+
+// PhoneNumber is read in the phone region of the request and presented in its E.164 form, along with its national
+// and international formats and its type
+type PhoneNumber struct {
+	Label         string `json:"label"`
+	Number        string `json:"number"`
+	National      string `json:"national,omitempty"`
+	International string `json:"international,omitempty"`
+	Type          string `json:"type,omitempty"`
+	Primary       bool   `json:"primary"`
+}
+
+type PostalAddress struct {
+	Label      string `json:"label"`
+	Street     string `json:"street"`
+	Locality   string `json:"locality"`
+	Region     string `json:"region,omitempty"`
+	PostalCode string `json:"postal_code,omitempty"`
+	Country    string `json:"country,omitempty"`
+	Primary    bool   `json:"primary"`
+}
+
+type Share struct {
+	UserId     string `json:"user_id"`
+	Permission string `json:"permission"`
+	GrantedAt  string `json:"granted_at"`
+}
+
+type AuditEntry struct {
+	Id        string        `json:"id"`
+	ContactId string        `json:"contact_id"`
+	Actor     Actor         `json:"actor"`
+	Action    string        `json:"action"`
+	At        string        `json:"at"`
+	Changes   []FieldChange `json:"changes"`
+}
+
+type Actor struct {
+	Id   string `json:"id"`
+	Type string `json:"type"`
+}
+
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+type ContactChange struct {
+	Event      string   `json:"event"`
+	OccurredAt string   `json:"occurred_at"`
+	Contact    *Contact `json:"contact"`
+}
+
+// ImportReport counts the imported contacts by status, the results are in the order of the file
+type ImportReport struct {
+	Created int `json:"created"`
+	// Valid contacts would be created if the import was not a dry run
+	Valid   int            `json:"valid"`
+	Skipped int            `json:"skipped"`
+	Failed  int            `json:"failed"`
+	Results []ImportResult `json:"results"`
+}
+
+type ImportResult struct {
+	// Card is the position of the card in a vCard file, starting at 1
+	Card int `json:"card,omitempty"`
+	// Row is the line of the row in a csv file, the header being the first one
+	Row     int      `json:"row,omitempty"`
+	Status  string   `json:"status"`
+	Contact *Contact `json:"contact,omitempty"`
+	Reason  string   `json:"reason,omitempty"`
+}
+
+// BatchReport counts the batch commands by status, the results are in the order of the commands
+type BatchReport struct {
+	Applied int `json:"applied"`
+	Failed  int `json:"failed"`
+	// Aborted commands of an atomic batch are valid but were not applied because another one failed
+	Aborted int           `json:"aborted"`
+	Create  []BatchResult `json:"create"`
+	Update  []BatchResult `json:"update"`
+	Delete  []BatchResult `json:"delete"`
+}
+
+type BatchResult struct {
+	Status  string   `json:"status"`
+	Contact *Contact `json:"contact,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// Duplicate is a pair of contacts which may be the same person, the oldest contact comes first
+type Duplicate struct {
+	Contacts       []*Contact `json:"contacts"`
+	Reasons        []string   `json:"reasons"`
+	NameSimilarity float64    `json:"name_similarity"`
+}
+
+type DuplicateList struct {
+	Duplicates []Duplicate `json:"duplicates"`
+}
+
+type ContactList struct {
+	Contacts   []*Contact `json:"contacts"`
+	NextCursor string     `json:"next_cursor,omitempty"`
 }
 
 func fromDomain(c *domain.Contact) *Contact {
-	return &Contact{
-		Id:        c.Id.String(),
-		CreatedBy: c.CreatedBy.String(),
-		CreatedAt: c.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt: c.UpdatedAt.Format("2006-01-02T15:04:05Z"),
-		FirstName: c.FirstName,
-		LastName:  c.LastName,
-		Email:     c.Email,
-		Phone:     c.Phone,
+	contact := &Contact{
+		Id:            c.Id.String(),
+		CreatedBy:     c.CreatedBy.String(),
+		CreatedAt:     c.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:     c.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		Version:       c.Version,
+		FirstName:     c.FirstName,
+		LastName:      c.LastName,
+		Email:         c.Email,
+		EmailStatus:   string(c.EmailStatus.OrUnverified()),
+		Phone:         c.Phone,
+		Emails:        fromDomainEmails(c.EmailAddresses()),
+		Phones:        fromDomainPhones(c.PhoneNumbers()),
+		Addresses:     fromDomainAddresses(c.Addresses),
+		CustomFields:  fromDomainCustomFields(c.CustomFields),
+		Shares:        fromDomainGrants(c.Grants),
+		GroupIds:      make([]string, 0, len(c.GroupIds)),
+		Relationships: fromDomainRelationshipList(c.Relationships),
+	}
+	for _, groupId := range c.GroupIds {
+		contact.GroupIds = append(contact.GroupIds, groupId.String())
+	}
+	if c.AddressBookId != uuid.Nil {
+		contact.AddressBookId = c.AddressBookId.String()
+	}
+	if c.DeletedAt != nil {
+		contact.DeletedAt = c.DeletedAt.Format("2006-01-02T15:04:05Z")
+	}
+
+	return contact
+}
+
+func fromDomainCustomFields(fields map[string]domain.CustomValue) map[string]any {
+	var result = make(map[string]any, len(fields))
+	for name, value := range fields {
+		result[name] = value.Value()
+	}
+
+	return result
+}
+
+func fromDomainEmails(emails []domain.EmailAddress) []EmailAddress {
+	var result = make([]EmailAddress, 0, len(emails))
+	for _, e := range emails {
+		result = append(result, EmailAddress{Label: string(e.Label), Address: e.Address, Primary: e.Primary})
+	}
+
+	return result
+}
+
+func fromDomainPhones(phones []domain.PhoneNumber) []PhoneNumber {
+	var result = make([]PhoneNumber, 0, len(phones))
+	for _, p := range phones {
+		number := PhoneNumber{Label: string(p.Label), Number: p.Number, Primary: p.Primary}
+		if n, err := phone.Parse(p.Number, ""); err == nil {
+			number.National = n.National()
+			number.International = n.International()
+			number.Type = string(n.Type)
+		}
+		result = append(result, number)
+	}
+
+	return result
+}
+
+func fromDomainAddresses(addresses []domain.PostalAddress) []PostalAddress {
+	var result = make([]PostalAddress, 0, len(addresses))
+	for _, a := range addresses {
+		result = append(result, PostalAddress{
+			Label:      string(a.Label),
+			Street:     a.Street,
+			Locality:   a.Locality,
+			Region:     a.Region,
+			PostalCode: a.PostalCode,
+			Country:    a.Country,
+			Primary:    a.Primary,
+		})
+	}
+
+	return result
+}
+
+func fromDomainGrants(grants []domain.Grant) []Share {
+	var shares = make([]Share, 0, len(grants))
+	for _, g := range grants {
+		shares = append(shares, Share{
+			UserId:     g.UserId.String(),
+			Permission: string(g.Permission),
+			GrantedAt:  g.GrantedAt.Format("2006-01-02T15:04:05Z"),
+		})
+	}
+
+	return shares
+}
+
+func fromDomainRelationshipList(relationships []domain.Relationship) []Relationship {
+	var result = make([]Relationship, 0, len(relationships))
+	for _, r := range relationships {
+		result = append(result, Relationship{
+			Type:      string(r.Type),
+			ContactId: r.ContactId.String(),
+			OnDelete:  string(r.OnDelete),
+			CreatedAt: r.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		})
+	}
+
+	return result
+}
+
+func fromDomainRelatedContacts(related []usecase.RelatedContact) []RelatedContact {
+	var result = make([]RelatedContact, 0, len(related))
+	for _, r := range related {
+		result = append(result, RelatedContact{
+			Type:      string(r.Relationship.Type),
+			OnDelete:  string(r.Relationship.OnDelete),
+			CreatedAt: r.Relationship.CreatedAt.Format("2006-01-02T15:04:05Z"),
+			Contact:   fromDomain(r.Contact),
+		})
+	}
+
+	return result
+}
+
+func fromDomainRelationships(relationships *usecase.ContactRelationships) *ContactRelationships {
+	return &ContactRelationships{
+		Outgoing: fromDomainRelatedContacts(relationships.Outgoing),
+		Incoming: fromDomainRelatedContacts(relationships.Incoming),
 	}
 }
 
@@ -34,3 +303,127 @@ func fromDomainList(contacts []*domain.Contact) []*Contact {
 
 	return list
 }
+
+func fromDomainPage(page *usecase.ContactPage) *ContactList {
+	return &ContactList{
+		Contacts:   fromDomainList(page.Contacts),
+		NextCursor: page.NextCursor,
+	}
+}
+
+func fromDomainAuditEntries(entries []*domain.AuditEntry) []*AuditEntry {
+	var history = make([]*AuditEntry, 0, len(entries))
+	for _, entry := range entries {
+		changes := make([]FieldChange, 0, len(entry.Changes))
+		for _, change := range entry.Changes {
+			changes = append(changes, FieldChange(change))
+		}
+
+		history = append(history, &AuditEntry{
+			Id:        entry.Id.String(),
+			ContactId: entry.ContactId.String(),
+			Actor: Actor{
+				Id:   entry.Actor.Id().String(),
+				Type: string(entry.Actor.Type()),
+			},
+			Action:  string(entry.Action),
+			At:      entry.At.Format("2006-01-02T15:04:05Z"),
+			Changes: changes,
+		})
+	}
+
+	return history
+}
+
+func fromDomainChange(change usecase.ContactChange) *ContactChange {
+	return &ContactChange{
+		Event:      change.Event,
+		OccurredAt: change.OccurredAt.UTC().Format("2006-01-02T15:04:05Z"),
+		Contact:    fromDomain(change.Contact),
+	}
+}
+
+func fromDomainImportResults(results []usecase.ImportResult, format string) *ImportReport {
+	report := &ImportReport{Results: make([]ImportResult, 0, len(results))}
+	for i, result := range results {
+		switch result.Status {
+		case usecase.ImportStatusCreated:
+			report.Created++
+		case usecase.ImportStatusValid:
+			report.Valid++
+		case usecase.ImportStatusSkipped:
+			report.Skipped++
+		case usecase.ImportStatusFailed:
+			report.Failed++
+		}
+
+		imported := ImportResult{
+			Status: string(result.Status),
+			Reason: result.Reason,
+		}
+		if format == importFormatCSV {
+			imported.Row = i + 2
+		} else {
+			imported.Card = i + 1
+		}
+		if result.Contact != nil {
+			imported.Contact = fromDomain(result.Contact)
+		}
+		report.Results = append(report.Results, imported)
+	}
+
+	return report
+}
+
+func fromDomainBatchResults(results usecase.BatchResults) *BatchReport {
+	report := &BatchReport{}
+	report.Create = report.toBatchResults(results.Create)
+	report.Update = report.toBatchResults(results.Update)
+	report.Delete = report.toBatchResults(results.Delete)
+
+	return report
+}
+
+// toBatchResults presents the results and counts them by status
+func (r *BatchReport) toBatchResults(results []usecase.BatchResult) []BatchResult {
+	batchResults := make([]BatchResult, 0, len(results))
+	for _, result := range results {
+		switch result.Status {
+		case usecase.BatchStatusApplied:
+			r.Applied++
+		case usecase.BatchStatusFailed:
+			r.Failed++
+		case usecase.BatchStatusAborted:
+			r.Aborted++
+		}
+
+		batchResult := BatchResult{Status: string(result.Status)}
+		if result.Contact != nil {
+			batchResult.Contact = fromDomain(result.Contact)
+		}
+		if result.Err != nil {
+			batchResult.Error = result.Err.Error()
+		}
+		batchResults = append(batchResults, batchResult)
+	}
+
+	return batchResults
+}
+
+func fromDomainDuplicates(duplicates []usecase.Duplicate) *DuplicateList {
+	list := &DuplicateList{Duplicates: make([]Duplicate, 0, len(duplicates))}
+	for _, duplicate := range duplicates {
+		reasons := make([]string, 0, len(duplicate.Reasons))
+		for _, reason := range duplicate.Reasons {
+			reasons = append(reasons, string(reason))
+		}
+
+		list.Duplicates = append(list.Duplicates, Duplicate{
+			Contacts:       []*Contact{fromDomain(duplicate.Contacts[0]), fromDomain(duplicate.Contacts[1])},
+			Reasons:        reasons,
+			NameSimilarity: duplicate.NameSimilarity,
+		})
+	}
+
+	return list
+}
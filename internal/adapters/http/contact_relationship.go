@@ -0,0 +1,114 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/internal/usecase"
+	"github.com/davidterranova/contacts/pkg/auth"
+	"github.com/davidterranova/contacts/pkg/xhttp"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+)
+
+// relateContactsRequest is the optional body of a relationship, on_delete defaults to nullify
+type relateContactsRequest struct {
+	OnDelete string `json:"on_delete"`
+}
+
+func (h *ContactHandler) Relationships(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	contactId := mux.Vars(r)[pathContactId]
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:relationships failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	relationships, err := h.app.ContactRelationships(ctx, usecase.QueryContactRelationships{
+		Requester: user,
+		ContactId: contactId,
+	})
+	if err != nil {
+		writeRelationshipError(ctx, w, err)
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, fromDomainRelationships(relationships))
+}
+
+func (h *ContactHandler) Relate(w http.ResponseWriter, r *http.Request) {
+	var req relateContactsRequest
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:relate failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil && !errors.Is(err, io.EOF) {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:relate failed to decode request")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "failed to decode request", err)
+		return
+	}
+
+	contact, err := h.app.RelateContacts(ctx, usecase.CmdRelateContacts{
+		Requester: user,
+		ContactId: vars[pathContactId],
+		RelatedId: vars[pathRelatedId],
+		Type:      domain.RelationshipType(vars[pathRelationshipType]),
+		OnDelete:  domain.OnDelete(req.OnDelete),
+	})
+	if err != nil {
+		writeRelationshipError(ctx, w, err)
+		return
+	}
+
+	writeContact(ctx, w, http.StatusOK, contact)
+}
+
+func (h *ContactHandler) Unrelate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:unrelate failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	contact, err := h.app.UnrelateContacts(ctx, usecase.CmdUnrelateContacts{
+		Requester: user,
+		ContactId: vars[pathContactId],
+		RelatedId: vars[pathRelatedId],
+		Type:      domain.RelationshipType(vars[pathRelationshipType]),
+	})
+	if err != nil {
+		writeRelationshipError(ctx, w, err)
+		return
+	}
+
+	writeContact(ctx, w, http.StatusOK, contact)
+}
+
+func writeRelationshipError(ctx context.Context, w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidCommand):
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "relationship validation failed", err)
+	case errors.Is(err, usecase.ErrNotFound):
+		xhttp.WriteError(ctx, w, http.StatusNotFound, "contact or relationship not found", err)
+	case errors.Is(err, usecase.ErrForbidden):
+		xhttp.WriteError(ctx, w, http.StatusForbidden, "contact access forbidden", err)
+	default:
+		log.Ctx(ctx).Warn().Err(err).Msg("user_contacts:relationships failed to handle contact relationships")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to handle contact relationships", err)
+	}
+}
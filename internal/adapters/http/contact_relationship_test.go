@@ -0,0 +1,170 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/internal/usecase"
+	"github.com/davidterranova/contacts/pkg/user"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/steinfletcher/apitest"
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+)
+
+func TestContactRelationships(t *testing.T) {
+	t.Parallel()
+
+	authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+	employee := domain.New(authorizedUser.Id())
+	company := domain.New(authorizedUser.Id())
+	relationship := domain.Relationship{
+		Type:      domain.RelationshipOrganization,
+		ContactId: company.Id,
+		OnDelete:  domain.OnDeleteCascade,
+		CreatedAt: time.Now().UTC(),
+	}
+	employee.Relate(relationship)
+	container := testContainer(t)
+	container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+	container.app.EXPECT().
+		ContactRelationships(gomock.Any(), usecase.QueryContactRelationships{Requester: authorizedUser, ContactId: company.Id.String()}).
+		Times(1).
+		Return(&usecase.ContactRelationships{
+			Outgoing: []usecase.RelatedContact{},
+			Incoming: []usecase.RelatedContact{{Relationship: relationship, Contact: employee}},
+		}, nil)
+
+	apitest.New().
+		Report(apitest.SequenceDiagram()).
+		Handler(container.handler).
+		Getf("/v1/contacts/%s/relationships", company.Id).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(jsonpath.Len("$.outgoing", 0)).
+		Assert(jsonpath.Len("$.incoming", 1)).
+		Assert(jsonpath.Equal("$.incoming[0].type", "organization")).
+		Assert(jsonpath.Equal("$.incoming[0].on_delete", "cascade")).
+		Assert(jsonpath.Equal("$.incoming[0].contact.id", employee.Id.String())).
+		Assert(jsonpath.Equal("$.incoming[0].contact.relationships[0].contact_id", company.Id.String())).
+		End()
+}
+
+func TestRelateContacts(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name             string
+		body             string
+		expectedOnDelete domain.OnDelete
+		returnedAppErr   error
+		expectedStatus   int
+	}{
+		{
+			name:             "ok",
+			body:             `{"on_delete": "restrict"}`,
+			expectedOnDelete: domain.OnDeleteRestrict,
+			expectedStatus:   http.StatusOK,
+		},
+		{
+			name:           "without body",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:             "bad request",
+			body:             `{"on_delete": "ignore"}`,
+			expectedOnDelete: "ignore",
+			returnedAppErr:   usecase.ErrInvalidCommand,
+			expectedStatus:   http.StatusBadRequest,
+		},
+		{
+			name:           "forbidden",
+			returnedAppErr: usecase.ErrForbidden,
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+			contact := domain.New(authorizedUser.Id())
+			relatedId := uuid.NewString()
+			container := testContainer(t)
+			container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+			container.app.EXPECT().
+				RelateContacts(gomock.Any(), usecase.CmdRelateContacts{
+					Requester: authorizedUser,
+					ContactId: contact.Id.String(),
+					RelatedId: relatedId,
+					Type:      domain.RelationshipManager,
+					OnDelete:  c.expectedOnDelete,
+				}).
+				Times(1).
+				Return(contact, c.returnedAppErr)
+
+			test := apitest.New().
+				Report(apitest.SequenceDiagram()).
+				Handler(container.handler).
+				Putf("/v1/contacts/%s/relationships/manager/%s", contact.Id, relatedId)
+			if c.body != "" {
+				test = test.JSON(c.body)
+			}
+			test.Expect(t).
+				Status(c.expectedStatus).
+				End()
+		})
+	}
+}
+
+func TestUnrelateContacts(t *testing.T) {
+	t.Parallel()
+
+	authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+	contactId := uuid.NewString()
+	relatedId := uuid.NewString()
+	container := testContainer(t)
+	container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+	container.app.EXPECT().
+		UnrelateContacts(gomock.Any(), usecase.CmdUnrelateContacts{
+			Requester: authorizedUser,
+			ContactId: contactId,
+			RelatedId: relatedId,
+			Type:      domain.RelationshipSpouse,
+		}).
+		Times(1).
+		Return(nil, usecase.ErrNotFound)
+
+	apitest.New().
+		Report(apitest.SequenceDiagram()).
+		Handler(container.handler).
+		Deletef("/v1/contacts/%s/relationships/spouse/%s", contactId, relatedId).
+		Expect(t).
+		Status(http.StatusNotFound).
+		End()
+}
+
+func TestDeleteRestrictedContact(t *testing.T) {
+	t.Parallel()
+
+	authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+	contactId := uuid.NewString()
+	container := testContainer(t)
+	container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+	container.app.EXPECT().
+		DeleteContact(gomock.Any(), usecase.CmdDeleteContact{Deleter: authorizedUser, ContactId: contactId}).
+		Times(1).
+		Return(usecase.ErrRestricted)
+
+	apitest.New().
+		Report(apitest.SequenceDiagram()).
+		Handler(container.handler).
+		Deletef("/v1/contacts/%s", contactId).
+		Expect(t).
+		Status(http.StatusConflict).
+		End()
+}
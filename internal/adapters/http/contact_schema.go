@@ -0,0 +1,88 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/davidterranova/contacts/internal/usecase"
+	"github.com/davidterranova/contacts/pkg/auth"
+	"github.com/davidterranova/contacts/pkg/xhttp"
+	"github.com/rs/zerolog/log"
+)
+
+// ContactSchemaHandler manages the custom fields of the contacts created by the user
+type ContactSchemaHandler struct {
+	app App
+}
+
+func NewContactSchemaHandler(app App) *ContactSchemaHandler {
+	return &ContactSchemaHandler{
+		app: app,
+	}
+}
+
+func (h *ContactSchemaHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("contact_schema:get failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	schema, err := h.app.GetContactSchema(ctx, usecase.QueryGetContactSchema{Owner: user})
+	if err != nil {
+		writeContactSchemaError(ctx, w, err, "contact_schema:get failed to get contact schema")
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, fromDomainContactSchema(schema))
+}
+
+type contactSchemaRequest struct {
+	Fields             []CustomField `json:"fields"`
+	DefaultPhoneRegion string        `json:"default_phone_region"`
+}
+
+// Set replaces the custom fields, the values of removed fields are kept on the contacts until they are updated
+func (h *ContactSchemaHandler) Set(w http.ResponseWriter, r *http.Request) {
+	var req contactSchemaRequest
+	ctx := r.Context()
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("contact_schema:set failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("contact_schema:set failed to decode request")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "failed to decode request", err)
+		return
+	}
+
+	schema, err := h.app.SetContactSchema(ctx, usecase.CmdSetContactSchema{
+		Owner:              user,
+		Fields:             toCmdCustomFields(req.Fields),
+		DefaultPhoneRegion: req.DefaultPhoneRegion,
+	})
+	if err != nil {
+		writeContactSchemaError(ctx, w, err, "contact_schema:set failed to set contact schema")
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, fromDomainContactSchema(schema))
+}
+
+func writeContactSchemaError(ctx context.Context, w http.ResponseWriter, err error, msg string) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidCommand):
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "contact schema validation failed", err)
+	default:
+		log.Ctx(ctx).Warn().Err(err).Msg(msg)
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to handle contact schema", err)
+	}
+}
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/internal/usecase"
+)
+
+type ContactSchema struct {
+	OwnerId            string        `json:"owner_id"`
+	UpdatedAt          string        `json:"updated_at,omitempty"`
+	Fields             []CustomField `json:"fields"`
+	DefaultPhoneRegion string        `json:"default_phone_region,omitempty"`
+}
+
+// CustomField is a custom field definition, min and max bound numbers and the length of strings and urls
+type CustomField struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Required bool     `json:"required"`
+	Min      *float64 `json:"min,omitempty"`
+	Max      *float64 `json:"max,omitempty"`
+	Pattern  string   `json:"pattern,omitempty"`
+	Options  []string `json:"options,omitempty"`
+}
+
+func fromDomainContactSchema(s *domain.ContactSchema) *ContactSchema {
+	schema := &ContactSchema{
+		OwnerId:            s.OwnerId.String(),
+		Fields:             make([]CustomField, 0, len(s.Fields)),
+		DefaultPhoneRegion: s.DefaultPhoneRegion,
+	}
+	if !s.UpdatedAt.IsZero() {
+		schema.UpdatedAt = s.UpdatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	for _, f := range s.Fields {
+		schema.Fields = append(schema.Fields, CustomField{
+			Name:     f.Name,
+			Type:     string(f.Type),
+			Required: f.Required,
+			Min:      f.Min,
+			Max:      f.Max,
+			Pattern:  f.Pattern,
+			Options:  f.Options,
+		})
+	}
+
+	return schema
+}
+
+func toCmdCustomFields(fields []CustomField) []usecase.CustomField {
+	var result = make([]usecase.CustomField, 0, len(fields))
+	for _, f := range fields {
+		result = append(result, usecase.CustomField{
+			Name:     f.Name,
+			Type:     f.Type,
+			Required: f.Required,
+			Min:      f.Min,
+			Max:      f.Max,
+			Pattern:  f.Pattern,
+			Options:  f.Options,
+		})
+	}
+
+	return result
+}
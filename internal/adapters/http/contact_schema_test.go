@@ -0,0 +1,115 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/internal/usecase"
+	"github.com/davidterranova/contacts/pkg/user"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/steinfletcher/apitest"
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+)
+
+func TestSetContactSchema(t *testing.T) {
+	t.Parallel()
+
+	five := 5.0
+	owner := uuid.New()
+
+	cases := []struct {
+		name              string
+		returnedAppSchema *domain.ContactSchema
+		returnedAppErr    error
+		expectedStatus    int
+	}{
+		{
+			name: "ok",
+			returnedAppSchema: &domain.ContactSchema{
+				OwnerId: owner,
+				Fields: []domain.FieldDefinition{
+					{Name: "tier", Type: domain.FieldTypeEnum, Required: true, Options: []string{"gold", "silver"}},
+					{Name: "rating", Type: domain.FieldTypeNumber, Max: &five},
+				},
+				DefaultPhoneRegion: "FR",
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "bad request",
+			returnedAppErr: usecase.ErrInvalidCommand,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "internal server error",
+			returnedAppErr: usecase.ErrInternal,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			authorizedUser := user.New(owner, user.UserTypeAuthenticated)
+			container := testContainer(t)
+			container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+			container.app.EXPECT().
+				SetContactSchema(gomock.Any(), usecase.CmdSetContactSchema{
+					Owner: authorizedUser,
+					Fields: []usecase.CustomField{
+						{Name: "tier", Type: "enum", Required: true, Options: []string{"gold", "silver"}},
+						{Name: "rating", Type: "number", Max: &five},
+					},
+					DefaultPhoneRegion: "FR",
+				}).
+				Times(1).
+				Return(c.returnedAppSchema, c.returnedAppErr)
+
+			test := apitest.New().
+				Report(apitest.SequenceDiagram()).
+				Handler(container.handler).
+				Put("/v1/contact-schema").
+				JSON(`{"fields": [
+					{"name": "tier", "type": "enum", "required": true, "options": ["gold", "silver"]},
+					{"name": "rating", "type": "number", "max": 5}
+				], "default_phone_region": "FR"}`).
+				Expect(t).
+				Status(c.expectedStatus)
+			if c.returnedAppSchema != nil {
+				test = test.
+					Assert(jsonpath.Equal("$.owner_id", owner.String())).
+					Assert(jsonpath.Len("$.fields", 2)).
+					Assert(jsonpath.Equal("$.fields[1].max", 5.0)).
+					Assert(jsonpath.NotPresent("$.fields[1].min")).
+					Assert(jsonpath.Equal("$.default_phone_region", "FR"))
+			}
+			test.End()
+		})
+	}
+}
+
+func TestGetContactSchema(t *testing.T) {
+	t.Parallel()
+
+	authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+	container := testContainer(t)
+	container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+	container.app.EXPECT().
+		GetContactSchema(gomock.Any(), usecase.QueryGetContactSchema{Owner: authorizedUser}).
+		Times(1).
+		Return(&domain.ContactSchema{OwnerId: authorizedUser.Id()}, nil)
+
+	apitest.New().
+		Report(apitest.SequenceDiagram()).
+		Handler(container.handler).
+		Get("/v1/contact-schema").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(jsonpath.Len("$.fields", 0)).
+		Assert(jsonpath.NotPresent("$.updated_at")).
+		End()
+}
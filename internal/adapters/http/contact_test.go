@@ -1,20 +1,31 @@
 package http
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/davidterranova/contacts/internal/domain"
 	"github.com/davidterranova/contacts/internal/usecase"
 	"github.com/davidterranova/contacts/pkg/auth"
 	"github.com/davidterranova/contacts/pkg/user"
+	"github.com/davidterranova/contacts/pkg/vcard"
+	"github.com/davidterranova/contacts/pkg/xhttp"
 	gomock "github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/steinfletcher/apitest"
 	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type container struct {
@@ -33,9 +44,11 @@ func TestList(t *testing.T) {
 		container.app.EXPECT().
 			ListContacts(gomock.Any(), gomock.Any()).
 			Return(
-				[]*domain.Contact{
-					domain.New(uuid.New()),
-					domain.New(uuid.New()),
+				&usecase.ContactPage{
+					Contacts: []*domain.Contact{
+						domain.New(uuid.New()),
+						domain.New(uuid.New()),
+					},
 				},
 				nil,
 			)
@@ -46,7 +59,95 @@ func TestList(t *testing.T) {
 			Get("/v1/contacts").
 			Expect(t).
 			Status(http.StatusOK).
-			Assert(jsonpath.Len("$", 2)).
+			Assert(jsonpath.Len("$.contacts", 2)).
+			Assert(jsonpath.NotPresent("$.next_cursor")).
+			End()
+	})
+
+	t.Run("list contacts with filters", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(user.New(uuid.New(), user.UserTypeAuthenticated)))
+		container.app.EXPECT().
+			ListContacts(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, query usecase.QueryListContact) (*usecase.ContactPage, error) {
+				assert.Equal(t, "doe", query.Search)
+				assert.Equal(t, domain.FieldMatch{Value: "Jo", Mode: domain.MatchPrefix}, query.FirstName)
+				assert.Equal(t, domain.FieldMatch{Value: "jdoe@contact.local", Mode: domain.MatchExact}, query.Email)
+				assert.True(t, query.LastName.IsZero())
+				assert.Equal(t, map[string]domain.FieldMatch{
+					"tier":    {Value: "gold", Mode: domain.MatchExact},
+					"company": {Value: "Acme", Mode: domain.MatchPrefix},
+				}, query.CustomFields)
+				require.NotNil(t, query.CreatedAt.From)
+				assert.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), query.CreatedAt.From.UTC())
+				assert.Nil(t, query.CreatedAt.To)
+
+				return &usecase.ContactPage{Contacts: []*domain.Contact{}}, nil
+			})
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Get("/v1/contacts").
+			Query("q", "doe").
+			Query("first_name_prefix", "Jo").
+			Query("email", "jdoe@contact.local").
+			Query("custom_fields.tier", "gold").
+			Query("custom_fields.company_prefix", "Acme").
+			Query("created_after", "2023-01-01T00:00:00Z").
+			Expect(t).
+			Status(http.StatusOK).
+			Assert(jsonpath.Len("$.contacts", 0)).
+			End()
+	})
+
+	t.Run("list a page of sorted contacts", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(user.New(uuid.New(), user.UserTypeAuthenticated)))
+		container.app.EXPECT().
+			ListContacts(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, query usecase.QueryListContact) (*usecase.ContactPage, error) {
+				assert.Equal(t, domain.Sort{Field: domain.SortByLastName, Desc: true}, query.Sort)
+				assert.Equal(t, 10, query.Limit)
+				assert.Equal(t, "previous-cursor", query.Cursor)
+
+				return &usecase.ContactPage{
+					Contacts:   []*domain.Contact{domain.New(uuid.New())},
+					NextCursor: "next-cursor",
+				}, nil
+			})
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Get("/v1/contacts").
+			Query("sort", "-last_name").
+			Query("limit", "10").
+			Query("cursor", "previous-cursor").
+			Expect(t).
+			Status(http.StatusOK).
+			Assert(jsonpath.Len("$.contacts", 1)).
+			Assert(jsonpath.Equal("$.next_cursor", "next-cursor")).
+			End()
+	})
+
+	t.Run("invalid query parameters", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(user.New(uuid.New(), user.UserTypeAuthenticated)))
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Get("/v1/contacts").
+			Query("updated_before", "yesterday").
+			Expect(t).
+			Status(http.StatusBadRequest).
 			End()
 	})
 }
@@ -78,6 +179,22 @@ func TestCreate(t *testing.T) {
 			returnedAppErr:     usecase.ErrInvalidCommand,
 			expectedStatus:     http.StatusBadRequest,
 		},
+		{
+			name:               "address book forbidden",
+			requestBodyContent: json.RawMessage(`{"address_book_id": "` + uuid.NewString() + `", "first_name": "John", "last_name": "Doe", "email": "jdoe@contact.local", "phone": "+15555555555"}`),
+			authorizedUser:     user.New(uuid.New(), user.UserTypeAuthenticated),
+			returnedAppContact: nil,
+			returnedAppErr:     usecase.ErrForbidden,
+			expectedStatus:     http.StatusForbidden,
+		},
+		{
+			name:               "email already used",
+			requestBodyContent: json.RawMessage(`{"first_name": "John", "last_name": "Doe", "email": "jdoe@contact.local", "phone": "+15555555555"}`),
+			authorizedUser:     user.New(uuid.New(), user.UserTypeAuthenticated),
+			returnedAppContact: nil,
+			returnedAppErr:     usecase.ErrAlreadyExists,
+			expectedStatus:     http.StatusConflict,
+		},
 		{
 			name:               "internal server error",
 			requestBodyContent: json.RawMessage(`{"first_name": "John", "last_name": "Doe", "email": "jdoe@contact.local", "phone": "+15555555555"}`),
@@ -114,6 +231,169 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestCreateContactDetails(t *testing.T) {
+	t.Parallel()
+
+	authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+	contact := domain.New(authorizedUser.Id())
+	contact.SetEmails([]domain.EmailAddress{
+		{Label: domain.LabelWork, Address: "jdoe@work.local"},
+		{Label: domain.LabelHome, Address: "jdoe@home.local", Primary: true},
+	})
+	contact.SetPhone("+15555555555")
+	contact.SetAddresses([]domain.PostalAddress{{Label: domain.LabelHome, Street: "1 Main Street", Locality: "Springfield", Country: "US"}})
+
+	container := testContainer(t)
+	container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+	container.app.EXPECT().
+		CreateContact(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, cmd usecase.CmdCreateContact) (*domain.Contact, error) {
+			assert.Equal(t, []usecase.ContactEmail{
+				{Label: "work", Address: "jdoe@work.local"},
+				{Label: "home", Address: "jdoe@home.local", Primary: true},
+			}, cmd.Emails)
+			assert.Equal(t, []usecase.ContactAddress{{Label: "home", Street: "1 Main Street", Locality: "Springfield", Country: "US"}}, cmd.Addresses)
+			return contact, nil
+		})
+
+	apitest.New().
+		Report(apitest.SequenceDiagram()).
+		Handler(container.handler).
+		Post("/v1/contacts").
+		JSON(`{
+			"first_name": "John",
+			"last_name": "Doe",
+			"phone": "+15555555555",
+			"emails": [
+				{"label": "work", "address": "jdoe@work.local"},
+				{"label": "home", "address": "jdoe@home.local", "primary": true}
+			],
+			"addresses": [{"label": "home", "street": "1 Main Street", "locality": "Springfield", "country": "US"}]
+		}`).
+		Expect(t).
+		Status(http.StatusCreated).
+		Assert(jsonpath.Equal("$.email", "jdoe@home.local")).
+		Assert(jsonpath.Len("$.emails", 2)).
+		Assert(jsonpath.Equal("$.emails[1].primary", true)).
+		Assert(jsonpath.Equal("$.phones[0].number", "+15555555555")).
+		Assert(jsonpath.Equal("$.phones[0].label", "other")).
+		Assert(jsonpath.Equal("$.addresses[0].primary", true)).
+		End()
+}
+
+func TestCreateContactPhones(t *testing.T) {
+	t.Parallel()
+
+	authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+	contact := domain.New(authorizedUser.Id())
+	contact.SetEmail("jdoe@contact.local")
+	contact.SetPhone("+14155550100")
+	contact.Phones = append(contact.Phones, domain.PhoneNumber{Label: domain.LabelWork, Number: "+81312345678"})
+
+	container := testContainer(t)
+	container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+	container.app.EXPECT().
+		CreateContact(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, cmd usecase.CmdCreateContact) (*domain.Contact, error) {
+			assert.Equal(t, "(415) 555-0100", cmd.Phone)
+			assert.Equal(t, "US", cmd.PhoneRegion)
+			return contact, nil
+		})
+
+	apitest.New().
+		Report(apitest.SequenceDiagram()).
+		Handler(container.handler).
+		Post("/v1/contacts").
+		JSON(`{
+			"first_name": "John",
+			"last_name": "Doe",
+			"email": "jdoe@contact.local",
+			"phone": "(415) 555-0100",
+			"phone_region": "US"
+		}`).
+		Expect(t).
+		Status(http.StatusCreated).
+		Assert(jsonpath.Equal("$.phone", "+14155550100")).
+		Assert(jsonpath.Equal("$.phones[0].national", "(415) 555-0100")).
+		Assert(jsonpath.Equal("$.phones[0].international", "+1 415-555-0100")).
+		Assert(jsonpath.Equal("$.phones[0].type", "fixed_or_mobile")).
+		Assert(jsonpath.Equal("$.phones[1].international", "+81 312345678")).
+		Assert(jsonpath.NotPresent("$.phones[1].national")).
+		Assert(jsonpath.NotPresent("$.phones[1].type")).
+		End()
+}
+
+func TestCreateContactEmailStatus(t *testing.T) {
+	t.Parallel()
+
+	authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+	contact := domain.New(authorizedUser.Id())
+	contact.SetEmail("jdoe@contact.local")
+	contact.SetPhone("+15555555555")
+	contact.EmailStatus = domain.EmailStatusUndeliverable
+
+	container := testContainer(t)
+	container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+	container.app.EXPECT().
+		CreateContact(gomock.Any(), gomock.Any()).
+		Return(contact, nil)
+
+	apitest.New().
+		Report(apitest.SequenceDiagram()).
+		Handler(container.handler).
+		Post("/v1/contacts").
+		JSON(`{
+			"first_name": "John",
+			"last_name": "Doe",
+			"email": "JDoe@Contact.local",
+			"phone": "+15555555555"
+		}`).
+		Expect(t).
+		Status(http.StatusCreated).
+		Assert(jsonpath.Equal("$.email", "jdoe@contact.local")).
+		Assert(jsonpath.Equal("$.email_status", "undeliverable")).
+		End()
+}
+
+func TestCreateContactCustomFields(t *testing.T) {
+	t.Parallel()
+
+	authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+	contact := domain.New(authorizedUser.Id())
+	contact.SetEmail("jdoe@contact.local")
+	contact.SetPhone("+15555555555")
+	contact.CustomFields = map[string]domain.CustomValue{
+		"tier":   {Type: domain.FieldTypeEnum, Text: "gold"},
+		"rating": {Type: domain.FieldTypeNumber, Number: 4.5},
+	}
+
+	container := testContainer(t)
+	container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+	container.app.EXPECT().
+		CreateContact(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, cmd usecase.CmdCreateContact) (*domain.Contact, error) {
+			assert.Equal(t, map[string]any{"tier": "gold", "rating": 4.5}, cmd.CustomFields)
+			return contact, nil
+		})
+
+	apitest.New().
+		Report(apitest.SequenceDiagram()).
+		Handler(container.handler).
+		Post("/v1/contacts").
+		JSON(`{
+			"first_name": "John",
+			"last_name": "Doe",
+			"email": "jdoe@contact.local",
+			"phone": "+15555555555",
+			"custom_fields": {"tier": "gold", "rating": 4.5}
+		}`).
+		Expect(t).
+		Status(http.StatusCreated).
+		Assert(jsonpath.Equal("$.custom_fields.tier", "gold")).
+		Assert(jsonpath.Equal("$.custom_fields.rating", 4.5)).
+		End()
+}
+
 func TestUpdateContact(t *testing.T) {
 	t.Parallel()
 
@@ -207,6 +487,12 @@ func TestDeleteContact(t *testing.T) {
 			returnedAppErr: usecase.ErrNotFound,
 			expectedStatus: http.StatusNotFound,
 		},
+		{
+			name:           "forbidden",
+			authorizedUser: user.New(uuid.New(), user.UserTypeAuthenticated),
+			returnedAppErr: usecase.ErrForbidden,
+			expectedStatus: http.StatusForbidden,
+		},
 		{
 			name:           "internal server error",
 			authorizedUser: user.New(uuid.New(), user.UserTypeAuthenticated),
@@ -240,22 +526,1302 @@ func TestDeleteContact(t *testing.T) {
 	}
 }
 
-func testContainer(t *testing.T) *container {
-	t.Helper()
+func TestContactPreconditions(t *testing.T) {
+	t.Parallel()
 
-	controller := gomock.NewController(t)
-	app := NewMockApp(controller)
+	authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+	updatedContact := domain.New(authorizedUser.Id())
+	updatedContact.Version = 4
 
-	return &container{
-		app:     app,
-		handler: New(app, nil),
+	t.Run("update at the expected version", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+		container.app.EXPECT().
+			UpdateContact(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, cmd usecase.CmdUpdateContact) (*domain.Contact, error) {
+				assert.Equal(t, 3, cmd.Version)
+				return updatedContact, nil
+			})
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Putf("/v1/contacts/%s", updatedContact.Id).
+			Header("If-Match", `"3"`).
+			JSON(`{"first_name": "John"}`).
+			Expect(t).
+			Status(http.StatusOK).
+			Header("ETag", `"4"`).
+			Assert(jsonpath.Equal("$.version", float64(4))).
+			End()
+	})
+
+	t.Run("update at a stale version", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+		container.app.EXPECT().
+			UpdateContact(gomock.Any(), gomock.Any()).
+			Times(1).
+			Return(nil, usecase.ErrConflict)
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Putf("/v1/contacts/%s", uuid.NewString()).
+			Header("If-Match", `"3"`).
+			JSON(`{"first_name": "John"}`).
+			Expect(t).
+			Status(http.StatusPreconditionFailed).
+			End()
+	})
+
+	t.Run("delete at a stale version", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+		container.app.EXPECT().
+			DeleteContact(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, cmd usecase.CmdDeleteContact) error {
+				assert.Equal(t, 2, cmd.Version)
+				return usecase.ErrConflict
+			})
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Deletef("/v1/contacts/%s", uuid.NewString()).
+			Header("If-Match", `"2"`).
+			Expect(t).
+			Status(http.StatusPreconditionFailed).
+			End()
+	})
+
+	t.Run("invalid If-Match header", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Deletef("/v1/contacts/%s", uuid.NewString()).
+			Header("If-Match", `W/"2"`).
+			Expect(t).
+			Status(http.StatusBadRequest).
+			End()
+	})
+}
+
+func TestListTrash(t *testing.T) {
+	t.Parallel()
+
+	trashed := domain.New(uuid.New())
+	trashed.Trash()
+
+	container := testContainer(t)
+	container.handler.Use(appendUserToContextMiddleware(user.New(uuid.New(), user.UserTypeAuthenticated)))
+	container.app.EXPECT().
+		ListContacts(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, query usecase.QueryListContact) (*usecase.ContactPage, error) {
+			assert.True(t, query.Trash)
+			assert.Equal(t, "doe", query.Search)
+
+			return &usecase.ContactPage{Contacts: []*domain.Contact{trashed}}, nil
+		})
+
+	apitest.New().
+		Report(apitest.SequenceDiagram()).
+		Handler(container.handler).
+		Get("/v1/contacts/trash").
+		Query("q", "doe").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(jsonpath.Len("$.contacts", 1)).
+		Assert(jsonpath.Present("$.contacts[0].deleted_at")).
+		End()
+}
+
+func TestRestoreContact(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name               string
+		returnedAppContact *domain.Contact
+		returnedAppErr     error
+		expectedStatus     int
+	}{
+		{
+			name:               "ok",
+			returnedAppContact: domain.New(uuid.New()),
+			expectedStatus:     http.StatusOK,
+		},
+		{
+			name:           "not in the trash",
+			returnedAppErr: usecase.ErrInvalidCommand,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "not found",
+			returnedAppErr: usecase.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "forbidden",
+			returnedAppErr: usecase.ErrForbidden,
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+			contactId := uuid.NewString()
+			container := testContainer(t)
+			container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+			container.app.EXPECT().
+				RestoreContact(gomock.Any(), usecase.CmdRestoreContact{Restorer: authorizedUser, ContactId: contactId}).
+				Times(1).
+				Return(c.returnedAppContact, c.returnedAppErr)
+
+			apitest.New().
+				Report(apitest.SequenceDiagram()).
+				Handler(container.handler).
+				Postf("/v1/contacts/%s/restore", contactId).
+				Expect(t).
+				Status(c.expectedStatus).
+				End()
+		})
 	}
 }
 
-func appendUserToContextMiddleware(u user.User) func(handler http.Handler) http.Handler {
-	return func(handler http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), auth.RequestCtxUserKey, u)))
+func TestContactHistory(t *testing.T) {
+	t.Parallel()
+
+	authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+	contact := domain.New(authorizedUser.Id())
+	contact.FirstName = "John"
+	entry := domain.NewAuditEntry(authorizedUser, domain.AuditActionCreate, domain.Contact{}, *contact)
+
+	cases := []struct {
+		name           string
+		returnedAppErr error
+		expectedStatus int
+	}{
+		{
+			name:           "ok",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "not found",
+			returnedAppErr: usecase.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "forbidden",
+			returnedAppErr: usecase.ErrForbidden,
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			container := testContainer(t)
+			container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+			container.app.EXPECT().
+				ContactHistory(gomock.Any(), usecase.QueryContactHistory{Requester: authorizedUser, ContactId: contact.Id.String()}).
+				Times(1).
+				Return([]*domain.AuditEntry{entry}, c.returnedAppErr)
+
+			test := apitest.New().
+				Report(apitest.SequenceDiagram()).
+				Handler(container.handler).
+				Getf("/v1/contacts/%s/history", contact.Id).
+				Expect(t).
+				Status(c.expectedStatus)
+			if c.returnedAppErr == nil {
+				test = test.
+					Assert(jsonpath.Len("$", 1)).
+					Assert(jsonpath.Equal("$[0].action", "create")).
+					Assert(jsonpath.Equal("$[0].actor.id", authorizedUser.Id().String())).
+					Assert(jsonpath.Equal("$[0].changes[0].field", "first_name")).
+					Assert(jsonpath.Equal("$[0].changes[0].after", "John"))
+			}
+			test.End()
+		})
+	}
+}
+
+func TestGetContact(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name               string
+		authorizedUser     user.User
+		returnedAppContact *domain.Contact
+		returnedAppErr     error
+		expectedStatus     int
+	}{
+		{
+			name:               "ok",
+			authorizedUser:     user.New(uuid.New(), user.UserTypeAuthenticated),
+			returnedAppContact: domain.New(uuid.New()),
+			expectedStatus:     http.StatusOK,
+		},
+		{
+			name:           "bad request",
+			authorizedUser: user.New(uuid.New(), user.UserTypeAuthenticated),
+			returnedAppErr: usecase.ErrInvalidCommand,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "not found",
+			authorizedUser: user.New(uuid.New(), user.UserTypeAuthenticated),
+			returnedAppErr: usecase.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "forbidden",
+			authorizedUser: user.New(uuid.New(), user.UserTypeAuthenticated),
+			returnedAppErr: usecase.ErrForbidden,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "internal server error",
+			authorizedUser: user.New(uuid.New(), user.UserTypeAuthenticated),
+			returnedAppErr: usecase.ErrInternal,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			contactId := uuid.NewString()
+			container := testContainer(t)
+			container.handler.Use(appendUserToContextMiddleware(c.authorizedUser))
+			container.app.EXPECT().
+				GetContact(gomock.Any(), usecase.QueryGetContact{Requester: c.authorizedUser, ContactId: contactId}).
+				Times(1).
+				Return(c.returnedAppContact, c.returnedAppErr)
+
+			apitest.New().
+				Report(apitest.SequenceDiagram()).
+				Handler(container.handler).
+				Getf("/v1/contacts/%s", contactId).
+				Expect(t).
+				Status(c.expectedStatus).
+				End()
+		})
+	}
+}
+
+func TestShareContact(t *testing.T) {
+	t.Parallel()
+
+	sharedContact := domain.New(uuid.New())
+	sharedContact.Share(uuid.New(), domain.PermissionRead)
+
+	cases := []struct {
+		name               string
+		returnedAppContact *domain.Contact
+		returnedAppErr     error
+		expectedStatus     int
+	}{
+		{
+			name:               "ok",
+			returnedAppContact: sharedContact,
+			expectedStatus:     http.StatusOK,
+		},
+		{
+			name:           "bad request",
+			returnedAppErr: usecase.ErrInvalidCommand,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "not found",
+			returnedAppErr: usecase.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "forbidden",
+			returnedAppErr: usecase.ErrForbidden,
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+			contactId, userId := uuid.NewString(), uuid.NewString()
+			container := testContainer(t)
+			container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+			container.app.EXPECT().
+				ShareContact(gomock.Any(), usecase.CmdShareContact{
+					Sharer:     authorizedUser,
+					ContactId:  contactId,
+					UserId:     userId,
+					Permission: "read",
+				}).
+				Times(1).
+				Return(c.returnedAppContact, c.returnedAppErr)
+
+			test := apitest.New().
+				Report(apitest.SequenceDiagram()).
+				Handler(container.handler).
+				Postf("/v1/contacts/%s/shares", contactId).
+				JSON(fmt.Sprintf(`{"user_id": %q, "permission": "read"}`, userId)).
+				Expect(t).
+				Status(c.expectedStatus)
+			if c.returnedAppContact != nil {
+				test = test.Assert(jsonpath.Equal("$.shares[0].permission", "read"))
+			}
+			test.End()
+		})
+	}
+}
+
+func TestUnshareContact(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name               string
+		returnedAppContact *domain.Contact
+		returnedAppErr     error
+		expectedStatus     int
+	}{
+		{
+			name:               "ok",
+			returnedAppContact: domain.New(uuid.New()),
+			expectedStatus:     http.StatusOK,
+		},
+		{
+			name:           "share not found",
+			returnedAppErr: usecase.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "forbidden",
+			returnedAppErr: usecase.ErrForbidden,
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+			contactId, userId := uuid.NewString(), uuid.NewString()
+			container := testContainer(t)
+			container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+			container.app.EXPECT().
+				UnshareContact(gomock.Any(), usecase.CmdUnshareContact{
+					Sharer:    authorizedUser,
+					ContactId: contactId,
+					UserId:    userId,
+				}).
+				Times(1).
+				Return(c.returnedAppContact, c.returnedAppErr)
+
+			apitest.New().
+				Report(apitest.SequenceDiagram()).
+				Handler(container.handler).
+				Deletef("/v1/contacts/%s/shares/%s", contactId, userId).
+				Expect(t).
+				Status(c.expectedStatus).
+				End()
+		})
+	}
+}
+
+func TestEvents(t *testing.T) {
+	t.Parallel()
+
+	watcher := user.New(uuid.New(), user.UserTypeAuthenticated)
+
+	t.Run("stream the contact changes", func(t *testing.T) {
+		t.Parallel()
+
+		change := usecase.ContactChange{
+			EventId:    uuid.New(),
+			Event:      domain.EventContactCreated,
+			OccurredAt: time.Now(),
+			Contact:    domain.New(watcher.Id()),
+		}
+		changes := make(chan usecase.ContactChange, 1)
+		changes <- change
+		close(changes)
+
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(watcher))
+		container.app.EXPECT().
+			WatchContacts(gomock.Any(), usecase.QueryWatchContacts{Watcher: watcher}).
+			Return(changes, nil)
+
+		rec := httptest.NewRecorder()
+		container.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/contacts/events", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+		data, err := json.Marshal(fromDomainChange(change))
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("id: %s\nevent: contact.created\ndata: %s\n\n", change.EventId, data), rec.Body.String())
+	})
+
+	t.Run("resume after the last event id", func(t *testing.T) {
+		t.Parallel()
+
+		lastEventId := uuid.NewString()
+		changes := make(chan usecase.ContactChange)
+		close(changes)
+
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(watcher))
+		container.app.EXPECT().
+			WatchContacts(gomock.Any(), usecase.QueryWatchContacts{Watcher: watcher, After: lastEventId}).
+			Return(changes, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/contacts/events", nil)
+		req.Header.Set("Last-Event-ID", lastEventId)
+		rec := httptest.NewRecorder()
+		container.handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("reset when the missed changes are no longer available", func(t *testing.T) {
+		t.Parallel()
+
+		lastEventId := uuid.NewString()
+		changes := make(chan usecase.ContactChange)
+		close(changes)
+
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(watcher))
+		gomock.InOrder(
+			container.app.EXPECT().
+				WatchContacts(gomock.Any(), usecase.QueryWatchContacts{Watcher: watcher, After: lastEventId}).
+				Return(nil, usecase.ErrNotFound),
+			container.app.EXPECT().
+				WatchContacts(gomock.Any(), usecase.QueryWatchContacts{Watcher: watcher}).
+				Return(changes, nil),
+		)
+
+		rec := httptest.NewRecorder()
+		container.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/contacts/events?last_event_id="+lastEventId, nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "event: reset\ndata: {}\n\n", rec.Body.String())
+	})
+
+	t.Run("invalid last event id", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(watcher))
+		container.app.EXPECT().
+			WatchContacts(gomock.Any(), gomock.Any()).
+			Return(nil, usecase.ErrInvalidCommand)
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Get("/v1/contacts/events").
+			Header("Last-Event-ID", "invalid").
+			Expect(t).
+			Status(http.StatusBadRequest).
+			End()
+	})
+
+	t.Run("heartbeats outlive the server write timeout", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.app.EXPECT().
+			WatchContacts(gomock.Any(), gomock.Any()).
+			Return(make(chan usecase.ContactChange), nil)
+
+		server := httptest.NewUnstartedServer(appendUserToContextMiddleware(watcher)(http.HandlerFunc(NewContactHandler(container.app).Events)))
+		server.Config.WriteTimeout = xhttp.DefaultWriteTimeout
+		server.Config.ReadTimeout = xhttp.DefaultReadTimeout
+		server.Start()
+		defer server.Close()
+		require.Greater(t, xhttp.DefaultHeartbeatInterval, xhttp.DefaultWriteTimeout)
+
+		start := time.Now()
+		resp, err := http.Get(server.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Less(t, time.Since(start), xhttp.DefaultWriteTimeout, "the headers are flushed before the first event")
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		line, err := bufio.NewReader(resp.Body).ReadString('\n')
+		require.NoError(t, err)
+		assert.Equal(t, ": heartbeat\n", line)
+		assert.GreaterOrEqual(t, time.Since(start), xhttp.DefaultHeartbeatInterval)
+	})
+}
+
+func TestVCard(t *testing.T) {
+	t.Parallel()
+
+	owner := user.New(uuid.New(), user.UserTypeAuthenticated)
+	contact := domain.New(owner.Id())
+	contact.FirstName = "John"
+	contact.LastName = "Doe, Jr"
+	contact.Email = "jdoe@contact.local"
+	contact.Phone = "+33612345678"
+
+	t.Run("render a contact as a vCard 4.0", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(owner))
+		container.app.EXPECT().
+			GetContact(gomock.Any(), usecase.QueryGetContact{Requester: owner, ContactId: contact.Id.String()}).
+			Return(contact, nil)
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Get(fmt.Sprintf("/v1/contacts/%s.vcf", contact.Id)).
+			Expect(t).
+			Status(http.StatusOK).
+			Header("Content-Type", "text/vcard; charset=utf-8").
+			Body(strings.Join([]string{
+				"BEGIN:VCARD",
+				"VERSION:4.0",
+				"FN:John Doe\\, Jr",
+				"N:Doe\\, Jr;John;;;",
+				"EMAIL:jdoe@contact.local",
+				"TEL;VALUE=uri:tel:+33612345678",
+				"UID:urn:uuid:" + contact.Id.String(),
+				"REV:" + contact.UpdatedAt.Format("20060102T150405Z"),
+				"END:VCARD",
+				"",
+			}, "\r\n")).
+			End()
+	})
+
+	t.Run("render a contact as a vCard 3.0", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(owner))
+		container.app.EXPECT().
+			GetContact(gomock.Any(), gomock.Any()).
+			Return(contact, nil)
+
+		rec := httptest.NewRecorder()
+		container.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v1/contacts/%s.vcf?version=3.0", contact.Id), nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "VERSION:3.0\r\n")
+		assert.Contains(t, rec.Body.String(), "TEL:+33612345678\r\n")
+		assert.Contains(t, rec.Body.String(), "UID:"+contact.Id.String()+"\r\n")
+	})
+
+	t.Run("invalid version", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(owner))
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Get(fmt.Sprintf("/v1/contacts/%s.vcf", contact.Id)).
+			Query("version", "2.1").
+			Expect(t).
+			Status(http.StatusBadRequest).
+			End()
+	})
+
+	t.Run("contact not found", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(owner))
+		container.app.EXPECT().
+			GetContact(gomock.Any(), gomock.Any()).
+			Return(nil, usecase.ErrNotFound)
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Get(fmt.Sprintf("/v1/contacts/%s.vcf", uuid.New())).
+			Expect(t).
+			Status(http.StatusNotFound).
+			End()
+	})
+}
+
+func TestExport(t *testing.T) {
+	t.Parallel()
+
+	owner := user.New(uuid.New(), user.UserTypeAuthenticated)
+
+	t.Run("export all the pages of contacts", func(t *testing.T) {
+		t.Parallel()
+
+		first, second := domain.New(owner.Id()), domain.New(owner.Id())
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(owner))
+		gomock.InOrder(
+			container.app.EXPECT().
+				ListContacts(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, query usecase.QueryListContact) (*usecase.ContactPage, error) {
+					assert.Equal(t, exportPageSize, query.Limit)
+					assert.Equal(t, "doe", query.Search)
+					assert.Empty(t, query.Cursor)
+					return &usecase.ContactPage{Contacts: []*domain.Contact{first}, NextCursor: "next"}, nil
+				}),
+			container.app.EXPECT().
+				ListContacts(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, query usecase.QueryListContact) (*usecase.ContactPage, error) {
+					assert.Equal(t, "next", query.Cursor)
+					return &usecase.ContactPage{Contacts: []*domain.Contact{second}}, nil
+				}),
+		)
+
+		rec := httptest.NewRecorder()
+		container.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/contacts/export.vcf?q=doe", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `attachment; filename="contacts.vcf"`, rec.Header().Get("Content-Disposition"))
+		assert.Equal(t, 2, strings.Count(rec.Body.String(), "BEGIN:VCARD"))
+		assert.Contains(t, rec.Body.String(), first.Id.String())
+		assert.Contains(t, rec.Body.String(), second.Id.String())
+	})
+
+	t.Run("export the contacts as csv", func(t *testing.T) {
+		t.Parallel()
+
+		contact := domain.New(owner.Id())
+		contact.FirstName = "John"
+		contact.LastName = "Doe, Jr"
+		contact.Email = "jdoe@contact.local"
+		contact.Phone = "+33612345678"
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(owner))
+		container.app.EXPECT().
+			ListContacts(gomock.Any(), gomock.Any()).
+			Return(&usecase.ContactPage{Contacts: []*domain.Contact{contact}}, nil)
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Get("/v1/contacts/export.csv").
+			Expect(t).
+			Status(http.StatusOK).
+			Header("Content-Type", "text/csv; charset=utf-8").
+			Body(fmt.Sprintf(
+				"id,address_book_id,created_at,updated_at,first_name,last_name,email,phone,email_1,email_1_label,phone_1,phone_1_label\n%s,,%s,%s,John,\"Doe, Jr\",jdoe@contact.local,'+33612345678,jdoe@contact.local,other,'+33612345678,other\n",
+				contact.Id,
+				contact.CreatedAt.Format(time.RFC3339),
+				contact.UpdatedAt.Format(time.RFC3339),
+			)).
+			End()
+	})
+
+	t.Run("escape the cells read as formulas", func(t *testing.T) {
+		t.Parallel()
+
+		contact := domain.New(owner.Id())
+		contact.FirstName = "=HYPERLINK(\"http://attacker.local\")"
+		contact.LastName = "@SUM(1)"
+		contact.Email = "-jdoe@contact.local"
+		contact.Phone = "+33612345678"
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(owner))
+		container.app.EXPECT().
+			ListContacts(gomock.Any(), gomock.Any()).
+			Return(&usecase.ContactPage{Contacts: []*domain.Contact{contact}}, nil)
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Get("/v1/contacts/export.csv").
+			Expect(t).
+			Status(http.StatusOK).
+			Body(fmt.Sprintf(
+				"id,address_book_id,created_at,updated_at,first_name,last_name,email,phone,email_1,email_1_label,phone_1,phone_1_label\n%s,,%s,%s,\"'=HYPERLINK(\"\"http://attacker.local\"\")\",'@SUM(1),'-jdoe@contact.local,'+33612345678,'-jdoe@contact.local,other,'+33612345678,other\n",
+				contact.Id,
+				contact.CreatedAt.Format(time.RFC3339),
+				contact.UpdatedAt.Format(time.RFC3339),
+			)).
+			End()
+	})
+
+	t.Run("address book access forbidden", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(owner))
+		container.app.EXPECT().
+			ListContacts(gomock.Any(), gomock.Any()).
+			Return(nil, usecase.ErrForbidden)
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Get("/v1/contacts/export.vcf").
+			Query("address_book_id", uuid.NewString()).
+			Expect(t).
+			Status(http.StatusForbidden).
+			End()
+	})
+}
+
+func TestImport(t *testing.T) {
+	t.Parallel()
+
+	importer := user.New(uuid.New(), user.UserTypeAuthenticated)
+	cards := strings.Join([]string{
+		"BEGIN:VCARD",
+		"VERSION:3.0",
+		"N:Doe;John;;;",
+		"FN:John Doe",
+		"item1.EMAIL;TYPE=INTERNET:jdoe@contact.local",
+		"TEL;TYPE=CELL:+33 6 12 34 56 78",
+		"END:VCARD",
+		"BEGIN:VCARD",
+		"VERSION:4.0",
+		"FN:Jane Mary",
+		"  Doe",
+		"EMAIL:jane@contact.local",
+		"TEL;VALUE=uri:tel:+33612345679",
+		"END:VCARD",
+		"",
+	}, "\r\n")
+	expectedContacts := []usecase.ImportedContact{
+		{
+			FirstName: "John",
+			LastName:  "Doe",
+			Email:     "jdoe@contact.local",
+			Phone:     "+33612345678",
+			Emails:    []usecase.ContactEmail{{Address: "jdoe@contact.local", Primary: true}},
+			Phones:    []usecase.ContactPhone{{Label: "mobile", Number: "+33612345678", Primary: true}},
+		},
+		{
+			FirstName: "Jane Mary",
+			LastName:  "Doe",
+			Email:     "jane@contact.local",
+			Phone:     "+33612345679",
+			Emails:    []usecase.ContactEmail{{Address: "jane@contact.local", Primary: true}},
+			Phones:    []usecase.ContactPhone{{Number: "+33612345679", Primary: true}},
+		},
+	}
+
+	t.Run("import the cards and report their status", func(t *testing.T) {
+		t.Parallel()
+
+		created := domain.New(importer.Id())
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(importer))
+		container.app.EXPECT().
+			ImportContacts(gomock.Any(), usecase.CmdImportContacts{Importer: importer, Contacts: expectedContacts}).
+			Return([]usecase.ImportResult{
+				{Status: usecase.ImportStatusCreated, Contact: created},
+				{Status: usecase.ImportStatusSkipped, Reason: "duplicate"},
+			}, nil)
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Post("/v1/contacts/import").
+			Header("Content-Type", "text/vcard").
+			Body(cards).
+			Expect(t).
+			Status(http.StatusOK).
+			Assert(jsonpath.Equal("$.created", float64(1))).
+			Assert(jsonpath.Equal("$.skipped", float64(1))).
+			Assert(jsonpath.Equal("$.failed", float64(0))).
+			Assert(jsonpath.Equal("$.results[0].card", float64(1))).
+			Assert(jsonpath.Equal("$.results[0].contact.id", created.Id.String())).
+			Assert(jsonpath.Equal("$.results[1].status", "skipped")).
+			Assert(jsonpath.Equal("$.results[1].reason", "duplicate")).
+			End()
+	})
+
+	t.Run("import an uploaded file in an address book", func(t *testing.T) {
+		t.Parallel()
+
+		addressBookId := uuid.NewString()
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(importer))
+		container.app.EXPECT().
+			ImportContacts(gomock.Any(), usecase.CmdImportContacts{Importer: importer, AddressBookId: addressBookId, Contacts: expectedContacts}).
+			Return([]usecase.ImportResult{
+				{Status: usecase.ImportStatusFailed, Reason: "invalid"},
+				{Status: usecase.ImportStatusFailed, Reason: "invalid"},
+			}, nil)
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Post("/v1/contacts/import").
+			Query("address_book_id", addressBookId).
+			MultipartFile("file", writeTempFile(t, "contacts.vcf", cards)).
+			Expect(t).
+			Status(http.StatusOK).
+			Assert(jsonpath.Equal("$.failed", float64(2))).
+			End()
+	})
+
+	t.Run("import a csv file with a column mapping", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(importer))
+		container.app.EXPECT().
+			ImportContacts(gomock.Any(), usecase.CmdImportContacts{
+				Importer: importer,
+				DryRun:   true,
+				Contacts: []usecase.ImportedContact{
+					{FirstName: "John", LastName: "Doe", Email: "jdoe@contact.local", Phone: "+33612345678"},
+					{FirstName: "Jane", Email: "jane@contact.local"},
+				},
+			}).
+			Return([]usecase.ImportResult{
+				{Status: usecase.ImportStatusValid},
+				{Status: usecase.ImportStatusFailed, Reason: "invalid last name"},
+			}, nil)
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Post("/v1/contacts/import").
+			Query("format", "csv").
+			Query("dry_run", "true").
+			Query("mapping", `{"Given Name":"first_name","Family Name":"last_name","E-mail 1":"email","Mobile":"phone"}`).
+			Body("Given Name,Family Name,E-mail 1,Mobile,Notes\nJohn,Doe,jdoe@contact.local,+33 6 12 34 56 78,friend\nJane,,jane@contact.local\n").
+			Expect(t).
+			Status(http.StatusOK).
+			Assert(jsonpath.Equal("$.valid", float64(1))).
+			Assert(jsonpath.Equal("$.failed", float64(1))).
+			Assert(jsonpath.Equal("$.results[0].row", float64(2))).
+			Assert(jsonpath.NotPresent("$.results[0].card")).
+			Assert(jsonpath.Equal("$.results[1].row", float64(3))).
+			Assert(jsonpath.Equal("$.results[1].reason", "invalid last name")).
+			End()
+	})
+
+	t.Run("import a csv file with the default mapping", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(importer))
+		container.app.EXPECT().
+			ImportContacts(gomock.Any(), usecase.CmdImportContacts{
+				Importer: importer,
+				Contacts: []usecase.ImportedContact{
+					{FirstName: "John", LastName: "Doe", Email: "jdoe@contact.local", Phone: "+33612345678"},
+				},
+			}).
+			Return([]usecase.ImportResult{{Status: usecase.ImportStatusCreated, Contact: domain.New(importer.Id())}}, nil)
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Post("/v1/contacts/import").
+			Query("format", "csv").
+			Body("\ufeffid,First Name,last-name,Email,Phone\n1,John,Doe,jdoe@contact.local,'+33612345678\n").
+			Expect(t).
+			Status(http.StatusOK).
+			Assert(jsonpath.Equal("$.created", float64(1))).
+			End()
+	})
+
+	t.Run("invalid csv mapping", func(t *testing.T) {
+		t.Parallel()
+
+		for _, mapping := range []string{
+			`{"Given Name":"nickname"}`,
+			`{"Missing":"first_name"}`,
+			`{"Given Name":"first_name","Family Name":"first_name"}`,
+			`not json`,
+		} {
+			container := testContainer(t)
+			container.handler.Use(appendUserToContextMiddleware(importer))
+
+			apitest.New().
+				Report(apitest.SequenceDiagram()).
+				Handler(container.handler).
+				Post("/v1/contacts/import").
+				Query("format", "csv").
+				Query("mapping", mapping).
+				Body("Given Name,Family Name\nJohn,Doe\n").
+				Expect(t).
+				Status(http.StatusBadRequest).
+				End()
+		}
+	})
+
+	t.Run("report the malformed cards as failed", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(importer))
+		container.app.EXPECT().
+			ImportContacts(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, cmd usecase.CmdImportContacts) ([]usecase.ImportResult, error) {
+				require.Len(t, cmd.Contacts, 2)
+				assert.ErrorIs(t, cmd.Contacts[0].Err, vcard.ErrUnsupportedVersion)
+				assert.Equal(t, usecase.ImportedContact{FirstName: "Jane", LastName: "Doe"}, cmd.Contacts[1])
+
+				return []usecase.ImportResult{
+					{Status: usecase.ImportStatusFailed, Reason: cmd.Contacts[0].Err.Error()},
+					{Status: usecase.ImportStatusCreated, Contact: domain.New(importer.Id())},
+				}, nil
+			})
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Post("/v1/contacts/import").
+			Body("BEGIN:VCARD\r\nVERSION:2.1\r\nFN:John Doe\r\nEND:VCARD\r\nBEGIN:VCARD\r\nVERSION:4.0\r\nFN:Jane Doe\r\nEND:VCARD\r\n").
+			Expect(t).
+			Status(http.StatusOK).
+			Assert(jsonpath.Equal("$.created", float64(1))).
+			Assert(jsonpath.Equal("$.failed", float64(1))).
+			Assert(jsonpath.Equal("$.results[0].card", float64(1))).
+			Assert(jsonpath.Equal("$.results[0].reason", `line 4: unsupported vcard version: "2.1"`)).
+			End()
+	})
+
+	t.Run("address book access forbidden", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(importer))
+		container.app.EXPECT().
+			ImportContacts(gomock.Any(), gomock.Any()).
+			Return(nil, usecase.ErrForbidden)
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Post("/v1/contacts/import").
+			Body(cards).
+			Expect(t).
+			Status(http.StatusForbidden).
+			End()
+	})
+}
+
+func writeTempFile(t *testing.T, name string, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func testContainer(t *testing.T) *container {
+	t.Helper()
+
+	controller := gomock.NewController(t)
+	app := NewMockApp(controller)
+
+	return &container{
+		app:     app,
+		handler: New(app, nil),
+	}
+}
+
+func appendUserToContextMiddleware(u user.User) func(handler http.Handler) http.Handler {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), auth.RequestCtxUserKey, u)))
+		})
+	}
+}
+
+func TestExportImportLabeledValues(t *testing.T) {
+	t.Parallel()
+
+	owner := user.New(uuid.New(), user.UserTypeAuthenticated)
+	contact := domain.New(owner.Id())
+	contact.FirstName = "John"
+	contact.LastName = "Doe"
+	contact.SetEmails([]domain.EmailAddress{
+		{Label: domain.LabelHome, Address: "jdoe@contact.local", Primary: true},
+		{Label: domain.LabelWork, Address: "john.doe@work.local"},
+	})
+	contact.SetPhones([]domain.PhoneNumber{
+		{Label: domain.LabelMobile, Number: "+33612345678", Primary: true},
+		{Label: domain.LabelWork, Number: "+33123456789"},
+	})
+	contact.SetAddresses([]domain.PostalAddress{
+		{Label: domain.LabelHome, Street: "1 rue de la Paix", Locality: "Paris", PostalCode: "75002", Country: "FR", Primary: true},
+		{Label: domain.LabelWork, Street: "2 Main Street", Locality: "London", Region: "Greater London", PostalCode: "EC1A 1BB", Country: "GB"},
+	})
+	expectedContacts := []usecase.ImportedContact{{
+		FirstName: "John",
+		LastName:  "Doe",
+		Email:     "jdoe@contact.local",
+		Phone:     "+33612345678",
+		Emails: []usecase.ContactEmail{
+			{Label: "home", Address: "jdoe@contact.local", Primary: true},
+			{Label: "work", Address: "john.doe@work.local"},
+		},
+		Phones: []usecase.ContactPhone{
+			{Label: "mobile", Number: "+33612345678", Primary: true},
+			{Label: "work", Number: "+33123456789"},
+		},
+		Addresses: []usecase.ContactAddress{
+			{Label: "home", Street: "1 rue de la Paix", Locality: "Paris", PostalCode: "75002", Country: "FR", Primary: true},
+			{Label: "work", Street: "2 Main Street", Locality: "London", Region: "Greater London", PostalCode: "EC1A 1BB", Country: "GB"},
+		},
+	}}
+
+	for _, export := range []struct {
+		path   string
+		format string
+	}{
+		{path: "/v1/contacts/export.vcf?version=3.0", format: "vcard"},
+		{path: "/v1/contacts/export.vcf?version=4.0", format: "vcard"},
+		{path: "/v1/contacts/export.csv", format: "csv"},
+	} {
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(owner))
+		container.app.EXPECT().
+			ListContacts(gomock.Any(), gomock.Any()).
+			Return(&usecase.ContactPage{Contacts: []*domain.Contact{contact}}, nil)
+		container.app.EXPECT().
+			ImportContacts(gomock.Any(), usecase.CmdImportContacts{Importer: owner, Contacts: expectedContacts}).
+			Return([]usecase.ImportResult{{Status: usecase.ImportStatusCreated, Contact: contact}}, nil)
+
+		rec := httptest.NewRecorder()
+		container.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, export.path, nil))
+		assert.Equal(t, http.StatusOK, rec.Code, export.path)
+
+		exported := rec.Body
+		rec = httptest.NewRecorder()
+		container.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/contacts/import?format="+export.format, exported))
+		assert.Equal(t, http.StatusOK, rec.Code, export.path)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	t.Parallel()
+
+	requester := user.New(uuid.New(), user.UserTypeAuthenticated)
+	contactId := uuid.NewString()
+	body := `{
+		"atomic": true,
+		"create": [{"first_name": "John", "last_name": "Doe", "email": "jdoe@contact.local", "phone": "+33612345678"}],
+		"update": [{"id": "` + contactId + `", "version": 2, "first_name": "Jane"}],
+		"delete": [{"id": "` + contactId + `"}]
+	}`
+	expectedCmd := usecase.CmdBatchContacts{
+		Requester: requester,
+		Atomic:    true,
+		Create:    []usecase.CmdCreateContact{{FirstName: "John", LastName: "Doe", Email: "jdoe@contact.local", Phone: "+33612345678"}},
+		Update:    []usecase.CmdUpdateContact{{ContactId: contactId, FirstName: "Jane", Version: 2}},
+		Delete:    []usecase.CmdDeleteContact{{ContactId: contactId}},
+	}
+
+	t.Run("reports the applied commands", func(t *testing.T) {
+		t.Parallel()
+
+		created := domain.New(requester.Id())
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(requester))
+		container.app.EXPECT().
+			BatchContacts(gomock.Any(), expectedCmd).
+			Return(usecase.BatchResults{
+				Create: []usecase.BatchResult{{Status: usecase.BatchStatusApplied, Contact: created}},
+				Update: []usecase.BatchResult{{Status: usecase.BatchStatusApplied, Contact: created}},
+				Delete: []usecase.BatchResult{{Status: usecase.BatchStatusApplied, Contact: created}},
+			}, nil)
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Post("/v1/contacts:batch").
+			Body(body).
+			Expect(t).
+			Status(http.StatusOK).
+			Assert(jsonpath.Equal("$.applied", float64(3))).
+			Assert(jsonpath.Equal("$.create[0].status", "applied")).
+			Assert(jsonpath.Equal("$.create[0].contact.id", created.Id.String())).
+			End()
+	})
+
+	t.Run("atomic batch not applied", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(requester))
+		container.app.EXPECT().
+			BatchContacts(gomock.Any(), expectedCmd).
+			Return(usecase.BatchResults{
+				Create: []usecase.BatchResult{{Status: usecase.BatchStatusAborted}},
+				Update: []usecase.BatchResult{{Status: usecase.BatchStatusFailed, Err: usecase.ErrConflict}},
+				Delete: []usecase.BatchResult{{Status: usecase.BatchStatusAborted}},
+			}, nil)
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Post("/v1/contacts:batch").
+			Body(body).
+			Expect(t).
+			Status(http.StatusUnprocessableEntity).
+			Assert(jsonpath.Equal("$.failed", float64(1))).
+			Assert(jsonpath.Equal("$.aborted", float64(2))).
+			Assert(jsonpath.Equal("$.update[0].error", "conflict")).
+			End()
+	})
+
+	t.Run("invalid batch", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(requester))
+		container.app.EXPECT().
+			BatchContacts(gomock.Any(), usecase.CmdBatchContacts{
+				Requester: requester,
+				Create:    []usecase.CmdCreateContact{},
+				Update:    []usecase.CmdUpdateContact{},
+				Delete:    []usecase.CmdDeleteContact{},
+			}).
+			Return(usecase.BatchResults{}, usecase.ErrInvalidCommand)
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Post("/v1/contacts:batch").
+			Body(`{}`).
+			Expect(t).
+			Status(http.StatusBadRequest).
+			End()
+	})
+}
+
+func TestDuplicates(t *testing.T) {
+	t.Parallel()
+
+	requester := user.New(uuid.New(), user.UserTypeAuthenticated)
+
+	t.Run("report the duplicates", func(t *testing.T) {
+		t.Parallel()
+
+		john := domain.New(requester.Id())
+		johnny := domain.New(requester.Id())
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(requester))
+		container.app.EXPECT().
+			FindDuplicates(gomock.Any(), usecase.QueryFindDuplicates{Requester: requester, NameSimilarity: 0.9}).
+			Return([]usecase.Duplicate{{
+				Contacts:       [2]*domain.Contact{john, johnny},
+				Reasons:        []domain.DuplicateReason{domain.DuplicateEmail, domain.DuplicateName},
+				NameSimilarity: 0.95,
+			}}, nil)
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Get("/v1/contacts/duplicates").
+			Query("name_similarity", "0.9").
+			Expect(t).
+			Status(http.StatusOK).
+			Assert(jsonpath.Equal("$.duplicates[0].contacts[0].id", john.Id.String())).
+			Assert(jsonpath.Equal("$.duplicates[0].contacts[1].id", johnny.Id.String())).
+			Assert(jsonpath.Equal("$.duplicates[0].reasons", []interface{}{"email", "name"})).
+			Assert(jsonpath.Equal("$.duplicates[0].name_similarity", 0.95)).
+			End()
+	})
+
+	t.Run("invalid name similarity", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(requester))
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Get("/v1/contacts/duplicates").
+			Query("name_similarity", "high").
+			Expect(t).
+			Status(http.StatusBadRequest).
+			End()
+	})
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	requester := user.New(uuid.New(), user.UserTypeAuthenticated)
+	survivor := domain.New(requester.Id())
+	duplicateId := uuid.NewString()
+	body := `{"duplicate_id": "` + duplicateId + `", "rules": {"email": "duplicate"}}`
+	expectedCmd := usecase.CmdMergeContacts{
+		Merger:      requester,
+		SurvivorId:  survivor.Id.String(),
+		DuplicateId: duplicateId,
+		Rules:       map[string]usecase.MergeRule{"email": usecase.MergeTakeDuplicate},
+		Version:     1,
+	}
+
+	testCases := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{"merge the duplicate", nil, http.StatusOK},
+		{"contact not found", usecase.ErrNotFound, http.StatusNotFound},
+		{"contact access forbidden", usecase.ErrForbidden, http.StatusForbidden},
+		{"survivor was modified", usecase.ErrConflict, http.StatusPreconditionFailed},
+	}
+
+	for _, c := range testCases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			container := testContainer(t)
+			container.handler.Use(appendUserToContextMiddleware(requester))
+			if c.err != nil {
+				container.app.EXPECT().MergeContacts(gomock.Any(), expectedCmd).Return(nil, c.err)
+			} else {
+				container.app.EXPECT().MergeContacts(gomock.Any(), expectedCmd).Return(survivor, nil)
+			}
+
+			apitest.New().
+				Report(apitest.SequenceDiagram()).
+				Handler(container.handler).
+				Post("/v1/contacts/"+survivor.Id.String()+"/merge").
+				Header("If-Match", `"1"`).
+				Body(body).
+				Expect(t).
+				Status(c.expectedStatus).
+				End()
 		})
 	}
 }
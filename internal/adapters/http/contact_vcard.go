@@ -0,0 +1,190 @@
+package http
+
+import (
+	"strings"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/internal/usecase"
+	"github.com/davidterranova/contacts/pkg/vcard"
+)
+
+// phoneSeparators are the characters used to make phone numbers readable, they are dropped on import
+var phoneSeparators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+
+func toVCard(c *domain.Contact, version string) vcard.Card {
+	card := vcard.Card{
+		Version: version,
+		Properties: []vcard.Property{
+			vcard.NewText("FN", strings.TrimSpace(c.FirstName+" "+c.LastName)),
+			vcard.NewStructured("N", c.LastName, c.FirstName, "", "", ""),
+		},
+	}
+	emails := c.EmailAddresses()
+	for _, email := range emails {
+		card.Properties = append(card.Properties, withLabel(vcard.NewText("EMAIL", email.Address), version, email.Label, email.Primary && len(emails) > 1))
+	}
+	phones := c.PhoneNumbers()
+	for _, phone := range phones {
+		tel := vcard.NewText("TEL", phone.Number)
+		if version == vcard.Version4 {
+			tel = vcard.NewText("TEL", "tel:"+phone.Number).WithParam("VALUE", "uri")
+		}
+		card.Properties = append(card.Properties, withLabel(tel, version, phone.Label, phone.Primary && len(phones) > 1))
+	}
+	for _, address := range c.Addresses {
+		adr := vcard.NewStructured("ADR", "", "", address.Street, address.Locality, address.Region, address.PostalCode, address.Country)
+		card.Properties = append(card.Properties, withLabel(adr, version, address.Label, address.Primary && len(c.Addresses) > 1))
+	}
+
+	uid := c.Id.String()
+	if version == vcard.Version4 {
+		uid = "urn:uuid:" + uid
+	}
+	card.Properties = append(
+		card.Properties,
+		vcard.NewText("UID", uid),
+		vcard.NewText("REV", c.UpdatedAt.UTC().Format("20060102T150405Z")),
+	)
+
+	return card
+}
+
+// fromVCard reads the contact fields of a card, the names are taken from FN when N is missing
+func fromVCard(card vcard.Card) usecase.ImportedContact {
+	var contact usecase.ImportedContact
+
+	if n, ok := card.Get("N"); ok {
+		components := n.Components()
+		contact.LastName = strings.TrimSpace(components[0])
+		if len(components) > 1 {
+			contact.FirstName = strings.TrimSpace(components[1])
+		}
+	}
+	if fn, ok := card.Get("FN"); ok && contact.FirstName == "" && contact.LastName == "" {
+		names := strings.Fields(fn.Text())
+		if len(names) > 0 {
+			contact.FirstName = strings.Join(names[:len(names)-1], " ")
+			contact.LastName = names[len(names)-1]
+		}
+	}
+
+	emails := valued(card.All("EMAIL"))
+	primary := preferredIndex(emails)
+	for i, email := range emails {
+		contact.Emails = append(contact.Emails, usecase.ContactEmail{Label: vcardLabel(email), Address: strings.TrimSpace(email.Text()), Primary: i == primary})
+	}
+	if primary >= 0 {
+		contact.Email = contact.Emails[primary].Address
+	}
+
+	tels := valued(card.All("TEL"))
+	primary = preferredIndex(tels)
+	for i, tel := range tels {
+		number := phoneSeparators.Replace(strings.TrimPrefix(strings.TrimSpace(tel.Text()), "tel:"))
+		contact.Phones = append(contact.Phones, usecase.ContactPhone{Label: vcardLabel(tel), Number: number, Primary: i == primary})
+	}
+	if primary >= 0 {
+		contact.Phone = contact.Phones[primary].Number
+	}
+
+	addresses := valued(card.All("ADR"))
+	primary = preferredIndex(addresses)
+	for i, adr := range addresses {
+		// post office box, extended address, street, locality, region, postal code and country, trailing ones may be missing
+		components := append(adr.Components(), make([]string, 7)...)
+		contact.Addresses = append(contact.Addresses, usecase.ContactAddress{
+			Label:      vcardLabel(adr),
+			Street:     strings.TrimSpace(components[2]),
+			Locality:   strings.TrimSpace(components[3]),
+			Region:     strings.TrimSpace(components[4]),
+			PostalCode: strings.TrimSpace(components[5]),
+			Country:    countryCode(components[6]),
+			Primary:    i == primary,
+		})
+	}
+
+	return contact
+}
+
+// vcardTypes are the vCard types of the labels, other values having no type
+var vcardTypes = map[domain.Label]string{
+	domain.LabelWork:   "work",
+	domain.LabelHome:   "home",
+	domain.LabelMobile: "cell",
+}
+
+// withLabel sets the type of the property to the label, preferred telling the primary value apart from the others
+func withLabel(p vcard.Property, version string, label domain.Label, preferred bool) vcard.Property {
+	var types []string
+	if t, ok := vcardTypes[label]; ok {
+		types = append(types, t)
+	}
+	if preferred && version == vcard.Version4 {
+		p = p.WithParam("PREF", "1")
+	} else if preferred {
+		types = append(types, "pref")
+	}
+	if len(types) > 0 {
+		p = p.WithParam("TYPE", types...)
+	}
+
+	return p
+}
+
+// vcardLabel returns the label of the first vCard type of the property having one, empty meaning other
+func vcardLabel(p vcard.Property) string {
+	for _, t := range p.Params["TYPE"] {
+		switch strings.ToLower(t) {
+		case "work":
+			return string(domain.LabelWork)
+		case "home":
+			return string(domain.LabelHome)
+		case "cell", "mobile":
+			return string(domain.LabelMobile)
+		}
+	}
+
+	return ""
+}
+
+// isPreferred tells whether the property is the preferred one of its kind, in vCard 3.0 or 4.0
+func isPreferred(p vcard.Property) bool {
+	return p.HasParam("TYPE", "pref") || p.HasParam("PREF", "1")
+}
+
+// countryCode returns the ISO 3166-1 alpha-2 code of the country, countries written in full are left out
+func countryCode(country string) string {
+	country = strings.TrimSpace(country)
+	if len(country) != 2 {
+		return ""
+	}
+
+	return strings.ToUpper(country)
+}
+
+// valued returns the properties having a value, structured values having at least one component
+func valued(properties []vcard.Property) []vcard.Property {
+	var result []vcard.Property
+	for _, p := range properties {
+		if strings.Trim(p.Text(), " ;") != "" {
+			result = append(result, p)
+		}
+	}
+
+	return result
+}
+
+// preferredIndex returns the index of the first preferred property, the first property when none is, -1 when there
+// is none
+func preferredIndex(properties []vcard.Property) int {
+	for i, p := range properties {
+		if isPreferred(p) {
+			return i
+		}
+	}
+	if len(properties) == 0 {
+		return -1
+	}
+
+	return 0
+}
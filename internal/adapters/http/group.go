@@ -0,0 +1,326 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/davidterranova/contacts/internal/usecase"
+	"github.com/davidterranova/contacts/pkg/auth"
+	"github.com/davidterranova/contacts/pkg/xhttp"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+)
+
+type GroupHandler struct {
+	app App
+	// contacts renders the group exports like the contact ones
+	contacts *ContactHandler
+}
+
+func NewGroupHandler(app App) *GroupHandler {
+	return &GroupHandler{
+		app:      app,
+		contacts: NewContactHandler(app),
+	}
+}
+
+func (h *GroupHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:list failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	groups, err := h.app.ListGroups(ctx, usecase.QueryListGroups{Owner: user})
+	if err != nil {
+		writeGroupError(ctx, w, err, "groups:list failed to list groups")
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, fromDomainGroupList(groups))
+}
+
+func (h *GroupHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:get failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	group, err := h.app.GetGroup(ctx, usecase.QueryGetGroup{
+		Requester: user,
+		GroupId:   mux.Vars(r)[pathGroupId],
+	})
+	if err != nil {
+		writeGroupError(ctx, w, err, "groups:get failed to get group")
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, fromDomainGroup(group))
+}
+
+type groupRequest struct {
+	Name string `json:"name"`
+}
+
+func (h *GroupHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req groupRequest
+	ctx := r.Context()
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:create failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:create failed to decode request")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "failed to decode request", err)
+		return
+	}
+
+	group, err := h.app.CreateGroup(ctx, usecase.CmdCreateGroup{
+		Creator: user,
+		Name:    req.Name,
+	})
+	if err != nil {
+		writeGroupError(ctx, w, err, "groups:create failed to create group")
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusCreated, fromDomainGroup(group))
+}
+
+func (h *GroupHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req groupRequest
+	ctx := r.Context()
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:update failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:update failed to decode request")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "failed to decode request", err)
+		return
+	}
+
+	group, err := h.app.UpdateGroup(ctx, usecase.CmdUpdateGroup{
+		Updater: user,
+		GroupId: mux.Vars(r)[pathGroupId],
+		Name:    req.Name,
+	})
+	if err != nil {
+		writeGroupError(ctx, w, err, "groups:update failed to update group")
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, fromDomainGroup(group))
+}
+
+// Delete deletes the group, its contacts are kept
+func (h *GroupHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:delete failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	err = h.app.DeleteGroup(ctx, usecase.CmdDeleteGroup{
+		Deleter: user,
+		GroupId: mux.Vars(r)[pathGroupId],
+	})
+	if err != nil {
+		writeGroupError(ctx, w, err, "groups:delete failed to delete group")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *GroupHandler) AddContact(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:add_contact failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	contact, err := h.app.AddGroupContact(ctx, usecase.CmdAddGroupContact{
+		Requester: user,
+		GroupId:   vars[pathGroupId],
+		ContactId: vars[pathContactId],
+	})
+	if err != nil {
+		writeGroupError(ctx, w, err, "groups:add_contact failed to add contact to group")
+		return
+	}
+
+	writeContact(ctx, w, http.StatusOK, contact)
+}
+
+func (h *GroupHandler) RemoveContact(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:remove_contact failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	contact, err := h.app.RemoveGroupContact(ctx, usecase.CmdRemoveGroupContact{
+		Requester: user,
+		GroupId:   vars[pathGroupId],
+		ContactId: vars[pathContactId],
+	})
+	if err != nil {
+		writeGroupError(ctx, w, err, "groups:remove_contact failed to remove contact from group")
+		return
+	}
+
+	writeContact(ctx, w, http.StatusOK, contact)
+}
+
+// DeleteContacts moves the contacts of the group to the trash, the atomic query parameter deletes all of them or
+// none. It answers 422 when an atomic deletion is not applied, like a batch.
+func (h *GroupHandler) DeleteContacts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:delete_contacts failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	var atomic bool
+	if value := r.URL.Query().Get("atomic"); value != "" {
+		atomic, err = strconv.ParseBool(value)
+		if err != nil {
+			xhttp.WriteError(ctx, w, http.StatusBadRequest, "invalid query parameters", err)
+			return
+		}
+	}
+
+	results, err := h.app.DeleteGroupContacts(ctx, usecase.CmdDeleteGroupContacts{
+		Deleter: user,
+		GroupId: mux.Vars(r)[pathGroupId],
+		Atomic:  atomic,
+	})
+	if err != nil {
+		writeGroupError(ctx, w, err, "groups:delete_contacts failed to delete group contacts")
+		return
+	}
+
+	status := http.StatusOK
+	if atomic && !results.Applied() {
+		status = http.StatusUnprocessableEntity
+	}
+	xhttp.WriteObject(ctx, w, status, fromDomainBatchResults(results))
+}
+
+// ShareContacts shares every contact of the group with a user, the contacts the requester did not create are
+// reported as failed
+func (h *GroupHandler) ShareContacts(w http.ResponseWriter, r *http.Request) {
+	var req shareContactRequest
+	ctx := r.Context()
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:share_contacts failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:share_contacts failed to decode request")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "failed to decode request", err)
+		return
+	}
+
+	results, err := h.app.ShareGroupContacts(ctx, usecase.CmdShareGroupContacts{
+		Sharer:     user,
+		GroupId:    mux.Vars(r)[pathGroupId],
+		UserId:     req.UserId,
+		Permission: req.Permission,
+	})
+	if err != nil {
+		writeGroupError(ctx, w, err, "groups:share_contacts failed to share group contacts")
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, fromDomainShareResults(results))
+}
+
+// ExportVCard renders the contacts of the group as vCards, it accepts the query parameters of the contacts export
+func (h *GroupHandler) ExportVCard(w http.ResponseWriter, r *http.Request) {
+	r, ok := h.groupExport(w, r)
+	if ok {
+		h.contacts.ExportVCard(w, r)
+	}
+}
+
+// ExportCSV renders the contacts of the group as a csv file, it accepts the query parameters of the contacts export
+func (h *GroupHandler) ExportCSV(w http.ResponseWriter, r *http.Request) {
+	r, ok := h.groupExport(w, r)
+	if ok {
+		h.contacts.ExportCSV(w, r)
+	}
+}
+
+// groupExport checks that the requester created the group and returns the request restricted to its contacts.
+// It writes the error response when the group cannot be read.
+func (h *GroupHandler) groupExport(w http.ResponseWriter, r *http.Request) (*http.Request, bool) {
+	ctx := r.Context()
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("groups:export failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return nil, false
+	}
+
+	group, err := h.app.GetGroup(ctx, usecase.QueryGetGroup{
+		Requester: user,
+		GroupId:   mux.Vars(r)[pathGroupId],
+	})
+	if err != nil {
+		writeGroupError(ctx, w, err, "groups:export failed to get group")
+		return nil, false
+	}
+
+	export := r.Clone(ctx)
+	values := export.URL.Query()
+	values.Set("group_id", group.Id.String())
+	export.URL.RawQuery = values.Encode()
+
+	return export, true
+}
+
+func writeGroupError(ctx context.Context, w http.ResponseWriter, err error, msg string) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidCommand):
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "group validation failed", err)
+	case errors.Is(err, usecase.ErrNotFound):
+		xhttp.WriteError(ctx, w, http.StatusNotFound, "group or contact not found", err)
+	case errors.Is(err, usecase.ErrForbidden):
+		xhttp.WriteError(ctx, w, http.StatusForbidden, "group access forbidden", err)
+	default:
+		log.Ctx(ctx).Warn().Err(err).Msg(msg)
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to handle group", err)
+	}
+}
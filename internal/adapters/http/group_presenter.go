@@ -0,0 +1,51 @@
+package http
+
+import (
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/internal/usecase"
+)
+
+type Group struct {
+	Id        string `json:"id"`
+	CreatedBy string `json:"created_by"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+	Name      string `json:"name"`
+}
+
+// ShareReport counts the contacts of a group by share status, the results are in the order of the contacts
+type ShareReport struct {
+	Applied int           `json:"applied"`
+	Failed  int           `json:"failed"`
+	Results []BatchResult `json:"results"`
+}
+
+func fromDomainGroup(g *domain.Group) *Group {
+	return &Group{
+		Id:        g.Id.String(),
+		CreatedBy: g.CreatedBy.String(),
+		CreatedAt: g.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt: g.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		Name:      g.Name,
+	}
+}
+
+func fromDomainGroupList(groups []*domain.Group) []*Group {
+	var list = make([]*Group, 0, len(groups))
+	for _, g := range groups {
+		list = append(list, fromDomainGroup(g))
+	}
+
+	return list
+}
+
+func fromDomainShareResults(results []usecase.BatchResult) *ShareReport {
+	report := &BatchReport{}
+	shared := report.toBatchResults(results)
+
+	return &ShareReport{
+		Applied: report.Applied,
+		Failed:  report.Failed,
+		Results: shared,
+	}
+}
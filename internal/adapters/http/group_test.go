@@ -0,0 +1,305 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/internal/usecase"
+	"github.com/davidterranova/contacts/pkg/user"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/steinfletcher/apitest"
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListGroups(t *testing.T) {
+	t.Parallel()
+
+	authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+	container := testContainer(t)
+	container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+	container.app.EXPECT().
+		ListGroups(gomock.Any(), usecase.QueryListGroups{Owner: authorizedUser}).
+		Times(1).
+		Return([]*domain.Group{domain.NewGroup(authorizedUser.Id(), "friends")}, nil)
+
+	apitest.New().
+		Report(apitest.SequenceDiagram()).
+		Handler(container.handler).
+		Get("/v1/groups").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(jsonpath.Len("$", 1)).
+		Assert(jsonpath.Equal("$[0].name", "friends")).
+		Assert(jsonpath.Equal("$[0].created_by", authorizedUser.Id().String())).
+		End()
+}
+
+func TestCreateGroup(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name             string
+		returnedAppGroup *domain.Group
+		returnedAppErr   error
+		expectedStatus   int
+	}{
+		{
+			name:             "ok",
+			returnedAppGroup: domain.NewGroup(uuid.New(), "friends"),
+			expectedStatus:   http.StatusCreated,
+		},
+		{
+			name:           "bad request",
+			returnedAppErr: usecase.ErrInvalidCommand,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "internal server error",
+			returnedAppErr: usecase.ErrInternal,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+			container := testContainer(t)
+			container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+			container.app.EXPECT().
+				CreateGroup(gomock.Any(), usecase.CmdCreateGroup{Creator: authorizedUser, Name: "friends"}).
+				Times(1).
+				Return(c.returnedAppGroup, c.returnedAppErr)
+
+			apitest.New().
+				Report(apitest.SequenceDiagram()).
+				Handler(container.handler).
+				Post("/v1/groups").
+				JSON(`{"name": "friends"}`).
+				Expect(t).
+				Status(c.expectedStatus).
+				End()
+		})
+	}
+}
+
+func TestDeleteGroup(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name           string
+		returnedAppErr error
+		expectedStatus int
+	}{
+		{
+			name:           "ok",
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "not found",
+			returnedAppErr: usecase.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "forbidden",
+			returnedAppErr: usecase.ErrForbidden,
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+			groupId := uuid.NewString()
+			container := testContainer(t)
+			container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+			container.app.EXPECT().
+				DeleteGroup(gomock.Any(), usecase.CmdDeleteGroup{Deleter: authorizedUser, GroupId: groupId}).
+				Times(1).
+				Return(c.returnedAppErr)
+
+			apitest.New().
+				Report(apitest.SequenceDiagram()).
+				Handler(container.handler).
+				Deletef("/v1/groups/%s", groupId).
+				Expect(t).
+				Status(c.expectedStatus).
+				End()
+		})
+	}
+}
+
+func TestAddGroupContact(t *testing.T) {
+	t.Parallel()
+
+	authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+	group := domain.NewGroup(authorizedUser.Id(), "friends")
+	contact := domain.New(authorizedUser.Id())
+	contact.AddToGroup(group.Id)
+	container := testContainer(t)
+	container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+	container.app.EXPECT().
+		AddGroupContact(gomock.Any(), usecase.CmdAddGroupContact{
+			Requester: authorizedUser,
+			GroupId:   group.Id.String(),
+			ContactId: contact.Id.String(),
+		}).
+		Times(1).
+		Return(contact, nil)
+
+	apitest.New().
+		Report(apitest.SequenceDiagram()).
+		Handler(container.handler).
+		Putf("/v1/groups/%s/contacts/%s", group.Id, contact.Id).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(jsonpath.Equal("$.group_ids[0]", group.Id.String())).
+		End()
+}
+
+func TestDeleteGroupContacts(t *testing.T) {
+	t.Parallel()
+
+	authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+	groupId := uuid.NewString()
+
+	t.Run("atomic deletion not applied", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+		container.app.EXPECT().
+			DeleteGroupContacts(gomock.Any(), usecase.CmdDeleteGroupContacts{Deleter: authorizedUser, GroupId: groupId, Atomic: true}).
+			Times(1).
+			Return(usecase.BatchResults{Delete: []usecase.BatchResult{
+				{Status: usecase.BatchStatusAborted},
+				{Status: usecase.BatchStatusFailed, Err: usecase.ErrForbidden},
+			}}, nil)
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Deletef("/v1/groups/%s/contacts", groupId).
+			Query("atomic", "true").
+			Expect(t).
+			Status(http.StatusUnprocessableEntity).
+			Assert(jsonpath.Equal("$.applied", float64(0))).
+			Assert(jsonpath.Equal("$.aborted", float64(1))).
+			Assert(jsonpath.Equal("$.delete[1].status", "failed")).
+			End()
+	})
+
+	t.Run("invalid atomic parameter", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Deletef("/v1/groups/%s/contacts", groupId).
+			Query("atomic", "maybe").
+			Expect(t).
+			Status(http.StatusBadRequest).
+			End()
+	})
+}
+
+func TestShareGroupContacts(t *testing.T) {
+	t.Parallel()
+
+	authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+	groupId := uuid.NewString()
+	userId := uuid.NewString()
+	container := testContainer(t)
+	container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+	container.app.EXPECT().
+		ShareGroupContacts(gomock.Any(), usecase.CmdShareGroupContacts{
+			Sharer:     authorizedUser,
+			GroupId:    groupId,
+			UserId:     userId,
+			Permission: "read",
+		}).
+		Times(1).
+		Return([]usecase.BatchResult{
+			{Status: usecase.BatchStatusApplied, Contact: domain.New(authorizedUser.Id())},
+			{Status: usecase.BatchStatusFailed, Err: usecase.ErrForbidden},
+		}, nil)
+
+	apitest.New().
+		Report(apitest.SequenceDiagram()).
+		Handler(container.handler).
+		Postf("/v1/groups/%s/shares", groupId).
+		JSON(fmt.Sprintf(`{"user_id": "%s", "permission": "read"}`, userId)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(jsonpath.Equal("$.applied", float64(1))).
+		Assert(jsonpath.Equal("$.failed", float64(1))).
+		Assert(jsonpath.Len("$.results", 2)).
+		End()
+}
+
+func TestExportGroup(t *testing.T) {
+	t.Parallel()
+
+	owner := user.New(uuid.New(), user.UserTypeAuthenticated)
+	group := domain.NewGroup(owner.Id(), "friends")
+
+	t.Run("export the contacts of the group", func(t *testing.T) {
+		t.Parallel()
+
+		contact := domain.New(owner.Id())
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(owner))
+		container.app.EXPECT().
+			GetGroup(gomock.Any(), usecase.QueryGetGroup{Requester: owner, GroupId: group.Id.String()}).
+			Times(1).
+			Return(group, nil)
+		container.app.EXPECT().
+			ListContacts(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, query usecase.QueryListContact) (*usecase.ContactPage, error) {
+				assert.Equal(t, group.Id.String(), query.GroupId)
+				assert.Equal(t, "doe", query.Search)
+				return &usecase.ContactPage{Contacts: []*domain.Contact{contact}}, nil
+			})
+
+		rec := httptest.NewRecorder()
+		container.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/groups/"+group.Id.String()+"/export.vcf?q=doe", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, 1, strings.Count(rec.Body.String(), "BEGIN:VCARD"))
+		assert.Contains(t, rec.Body.String(), contact.Id.String())
+	})
+
+	t.Run("group of another user", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.handler.Use(appendUserToContextMiddleware(owner))
+		container.app.EXPECT().
+			GetGroup(gomock.Any(), gomock.Any()).
+			Times(1).
+			Return(nil, usecase.ErrForbidden)
+
+		apitest.New().
+			Report(apitest.SequenceDiagram()).
+			Handler(container.handler).
+			Getf("/v1/groups/%s/export.csv", group.Id).
+			Expect(t).
+			Status(http.StatusForbidden).
+			End()
+	})
+}
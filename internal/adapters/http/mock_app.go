@@ -36,6 +36,81 @@ func (m *MockApp) EXPECT() *MockAppMockRecorder {
 	return m.recorder
 }
 
+// AddGroupContact mocks base method.
+func (m *MockApp) AddGroupContact(arg0 context.Context, arg1 usecase.CmdAddGroupContact) (*domain.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddGroupContact", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddGroupContact indicates an expected call of AddGroupContact.
+func (mr *MockAppMockRecorder) AddGroupContact(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGroupContact", reflect.TypeOf((*MockApp)(nil).AddGroupContact), arg0, arg1)
+}
+
+// BatchContacts mocks base method.
+func (m *MockApp) BatchContacts(arg0 context.Context, arg1 usecase.CmdBatchContacts) (usecase.BatchResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchContacts", arg0, arg1)
+	ret0, _ := ret[0].(usecase.BatchResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchContacts indicates an expected call of BatchContacts.
+func (mr *MockAppMockRecorder) BatchContacts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchContacts", reflect.TypeOf((*MockApp)(nil).BatchContacts), arg0, arg1)
+}
+
+// ContactHistory mocks base method.
+func (m *MockApp) ContactHistory(arg0 context.Context, arg1 usecase.QueryContactHistory) ([]*domain.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContactHistory", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ContactHistory indicates an expected call of ContactHistory.
+func (mr *MockAppMockRecorder) ContactHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContactHistory", reflect.TypeOf((*MockApp)(nil).ContactHistory), arg0, arg1)
+}
+
+// ContactRelationships mocks base method.
+func (m *MockApp) ContactRelationships(arg0 context.Context, arg1 usecase.QueryContactRelationships) (*usecase.ContactRelationships, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContactRelationships", arg0, arg1)
+	ret0, _ := ret[0].(*usecase.ContactRelationships)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ContactRelationships indicates an expected call of ContactRelationships.
+func (mr *MockAppMockRecorder) ContactRelationships(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContactRelationships", reflect.TypeOf((*MockApp)(nil).ContactRelationships), arg0, arg1)
+}
+
+// CreateAddressBook mocks base method.
+func (m *MockApp) CreateAddressBook(arg0 context.Context, arg1 usecase.CmdCreateAddressBook) (*domain.AddressBook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAddressBook", arg0, arg1)
+	ret0, _ := ret[0].(*domain.AddressBook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAddressBook indicates an expected call of CreateAddressBook.
+func (mr *MockAppMockRecorder) CreateAddressBook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAddressBook", reflect.TypeOf((*MockApp)(nil).CreateAddressBook), arg0, arg1)
+}
+
 // CreateContact mocks base method.
 func (m *MockApp) CreateContact(arg0 context.Context, arg1 usecase.CmdCreateContact) (*domain.Contact, error) {
 	m.ctrl.T.Helper()
@@ -51,6 +126,50 @@ func (mr *MockAppMockRecorder) CreateContact(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateContact", reflect.TypeOf((*MockApp)(nil).CreateContact), arg0, arg1)
 }
 
+// CreateGroup mocks base method.
+func (m *MockApp) CreateGroup(arg0 context.Context, arg1 usecase.CmdCreateGroup) (*domain.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroup", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGroup indicates an expected call of CreateGroup.
+func (mr *MockAppMockRecorder) CreateGroup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*MockApp)(nil).CreateGroup), arg0, arg1)
+}
+
+// CreateWebhook mocks base method.
+func (m *MockApp) CreateWebhook(arg0 context.Context, arg1 usecase.CmdCreateWebhook) (*domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockAppMockRecorder) CreateWebhook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockApp)(nil).CreateWebhook), arg0, arg1)
+}
+
+// DeleteAddressBook mocks base method.
+func (m *MockApp) DeleteAddressBook(arg0 context.Context, arg1 usecase.CmdDeleteAddressBook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAddressBook", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAddressBook indicates an expected call of DeleteAddressBook.
+func (mr *MockAppMockRecorder) DeleteAddressBook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAddressBook", reflect.TypeOf((*MockApp)(nil).DeleteAddressBook), arg0, arg1)
+}
+
 // DeleteContact mocks base method.
 func (m *MockApp) DeleteContact(arg0 context.Context, arg1 usecase.CmdDeleteContact) error {
 	m.ctrl.T.Helper()
@@ -65,11 +184,174 @@ func (mr *MockAppMockRecorder) DeleteContact(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContact", reflect.TypeOf((*MockApp)(nil).DeleteContact), arg0, arg1)
 }
 
+// DeleteGroup mocks base method.
+func (m *MockApp) DeleteGroup(arg0 context.Context, arg1 usecase.CmdDeleteGroup) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGroup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGroup indicates an expected call of DeleteGroup.
+func (mr *MockAppMockRecorder) DeleteGroup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockApp)(nil).DeleteGroup), arg0, arg1)
+}
+
+// DeleteGroupContacts mocks base method.
+func (m *MockApp) DeleteGroupContacts(arg0 context.Context, arg1 usecase.CmdDeleteGroupContacts) (usecase.BatchResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGroupContacts", arg0, arg1)
+	ret0, _ := ret[0].(usecase.BatchResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteGroupContacts indicates an expected call of DeleteGroupContacts.
+func (mr *MockAppMockRecorder) DeleteGroupContacts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroupContacts", reflect.TypeOf((*MockApp)(nil).DeleteGroupContacts), arg0, arg1)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockApp) DeleteWebhook(arg0 context.Context, arg1 usecase.CmdDeleteWebhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockAppMockRecorder) DeleteWebhook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockApp)(nil).DeleteWebhook), arg0, arg1)
+}
+
+// FindDuplicates mocks base method.
+func (m *MockApp) FindDuplicates(arg0 context.Context, arg1 usecase.QueryFindDuplicates) ([]usecase.Duplicate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDuplicates", arg0, arg1)
+	ret0, _ := ret[0].([]usecase.Duplicate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDuplicates indicates an expected call of FindDuplicates.
+func (mr *MockAppMockRecorder) FindDuplicates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDuplicates", reflect.TypeOf((*MockApp)(nil).FindDuplicates), arg0, arg1)
+}
+
+// GetAddressBook mocks base method.
+func (m *MockApp) GetAddressBook(arg0 context.Context, arg1 usecase.QueryGetAddressBook) (*domain.AddressBook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddressBook", arg0, arg1)
+	ret0, _ := ret[0].(*domain.AddressBook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddressBook indicates an expected call of GetAddressBook.
+func (mr *MockAppMockRecorder) GetAddressBook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddressBook", reflect.TypeOf((*MockApp)(nil).GetAddressBook), arg0, arg1)
+}
+
+// GetContact mocks base method.
+func (m *MockApp) GetContact(arg0 context.Context, arg1 usecase.QueryGetContact) (*domain.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContact", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContact indicates an expected call of GetContact.
+func (mr *MockAppMockRecorder) GetContact(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContact", reflect.TypeOf((*MockApp)(nil).GetContact), arg0, arg1)
+}
+
+// GetContactSchema mocks base method.
+func (m *MockApp) GetContactSchema(arg0 context.Context, arg1 usecase.QueryGetContactSchema) (*domain.ContactSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContactSchema", arg0, arg1)
+	ret0, _ := ret[0].(*domain.ContactSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContactSchema indicates an expected call of GetContactSchema.
+func (mr *MockAppMockRecorder) GetContactSchema(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactSchema", reflect.TypeOf((*MockApp)(nil).GetContactSchema), arg0, arg1)
+}
+
+// GetGroup mocks base method.
+func (m *MockApp) GetGroup(arg0 context.Context, arg1 usecase.QueryGetGroup) (*domain.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroup", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroup indicates an expected call of GetGroup.
+func (mr *MockAppMockRecorder) GetGroup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroup", reflect.TypeOf((*MockApp)(nil).GetGroup), arg0, arg1)
+}
+
+// GetWebhook mocks base method.
+func (m *MockApp) GetWebhook(arg0 context.Context, arg1 usecase.QueryGetWebhook) (*domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhook", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhook indicates an expected call of GetWebhook.
+func (mr *MockAppMockRecorder) GetWebhook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhook", reflect.TypeOf((*MockApp)(nil).GetWebhook), arg0, arg1)
+}
+
+// ImportContacts mocks base method.
+func (m *MockApp) ImportContacts(arg0 context.Context, arg1 usecase.CmdImportContacts) ([]usecase.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportContacts", arg0, arg1)
+	ret0, _ := ret[0].([]usecase.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportContacts indicates an expected call of ImportContacts.
+func (mr *MockAppMockRecorder) ImportContacts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportContacts", reflect.TypeOf((*MockApp)(nil).ImportContacts), arg0, arg1)
+}
+
+// ListAddressBooks mocks base method.
+func (m *MockApp) ListAddressBooks(arg0 context.Context, arg1 usecase.QueryListAddressBooks) ([]*domain.AddressBook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAddressBooks", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.AddressBook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAddressBooks indicates an expected call of ListAddressBooks.
+func (mr *MockAppMockRecorder) ListAddressBooks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAddressBooks", reflect.TypeOf((*MockApp)(nil).ListAddressBooks), arg0, arg1)
+}
+
 // ListContacts mocks base method.
-func (m *MockApp) ListContacts(arg0 context.Context, arg1 usecase.QueryListContact) ([]*domain.Contact, error) {
+func (m *MockApp) ListContacts(arg0 context.Context, arg1 usecase.QueryListContact) (*usecase.ContactPage, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListContacts", arg0, arg1)
-	ret0, _ := ret[0].([]*domain.Contact)
+	ret0, _ := ret[0].(*usecase.ContactPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -80,6 +362,231 @@ func (mr *MockAppMockRecorder) ListContacts(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListContacts", reflect.TypeOf((*MockApp)(nil).ListContacts), arg0, arg1)
 }
 
+// ListGroups mocks base method.
+func (m *MockApp) ListGroups(arg0 context.Context, arg1 usecase.QueryListGroups) ([]*domain.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroups", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroups indicates an expected call of ListGroups.
+func (mr *MockAppMockRecorder) ListGroups(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroups", reflect.TypeOf((*MockApp)(nil).ListGroups), arg0, arg1)
+}
+
+// ListWebhookDeliveries mocks base method.
+func (m *MockApp) ListWebhookDeliveries(arg0 context.Context, arg1 usecase.QueryListWebhookDeliveries) ([]*domain.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeliveries", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookDeliveries indicates an expected call of ListWebhookDeliveries.
+func (mr *MockAppMockRecorder) ListWebhookDeliveries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveries", reflect.TypeOf((*MockApp)(nil).ListWebhookDeliveries), arg0, arg1)
+}
+
+// ListWebhooks mocks base method.
+func (m *MockApp) ListWebhooks(arg0 context.Context, arg1 usecase.QueryListWebhooks) ([]*domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhooks", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhooks indicates an expected call of ListWebhooks.
+func (mr *MockAppMockRecorder) ListWebhooks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhooks", reflect.TypeOf((*MockApp)(nil).ListWebhooks), arg0, arg1)
+}
+
+// MergeContacts mocks base method.
+func (m *MockApp) MergeContacts(arg0 context.Context, arg1 usecase.CmdMergeContacts) (*domain.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeContacts", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeContacts indicates an expected call of MergeContacts.
+func (mr *MockAppMockRecorder) MergeContacts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeContacts", reflect.TypeOf((*MockApp)(nil).MergeContacts), arg0, arg1)
+}
+
+// RelateContacts mocks base method.
+func (m *MockApp) RelateContacts(arg0 context.Context, arg1 usecase.CmdRelateContacts) (*domain.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelateContacts", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RelateContacts indicates an expected call of RelateContacts.
+func (mr *MockAppMockRecorder) RelateContacts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelateContacts", reflect.TypeOf((*MockApp)(nil).RelateContacts), arg0, arg1)
+}
+
+// RemoveAddressBookMember mocks base method.
+func (m *MockApp) RemoveAddressBookMember(arg0 context.Context, arg1 usecase.CmdRemoveAddressBookMember) (*domain.AddressBook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAddressBookMember", arg0, arg1)
+	ret0, _ := ret[0].(*domain.AddressBook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveAddressBookMember indicates an expected call of RemoveAddressBookMember.
+func (mr *MockAppMockRecorder) RemoveAddressBookMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAddressBookMember", reflect.TypeOf((*MockApp)(nil).RemoveAddressBookMember), arg0, arg1)
+}
+
+// RemoveGroupContact mocks base method.
+func (m *MockApp) RemoveGroupContact(arg0 context.Context, arg1 usecase.CmdRemoveGroupContact) (*domain.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveGroupContact", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveGroupContact indicates an expected call of RemoveGroupContact.
+func (mr *MockAppMockRecorder) RemoveGroupContact(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveGroupContact", reflect.TypeOf((*MockApp)(nil).RemoveGroupContact), arg0, arg1)
+}
+
+// RestoreContact mocks base method.
+func (m *MockApp) RestoreContact(arg0 context.Context, arg1 usecase.CmdRestoreContact) (*domain.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreContact", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreContact indicates an expected call of RestoreContact.
+func (mr *MockAppMockRecorder) RestoreContact(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreContact", reflect.TypeOf((*MockApp)(nil).RestoreContact), arg0, arg1)
+}
+
+// SetAddressBookMember mocks base method.
+func (m *MockApp) SetAddressBookMember(arg0 context.Context, arg1 usecase.CmdSetAddressBookMember) (*domain.AddressBook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAddressBookMember", arg0, arg1)
+	ret0, _ := ret[0].(*domain.AddressBook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetAddressBookMember indicates an expected call of SetAddressBookMember.
+func (mr *MockAppMockRecorder) SetAddressBookMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAddressBookMember", reflect.TypeOf((*MockApp)(nil).SetAddressBookMember), arg0, arg1)
+}
+
+// SetContactSchema mocks base method.
+func (m *MockApp) SetContactSchema(arg0 context.Context, arg1 usecase.CmdSetContactSchema) (*domain.ContactSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetContactSchema", arg0, arg1)
+	ret0, _ := ret[0].(*domain.ContactSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetContactSchema indicates an expected call of SetContactSchema.
+func (mr *MockAppMockRecorder) SetContactSchema(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetContactSchema", reflect.TypeOf((*MockApp)(nil).SetContactSchema), arg0, arg1)
+}
+
+// ShareContact mocks base method.
+func (m *MockApp) ShareContact(arg0 context.Context, arg1 usecase.CmdShareContact) (*domain.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareContact", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShareContact indicates an expected call of ShareContact.
+func (mr *MockAppMockRecorder) ShareContact(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareContact", reflect.TypeOf((*MockApp)(nil).ShareContact), arg0, arg1)
+}
+
+// ShareGroupContacts mocks base method.
+func (m *MockApp) ShareGroupContacts(arg0 context.Context, arg1 usecase.CmdShareGroupContacts) ([]usecase.BatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareGroupContacts", arg0, arg1)
+	ret0, _ := ret[0].([]usecase.BatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShareGroupContacts indicates an expected call of ShareGroupContacts.
+func (mr *MockAppMockRecorder) ShareGroupContacts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareGroupContacts", reflect.TypeOf((*MockApp)(nil).ShareGroupContacts), arg0, arg1)
+}
+
+// UnrelateContacts mocks base method.
+func (m *MockApp) UnrelateContacts(arg0 context.Context, arg1 usecase.CmdUnrelateContacts) (*domain.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnrelateContacts", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnrelateContacts indicates an expected call of UnrelateContacts.
+func (mr *MockAppMockRecorder) UnrelateContacts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnrelateContacts", reflect.TypeOf((*MockApp)(nil).UnrelateContacts), arg0, arg1)
+}
+
+// UnshareContact mocks base method.
+func (m *MockApp) UnshareContact(arg0 context.Context, arg1 usecase.CmdUnshareContact) (*domain.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnshareContact", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnshareContact indicates an expected call of UnshareContact.
+func (mr *MockAppMockRecorder) UnshareContact(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnshareContact", reflect.TypeOf((*MockApp)(nil).UnshareContact), arg0, arg1)
+}
+
+// UpdateAddressBook mocks base method.
+func (m *MockApp) UpdateAddressBook(arg0 context.Context, arg1 usecase.CmdUpdateAddressBook) (*domain.AddressBook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAddressBook", arg0, arg1)
+	ret0, _ := ret[0].(*domain.AddressBook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAddressBook indicates an expected call of UpdateAddressBook.
+func (mr *MockAppMockRecorder) UpdateAddressBook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAddressBook", reflect.TypeOf((*MockApp)(nil).UpdateAddressBook), arg0, arg1)
+}
+
 // UpdateContact mocks base method.
 func (m *MockApp) UpdateContact(arg0 context.Context, arg1 usecase.CmdUpdateContact) (*domain.Contact, error) {
 	m.ctrl.T.Helper()
@@ -94,3 +601,48 @@ func (mr *MockAppMockRecorder) UpdateContact(arg0, arg1 interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContact", reflect.TypeOf((*MockApp)(nil).UpdateContact), arg0, arg1)
 }
+
+// UpdateGroup mocks base method.
+func (m *MockApp) UpdateGroup(arg0 context.Context, arg1 usecase.CmdUpdateGroup) (*domain.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGroup", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGroup indicates an expected call of UpdateGroup.
+func (mr *MockAppMockRecorder) UpdateGroup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroup", reflect.TypeOf((*MockApp)(nil).UpdateGroup), arg0, arg1)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockApp) UpdateWebhook(arg0 context.Context, arg1 usecase.CmdUpdateWebhook) (*domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockAppMockRecorder) UpdateWebhook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockApp)(nil).UpdateWebhook), arg0, arg1)
+}
+
+// WatchContacts mocks base method.
+func (m *MockApp) WatchContacts(arg0 context.Context, arg1 usecase.QueryWatchContacts) (<-chan usecase.ContactChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchContacts", arg0, arg1)
+	ret0, _ := ret[0].(<-chan usecase.ContactChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchContacts indicates an expected call of WatchContacts.
+func (mr *MockAppMockRecorder) WatchContacts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchContacts", reflect.TypeOf((*MockApp)(nil).WatchContacts), arg0, arg1)
+}
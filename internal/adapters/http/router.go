@@ -7,13 +7,26 @@ import (
 	"github.com/gorilla/mux"
 )
 
-const pathContactId = "contactId"
+const (
+	pathContactId     = "contactId"
+	pathUserId        = "userId"
+	pathAddressBookId = "addressBookId"
+	pathWebhookId     = "webhookId"
+	pathGroupId       = "groupId"
+
+	pathRelationshipType = "relationshipType"
+	pathRelatedId        = "relatedId"
+)
 
 // New returns a new contacts API router
 func New(app App, authFn xhttp.AuthFn) *mux.Router {
 	root := mux.NewRouter()
 
 	mountV1Contacts(root, authFn, app)
+	mountV1AddressBooks(root, authFn, app)
+	mountV1Groups(root, authFn, app)
+	mountV1Webhooks(root, authFn, app)
+	mountV1ContactSchema(root, authFn, app)
 	mountPublic(root)
 
 	return root
@@ -21,16 +34,104 @@ func New(app App, authFn xhttp.AuthFn) *mux.Router {
 
 func mountV1Contacts(root *mux.Router, authFn xhttp.AuthFn, app App) {
 	contactsHandler := NewContactHandler(app)
+
+	// the :batch custom method extends the collection path, it is matched before the collection prefix
+	batch := root.Path("/v1/contacts:batch").Subrouter()
 	v1 := root.PathPrefix("/v1/contacts").Subrouter()
 
 	if authFn != nil {
+		batch.Use(xhttp.AuthMiddleware(authFn))
 		v1.Use(xhttp.AuthMiddleware(authFn))
 	}
 
+	batch.HandleFunc("", contactsHandler.Batch).Methods(http.MethodPost)
+
 	v1.HandleFunc("", contactsHandler.List).Methods(http.MethodGet)
 	v1.HandleFunc("", contactsHandler.Create).Methods(http.MethodPost)
+	v1.HandleFunc("/trash", contactsHandler.ListTrash).Methods(http.MethodGet)
+	v1.HandleFunc("/events", contactsHandler.Events).Methods(http.MethodGet)
+	v1.HandleFunc("/export.vcf", contactsHandler.ExportVCard).Methods(http.MethodGet)
+	v1.HandleFunc("/export.csv", contactsHandler.ExportCSV).Methods(http.MethodGet)
+	v1.HandleFunc("/import", contactsHandler.Import).Methods(http.MethodPost)
+	v1.HandleFunc("/duplicates", contactsHandler.Duplicates).Methods(http.MethodGet)
+	v1.HandleFunc("/{"+pathContactId+"}.vcf", contactsHandler.VCard).Methods(http.MethodGet)
+	v1.HandleFunc("/{"+pathContactId+"}", contactsHandler.Get).Methods(http.MethodGet)
 	v1.HandleFunc("/{"+pathContactId+"}", contactsHandler.Update).Methods(http.MethodPut)
 	v1.HandleFunc("/{"+pathContactId+"}", contactsHandler.Delete).Methods(http.MethodDelete)
+	v1.HandleFunc("/{"+pathContactId+"}/restore", contactsHandler.Restore).Methods(http.MethodPost)
+	v1.HandleFunc("/{"+pathContactId+"}/history", contactsHandler.History).Methods(http.MethodGet)
+	v1.HandleFunc("/{"+pathContactId+"}/merge", contactsHandler.Merge).Methods(http.MethodPost)
+	v1.HandleFunc("/{"+pathContactId+"}/shares", contactsHandler.Share).Methods(http.MethodPost)
+	v1.HandleFunc("/{"+pathContactId+"}/shares/{"+pathUserId+"}", contactsHandler.Unshare).Methods(http.MethodDelete)
+	v1.HandleFunc("/{"+pathContactId+"}/relationships", contactsHandler.Relationships).Methods(http.MethodGet)
+	v1.HandleFunc("/{"+pathContactId+"}/relationships/{"+pathRelationshipType+"}/{"+pathRelatedId+"}", contactsHandler.Relate).Methods(http.MethodPut)
+	v1.HandleFunc("/{"+pathContactId+"}/relationships/{"+pathRelationshipType+"}/{"+pathRelatedId+"}", contactsHandler.Unrelate).Methods(http.MethodDelete)
+}
+
+func mountV1AddressBooks(root *mux.Router, authFn xhttp.AuthFn, app App) {
+	addressBooksHandler := NewAddressBookHandler(app)
+	v1 := root.PathPrefix("/v1/address-books").Subrouter()
+
+	if authFn != nil {
+		v1.Use(xhttp.AuthMiddleware(authFn))
+	}
+
+	v1.HandleFunc("", addressBooksHandler.List).Methods(http.MethodGet)
+	v1.HandleFunc("", addressBooksHandler.Create).Methods(http.MethodPost)
+	v1.HandleFunc("/{"+pathAddressBookId+"}", addressBooksHandler.Get).Methods(http.MethodGet)
+	v1.HandleFunc("/{"+pathAddressBookId+"}", addressBooksHandler.Update).Methods(http.MethodPut)
+	v1.HandleFunc("/{"+pathAddressBookId+"}", addressBooksHandler.Delete).Methods(http.MethodDelete)
+	v1.HandleFunc("/{"+pathAddressBookId+"}/members/{"+pathUserId+"}", addressBooksHandler.SetMember).Methods(http.MethodPut)
+	v1.HandleFunc("/{"+pathAddressBookId+"}/members/{"+pathUserId+"}", addressBooksHandler.RemoveMember).Methods(http.MethodDelete)
+}
+
+func mountV1Groups(root *mux.Router, authFn xhttp.AuthFn, app App) {
+	groupsHandler := NewGroupHandler(app)
+	v1 := root.PathPrefix("/v1/groups").Subrouter()
+
+	if authFn != nil {
+		v1.Use(xhttp.AuthMiddleware(authFn))
+	}
+
+	v1.HandleFunc("", groupsHandler.List).Methods(http.MethodGet)
+	v1.HandleFunc("", groupsHandler.Create).Methods(http.MethodPost)
+	v1.HandleFunc("/{"+pathGroupId+"}", groupsHandler.Get).Methods(http.MethodGet)
+	v1.HandleFunc("/{"+pathGroupId+"}", groupsHandler.Update).Methods(http.MethodPut)
+	v1.HandleFunc("/{"+pathGroupId+"}", groupsHandler.Delete).Methods(http.MethodDelete)
+	v1.HandleFunc("/{"+pathGroupId+"}/contacts", groupsHandler.DeleteContacts).Methods(http.MethodDelete)
+	v1.HandleFunc("/{"+pathGroupId+"}/contacts/{"+pathContactId+"}", groupsHandler.AddContact).Methods(http.MethodPut)
+	v1.HandleFunc("/{"+pathGroupId+"}/contacts/{"+pathContactId+"}", groupsHandler.RemoveContact).Methods(http.MethodDelete)
+	v1.HandleFunc("/{"+pathGroupId+"}/shares", groupsHandler.ShareContacts).Methods(http.MethodPost)
+	v1.HandleFunc("/{"+pathGroupId+"}/export.vcf", groupsHandler.ExportVCard).Methods(http.MethodGet)
+	v1.HandleFunc("/{"+pathGroupId+"}/export.csv", groupsHandler.ExportCSV).Methods(http.MethodGet)
+}
+
+func mountV1Webhooks(root *mux.Router, authFn xhttp.AuthFn, app App) {
+	webhooksHandler := NewWebhookHandler(app)
+	v1 := root.PathPrefix("/v1/webhooks").Subrouter()
+
+	if authFn != nil {
+		v1.Use(xhttp.AuthMiddleware(authFn))
+	}
+
+	v1.HandleFunc("", webhooksHandler.List).Methods(http.MethodGet)
+	v1.HandleFunc("", webhooksHandler.Create).Methods(http.MethodPost)
+	v1.HandleFunc("/{"+pathWebhookId+"}", webhooksHandler.Get).Methods(http.MethodGet)
+	v1.HandleFunc("/{"+pathWebhookId+"}", webhooksHandler.Update).Methods(http.MethodPut)
+	v1.HandleFunc("/{"+pathWebhookId+"}", webhooksHandler.Delete).Methods(http.MethodDelete)
+	v1.HandleFunc("/{"+pathWebhookId+"}/deliveries", webhooksHandler.Deliveries).Methods(http.MethodGet)
+}
+
+func mountV1ContactSchema(root *mux.Router, authFn xhttp.AuthFn, app App) {
+	contactSchemaHandler := NewContactSchemaHandler(app)
+	v1 := root.Path("/v1/contact-schema").Subrouter()
+
+	if authFn != nil {
+		v1.Use(xhttp.AuthMiddleware(authFn))
+	}
+
+	v1.HandleFunc("", contactSchemaHandler.Get).Methods(http.MethodGet)
+	v1.HandleFunc("", contactSchemaHandler.Set).Methods(http.MethodPut)
 }
 
 func mountPublic(root *mux.Router) {
@@ -0,0 +1,188 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/davidterranova/contacts/internal/usecase"
+	"github.com/davidterranova/contacts/pkg/auth"
+	"github.com/davidterranova/contacts/pkg/xhttp"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+)
+
+type WebhookHandler struct {
+	app App
+}
+
+func NewWebhookHandler(app App) *WebhookHandler {
+	return &WebhookHandler{
+		app: app,
+	}
+}
+
+func (h *WebhookHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("webhooks:list failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	webhooks, err := h.app.ListWebhooks(ctx, usecase.QueryListWebhooks{Owner: user})
+	if err != nil {
+		writeWebhookError(ctx, w, err, "webhooks:list failed to list webhooks")
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, fromDomainWebhookList(webhooks))
+}
+
+func (h *WebhookHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("webhooks:get failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	webhook, err := h.app.GetWebhook(ctx, usecase.QueryGetWebhook{
+		Requester: user,
+		WebhookId: mux.Vars(r)[pathWebhookId],
+	})
+	if err != nil {
+		writeWebhookError(ctx, w, err, "webhooks:get failed to get webhook")
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, fromDomainWebhook(webhook))
+}
+
+type webhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Secret string   `json:"secret"`
+}
+
+func (h *WebhookHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req webhookRequest
+	ctx := r.Context()
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("webhooks:create failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("webhooks:create failed to decode request")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "failed to decode request", err)
+		return
+	}
+
+	webhook, err := h.app.CreateWebhook(ctx, usecase.CmdCreateWebhook{
+		Creator: user,
+		URL:     req.URL,
+		Events:  req.Events,
+		Secret:  req.Secret,
+	})
+	if err != nil {
+		writeWebhookError(ctx, w, err, "webhooks:create failed to create webhook")
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusCreated, fromDomainWebhook(webhook))
+}
+
+func (h *WebhookHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req webhookRequest
+	ctx := r.Context()
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("webhooks:update failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("webhooks:update failed to decode request")
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "failed to decode request", err)
+		return
+	}
+
+	webhook, err := h.app.UpdateWebhook(ctx, usecase.CmdUpdateWebhook{
+		Updater:   user,
+		WebhookId: mux.Vars(r)[pathWebhookId],
+		URL:       req.URL,
+		Events:    req.Events,
+		Secret:    req.Secret,
+	})
+	if err != nil {
+		writeWebhookError(ctx, w, err, "webhooks:update failed to update webhook")
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, fromDomainWebhook(webhook))
+}
+
+func (h *WebhookHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("webhooks:delete failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	err = h.app.DeleteWebhook(ctx, usecase.CmdDeleteWebhook{
+		Deleter:   user,
+		WebhookId: mux.Vars(r)[pathWebhookId],
+	})
+	if err != nil {
+		writeWebhookError(ctx, w, err, "webhooks:delete failed to delete webhook")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WebhookHandler) Deliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, err := auth.UserFromContext(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("webhooks:deliveries failed to get user from context")
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to get user from context", err)
+		return
+	}
+
+	deliveries, err := h.app.ListWebhookDeliveries(ctx, usecase.QueryListWebhookDeliveries{
+		Requester: user,
+		WebhookId: mux.Vars(r)[pathWebhookId],
+	})
+	if err != nil {
+		writeWebhookError(ctx, w, err, "webhooks:deliveries failed to list webhook deliveries")
+		return
+	}
+
+	xhttp.WriteObject(ctx, w, http.StatusOK, fromDomainDeliveries(deliveries))
+}
+
+func writeWebhookError(ctx context.Context, w http.ResponseWriter, err error, msg string) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidCommand):
+		xhttp.WriteError(ctx, w, http.StatusBadRequest, "webhook validation failed", err)
+	case errors.Is(err, usecase.ErrNotFound):
+		xhttp.WriteError(ctx, w, http.StatusNotFound, "webhook not found", err)
+	case errors.Is(err, usecase.ErrForbidden):
+		xhttp.WriteError(ctx, w, http.StatusForbidden, "webhook access forbidden", err)
+	default:
+		log.Ctx(ctx).Warn().Err(err).Msg(msg)
+		xhttp.WriteError(ctx, w, http.StatusInternalServerError, "failed to handle webhook", err)
+	}
+}
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"encoding/json"
+
+	"github.com/davidterranova/contacts/internal/domain"
+)
+
+// Webhook never exposes the secret, it is only known by its creator and the receiver
+type Webhook struct {
+	Id        string   `json:"id"`
+	CreatedBy string   `json:"created_by"`
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events"`
+}
+
+type Delivery struct {
+	Id             string          `json:"id"`
+	EventId        string          `json:"event_id"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload"`
+	CreatedAt      string          `json:"created_at"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  string          `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  string          `json:"last_attempt_at,omitempty"`
+	ResponseStatus int             `json:"response_status,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+}
+
+func fromDomainWebhook(w *domain.Webhook) *Webhook {
+	return &Webhook{
+		Id:        w.Id.String(),
+		CreatedBy: w.CreatedBy.String(),
+		CreatedAt: w.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt: w.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		URL:       w.URL,
+		Events:    w.Events,
+	}
+}
+
+func fromDomainWebhookList(webhooks []*domain.Webhook) []*Webhook {
+	var list = make([]*Webhook, 0, len(webhooks))
+	for _, w := range webhooks {
+		list = append(list, fromDomainWebhook(w))
+	}
+
+	return list
+}
+
+func fromDomainDeliveries(deliveries []*domain.Delivery) []*Delivery {
+	var list = make([]*Delivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		delivery := &Delivery{
+			Id:             d.Id.String(),
+			EventId:        d.EventId.String(),
+			Event:          d.EventName,
+			Payload:        d.Payload,
+			CreatedAt:      d.CreatedAt.Format("2006-01-02T15:04:05Z"),
+			Status:         string(d.Status),
+			Attempts:       d.Attempts,
+			ResponseStatus: d.ResponseStatus,
+			LastError:      d.LastError,
+		}
+		if d.Status == domain.DeliveryStatusPending {
+			delivery.NextAttemptAt = d.NextAttemptAt.Format("2006-01-02T15:04:05Z")
+		}
+		if d.LastAttemptAt != nil {
+			delivery.LastAttemptAt = d.LastAttemptAt.Format("2006-01-02T15:04:05Z")
+		}
+		list = append(list, delivery)
+	}
+
+	return list
+}
@@ -0,0 +1,141 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/internal/usecase"
+	"github.com/davidterranova/contacts/pkg/user"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/steinfletcher/apitest"
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name               string
+		returnedAppWebhook *domain.Webhook
+		returnedAppErr     error
+		expectedStatus     int
+	}{
+		{
+			name:               "ok",
+			returnedAppWebhook: domain.NewWebhook(uuid.New(), "https://crm.local/hooks", []string{domain.EventContactCreated}, "0123456789abcdef"),
+			expectedStatus:     http.StatusCreated,
+		},
+		{
+			name:           "bad request",
+			returnedAppErr: usecase.ErrInvalidCommand,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "internal server error",
+			returnedAppErr: usecase.ErrInternal,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+			container := testContainer(t)
+			container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+			container.app.EXPECT().
+				CreateWebhook(gomock.Any(), usecase.CmdCreateWebhook{
+					Creator: authorizedUser,
+					URL:     "https://crm.local/hooks",
+					Events:  []string{domain.EventContactCreated},
+					Secret:  "0123456789abcdef",
+				}).
+				Times(1).
+				Return(c.returnedAppWebhook, c.returnedAppErr)
+
+			test := apitest.New().
+				Report(apitest.SequenceDiagram()).
+				Handler(container.handler).
+				Post("/v1/webhooks").
+				JSON(`{"url": "https://crm.local/hooks", "events": ["contact.created"], "secret": "0123456789abcdef"}`).
+				Expect(t).
+				Status(c.expectedStatus)
+			if c.returnedAppWebhook != nil {
+				test = test.
+					Assert(jsonpath.Equal("$.url", "https://crm.local/hooks")).
+					Assert(jsonpath.Equal("$.events[0]", domain.EventContactCreated)).
+					Assert(jsonpath.NotPresent("$.secret"))
+			}
+			test.End()
+		})
+	}
+}
+
+func TestWebhookDeliveries(t *testing.T) {
+	t.Parallel()
+
+	webhookId := uuid.New()
+	failed := domain.NewDelivery(webhookId, domain.NewContactDeleted(uuid.New()), []byte(`{"event":"contact.deleted"}`))
+	failed.Fail(failed.CreatedAt, http.StatusServiceUnavailable, "webhook responded with status 503", domain.RetryPolicy{MaxAttempts: 1})
+
+	cases := []struct {
+		name           string
+		returnedAppErr error
+		expectedStatus int
+	}{
+		{
+			name:           "ok",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "not found",
+			returnedAppErr: usecase.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "forbidden",
+			returnedAppErr: usecase.ErrForbidden,
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			authorizedUser := user.New(uuid.New(), user.UserTypeAuthenticated)
+			container := testContainer(t)
+			container.handler.Use(appendUserToContextMiddleware(authorizedUser))
+			var deliveries []*domain.Delivery
+			if c.returnedAppErr == nil {
+				deliveries = []*domain.Delivery{failed}
+			}
+			container.app.EXPECT().
+				ListWebhookDeliveries(gomock.Any(), usecase.QueryListWebhookDeliveries{Requester: authorizedUser, WebhookId: webhookId.String()}).
+				Times(1).
+				Return(deliveries, c.returnedAppErr)
+
+			test := apitest.New().
+				Report(apitest.SequenceDiagram()).
+				Handler(container.handler).
+				Get("/v1/webhooks/" + webhookId.String() + "/deliveries").
+				Expect(t).
+				Status(c.expectedStatus)
+			if c.returnedAppErr == nil {
+				test = test.
+					Assert(jsonpath.Len("$", 1)).
+					Assert(jsonpath.Equal("$[0].status", string(domain.DeliveryStatusDead))).
+					Assert(jsonpath.Equal("$[0].attempts", float64(1))).
+					Assert(jsonpath.Equal("$[0].response_status", float64(http.StatusServiceUnavailable))).
+					Assert(jsonpath.Equal("$[0].payload.event", domain.EventContactDeleted)).
+					Assert(jsonpath.NotPresent("$[0].next_attempt_at"))
+			}
+			test.End()
+		})
+	}
+}
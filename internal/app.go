@@ -8,7 +8,11 @@ import (
 )
 
 type ListContact interface {
-	List(ctx context.Context, query usecase.QueryListContact) ([]*domain.Contact, error)
+	List(ctx context.Context, query usecase.QueryListContact) (*usecase.ContactPage, error)
+}
+
+type GetContact interface {
+	Get(ctx context.Context, query usecase.QueryGetContact) (*domain.Contact, error)
 }
 
 type CreateContact interface {
@@ -23,26 +27,174 @@ type DeleteContact interface {
 	Delete(ctx context.Context, cmd usecase.CmdDeleteContact) error
 }
 
+type RestoreContact interface {
+	Restore(ctx context.Context, cmd usecase.CmdRestoreContact) (*domain.Contact, error)
+}
+
+type ImportContacts interface {
+	Import(ctx context.Context, cmd usecase.CmdImportContacts) ([]usecase.ImportResult, error)
+}
+
+type BatchContacts interface {
+	Apply(ctx context.Context, cmd usecase.CmdBatchContacts) (usecase.BatchResults, error)
+}
+
+type FindDuplicates interface {
+	Find(ctx context.Context, query usecase.QueryFindDuplicates) ([]usecase.Duplicate, error)
+}
+
+type MergeContacts interface {
+	Merge(ctx context.Context, cmd usecase.CmdMergeContacts) (*domain.Contact, error)
+}
+
+type ContactHistory interface {
+	History(ctx context.Context, query usecase.QueryContactHistory) ([]*domain.AuditEntry, error)
+}
+
+type WatchContacts interface {
+	Watch(ctx context.Context, query usecase.QueryWatchContacts) (<-chan usecase.ContactChange, error)
+	Notify(ctx context.Context, event domain.Event) error
+}
+
+type PurgeTrash interface {
+	Purge(ctx context.Context, cmd usecase.CmdPurgeTrash) (int, error)
+}
+
+type PublishEvents interface {
+	Publish(ctx context.Context, cmd usecase.CmdPublishEvents) (int, error)
+}
+
+type ContactSchema interface {
+	Get(ctx context.Context, query usecase.QueryGetContactSchema) (*domain.ContactSchema, error)
+	Set(ctx context.Context, cmd usecase.CmdSetContactSchema) (*domain.ContactSchema, error)
+}
+
+type ContactRelationships interface {
+	Relate(ctx context.Context, cmd usecase.CmdRelateContacts) (*domain.Contact, error)
+	Unrelate(ctx context.Context, cmd usecase.CmdUnrelateContacts) (*domain.Contact, error)
+	Relationships(ctx context.Context, query usecase.QueryContactRelationships) (*usecase.ContactRelationships, error)
+}
+
+type Group interface {
+	Create(ctx context.Context, cmd usecase.CmdCreateGroup) (*domain.Group, error)
+	Get(ctx context.Context, query usecase.QueryGetGroup) (*domain.Group, error)
+	List(ctx context.Context, query usecase.QueryListGroups) ([]*domain.Group, error)
+	Update(ctx context.Context, cmd usecase.CmdUpdateGroup) (*domain.Group, error)
+	Delete(ctx context.Context, cmd usecase.CmdDeleteGroup) error
+}
+
+type GroupContacts interface {
+	Add(ctx context.Context, cmd usecase.CmdAddGroupContact) (*domain.Contact, error)
+	Remove(ctx context.Context, cmd usecase.CmdRemoveGroupContact) (*domain.Contact, error)
+	DeleteContacts(ctx context.Context, cmd usecase.CmdDeleteGroupContacts) (usecase.BatchResults, error)
+	ShareContacts(ctx context.Context, cmd usecase.CmdShareGroupContacts) ([]usecase.BatchResult, error)
+}
+
+type Webhook interface {
+	Create(ctx context.Context, cmd usecase.CmdCreateWebhook) (*domain.Webhook, error)
+	Get(ctx context.Context, query usecase.QueryGetWebhook) (*domain.Webhook, error)
+	List(ctx context.Context, query usecase.QueryListWebhooks) ([]*domain.Webhook, error)
+	Update(ctx context.Context, cmd usecase.CmdUpdateWebhook) (*domain.Webhook, error)
+	Delete(ctx context.Context, cmd usecase.CmdDeleteWebhook) error
+	Deliveries(ctx context.Context, query usecase.QueryListWebhookDeliveries) ([]*domain.Delivery, error)
+}
+
+type EnqueueWebhookDeliveries interface {
+	Enqueue(ctx context.Context, event domain.Event) error
+}
+
+type DeliverWebhooks interface {
+	Deliver(ctx context.Context, cmd usecase.CmdDeliverWebhooks) (int, error)
+}
+
+type ShareContact interface {
+	Share(ctx context.Context, cmd usecase.CmdShareContact) (*domain.Contact, error)
+	Unshare(ctx context.Context, cmd usecase.CmdUnshareContact) (*domain.Contact, error)
+}
+
+type AddressBook interface {
+	Create(ctx context.Context, cmd usecase.CmdCreateAddressBook) (*domain.AddressBook, error)
+	Get(ctx context.Context, query usecase.QueryGetAddressBook) (*domain.AddressBook, error)
+	List(ctx context.Context, query usecase.QueryListAddressBooks) ([]*domain.AddressBook, error)
+	Update(ctx context.Context, cmd usecase.CmdUpdateAddressBook) (*domain.AddressBook, error)
+	Delete(ctx context.Context, cmd usecase.CmdDeleteAddressBook) error
+}
+
+type AddressBookMember interface {
+	Set(ctx context.Context, cmd usecase.CmdSetAddressBookMember) (*domain.AddressBook, error)
+	Remove(ctx context.Context, cmd usecase.CmdRemoveAddressBookMember) (*domain.AddressBook, error)
+}
+
 type App struct {
-	listContact   ListContact
-	createContact CreateContact
-	updateContact UpdateContact
-	deleteContact DeleteContact
+	listContact    ListContact
+	getContact     GetContact
+	createContact  CreateContact
+	updateContact  UpdateContact
+	deleteContact  DeleteContact
+	restoreContact RestoreContact
+	contactHistory ContactHistory
+	importContacts ImportContacts
+	batchContacts  BatchContacts
+	findDuplicates FindDuplicates
+	mergeContacts  MergeContacts
+	watchContacts  WatchContacts
+	purgeTrash     PurgeTrash
+	publishEvents  PublishEvents
+	shareContact   ShareContact
+	contactSchema  ContactSchema
+	relationships  ContactRelationships
+
+	addressBook       AddressBook
+	addressBookMember AddressBookMember
+
+	group         Group
+	groupContacts GroupContacts
+
+	webhook                  Webhook
+	enqueueWebhookDeliveries EnqueueWebhookDeliveries
+	deliverWebhooks          DeliverWebhooks
 }
 
-func New(repo usecase.ContactRepository) *App {
+func New(repo usecase.ContactRepository, books usecase.AddressBookRepository, audit usecase.AuditRepository, schemas usecase.ContactSchemaRepository, groups usecase.GroupRepository, outbox usecase.EventOutbox, publisher usecase.EventPublisher, webhooks usecase.WebhookRepository, sender usecase.WebhookSender, emails usecase.EmailVerifier) *App {
 	return &App{
-		listContact:   usecase.NewListContact(repo),
-		createContact: usecase.NewCreateContact(repo),
-		updateContact: usecase.NewUpdateContact(repo),
-		deleteContact: usecase.NewDeleteContact(repo),
+		listContact:    usecase.NewListContact(repo, books),
+		getContact:     usecase.NewGetContact(repo, books),
+		createContact:  usecase.NewCreateContact(repo, books, schemas, emails),
+		updateContact:  usecase.NewUpdateContact(repo, books, schemas, emails),
+		deleteContact:  usecase.NewDeleteContact(repo, books),
+		restoreContact: usecase.NewRestoreContact(repo, books),
+		contactHistory: usecase.NewContactHistory(repo, books, audit),
+		importContacts: usecase.NewImportContacts(repo, books, schemas, emails),
+		batchContacts:  usecase.NewBatchContacts(repo, books, schemas, emails),
+		findDuplicates: usecase.NewFindDuplicates(repo, books),
+		mergeContacts:  usecase.NewMergeContacts(repo, books),
+		watchContacts:  usecase.NewWatchContacts(repo, books),
+		purgeTrash:     usecase.NewPurgeTrash(repo),
+		publishEvents:  usecase.NewPublishEvents(outbox, publisher),
+		shareContact:   usecase.NewShareContact(repo),
+		contactSchema:  usecase.NewContactSchemaHandler(schemas),
+		relationships:  usecase.NewContactRelationships(repo, books),
+
+		addressBook:       usecase.NewAddressBookHandler(books),
+		addressBookMember: usecase.NewAddressBookMemberHandler(books),
+
+		group:         usecase.NewGroupHandler(groups),
+		groupContacts: usecase.NewGroupContactsHandler(groups, repo, books, schemas, emails),
+
+		webhook:                  usecase.NewWebhookHandler(webhooks),
+		enqueueWebhookDeliveries: usecase.NewEnqueueWebhookDeliveries(webhooks, repo, books),
+		deliverWebhooks:          usecase.NewDeliverWebhooks(webhooks, sender),
 	}
 }
 
-func (a *App) ListContacts(ctx context.Context, query usecase.QueryListContact) ([]*domain.Contact, error) {
+func (a *App) ListContacts(ctx context.Context, query usecase.QueryListContact) (*usecase.ContactPage, error) {
 	return a.listContact.List(ctx, query)
 }
 
+func (a *App) GetContact(ctx context.Context, query usecase.QueryGetContact) (*domain.Contact, error) {
+	return a.getContact.Get(ctx, query)
+}
+
 func (a *App) CreateContact(ctx context.Context, cmd usecase.CmdCreateContact) (*domain.Contact, error) {
 	return a.createContact.Create(ctx, cmd)
 }
@@ -54,3 +206,169 @@ func (a *App) UpdateContact(ctx context.Context, cmd usecase.CmdUpdateContact) (
 func (a *App) DeleteContact(ctx context.Context, cmd usecase.CmdDeleteContact) error {
 	return a.deleteContact.Delete(ctx, cmd)
 }
+
+func (a *App) RestoreContact(ctx context.Context, cmd usecase.CmdRestoreContact) (*domain.Contact, error) {
+	return a.restoreContact.Restore(ctx, cmd)
+}
+
+func (a *App) ContactHistory(ctx context.Context, query usecase.QueryContactHistory) ([]*domain.AuditEntry, error) {
+	return a.contactHistory.History(ctx, query)
+}
+
+func (a *App) ImportContacts(ctx context.Context, cmd usecase.CmdImportContacts) ([]usecase.ImportResult, error) {
+	return a.importContacts.Import(ctx, cmd)
+}
+
+func (a *App) BatchContacts(ctx context.Context, cmd usecase.CmdBatchContacts) (usecase.BatchResults, error) {
+	return a.batchContacts.Apply(ctx, cmd)
+}
+
+func (a *App) FindDuplicates(ctx context.Context, query usecase.QueryFindDuplicates) ([]usecase.Duplicate, error) {
+	return a.findDuplicates.Find(ctx, query)
+}
+
+func (a *App) MergeContacts(ctx context.Context, cmd usecase.CmdMergeContacts) (*domain.Contact, error) {
+	return a.mergeContacts.Merge(ctx, cmd)
+}
+
+func (a *App) WatchContacts(ctx context.Context, query usecase.QueryWatchContacts) (<-chan usecase.ContactChange, error) {
+	return a.watchContacts.Watch(ctx, query)
+}
+
+// NotifyContactWatchers is subscribed to the event bus to notify the contact watchers of the contact events
+func (a *App) NotifyContactWatchers(ctx context.Context, event domain.Event) error {
+	return a.watchContacts.Notify(ctx, event)
+}
+
+func (a *App) PurgeTrash(ctx context.Context, cmd usecase.CmdPurgeTrash) (int, error) {
+	return a.purgeTrash.Purge(ctx, cmd)
+}
+
+func (a *App) PublishEvents(ctx context.Context, cmd usecase.CmdPublishEvents) (int, error) {
+	return a.publishEvents.Publish(ctx, cmd)
+}
+
+func (a *App) ShareContact(ctx context.Context, cmd usecase.CmdShareContact) (*domain.Contact, error) {
+	return a.shareContact.Share(ctx, cmd)
+}
+
+func (a *App) UnshareContact(ctx context.Context, cmd usecase.CmdUnshareContact) (*domain.Contact, error) {
+	return a.shareContact.Unshare(ctx, cmd)
+}
+
+func (a *App) GetContactSchema(ctx context.Context, query usecase.QueryGetContactSchema) (*domain.ContactSchema, error) {
+	return a.contactSchema.Get(ctx, query)
+}
+
+func (a *App) SetContactSchema(ctx context.Context, cmd usecase.CmdSetContactSchema) (*domain.ContactSchema, error) {
+	return a.contactSchema.Set(ctx, cmd)
+}
+
+func (a *App) CreateAddressBook(ctx context.Context, cmd usecase.CmdCreateAddressBook) (*domain.AddressBook, error) {
+	return a.addressBook.Create(ctx, cmd)
+}
+
+func (a *App) GetAddressBook(ctx context.Context, query usecase.QueryGetAddressBook) (*domain.AddressBook, error) {
+	return a.addressBook.Get(ctx, query)
+}
+
+func (a *App) ListAddressBooks(ctx context.Context, query usecase.QueryListAddressBooks) ([]*domain.AddressBook, error) {
+	return a.addressBook.List(ctx, query)
+}
+
+func (a *App) UpdateAddressBook(ctx context.Context, cmd usecase.CmdUpdateAddressBook) (*domain.AddressBook, error) {
+	return a.addressBook.Update(ctx, cmd)
+}
+
+func (a *App) DeleteAddressBook(ctx context.Context, cmd usecase.CmdDeleteAddressBook) error {
+	return a.addressBook.Delete(ctx, cmd)
+}
+
+func (a *App) SetAddressBookMember(ctx context.Context, cmd usecase.CmdSetAddressBookMember) (*domain.AddressBook, error) {
+	return a.addressBookMember.Set(ctx, cmd)
+}
+
+func (a *App) RemoveAddressBookMember(ctx context.Context, cmd usecase.CmdRemoveAddressBookMember) (*domain.AddressBook, error) {
+	return a.addressBookMember.Remove(ctx, cmd)
+}
+
+func (a *App) RelateContacts(ctx context.Context, cmd usecase.CmdRelateContacts) (*domain.Contact, error) {
+	return a.relationships.Relate(ctx, cmd)
+}
+
+func (a *App) UnrelateContacts(ctx context.Context, cmd usecase.CmdUnrelateContacts) (*domain.Contact, error) {
+	return a.relationships.Unrelate(ctx, cmd)
+}
+
+func (a *App) ContactRelationships(ctx context.Context, query usecase.QueryContactRelationships) (*usecase.ContactRelationships, error) {
+	return a.relationships.Relationships(ctx, query)
+}
+
+func (a *App) CreateGroup(ctx context.Context, cmd usecase.CmdCreateGroup) (*domain.Group, error) {
+	return a.group.Create(ctx, cmd)
+}
+
+func (a *App) GetGroup(ctx context.Context, query usecase.QueryGetGroup) (*domain.Group, error) {
+	return a.group.Get(ctx, query)
+}
+
+func (a *App) ListGroups(ctx context.Context, query usecase.QueryListGroups) ([]*domain.Group, error) {
+	return a.group.List(ctx, query)
+}
+
+func (a *App) UpdateGroup(ctx context.Context, cmd usecase.CmdUpdateGroup) (*domain.Group, error) {
+	return a.group.Update(ctx, cmd)
+}
+
+func (a *App) DeleteGroup(ctx context.Context, cmd usecase.CmdDeleteGroup) error {
+	return a.group.Delete(ctx, cmd)
+}
+
+func (a *App) AddGroupContact(ctx context.Context, cmd usecase.CmdAddGroupContact) (*domain.Contact, error) {
+	return a.groupContacts.Add(ctx, cmd)
+}
+
+func (a *App) RemoveGroupContact(ctx context.Context, cmd usecase.CmdRemoveGroupContact) (*domain.Contact, error) {
+	return a.groupContacts.Remove(ctx, cmd)
+}
+
+func (a *App) DeleteGroupContacts(ctx context.Context, cmd usecase.CmdDeleteGroupContacts) (usecase.BatchResults, error) {
+	return a.groupContacts.DeleteContacts(ctx, cmd)
+}
+
+func (a *App) ShareGroupContacts(ctx context.Context, cmd usecase.CmdShareGroupContacts) ([]usecase.BatchResult, error) {
+	return a.groupContacts.ShareContacts(ctx, cmd)
+}
+
+func (a *App) CreateWebhook(ctx context.Context, cmd usecase.CmdCreateWebhook) (*domain.Webhook, error) {
+	return a.webhook.Create(ctx, cmd)
+}
+
+func (a *App) GetWebhook(ctx context.Context, query usecase.QueryGetWebhook) (*domain.Webhook, error) {
+	return a.webhook.Get(ctx, query)
+}
+
+func (a *App) ListWebhooks(ctx context.Context, query usecase.QueryListWebhooks) ([]*domain.Webhook, error) {
+	return a.webhook.List(ctx, query)
+}
+
+func (a *App) UpdateWebhook(ctx context.Context, cmd usecase.CmdUpdateWebhook) (*domain.Webhook, error) {
+	return a.webhook.Update(ctx, cmd)
+}
+
+func (a *App) DeleteWebhook(ctx context.Context, cmd usecase.CmdDeleteWebhook) error {
+	return a.webhook.Delete(ctx, cmd)
+}
+
+func (a *App) ListWebhookDeliveries(ctx context.Context, query usecase.QueryListWebhookDeliveries) ([]*domain.Delivery, error) {
+	return a.webhook.Deliveries(ctx, query)
+}
+
+// EnqueueWebhookDeliveries is subscribed to the event bus to notify the webhooks of the contact events
+func (a *App) EnqueueWebhookDeliveries(ctx context.Context, event domain.Event) error {
+	return a.enqueueWebhookDeliveries.Enqueue(ctx, event)
+}
+
+func (a *App) DeliverWebhooks(ctx context.Context, cmd usecase.CmdDeliverWebhooks) (int, error) {
+	return a.deliverWebhooks.Deliver(ctx, cmd)
+}
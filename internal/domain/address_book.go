@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrLastOwner      = errors.New("address book must keep at least one owner")
+	ErrMemberNotFound = errors.New("user is not a member of the address book")
+	ErrInvalidRole    = errors.New("invalid address book role")
+)
+
+type Role string
+
+const (
+	// RoleOwner manages the address book and its members, and edits its contacts
+	RoleOwner Role = "owner"
+	// RoleEditor creates, updates and deletes the address book contacts
+	RoleEditor Role = "editor"
+	// RoleViewer reads the address book contacts
+	RoleViewer Role = "viewer"
+)
+
+func (r Role) IsValid() bool {
+	return r == RoleOwner || r == RoleEditor || r == RoleViewer
+}
+
+// CanWrite tells whether the role allows creating, updating and deleting contacts
+func (r Role) CanWrite() bool {
+	return r == RoleOwner || r == RoleEditor
+}
+
+type Member struct {
+	UserId  uuid.UUID
+	Role    Role
+	AddedAt time.Time
+}
+
+// AddressBook owns contacts shared by its members according to their role
+type AddressBook struct {
+	Id uuid.UUID
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	CreatedBy uuid.UUID
+
+	Name    string
+	Members []Member
+}
+
+// NewAddressBook returns an address book owned by its creator
+func NewAddressBook(createdBy uuid.UUID, name string) *AddressBook {
+	now := time.Now().UTC()
+
+	return &AddressBook{
+		Id:        uuid.New(),
+		CreatedAt: now,
+		UpdatedAt: now,
+		CreatedBy: createdBy,
+		Name:      name,
+		Members: []Member{
+			{UserId: createdBy, Role: RoleOwner, AddedAt: now},
+		},
+	}
+}
+
+// Role returns the role of the user and whether they are a member
+func (b AddressBook) Role(userId uuid.UUID) (Role, bool) {
+	for _, member := range b.Members {
+		if member.UserId == userId {
+			return member.Role, true
+		}
+	}
+
+	return "", false
+}
+
+// SetMember adds the user with the given role or changes their role.
+// Members are copied so that the original address book is left untouched.
+func (b *AddressBook) SetMember(userId uuid.UUID, role Role) error {
+	if !role.IsValid() {
+		return ErrInvalidRole
+	}
+
+	now := time.Now().UTC()
+	members := make([]Member, 0, len(b.Members)+1)
+	added := true
+	for _, member := range b.Members {
+		if member.UserId == userId {
+			member.Role = role
+			added = false
+		}
+		members = append(members, member)
+	}
+	if added {
+		members = append(members, Member{UserId: userId, Role: role, AddedAt: now})
+	}
+
+	if !hasOwner(members) {
+		return ErrLastOwner
+	}
+
+	b.Members = members
+	b.UpdatedAt = now
+
+	return nil
+}
+
+// RemoveMember removes the user from the address book, the last owner cannot be removed
+func (b *AddressBook) RemoveMember(userId uuid.UUID) error {
+	members := make([]Member, 0, len(b.Members))
+	for _, member := range b.Members {
+		if member.UserId != userId {
+			members = append(members, member)
+		}
+	}
+
+	if len(members) == len(b.Members) {
+		return ErrMemberNotFound
+	}
+	if !hasOwner(members) {
+		return ErrLastOwner
+	}
+
+	b.Members = members
+	b.UpdatedAt = time.Now().UTC()
+
+	return nil
+}
+
+func hasOwner(members []Member) bool {
+	for _, member := range members {
+		if member.Role == RoleOwner {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/davidterranova/contacts/pkg/user"
+	"github.com/google/uuid"
+)
+
+type AuditAction string
+
+const (
+	AuditActionCreate  AuditAction = "create"
+	AuditActionUpdate  AuditAction = "update"
+	AuditActionDelete  AuditAction = "delete"
+	AuditActionRestore AuditAction = "restore"
+	AuditActionMerge   AuditAction = "merge"
+	// AuditActionShare and AuditActionUnshare change the grants of a contact
+	AuditActionShare   AuditAction = "share"
+	AuditActionUnshare AuditAction = "unshare"
+	// AuditActionGroup and AuditActionUngroup add a contact to a group and remove it
+	AuditActionGroup   AuditAction = "group"
+	AuditActionUngroup AuditAction = "ungroup"
+	// AuditActionRelate and AuditActionUnrelate add a relationship to a contact and remove it
+	AuditActionRelate   AuditAction = "relate"
+	AuditActionUnrelate AuditAction = "unrelate"
+)
+
+const (
+	// FieldMergedFrom and FieldMergedInto are the pseudo fields of the merge audit entries holding the other contact id
+	FieldMergedFrom = "merged_from"
+	FieldMergedInto = "merged_into"
+	// CustomFieldPrefix prefixes the name of the custom fields in the audit entries
+	CustomFieldPrefix = "custom_fields."
+)
+
+// FieldChange holds the values of a contact field before and after a change, empty when the field is not set
+type FieldChange struct {
+	Field  string
+	Before string
+	After  string
+}
+
+// AuditEntry is an immutable record of a change made to a contact
+type AuditEntry struct {
+	Id        uuid.UUID
+	ContactId uuid.UUID
+	Actor     user.User
+	Action    AuditAction
+	At        time.Time
+	Changes   []FieldChange
+}
+
+func NewAuditEntry(actor user.User, action AuditAction, before Contact, after Contact) *AuditEntry {
+	return &AuditEntry{
+		Id:        uuid.New(),
+		ContactId: after.Id,
+		Actor:     actor,
+		Action:    action,
+		At:        time.Now().UTC(),
+		Changes:   auditChanges(before, after),
+	}
+}
+
+// NewMergeAuditEntry records a merge on one of the merged contacts, field is FieldMergedFrom on the survivor and
+// FieldMergedInto on the duplicate, holding the id of the other contact
+func NewMergeAuditEntry(actor user.User, before Contact, after Contact, field string, otherId uuid.UUID) *AuditEntry {
+	entry := NewAuditEntry(actor, AuditActionMerge, before, after)
+	entry.Changes = append(entry.Changes, FieldChange{Field: field, After: otherId.String()})
+
+	return entry
+}
+
+// Diff lists the contact fields whose value changed between before and after
+func Diff(before Contact, after Contact) []FieldChange {
+	fields := []FieldChange{
+		{Field: "first_name", Before: before.FirstName, After: after.FirstName},
+		{Field: "last_name", Before: before.LastName, After: after.LastName},
+		{Field: "email", Before: before.Email, After: after.Email},
+		{Field: "phone", Before: before.Phone, After: after.Phone},
+		{Field: "emails", Before: emailsValue(before.EmailAddresses()), After: emailsValue(after.EmailAddresses())},
+		{Field: "phones", Before: phonesValue(before.PhoneNumbers()), After: phonesValue(after.PhoneNumbers())},
+		{Field: "addresses", Before: addressesValue(before.Addresses), After: addressesValue(after.Addresses)},
+		{Field: "address_book_id", Before: uuidValue(before.AddressBookId), After: uuidValue(after.AddressBookId)},
+		{Field: "deleted_at", Before: timeValue(before.DeletedAt), After: timeValue(after.DeletedAt)},
+	}
+
+	changes := make([]FieldChange, 0, len(fields))
+	for _, field := range fields {
+		if field.Before != field.After {
+			changes = append(changes, field)
+		}
+	}
+
+	return append(changes, customFieldsChanges(before.CustomFields, after.CustomFields)...)
+}
+
+// auditChanges are the changes of Diff along with the changes of the grants, groups and relationships of the contact,
+// which are audited but left out of the events
+func auditChanges(before Contact, after Contact) []FieldChange {
+	fields := []FieldChange{
+		{Field: "grants", Before: grantsValue(before.Grants), After: grantsValue(after.Grants)},
+		{Field: "group_ids", Before: groupIdsValue(before.GroupIds), After: groupIdsValue(after.GroupIds)},
+	}
+
+	changes := Diff(before, after)
+	for _, field := range fields {
+		if field.Before != field.After {
+			changes = append(changes, field)
+		}
+	}
+
+	return append(changes, RelationshipChanges(before, after)...)
+}
+
+// RelationshipChanges lists the change of the relationships of the contact between before and after, if any
+func RelationshipChanges(before Contact, after Contact) []FieldChange {
+	b, a := relationshipsValue(before.Relationships), relationshipsValue(after.Relationships)
+	if b == a {
+		return nil
+	}
+
+	return []FieldChange{{Field: "relationships", Before: b, After: a}}
+}
+
+func grantsValue(grants []Grant) string {
+	values := make([]string, 0, len(grants))
+	for _, grant := range grants {
+		values = append(values, fmt.Sprintf("%s:%s", grant.UserId, grant.Permission))
+	}
+	sort.Strings(values)
+
+	return strings.Join(values, "; ")
+}
+
+func groupIdsValue(groupIds []uuid.UUID) string {
+	values := make([]string, 0, len(groupIds))
+	for _, groupId := range groupIds {
+		values = append(values, groupId.String())
+	}
+
+	return strings.Join(values, "; ")
+}
+
+func relationshipsValue(relationships []Relationship) string {
+	values := make([]string, 0, len(relationships))
+	for _, relationship := range relationships {
+		values = append(values, fmt.Sprintf("%s:%s:%s", relationship.Type, relationship.ContactId, relationship.OnDelete))
+	}
+
+	return strings.Join(values, "; ")
+}
+
+func uuidValue(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+
+	return id.String()
+}
+
+func timeValue(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,13 +12,40 @@ type Contact struct {
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	// Version is incremented by the repositories on every update
+	Version int
 
 	CreatedBy uuid.UUID
+	// AddressBookId is the address book owning the contact, uuid.Nil for personal contacts
+	AddressBookId uuid.UUID
 
 	FirstName string
 	LastName  string
-	Email     string
-	Phone     string
+	// Email and Phone are the primary email and phone, kept along with the lists for the clients knowing a single one
+	Email string
+	Phone string
+	// EmailStatus is the deliverability of the primary email, checked when it is set
+	EmailStatus EmailStatus
+	Emails      []EmailAddress
+	Phones      []PhoneNumber
+	Addresses   []PostalAddress
+	// CustomFields are the values of the custom fields defined by the schema of the contact creator
+	CustomFields map[string]CustomValue
+
+	// Grants share the contact with other users
+	Grants []Grant
+	// GroupIds are the groups the contact belongs to, sorted
+	GroupIds []uuid.UUID
+	// Relationships link the contact to other contacts, sorted by type and related contact
+	Relationships []Relationship
+
+	// DeletedAt is set while the contact is in the trash
+	DeletedAt *time.Time
+
+	// events are recorded by the use cases until a repository saves them in its outbox
+	events []Event
+	// auditEntries are recorded by the use cases until a repository saves them along with the contact
+	auditEntries []*AuditEntry
 }
 
 func New(createdBy uuid.UUID) *Contact {
@@ -27,6 +55,62 @@ func New(createdBy uuid.UUID) *Contact {
 		Id:        uuid.New(),
 		CreatedAt: now,
 		UpdatedAt: now,
+		Version:   1,
 		CreatedBy: createdBy,
 	}
 }
+
+// MatchesSearch tells whether the contact first name, last name, email or phone contains text
+func (c Contact) MatchesSearch(text string) bool {
+	text = strings.ToLower(text)
+	for _, value := range []string{c.FirstName, c.LastName, c.Email, c.Phone} {
+		if strings.Contains(strings.ToLower(value), text) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c Contact) IsDeleted() bool {
+	return c.DeletedAt != nil
+}
+
+// Trash moves the contact to the trash, it is purged once the retention period is over
+func (c *Contact) Trash() {
+	now := time.Now().UTC()
+	c.DeletedAt = &now
+	c.UpdatedAt = now
+}
+
+// Record adds an event to be saved in the repository outbox along with the contact
+func (c *Contact) Record(event Event) {
+	c.events = append(c.events, event)
+}
+
+// PullEvents returns the recorded events and forgets them, repositories call it when saving the contact
+func (c *Contact) PullEvents() []Event {
+	events := c.events
+	c.events = nil
+
+	return events
+}
+
+// Audit adds an audit entry to be saved by the repository along with the contact
+func (c *Contact) Audit(entry *AuditEntry) {
+	c.auditEntries = append(c.auditEntries, entry)
+}
+
+// PullAuditEntries returns the recorded audit entries and forgets them, repositories call it when saving the contact
+func (c *Contact) PullAuditEntries() []*AuditEntry {
+	entries := c.auditEntries
+	c.auditEntries = nil
+
+	return entries
+}
+
+// Restore takes the contact out of the trash
+func (c *Contact) Restore() {
+	c.DeletedAt = nil
+	c.UpdatedAt = time.Now().UTC()
+}
@@ -0,0 +1,267 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Label tells what an email, a phone or a postal address of a contact is used for
+type Label string
+
+const (
+	LabelWork   Label = "work"
+	LabelHome   Label = "home"
+	LabelMobile Label = "mobile"
+	LabelOther  Label = "other"
+)
+
+func (l Label) IsValid() bool {
+	return l == LabelWork || l == LabelHome || l == LabelMobile || l == LabelOther
+}
+
+type EmailAddress struct {
+	Label   Label
+	Address string
+	Primary bool
+}
+
+// EmailStatus tells whether mails can be delivered to the primary email of a contact
+type EmailStatus string
+
+const (
+	// EmailStatusUnverified emails were not checked, deliverability checks being disabled or the contact being saved
+	// before they were added
+	EmailStatusUnverified EmailStatus = "unverified"
+	// EmailStatusDeliverable emails have a domain accepting mails
+	EmailStatusDeliverable EmailStatus = "deliverable"
+	// EmailStatusUndeliverable emails have a domain without mail server, mails sent to them are lost
+	EmailStatusUndeliverable EmailStatus = "undeliverable"
+	// EmailStatusUnknown emails could not be checked, their domain lookup failed
+	EmailStatusUnknown EmailStatus = "unknown"
+)
+
+// OrUnverified returns the status, unverified when it is not set
+func (s EmailStatus) OrUnverified() EmailStatus {
+	if s == "" {
+		return EmailStatusUnverified
+	}
+
+	return s
+}
+
+type PhoneNumber struct {
+	Label Label
+	// Number is in E.164 format
+	Number  string
+	Primary bool
+}
+
+type PostalAddress struct {
+	Label      Label
+	Street     string
+	Locality   string
+	Region     string
+	PostalCode string
+	// Country is an ISO 3166-1 alpha-2 code
+	Country string
+	Primary bool
+}
+
+func (a PostalAddress) String() string {
+	var parts []string
+	for _, part := range []string{a.Street, a.PostalCode, a.Locality, a.Region, a.Country} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// EmailAddresses returns the emails of the contact, the primary one being the Email field. Contacts saved before
+// emails were listed only have the Email field, it is returned as their single email.
+func (c Contact) EmailAddresses() []EmailAddress {
+	if len(c.Emails) == 0 && c.Email != "" {
+		return []EmailAddress{{Label: LabelOther, Address: c.Email, Primary: true}}
+	}
+
+	return c.Emails
+}
+
+// PhoneNumbers returns the phones of the contact like EmailAddresses returns its emails
+func (c Contact) PhoneNumbers() []PhoneNumber {
+	if len(c.Phones) == 0 && c.Phone != "" {
+		return []PhoneNumber{{Label: LabelOther, Number: c.Phone, Primary: true}}
+	}
+
+	return c.Phones
+}
+
+// SetEmails replaces the emails of the contact, the first one marked primary stays primary, or the first one when
+// none is. The Email field is set to the primary email.
+func (c *Contact) SetEmails(emails []EmailAddress) {
+	c.Emails = append([]EmailAddress{}, emails...)
+	primary := primaryIndex(len(c.Emails), func(i int) bool { return c.Emails[i].Primary })
+
+	c.Email = ""
+	for i := range c.Emails {
+		c.Emails[i].Primary = i == primary
+		if i == primary {
+			c.Email = c.Emails[i].Address
+		}
+	}
+}
+
+// SetEmail makes the address the primary email: an email with this address becomes primary, otherwise it replaces
+// the address of the primary email, or it is added when the contact has no email
+func (c *Contact) SetEmail(address string) {
+	emails := append([]EmailAddress{}, c.EmailAddresses()...)
+	primary := indexOf(len(emails), func(i int) bool { return emails[i].Address == address })
+	if primary < 0 {
+		primary = indexOf(len(emails), func(i int) bool { return emails[i].Primary })
+	}
+	if primary < 0 {
+		primary = len(emails)
+		emails = append(emails, EmailAddress{Label: LabelOther})
+	}
+
+	emails[primary].Address = address
+	for i := range emails {
+		emails[i].Primary = i == primary
+	}
+	c.SetEmails(emails)
+}
+
+// SetPhones replaces the phones of the contact like SetEmails replaces its emails
+func (c *Contact) SetPhones(phones []PhoneNumber) {
+	c.Phones = append([]PhoneNumber{}, phones...)
+	primary := primaryIndex(len(c.Phones), func(i int) bool { return c.Phones[i].Primary })
+
+	c.Phone = ""
+	for i := range c.Phones {
+		c.Phones[i].Primary = i == primary
+		if i == primary {
+			c.Phone = c.Phones[i].Number
+		}
+	}
+}
+
+// SetPhone makes the number the primary phone like SetEmail makes an address the primary email
+func (c *Contact) SetPhone(number string) {
+	phones := append([]PhoneNumber{}, c.PhoneNumbers()...)
+	primary := indexOf(len(phones), func(i int) bool { return phones[i].Number == number })
+	if primary < 0 {
+		primary = indexOf(len(phones), func(i int) bool { return phones[i].Primary })
+	}
+	if primary < 0 {
+		primary = len(phones)
+		phones = append(phones, PhoneNumber{Label: LabelOther})
+	}
+
+	phones[primary].Number = number
+	for i := range phones {
+		phones[i].Primary = i == primary
+	}
+	c.SetPhones(phones)
+}
+
+// SetAddresses replaces the postal addresses of the contact, one of them is primary like with SetEmails
+func (c *Contact) SetAddresses(addresses []PostalAddress) {
+	c.Addresses = append([]PostalAddress{}, addresses...)
+	primary := primaryIndex(len(c.Addresses), func(i int) bool { return c.Addresses[i].Primary })
+
+	for i := range c.Addresses {
+		c.Addresses[i].Primary = i == primary
+	}
+}
+
+// MergeDetails adds the emails, phones and postal addresses of the other contact which the contact does not have,
+// the primary values of the contact are kept
+func (c *Contact) MergeDetails(other Contact) {
+	emails := append([]EmailAddress{}, c.EmailAddresses()...)
+	for _, email := range other.EmailAddresses() {
+		if indexOf(len(emails), func(i int) bool { return strings.EqualFold(emails[i].Address, email.Address) }) < 0 {
+			email.Primary = false
+			emails = append(emails, email)
+		}
+	}
+	c.SetEmails(emails)
+
+	phones := append([]PhoneNumber{}, c.PhoneNumbers()...)
+	for _, phone := range other.PhoneNumbers() {
+		if indexOf(len(phones), func(i int) bool { return phones[i].Number == phone.Number }) < 0 {
+			phone.Primary = false
+			phones = append(phones, phone)
+		}
+	}
+	c.SetPhones(phones)
+
+	addresses := append([]PostalAddress{}, c.Addresses...)
+	for _, address := range other.Addresses {
+		address.Primary = false
+		if indexOf(len(addresses), func(i int) bool { return addresses[i].sameAs(address) }) < 0 {
+			addresses = append(addresses, address)
+		}
+	}
+	c.SetAddresses(addresses)
+}
+
+func (a PostalAddress) sameAs(other PostalAddress) bool {
+	return strings.EqualFold(a.String(), other.String())
+}
+
+// primaryIndex returns the index of the first primary value, 0 when none is primary
+func primaryIndex(n int, primary func(i int) bool) int {
+	if i := indexOf(n, primary); i >= 0 {
+		return i
+	}
+
+	return 0
+}
+
+// indexOf returns the index of the first value matching, -1 when none does
+func indexOf(n int, match func(i int) bool) int {
+	for i := 0; i < n; i++ {
+		if match(i) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// emailsValue, phonesValue and addressesValue render the lists in the audit entries, the primary value is starred
+func emailsValue(emails []EmailAddress) string {
+	values := make([]string, 0, len(emails))
+	for _, email := range emails {
+		values = append(values, labeledValue(email.Label, email.Address, email.Primary))
+	}
+
+	return strings.Join(values, "; ")
+}
+
+func phonesValue(phones []PhoneNumber) string {
+	values := make([]string, 0, len(phones))
+	for _, phone := range phones {
+		values = append(values, labeledValue(phone.Label, phone.Number, phone.Primary))
+	}
+
+	return strings.Join(values, "; ")
+}
+
+func addressesValue(addresses []PostalAddress) string {
+	values := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		values = append(values, labeledValue(address.Label, address.String(), address.Primary))
+	}
+
+	return strings.Join(values, "; ")
+}
+
+func labeledValue(label Label, value string, primary bool) string {
+	if primary {
+		return fmt.Sprintf("%s: %s *", label, value)
+	}
+
+	return fmt.Sprintf("%s: %s", label, value)
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+type DuplicateReason string
+
+const (
+	DuplicateEmail DuplicateReason = "email"
+	DuplicatePhone DuplicateReason = "phone"
+	DuplicateName  DuplicateReason = "name"
+)
+
+// NormalizedEmail lowercases the email and drops the sub-address of its local part,
+// "John.Doe+work@Mail.com" is normalized to "john.doe@mail.com"
+func (c Contact) NormalizedEmail() string {
+	email := strings.ToLower(strings.TrimSpace(c.Email))
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok {
+		return email
+	}
+	local, _, _ = strings.Cut(local, "+")
+
+	return local + "@" + domain
+}
+
+// NormalizedPhone returns the E.164 form of the phone: its digits prefixed by +, the 00 international prefix
+// is read as +. Phones without an international prefix are returned as digits only.
+func (c Contact) NormalizedPhone() string {
+	phone := strings.TrimSpace(c.Phone)
+	international := strings.HasPrefix(phone, "+")
+
+	digits := strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, phone)
+	if strings.HasPrefix(digits, "00") {
+		digits, international = digits[2:], true
+	}
+	if digits == "" || !international {
+		return digits
+	}
+
+	return "+" + digits
+}
+
+// NormalizedName returns the lowercase words of the first and last names in alphabetical order,
+// so that swapped first and last names are the same name
+func (c Contact) NormalizedName() string {
+	words := strings.Fields(strings.ToLower(c.FirstName + " " + c.LastName))
+	sort.Strings(words)
+
+	return strings.Join(words, " ")
+}
+
+// NameSimilarity compares the normalized names of the contacts, from 0 for unrelated names to 1 for the same name.
+// It is the share of characters left untouched by the edit distance between both names.
+func (c Contact) NameSimilarity(other Contact) float64 {
+	a, b := []rune(c.NormalizedName()), []rune(other.NormalizedName())
+	longest := len(a)
+	if len(b) > longest {
+		longest = len(b)
+	}
+	if longest == 0 {
+		return 0
+	}
+
+	return 1 - float64(levenshtein(a, b))/float64(longest)
+}
+
+// levenshtein counts the insertions, deletions and substitutions turning a into b
+func levenshtein(a []rune, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+
+	return m
+}
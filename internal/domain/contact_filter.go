@@ -1,7 +1,109 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type Filter interface {
 	CreatedBy() *uuid.UUID
+	VisibleTo() *Visibility
+	AddressBook() *uuid.UUID
+	// Group only keeps the contacts belonging to the group
+	Group() *uuid.UUID
+	// RelatedTo only keeps the contacts having a relationship to the given contact
+	RelatedTo() *uuid.UUID
+	// Trashed only keeps the contacts in the trash, they are left out otherwise
+	Trashed() bool
+
+	// Search matches contacts whose first name, last name, email or phone contains the given text
+	Search() string
+	FirstName() *FieldMatch
+	LastName() *FieldMatch
+	Email() *FieldMatch
+	Phone() *FieldMatch
+	// CustomFields matches the custom fields by name, against their formatted value
+	CustomFields() map[string]FieldMatch
+	CreatedAt() *TimeRange
+	UpdatedAt() *TimeRange
+
+	Sort() Sort
+	// After only keeps contacts sorted strictly after the given key
+	After() *SortKey
+	// Limit is the maximum number of contacts to return, 0 means no limit
+	Limit() int
+}
+
+// Visibility matches the contacts a user can read: the ones they created, the ones shared with them
+// and the ones of the address books they are a member of
+type Visibility struct {
+	UserId         uuid.UUID
+	AddressBookIds []uuid.UUID
+}
+
+func (v Visibility) Contains(c *Contact) bool {
+	if c.CanRead(v.UserId) {
+		return true
+	}
+
+	for _, id := range v.AddressBookIds {
+		if c.AddressBookId != uuid.Nil && c.AddressBookId == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+type MatchMode string
+
+const (
+	MatchExact  MatchMode = "exact"
+	MatchPrefix MatchMode = "prefix"
+)
+
+// FieldMatch matches a field value exactly or by prefix, case insensitively
+type FieldMatch struct {
+	Value string
+	Mode  MatchMode
+}
+
+func (m FieldMatch) IsZero() bool {
+	return m.Value == ""
+}
+
+func (m FieldMatch) Matches(value string) bool {
+	if m.Mode == MatchPrefix {
+		return strings.HasPrefix(strings.ToLower(value), strings.ToLower(m.Value))
+	}
+
+	return strings.EqualFold(value, m.Value)
+}
+
+// TimeRange is a [From, To) time interval, a nil bound is unbounded
+type TimeRange struct {
+	From *time.Time
+	To   *time.Time
+}
+
+func (r TimeRange) IsZero() bool {
+	return r.From == nil && r.To == nil
+}
+
+func (r TimeRange) IsValid() bool {
+	return r.From == nil || r.To == nil || r.From.Before(*r.To)
+}
+
+func (r TimeRange) Contains(t time.Time) bool {
+	if r.From != nil && t.Before(*r.From) {
+		return false
+	}
+
+	if r.To != nil && !t.Before(*r.To) {
+		return false
+	}
+
+	return true
 }
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Permission string
+
+const (
+	PermissionRead  Permission = "read"
+	PermissionWrite Permission = "write"
+)
+
+func (p Permission) IsValid() bool {
+	return p == PermissionRead || p == PermissionWrite
+}
+
+// Grant gives a user other than the contact creator access to the contact
+type Grant struct {
+	UserId     uuid.UUID
+	Permission Permission
+	GrantedAt  time.Time
+}
+
+// CanRead tells whether the user created the contact or has been granted any access to it
+func (c Contact) CanRead(userId uuid.UUID) bool {
+	return c.CreatedBy == userId || c.grant(userId) != nil
+}
+
+// CanWrite tells whether the user created the contact or has been granted write access to it
+func (c Contact) CanWrite(userId uuid.UUID) bool {
+	if c.CreatedBy == userId {
+		return true
+	}
+
+	grant := c.grant(userId)
+	return grant != nil && grant.Permission == PermissionWrite
+}
+
+func (c Contact) grant(userId uuid.UUID) *Grant {
+	for i := range c.Grants {
+		if c.Grants[i].UserId == userId {
+			return &c.Grants[i]
+		}
+	}
+
+	return nil
+}
+
+// Share grants the permission to the user, replacing any previous grant.
+// Grants are copied so that the original contact is left untouched.
+func (c *Contact) Share(userId uuid.UUID, permission Permission) {
+	grants := make([]Grant, 0, len(c.Grants)+1)
+	for _, grant := range c.Grants {
+		if grant.UserId != userId {
+			grants = append(grants, grant)
+		}
+	}
+
+	c.Grants = append(grants, Grant{
+		UserId:     userId,
+		Permission: permission,
+		GrantedAt:  time.Now().UTC(),
+	})
+}
+
+// Unshare revokes the user grant and tells whether there was one
+func (c *Contact) Unshare(userId uuid.UUID) bool {
+	grants := make([]Grant, 0, len(c.Grants))
+	for _, grant := range c.Grants {
+		if grant.UserId != userId {
+			grants = append(grants, grant)
+		}
+	}
+
+	revoked := len(grants) != len(c.Grants)
+	c.Grants = grants
+
+	return revoked
+}
+
+// MergeGrants gives the users who could access the other contact the same access to this one: its creator is granted
+// write access and its grants are added, a user granted both read and write access keeps write access
+func (c *Contact) MergeGrants(other Contact) {
+	grants := append([]Grant{{UserId: other.CreatedBy, Permission: PermissionWrite}}, other.Grants...)
+	for _, grant := range grants {
+		if grant.UserId == c.CreatedBy {
+			continue
+		}
+
+		existing := c.grant(grant.UserId)
+		if existing == nil || (existing.Permission == PermissionRead && grant.Permission == PermissionWrite) {
+			c.Share(grant.UserId, grant.Permission)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ContactOperation is a write applied by the repositories within a batch: either the creation of a contact,
+// or the update of the contact Id by UpdateFn, like in the repositories Update
+type ContactOperation struct {
+	Create *Contact
+
+	Id       uuid.UUID
+	UpdateFn func(c Contact) (Contact, error)
+}
+
+func CreateOperation(contact *Contact) ContactOperation {
+	return ContactOperation{Create: contact}
+}
+
+func UpdateOperation(id uuid.UUID, updateFn func(c Contact) (Contact, error)) ContactOperation {
+	return ContactOperation{Id: id, UpdateFn: updateFn}
+}
+
+// OperationError is returned by the repositories batches, it tells which operation failed
+type OperationError struct {
+	Index int
+	Err   error
+}
+
+func (e *OperationError) Error() string {
+	return fmt.Sprintf("operation %d: %s", e.Index, e.Err)
+}
+
+func (e *OperationError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RelationshipType tells how a contact relates to another one, relationships are directional:
+// a contact works at its organization, reports to its manager or is helped by its assistant
+type RelationshipType string
+
+const (
+	RelationshipOrganization RelationshipType = "organization"
+	RelationshipManager      RelationshipType = "manager"
+	RelationshipSpouse       RelationshipType = "spouse"
+	RelationshipAssistant    RelationshipType = "assistant"
+)
+
+func (t RelationshipType) IsValid() bool {
+	switch t {
+	case RelationshipOrganization, RelationshipManager, RelationshipSpouse, RelationshipAssistant:
+		return true
+	default:
+		return false
+	}
+}
+
+// OnDelete tells what happens to a relationship when the related contact is deleted
+type OnDelete string
+
+const (
+	// OnDeleteCascade deletes the contact along with the related contact
+	OnDeleteCascade OnDelete = "cascade"
+	// OnDeleteRestrict prevents the deletion of the related contact
+	OnDeleteRestrict OnDelete = "restrict"
+	// OnDeleteNullify removes the relationship, it is the default
+	OnDeleteNullify OnDelete = "nullify"
+)
+
+func (o OnDelete) IsValid() bool {
+	switch o {
+	case OnDeleteCascade, OnDeleteRestrict, OnDeleteNullify:
+		return true
+	default:
+		return false
+	}
+}
+
+// Relationship links the contact holding it to the related contact
+type Relationship struct {
+	Type      RelationshipType
+	ContactId uuid.UUID
+	OnDelete  OnDelete
+	CreatedAt time.Time
+}
+
+// RelatesTo tells whether the contact has any relationship to the other contact
+func (c Contact) RelatesTo(contactId uuid.UUID) bool {
+	return len(c.RelationshipsTo(contactId)) > 0
+}
+
+// RelationshipsTo returns the relationships of the contact to the other contact
+func (c Contact) RelationshipsTo(contactId uuid.UUID) []Relationship {
+	var relationships []Relationship
+	for _, r := range c.Relationships {
+		if r.ContactId == contactId {
+			relationships = append(relationships, r)
+		}
+	}
+
+	return relationships
+}
+
+// Relate adds the relationship to the contact, replacing the one of the same type to the same contact.
+// Relationships are copied so that the original contact is left untouched, they are kept sorted.
+func (c *Contact) Relate(relationship Relationship) {
+	relationships := make([]Relationship, 0, len(c.Relationships)+1)
+	for _, r := range c.Relationships {
+		if r.Type != relationship.Type || r.ContactId != relationship.ContactId {
+			relationships = append(relationships, r)
+		}
+	}
+	c.Relationships = sortRelationships(append(relationships, relationship))
+}
+
+// Unrelate removes the relationship of the given type to the other contact and tells whether it existed
+func (c *Contact) Unrelate(relationshipType RelationshipType, contactId uuid.UUID) bool {
+	return c.removeRelationships(func(r Relationship) bool {
+		return r.Type == relationshipType && r.ContactId == contactId
+	})
+}
+
+// UnrelateFrom removes all the relationships to the other contact and tells whether there was any
+func (c *Contact) UnrelateFrom(contactId uuid.UUID) bool {
+	return c.removeRelationships(func(r Relationship) bool {
+		return r.ContactId == contactId
+	})
+}
+
+// MergeRelationships adds the relationships of the other contact, except those to the contact itself
+func (c *Contact) MergeRelationships(other Contact) {
+	for _, r := range other.Relationships {
+		if r.ContactId != c.Id && !c.hasRelationship(r.Type, r.ContactId) {
+			c.Relate(r)
+		}
+	}
+}
+
+// Repoint moves the relationships to a contact onto another one, the ones the contact already has to it are kept
+func (c *Contact) Repoint(from uuid.UUID, to uuid.UUID) {
+	for _, r := range c.RelationshipsTo(from) {
+		c.Unrelate(r.Type, from)
+		if to != c.Id && !c.hasRelationship(r.Type, to) {
+			r.ContactId = to
+			c.Relate(r)
+		}
+	}
+}
+
+func (c Contact) hasRelationship(relationshipType RelationshipType, contactId uuid.UUID) bool {
+	for _, r := range c.RelationshipsTo(contactId) {
+		if r.Type == relationshipType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Contact) removeRelationships(match func(r Relationship) bool) bool {
+	relationships := make([]Relationship, 0, len(c.Relationships))
+	for _, r := range c.Relationships {
+		if !match(r) {
+			relationships = append(relationships, r)
+		}
+	}
+
+	removed := len(relationships) != len(c.Relationships)
+	c.Relationships = relationships
+	if len(relationships) == 0 {
+		c.Relationships = nil
+	}
+
+	return removed
+}
+
+func sortRelationships(relationships []Relationship) []Relationship {
+	sort.Slice(relationships, func(i, j int) bool {
+		if relationships[i].Type != relationships[j].Type {
+			return relationships[i].Type < relationships[j].Type
+		}
+		return relationships[i].ContactId.String() < relationships[j].ContactId.String()
+	})
+
+	return relationships
+}
@@ -0,0 +1,360 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidSchema     = errors.New("invalid contact schema")
+	ErrInvalidFieldValue = errors.New("invalid custom field value")
+)
+
+// MaxCustomFields is the maximum number of custom fields of a schema
+const MaxCustomFields = 50
+
+// DateLayout is the format of date custom values
+const DateLayout = "2006-01-02"
+
+var fieldNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+
+type FieldType string
+
+const (
+	FieldTypeString FieldType = "string"
+	FieldTypeNumber FieldType = "number"
+	FieldTypeDate   FieldType = "date"
+	FieldTypeEnum   FieldType = "enum"
+	FieldTypeURL    FieldType = "url"
+)
+
+func (t FieldType) IsValid() bool {
+	return t == FieldTypeString || t == FieldTypeNumber || t == FieldTypeDate || t == FieldTypeEnum || t == FieldTypeURL
+}
+
+// FieldDefinition is a typed custom field of the contacts of an owner
+type FieldDefinition struct {
+	// Name is the key of the field values, lowercase letters, digits and underscores starting with a letter
+	Name     string
+	Type     FieldType
+	Required bool
+	// Min and Max bound number values and the length of string and url values, nil is unbounded
+	Min *float64
+	Max *float64
+	// Pattern is a regular expression string values must match
+	Pattern string
+	// Options are the values allowed by enum fields
+	Options []string
+}
+
+// ContactSchema defines the custom fields of the contacts created by its owner, a user without schema has no
+// custom fields
+type ContactSchema struct {
+	OwnerId uuid.UUID
+	Fields  []FieldDefinition
+	// DefaultPhoneRegion is the ISO 3166-1 alpha-2 code of the region the phones of the contacts are read in when they
+	// have no international prefix, none when empty
+	DefaultPhoneRegion string
+	UpdatedAt          time.Time
+}
+
+func NewContactSchema(ownerId uuid.UUID, fields []FieldDefinition) (*ContactSchema, error) {
+	schema := &ContactSchema{
+		OwnerId:   ownerId,
+		Fields:    fields,
+		UpdatedAt: time.Now().UTC(),
+	}
+
+	return schema, schema.Validate()
+}
+
+func (s ContactSchema) Field(name string) (FieldDefinition, bool) {
+	for _, field := range s.Fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+
+	return FieldDefinition{}, false
+}
+
+// Validate fails with ErrInvalidSchema when a field is not well defined or when two fields share a name
+func (s ContactSchema) Validate() error {
+	if len(s.Fields) > MaxCustomFields {
+		return fmt.Errorf("%w: more than %d fields", ErrInvalidSchema, MaxCustomFields)
+	}
+
+	names := map[string]bool{}
+	for _, field := range s.Fields {
+		if names[field.Name] {
+			return fmt.Errorf("%w: field %s is defined twice", ErrInvalidSchema, field.Name)
+		}
+		names[field.Name] = true
+
+		err := field.validate()
+		if err != nil {
+			return fmt.Errorf("%w: field %s: %s", ErrInvalidSchema, field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (d FieldDefinition) validate() error {
+	if !fieldNamePattern.MatchString(d.Name) {
+		return errors.New("name must be lowercase letters, digits and underscores starting with a letter")
+	}
+	if !d.Type.IsValid() {
+		return fmt.Errorf("unknown type %q", d.Type)
+	}
+
+	if d.Type == FieldTypeEnum {
+		if len(d.Options) == 0 {
+			return errors.New("enum fields need options")
+		}
+		options := map[string]bool{}
+		for _, option := range d.Options {
+			if option == "" || options[option] {
+				return fmt.Errorf("option %q is empty or duplicated", option)
+			}
+			options[option] = true
+		}
+	} else if len(d.Options) > 0 {
+		return errors.New("only enum fields have options")
+	}
+
+	if d.Pattern != "" {
+		if d.Type != FieldTypeString {
+			return errors.New("only string fields have a pattern")
+		}
+		if _, err := regexp.Compile(d.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern: %s", err)
+		}
+	}
+
+	if d.Min != nil || d.Max != nil {
+		if d.Type == FieldTypeDate || d.Type == FieldTypeEnum {
+			return fmt.Errorf("%s fields have no bounds", d.Type)
+		}
+		if d.Type != FieldTypeNumber && ((d.Min != nil && *d.Min < 0) || (d.Max != nil && *d.Max < 0)) {
+			return errors.New("length bounds cannot be negative")
+		}
+		if d.Min != nil && d.Max != nil && *d.Min > *d.Max {
+			return errors.New("min is greater than max")
+		}
+	}
+
+	return nil
+}
+
+// Parse checks a value decoded from JSON against the definition: numbers are expected as float64, the other types
+// as strings, dates formatted as 2006-01-02 and urls being absolute http or https ones
+func (d FieldDefinition) Parse(value any) (CustomValue, error) {
+	if d.Type == FieldTypeNumber {
+		number, ok := value.(float64)
+		if !ok {
+			return CustomValue{}, fmt.Errorf("%w: %s must be a number", ErrInvalidFieldValue, d.Name)
+		}
+		if err := d.checkBounds(number, "value"); err != nil {
+			return CustomValue{}, err
+		}
+
+		return CustomValue{Type: d.Type, Number: number}, nil
+	}
+
+	text, ok := value.(string)
+	if !ok || text == "" {
+		return CustomValue{}, fmt.Errorf("%w: %s must be a non empty string", ErrInvalidFieldValue, d.Name)
+	}
+
+	switch d.Type {
+	case FieldTypeDate:
+		if _, err := time.Parse(DateLayout, text); err != nil {
+			return CustomValue{}, fmt.Errorf("%w: %s must be a date formatted as %s", ErrInvalidFieldValue, d.Name, DateLayout)
+		}
+	case FieldTypeEnum:
+		if !contains(d.Options, text) {
+			return CustomValue{}, fmt.Errorf("%w: %s must be one of %v", ErrInvalidFieldValue, d.Name, d.Options)
+		}
+	case FieldTypeURL:
+		u, err := url.Parse(text)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return CustomValue{}, fmt.Errorf("%w: %s must be an http or https url", ErrInvalidFieldValue, d.Name)
+		}
+	case FieldTypeString:
+		if d.Pattern != "" && !regexp.MustCompile(d.Pattern).MatchString(text) {
+			return CustomValue{}, fmt.Errorf("%w: %s must match %s", ErrInvalidFieldValue, d.Name, d.Pattern)
+		}
+	}
+
+	if d.Type == FieldTypeString || d.Type == FieldTypeURL {
+		if err := d.checkBounds(float64(utf8.RuneCountInString(text)), "length"); err != nil {
+			return CustomValue{}, err
+		}
+	}
+
+	return CustomValue{Type: d.Type, Text: text}, nil
+}
+
+func (d FieldDefinition) checkBounds(value float64, what string) error {
+	if d.Min != nil && value < *d.Min {
+		return fmt.Errorf("%w: %s %s must be at least %s", ErrInvalidFieldValue, d.Name, what, formatNumber(*d.Min))
+	}
+	if d.Max != nil && value > *d.Max {
+		return fmt.Errorf("%w: %s %s must be at most %s", ErrInvalidFieldValue, d.Name, what, formatNumber(*d.Max))
+	}
+
+	return nil
+}
+
+// CustomValue is the value of a custom field: numbers are held by Number, the other types by Text
+type CustomValue struct {
+	Type   FieldType
+	Text   string
+	Number float64
+}
+
+// Value returns the value as it is encoded in JSON, a float64 for numbers and a string otherwise
+func (v CustomValue) Value() any {
+	if v.Type == FieldTypeNumber {
+		return v.Number
+	}
+
+	return v.Text
+}
+
+// String formats the value, numbers with the fewest digits needed, it is the value custom fields are filtered on
+func (v CustomValue) String() string {
+	if v.Type == FieldTypeNumber {
+		return formatNumber(v.Number)
+	}
+
+	return v.Text
+}
+
+// ParseCustomValue reads a value formatted by CustomValue.String
+func ParseCustomValue(fieldType FieldType, value string) (CustomValue, error) {
+	if fieldType != FieldTypeNumber {
+		return CustomValue{Type: fieldType, Text: value}, nil
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return CustomValue{}, fmt.Errorf("%w: %s", ErrInvalidFieldValue, err)
+	}
+
+	return CustomValue{Type: fieldType, Number: number}, nil
+}
+
+// SetCustomFields checks the values against the schema of the contact owner before setting them, a nil value
+// removes the field. It fails when a field is unknown to the schema or when a required field is left without value,
+// the values of fields removed from the schema are kept until they are set.
+func (c *Contact) SetCustomFields(schema ContactSchema, values map[string]any) error {
+	fields := make(map[string]CustomValue, len(c.CustomFields)+len(values))
+	for name, value := range c.CustomFields {
+		fields[name] = value
+	}
+
+	for name, value := range values {
+		definition, ok := schema.Field(name)
+		if !ok {
+			return fmt.Errorf("%w: unknown field %s", ErrInvalidFieldValue, name)
+		}
+
+		if value == nil {
+			delete(fields, name)
+			continue
+		}
+
+		customValue, err := definition.Parse(value)
+		if err != nil {
+			return err
+		}
+		fields[name] = customValue
+	}
+
+	for _, definition := range schema.Fields {
+		if _, ok := fields[definition.Name]; definition.Required && !ok {
+			return fmt.Errorf("%w: %s is required", ErrInvalidFieldValue, definition.Name)
+		}
+	}
+
+	c.CustomFields = fields
+	if len(fields) == 0 {
+		c.CustomFields = nil
+	}
+
+	return nil
+}
+
+// MergeCustomFields sets the custom fields of the other contact the contact has no value for, the fields are copied
+// so that the contacts do not share them
+func (c *Contact) MergeCustomFields(other Contact) {
+	if len(other.CustomFields) == 0 {
+		return
+	}
+
+	fields := make(map[string]CustomValue, len(c.CustomFields)+len(other.CustomFields))
+	for name, value := range other.CustomFields {
+		fields[name] = value
+	}
+	for name, value := range c.CustomFields {
+		fields[name] = value
+	}
+	c.CustomFields = fields
+}
+
+// customFieldsChanges lists the custom fields whose value differs, sorted by name
+func customFieldsChanges(before map[string]CustomValue, after map[string]CustomValue) []FieldChange {
+	names := make([]string, 0, len(before)+len(after))
+	for name := range before {
+		names = append(names, name)
+	}
+	for name := range after {
+		if _, ok := before[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var changes []FieldChange
+	for _, name := range names {
+		b, a := customFieldValue(before, name), customFieldValue(after, name)
+		if b != a {
+			changes = append(changes, FieldChange{Field: CustomFieldPrefix + name, Before: b, After: a})
+		}
+	}
+
+	return changes
+}
+
+func customFieldValue(fields map[string]CustomValue, name string) string {
+	value, ok := fields[name]
+	if !ok {
+		return ""
+	}
+
+	return value.String()
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SortField string
+
+const (
+	SortByLastName  SortField = "last_name"
+	SortByFirstName SortField = "first_name"
+	SortByCreatedAt SortField = "created_at"
+	SortByUpdatedAt SortField = "updated_at"
+)
+
+func (f SortField) IsValid() bool {
+	switch f {
+	case SortByLastName, SortByFirstName, SortByCreatedAt, SortByUpdatedAt:
+		return true
+	default:
+		return false
+	}
+}
+
+// Sort orders contacts by a field, ties are broken by contact id so that the order is stable
+type Sort struct {
+	Field SortField
+	Desc  bool
+}
+
+// SortKey is the position of a contact in a sorted list, Text or Time holds the sorted field value
+type SortKey struct {
+	Text string
+	Time time.Time
+	Id   uuid.UUID
+}
+
+func (s Sort) Key(c *Contact) SortKey {
+	key := SortKey{Id: c.Id}
+	switch s.Field {
+	case SortByLastName:
+		key.Text = c.LastName
+	case SortByFirstName:
+		key.Text = c.FirstName
+	case SortByUpdatedAt:
+		key.Time = c.UpdatedAt
+	default:
+		key.Time = c.CreatedAt
+	}
+
+	return key
+}
+
+// Compare returns a negative number when a comes before b, a positive one when it comes after and 0 when they are equal
+func (s Sort) Compare(a, b SortKey) int {
+	result := strings.Compare(a.Text, b.Text)
+	if result == 0 {
+		result = a.Time.Compare(b.Time)
+	}
+	if result == 0 {
+		result = strings.Compare(a.Id.String(), b.Id.String())
+	}
+
+	if s.Desc {
+		return -result
+	}
+
+	return result
+}
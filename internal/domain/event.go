@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	EventContactCreated = "contact.created"
+	EventContactUpdated = "contact.updated"
+	EventContactDeleted = "contact.deleted"
+)
+
+// Event is a change made to a contact, recorded by the use cases on the contact
+// and saved by the repositories in their outbox along with the contact
+type Event interface {
+	EventId() uuid.UUID
+	EventName() string
+	AggregateId() uuid.UUID
+	OccurredAt() time.Time
+}
+
+// EventHeader holds the fields shared by all the contact events
+type EventHeader struct {
+	Id        uuid.UUID
+	ContactId uuid.UUID
+	At        time.Time
+}
+
+func newEventHeader(contactId uuid.UUID) EventHeader {
+	return EventHeader{
+		Id:        uuid.New(),
+		ContactId: contactId,
+		At:        time.Now().UTC(),
+	}
+}
+
+func (h EventHeader) EventId() uuid.UUID {
+	return h.Id
+}
+
+func (h EventHeader) AggregateId() uuid.UUID {
+	return h.ContactId
+}
+
+func (h EventHeader) OccurredAt() time.Time {
+	return h.At
+}
+
+type ContactCreated struct {
+	EventHeader
+	Contact Contact
+}
+
+func NewContactCreated(c Contact) ContactCreated {
+	c.events = nil
+	c.auditEntries = nil
+
+	return ContactCreated{
+		EventHeader: newEventHeader(c.Id),
+		Contact:     c,
+	}
+}
+
+func (ContactCreated) EventName() string {
+	return EventContactCreated
+}
+
+type ContactUpdated struct {
+	EventHeader
+	Changes []FieldChange
+}
+
+func NewContactUpdated(contactId uuid.UUID, changes []FieldChange) ContactUpdated {
+	return ContactUpdated{
+		EventHeader: newEventHeader(contactId),
+		Changes:     changes,
+	}
+}
+
+func (ContactUpdated) EventName() string {
+	return EventContactUpdated
+}
+
+// ContactDeleted is recorded when a contact is moved to the trash
+type ContactDeleted struct {
+	EventHeader
+}
+
+func NewContactDeleted(contactId uuid.UUID) ContactDeleted {
+	return ContactDeleted{
+		EventHeader: newEventHeader(contactId),
+	}
+}
+
+func (ContactDeleted) EventName() string {
+	return EventContactDeleted
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Group tags contacts for its creator, a contact may belong to the groups of every user who can read it
+type Group struct {
+	Id uuid.UUID
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	CreatedBy uuid.UUID
+
+	Name string
+}
+
+func NewGroup(createdBy uuid.UUID, name string) *Group {
+	now := time.Now().UTC()
+
+	return &Group{
+		Id:        uuid.New(),
+		CreatedAt: now,
+		UpdatedAt: now,
+		CreatedBy: createdBy,
+		Name:      name,
+	}
+}
+
+// InGroup tells whether the contact belongs to the group
+func (c Contact) InGroup(groupId uuid.UUID) bool {
+	for _, id := range c.GroupIds {
+		if id == groupId {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AddToGroup adds the contact to the group and tells whether it was not already a member.
+// Group ids are copied so that the original contact is left untouched, they are kept sorted.
+func (c *Contact) AddToGroup(groupId uuid.UUID) bool {
+	if c.InGroup(groupId) {
+		return false
+	}
+
+	groupIds := make([]uuid.UUID, 0, len(c.GroupIds)+1)
+	groupIds = append(groupIds, c.GroupIds...)
+	c.GroupIds = sortGroupIds(append(groupIds, groupId))
+
+	return true
+}
+
+// RemoveFromGroup removes the contact from the group and tells whether it was a member
+func (c *Contact) RemoveFromGroup(groupId uuid.UUID) bool {
+	groupIds := make([]uuid.UUID, 0, len(c.GroupIds))
+	for _, id := range c.GroupIds {
+		if id != groupId {
+			groupIds = append(groupIds, id)
+		}
+	}
+
+	removed := len(groupIds) != len(c.GroupIds)
+	c.GroupIds = groupIds
+	if len(groupIds) == 0 {
+		c.GroupIds = nil
+	}
+
+	return removed
+}
+
+// MergeGroups adds the contact to the groups of the other contact
+func (c *Contact) MergeGroups(other Contact) {
+	for _, groupId := range other.GroupIds {
+		c.AddToGroup(groupId)
+	}
+}
+
+func sortGroupIds(groupIds []uuid.UUID) []uuid.UUID {
+	sort.Slice(groupIds, func(i, j int) bool {
+		return groupIds[i].String() < groupIds[j].String()
+	})
+
+	return groupIds
+}
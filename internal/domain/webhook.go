@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook notifies its URL of the contact events it subscribes to, for the contacts its creator can read
+type Webhook struct {
+	Id uuid.UUID
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	CreatedBy uuid.UUID
+
+	URL    string
+	Events []string
+	// Secret signs the deliveries so that receivers can authenticate them
+	Secret string
+}
+
+func NewWebhook(createdBy uuid.UUID, url string, events []string, secret string) *Webhook {
+	now := time.Now().UTC()
+
+	return &Webhook{
+		Id:        uuid.New(),
+		CreatedAt: now,
+		UpdatedAt: now,
+		CreatedBy: createdBy,
+		URL:       url,
+		Events:    events,
+		Secret:    secret,
+	}
+}
+
+// Subscribes tells whether the webhook is notified of the given event
+func (w Webhook) Subscribes(eventName string) bool {
+	for _, event := range w.Events {
+		if event == eventName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Sign returns the signature of a delivery body sent at the given time, an hex encoded
+// HMAC-SHA256 of the unix timestamp and the body joined by a dot
+func (w Webhook) Sign(at time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(w.Secret))
+	mac.Write([]byte(strconv.FormatInt(at.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type DeliveryStatus string
+
+const (
+	// DeliveryStatusPending deliveries are attempted once their next attempt is due
+	DeliveryStatusPending DeliveryStatus = "pending"
+	// DeliveryStatusSucceeded deliveries were acknowledged by the receiver
+	DeliveryStatusSucceeded DeliveryStatus = "succeeded"
+	// DeliveryStatusDead deliveries failed too many times and are not attempted anymore
+	DeliveryStatusDead DeliveryStatus = "dead"
+)
+
+// RetryPolicy spaces the attempts of a failing delivery with an exponential backoff
+type RetryPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+}
+
+// Delay returns how long to wait after the given failed attempt, the backoff doubling on every attempt
+func (p RetryPolicy) Delay(attempt int) time.Duration {
+	delay := p.Backoff
+	for i := 1; i < attempt && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+
+	return delay
+}
+
+// Delivery is the notification of an event to a webhook, along with the outcome of its last attempt
+type Delivery struct {
+	Id        uuid.UUID
+	WebhookId uuid.UUID
+	EventId   uuid.UUID
+	EventName string
+	Payload   []byte
+
+	CreatedAt     time.Time
+	Status        DeliveryStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastAttemptAt *time.Time
+	// ResponseStatus is the HTTP status of the last attempt, 0 when the receiver could not be reached
+	ResponseStatus int
+	LastError      string
+}
+
+func NewDelivery(webhookId uuid.UUID, event Event, payload []byte) *Delivery {
+	now := time.Now().UTC()
+
+	return &Delivery{
+		Id:            uuid.New(),
+		WebhookId:     webhookId,
+		EventId:       event.EventId(),
+		EventName:     event.EventName(),
+		Payload:       payload,
+		CreatedAt:     now,
+		Status:        DeliveryStatusPending,
+		NextAttemptAt: now,
+	}
+}
+
+func (d *Delivery) Succeed(at time.Time, responseStatus int) {
+	d.attempt(at, responseStatus)
+	d.Status = DeliveryStatusSucceeded
+	d.LastError = ""
+}
+
+// Fail schedules the next attempt of the delivery, or gives up once the policy attempts are exhausted
+func (d *Delivery) Fail(at time.Time, responseStatus int, reason string, policy RetryPolicy) {
+	d.attempt(at, responseStatus)
+	d.LastError = reason
+	if d.Attempts >= policy.MaxAttempts {
+		d.Status = DeliveryStatusDead
+		return
+	}
+
+	d.NextAttemptAt = at.Add(policy.Delay(d.Attempts))
+}
+
+func (d *Delivery) attempt(at time.Time, responseStatus int) {
+	d.Attempts++
+	d.LastAttemptAt = &at
+	d.ResponseStatus = responseStatus
+}
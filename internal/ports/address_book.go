@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/davidterranova/contacts/internal/domain"
+)
+
+// ErrAddressBookNotEmpty is returned when deleting an address book which still owns contacts, the ones in the trash
+// included. The repositories check it within the deletion, along with the closure.
+var ErrAddressBookNotEmpty = errors.New("address book still owns contacts")
+
+// sortAddressBooks orders address books by name then id, it is shared by the repositories listing in memory
+func sortAddressBooks(addressBooks []*domain.AddressBook) []*domain.AddressBook {
+	sort.Slice(addressBooks, func(i, j int) bool {
+		if addressBooks[i].Name != addressBooks[j].Name {
+			return addressBooks[i].Name < addressBooks[j].Name
+		}
+		return addressBooks[i].Id.String() < addressBooks[j].Id.String()
+	})
+
+	return addressBooks
+}
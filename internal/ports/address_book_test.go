@@ -0,0 +1,87 @@
+package ports
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type addressBookRepository interface {
+	Create(ctx context.Context, addressBook *domain.AddressBook) (*domain.AddressBook, error)
+	Delete(ctx context.Context, id uuid.UUID, deleterFn func(b domain.AddressBook) error) error
+}
+
+type addressBookContacts interface {
+	Create(ctx context.Context, contact *domain.Contact) (*domain.Contact, error)
+	Update(ctx context.Context, id uuid.UUID, updateFn func(c domain.Contact) (domain.Contact, error)) (*domain.Contact, error)
+	Delete(ctx context.Context, id uuid.UUID, deleterFn func(c domain.Contact) error) error
+}
+
+func TestAddressBookRepositoriesDelete(t *testing.T) {
+	t.Parallel()
+
+	repositories := map[string]func(t *testing.T) (addressBookRepository, addressBookContacts){
+		"in memory": func(t *testing.T) (addressBookRepository, addressBookContacts) {
+			contacts := NewInMemoryContactRepository()
+			return NewInMemoryAddressBookRepository(contacts), contacts
+		},
+		"file": func(t *testing.T) (addressBookRepository, addressBookContacts) {
+			store, contacts := openFileContactRepository(t, t.TempDir())
+			return NewFileAddressBookRepository(store), contacts
+		},
+		"sql": func(t *testing.T) (addressBookRepository, addressBookContacts) {
+			contacts := testSQLContactRepository(t)
+			books, err := NewSQLAddressBookRepository(contacts.db, DriverSQLite)
+			require.NoError(t, err)
+			return books, contacts
+		},
+	}
+
+	for name, newRepo := range repositories {
+		newRepo := newRepo
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			books, contacts := newRepo(t)
+			owner := uuid.New()
+			addressBook, err := books.Create(ctx, domain.NewAddressBook(owner, "Team"))
+			require.NoError(t, err)
+
+			contact := testContact(owner)
+			contact.AddressBookId = addressBook.Id
+			_, err = contacts.Create(ctx, contact)
+			require.NoError(t, err)
+			_, err = contacts.Create(ctx, testContact(owner))
+			require.NoError(t, err)
+
+			err = books.Delete(ctx, addressBook.Id, func(b domain.AddressBook) error { return nil })
+			assert.ErrorIs(t, err, ErrAddressBookNotEmpty)
+
+			// contacts in the trash are still owned by the address book
+			_, err = contacts.Update(ctx, contact.Id, func(c domain.Contact) (domain.Contact, error) {
+				c.Trash()
+				return c, nil
+			})
+			require.NoError(t, err)
+			err = books.Delete(ctx, addressBook.Id, func(b domain.AddressBook) error { return nil })
+			assert.ErrorIs(t, err, ErrAddressBookNotEmpty)
+
+			// the closure error is returned first
+			err = books.Delete(ctx, addressBook.Id, func(b domain.AddressBook) error { return assert.AnError })
+			assert.ErrorIs(t, err, assert.AnError)
+
+			err = contacts.Delete(ctx, contact.Id, func(c domain.Contact) error { return nil })
+			require.NoError(t, err)
+			err = books.Delete(ctx, addressBook.Id, func(b domain.AddressBook) error { return nil })
+			require.NoError(t, err)
+
+			err = books.Delete(ctx, addressBook.Id, func(b domain.AddressBook) error { return nil })
+			assert.ErrorIs(t, err, ErrNotFound)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package ports
+
+import (
+	"sort"
+	"time"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/pkg/user"
+	"github.com/google/uuid"
+)
+
+// auditRecord is the serialized form of an audit entry, the actor is stored by id and type
+type auditRecord struct {
+	Id        uuid.UUID            `json:"id"`
+	ContactId uuid.UUID            `json:"contact_id"`
+	ActorId   uuid.UUID            `json:"actor_id"`
+	ActorType user.UserType        `json:"actor_type"`
+	Action    domain.AuditAction   `json:"action"`
+	At        time.Time            `json:"at"`
+	Changes   []domain.FieldChange `json:"changes"`
+}
+
+func toAuditRecord(entry *domain.AuditEntry) auditRecord {
+	return auditRecord{
+		Id:        entry.Id,
+		ContactId: entry.ContactId,
+		ActorId:   entry.Actor.Id(),
+		ActorType: entry.Actor.Type(),
+		Action:    entry.Action,
+		At:        entry.At.UTC(),
+		Changes:   entry.Changes,
+	}
+}
+
+func (r auditRecord) toDomain() *domain.AuditEntry {
+	return &domain.AuditEntry{
+		Id:        r.Id,
+		ContactId: r.ContactId,
+		Actor:     user.New(r.ActorId, r.ActorType),
+		Action:    r.Action,
+		At:        r.At.UTC(),
+		Changes:   r.Changes,
+	}
+}
+
+// sortAuditEntries orders audit entries from the oldest to the most recent, it is shared by the repositories listing in memory
+func sortAuditEntries(entries []*domain.AuditEntry) []*domain.AuditEntry {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].At.Before(entries[j].At)
+	})
+
+	return entries
+}
@@ -0,0 +1,137 @@
+package ports
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/pkg/user"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type auditRepository interface {
+	Append(ctx context.Context, entry *domain.AuditEntry) error
+	ListByContact(ctx context.Context, contactId uuid.UUID) ([]*domain.AuditEntry, error)
+}
+
+func TestAuditRepositories(t *testing.T) {
+	t.Parallel()
+
+	repositories := map[string]func(t *testing.T) auditRepository{
+		"in memory": func(t *testing.T) auditRepository { return NewInMemoryAuditRepository(NewInMemoryContactRepository()) },
+		"file": func(t *testing.T) auditRepository {
+			store, _ := openFileContactRepository(t, t.TempDir())
+			return NewFileAuditRepository(store)
+		},
+		"sql": func(t *testing.T) auditRepository {
+			repo, err := NewSQLAuditRepository(testSQLContactRepository(t).db, DriverSQLite)
+			require.NoError(t, err)
+			return repo
+		},
+	}
+
+	for name, newRepo := range repositories {
+		newRepo := newRepo
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			repo := newRepo(t)
+			actor := user.New(uuid.New(), user.UserTypeAuthenticated)
+
+			contact := *testContact(actor.Id())
+			created := domain.NewAuditEntry(actor, domain.AuditActionCreate, domain.Contact{}, contact)
+			updatedContact := contact
+			updatedContact.FirstName = "Jane"
+			updated := domain.NewAuditEntry(actor, domain.AuditActionUpdate, contact, updatedContact)
+			updated.At = created.At.Add(time.Second)
+			other := domain.NewAuditEntry(actor, domain.AuditActionCreate, domain.Contact{}, *testContact(actor.Id()))
+
+			// appended out of order, entries are listed from the oldest to the most recent
+			for _, entry := range []*domain.AuditEntry{updated, other, created} {
+				require.NoError(t, repo.Append(ctx, entry))
+			}
+
+			entries, err := repo.ListByContact(ctx, contact.Id)
+			require.NoError(t, err)
+			require.Len(t, entries, 2)
+			assert.Equal(t, created.Id, entries[0].Id)
+			assert.Equal(t, updated.Id, entries[1].Id)
+			assert.Equal(t, actor, entries[1].Actor)
+			assert.Equal(t, domain.AuditActionUpdate, entries[1].Action)
+			assert.Equal(t, []domain.FieldChange{{Field: "first_name", Before: "John", After: "Jane"}}, entries[1].Changes)
+			// the primary email and phone are also listed in the emails and phones changes
+			assert.Len(t, entries[0].Changes, 6)
+
+			entries, err = repo.ListByContact(ctx, uuid.New())
+			require.NoError(t, err)
+			assert.Empty(t, entries)
+		})
+	}
+}
+
+func TestContactRepositoriesAuditEntries(t *testing.T) {
+	t.Parallel()
+
+	repositories := map[string]func(t *testing.T) (outboxRepository, auditRepository){
+		"in memory": func(t *testing.T) (outboxRepository, auditRepository) {
+			contacts := NewInMemoryContactRepository()
+			return contacts, NewInMemoryAuditRepository(contacts)
+		},
+		"file": func(t *testing.T) (outboxRepository, auditRepository) {
+			store, contacts := openFileContactRepository(t, t.TempDir())
+			return contacts, NewFileAuditRepository(store)
+		},
+		"sql": func(t *testing.T) (outboxRepository, auditRepository) {
+			contacts := testSQLContactRepository(t)
+			audit, err := NewSQLAuditRepository(contacts.db, DriverSQLite)
+			require.NoError(t, err)
+			return contacts, audit
+		},
+	}
+
+	for name, newRepo := range repositories {
+		newRepo := newRepo
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			contacts, audit := newRepo(t)
+			actor := user.New(uuid.New(), user.UserTypeAuthenticated)
+
+			contact := testContact(actor.Id())
+			created := domain.NewAuditEntry(actor, domain.AuditActionCreate, domain.Contact{}, *contact)
+			contact.Audit(created)
+			_, err := contacts.Create(ctx, contact)
+			require.NoError(t, err)
+
+			// the entries recorded by a failing update are not saved
+			_, err = contacts.Update(ctx, contact.Id, func(c domain.Contact) (domain.Contact, error) {
+				c.Audit(domain.NewAuditEntry(actor, domain.AuditActionUpdate, c, c))
+				return c, ErrNotFound
+			})
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			var updated *domain.AuditEntry
+			_, err = contacts.Update(ctx, contact.Id, func(c domain.Contact) (domain.Contact, error) {
+				before := c
+				c.FirstName = "Jane"
+				updated = domain.NewAuditEntry(actor, domain.AuditActionUpdate, before, c)
+				updated.At = created.At.Add(time.Second)
+				c.Audit(updated)
+				return c, nil
+			})
+			require.NoError(t, err)
+
+			entries, err := audit.ListByContact(ctx, contact.Id)
+			require.NoError(t, err)
+			require.Len(t, entries, 2)
+			assert.Equal(t, created.Id, entries[0].Id)
+			assert.Equal(t, updated.Id, entries[1].Id)
+			assert.Equal(t, []domain.FieldChange{{Field: "first_name", Before: "John", After: "Jane"}}, entries[1].Changes)
+		})
+	}
+}
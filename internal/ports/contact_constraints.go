@@ -0,0 +1,71 @@
+package ports
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/davidterranova/contacts/internal/domain"
+)
+
+var ErrAlreadyExists = errors.New("already exists")
+
+// contactConstraints are the uniqueness constraints enforced by a contact repository on the contacts of a same owner.
+// Emails are compared case insensitively, phones as they are stored. Contacts in the trash and empty values are ignored.
+type contactConstraints struct {
+	uniqueEmail bool
+	uniquePhone bool
+}
+
+// ContactConstraint enables a uniqueness constraint of the contact repositories
+type ContactConstraint func(c *contactConstraints)
+
+// WithUniqueEmail rejects a contact whose email is already used by another contact of its owner
+func WithUniqueEmail() ContactConstraint {
+	return func(c *contactConstraints) {
+		c.uniqueEmail = true
+	}
+}
+
+// WithUniquePhone rejects a contact whose phone is already used by another contact of its owner
+func WithUniquePhone() ContactConstraint {
+	return func(c *contactConstraints) {
+		c.uniquePhone = true
+	}
+}
+
+func newContactConstraints(options ...ContactConstraint) contactConstraints {
+	var constraints contactConstraints
+	for _, option := range options {
+		option(&constraints)
+	}
+
+	return constraints
+}
+
+// check fails with ErrAlreadyExists when the contact and the other one break a constraint
+func (c contactConstraints) check(contact *domain.Contact, other *domain.Contact) error {
+	if contact.Id == other.Id || contact.CreatedBy != other.CreatedBy || contact.IsDeleted() || other.IsDeleted() {
+		return nil
+	}
+
+	if c.uniqueEmail && contact.Email != "" && strings.EqualFold(contact.Email, other.Email) {
+		return fmt.Errorf("%w: email %s is used by contact %s", ErrAlreadyExists, contact.Email, other.Id)
+	}
+	if c.uniquePhone && contact.Phone != "" && contact.Phone == other.Phone {
+		return fmt.Errorf("%w: phone %s is used by contact %s", ErrAlreadyExists, contact.Phone, other.Id)
+	}
+
+	return nil
+}
+
+// checkAll checks the contact against every other contact
+func (c contactConstraints) checkAll(contact *domain.Contact, others func(fn func(other *domain.Contact) error) error) error {
+	if !c.uniqueEmail && !c.uniquePhone {
+		return nil
+	}
+
+	return others(func(other *domain.Contact) error {
+		return c.check(contact, other)
+	})
+}
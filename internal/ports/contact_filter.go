@@ -0,0 +1,298 @@
+package ports
+
+import (
+	"sort"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+)
+
+type filter struct {
+	createdBy    *uuid.UUID
+	visibleTo    *domain.Visibility
+	addressBook  *uuid.UUID
+	group        *uuid.UUID
+	relatedTo    *uuid.UUID
+	trashed      bool
+	search       string
+	firstName    *domain.FieldMatch
+	lastName     *domain.FieldMatch
+	email        *domain.FieldMatch
+	phone        *domain.FieldMatch
+	customFields map[string]domain.FieldMatch
+	createdAt    *domain.TimeRange
+	updatedAt    *domain.TimeRange
+
+	sort  domain.Sort
+	after *domain.SortKey
+	limit int
+}
+
+func (f *filter) CreatedBy() *uuid.UUID {
+	return f.createdBy
+}
+
+func (f *filter) VisibleTo() *domain.Visibility {
+	return f.visibleTo
+}
+
+func (f *filter) AddressBook() *uuid.UUID {
+	return f.addressBook
+}
+
+func (f *filter) Group() *uuid.UUID {
+	return f.group
+}
+
+func (f *filter) RelatedTo() *uuid.UUID {
+	return f.relatedTo
+}
+
+func (f *filter) Trashed() bool {
+	return f.trashed
+}
+
+func (f *filter) Search() string {
+	return f.search
+}
+
+func (f *filter) FirstName() *domain.FieldMatch {
+	return f.firstName
+}
+
+func (f *filter) LastName() *domain.FieldMatch {
+	return f.lastName
+}
+
+func (f *filter) Email() *domain.FieldMatch {
+	return f.email
+}
+
+func (f *filter) Phone() *domain.FieldMatch {
+	return f.phone
+}
+
+func (f *filter) CustomFields() map[string]domain.FieldMatch {
+	return f.customFields
+}
+
+func (f *filter) CreatedAt() *domain.TimeRange {
+	return f.createdAt
+}
+
+func (f *filter) UpdatedAt() *domain.TimeRange {
+	return f.updatedAt
+}
+
+func (f *filter) Sort() domain.Sort {
+	return f.sort
+}
+
+func (f *filter) After() *domain.SortKey {
+	return f.after
+}
+
+func (f *filter) Limit() int {
+	return f.limit
+}
+
+func WithCreatedBy(id uuid.UUID) FilterOption {
+	return func(f *filter) {
+		f.createdBy = &id
+	}
+}
+
+func WithVisibleTo(visibility domain.Visibility) FilterOption {
+	return func(f *filter) {
+		f.visibleTo = &visibility
+	}
+}
+
+func WithAddressBook(id uuid.UUID) FilterOption {
+	return func(f *filter) {
+		f.addressBook = &id
+	}
+}
+
+func WithGroup(id uuid.UUID) FilterOption {
+	return func(f *filter) {
+		f.group = &id
+	}
+}
+
+func WithRelatedTo(id uuid.UUID) FilterOption {
+	return func(f *filter) {
+		f.relatedTo = &id
+	}
+}
+
+func WithTrashed() FilterOption {
+	return func(f *filter) {
+		f.trashed = true
+	}
+}
+
+func WithSearch(text string) FilterOption {
+	return func(f *filter) {
+		f.search = text
+	}
+}
+
+func WithFirstName(match domain.FieldMatch) FilterOption {
+	return func(f *filter) {
+		f.firstName = &match
+	}
+}
+
+func WithLastName(match domain.FieldMatch) FilterOption {
+	return func(f *filter) {
+		f.lastName = &match
+	}
+}
+
+func WithEmail(match domain.FieldMatch) FilterOption {
+	return func(f *filter) {
+		f.email = &match
+	}
+}
+
+func WithPhone(match domain.FieldMatch) FilterOption {
+	return func(f *filter) {
+		f.phone = &match
+	}
+}
+
+func WithCustomField(name string, match domain.FieldMatch) FilterOption {
+	return func(f *filter) {
+		if f.customFields == nil {
+			f.customFields = map[string]domain.FieldMatch{}
+		}
+		f.customFields[name] = match
+	}
+}
+
+func WithCreatedAt(timeRange domain.TimeRange) FilterOption {
+	return func(f *filter) {
+		f.createdAt = &timeRange
+	}
+}
+
+func WithUpdatedAt(timeRange domain.TimeRange) FilterOption {
+	return func(f *filter) {
+		f.updatedAt = &timeRange
+	}
+}
+
+func WithSort(sort domain.Sort) FilterOption {
+	return func(f *filter) {
+		f.sort = sort
+	}
+}
+
+func WithAfter(key domain.SortKey) FilterOption {
+	return func(f *filter) {
+		f.after = &key
+	}
+}
+
+func WithLimit(limit int) FilterOption {
+	return func(f *filter) {
+		f.limit = limit
+	}
+}
+
+type FilterOption func(f *filter)
+
+func NewFilter(filters ...FilterOption) *filter {
+	filter := &filter{}
+	for _, f := range filters {
+		f(filter)
+	}
+
+	return filter
+}
+
+// filterBy tells whether a contact matches the filter, it is shared by the repositories filtering in memory
+func filterBy(filter domain.Filter, contact *domain.Contact) bool {
+	if filter.CreatedBy() != nil && *filter.CreatedBy() != contact.CreatedBy {
+		return false
+	}
+
+	if filter.VisibleTo() != nil && !filter.VisibleTo().Contains(contact) {
+		return false
+	}
+
+	if filter.AddressBook() != nil && *filter.AddressBook() != contact.AddressBookId {
+		return false
+	}
+
+	if filter.Group() != nil && !contact.InGroup(*filter.Group()) {
+		return false
+	}
+
+	if filter.RelatedTo() != nil && !contact.RelatesTo(*filter.RelatedTo()) {
+		return false
+	}
+
+	if filter.Trashed() != contact.IsDeleted() {
+		return false
+	}
+
+	if filter.Search() != "" && !contact.MatchesSearch(filter.Search()) {
+		return false
+	}
+
+	fieldMatches := []struct {
+		match *domain.FieldMatch
+		value string
+	}{
+		{filter.FirstName(), contact.FirstName},
+		{filter.LastName(), contact.LastName},
+		{filter.Email(), contact.Email},
+		{filter.Phone(), contact.Phone},
+	}
+	for _, f := range fieldMatches {
+		if f.match != nil && !f.match.Matches(f.value) {
+			return false
+		}
+	}
+
+	for name, match := range filter.CustomFields() {
+		value, ok := contact.CustomFields[name]
+		if !ok || !match.Matches(value.String()) {
+			return false
+		}
+	}
+
+	if filter.CreatedAt() != nil && !filter.CreatedAt().Contains(contact.CreatedAt) {
+		return false
+	}
+
+	if filter.UpdatedAt() != nil && !filter.UpdatedAt().Contains(contact.UpdatedAt) {
+		return false
+	}
+
+	return true
+}
+
+// paginate sorts the filtered contacts and keeps the requested page, it is shared by the repositories filtering in memory
+func paginate(filter domain.Filter, contacts []*domain.Contact) []*domain.Contact {
+	order := filter.Sort()
+	sort.Slice(contacts, func(i, j int) bool {
+		return order.Compare(order.Key(contacts[i]), order.Key(contacts[j])) < 0
+	})
+
+	if filter.After() != nil {
+		after := *filter.After()
+		start := sort.Search(len(contacts), func(i int) bool {
+			return order.Compare(order.Key(contacts[i]), after) > 0
+		})
+		contacts = contacts[start:]
+	}
+
+	if filter.Limit() > 0 && len(contacts) > filter.Limit() {
+		contacts = contacts[:filter.Limit()]
+	}
+
+	return contacts
+}
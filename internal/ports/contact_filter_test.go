@@ -0,0 +1,530 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type contactLister interface {
+	List(ctx context.Context, filter domain.Filter) ([]*domain.Contact, error)
+	Create(ctx context.Context, contact *domain.Contact) (*domain.Contact, error)
+}
+
+func TestListPagination(t *testing.T) {
+	t.Parallel()
+
+	repositories := map[string]func(t *testing.T) contactLister{
+		"in memory": func(t *testing.T) contactLister { return NewInMemoryContactRepository() },
+		"file": func(t *testing.T) contactLister {
+			_, repo := openFileContactRepository(t, t.TempDir())
+			return repo
+		},
+		"sql": func(t *testing.T) contactLister { return testSQLContactRepository(t) },
+	}
+
+	sorts := []domain.Sort{
+		{Field: domain.SortByLastName},
+		{Field: domain.SortByFirstName, Desc: true},
+		{Field: domain.SortByCreatedAt},
+		{Field: domain.SortByUpdatedAt, Desc: true},
+	}
+
+	for name, newRepo := range repositories {
+		newRepo := newRepo
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			repo := newRepo(t)
+			owner := uuid.New()
+			createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+			// last names and creation dates collide so that ties are broken by id
+			for i, lastName := range []string{"Doe", "Smith", "Doe", "Adams", "Smith", "Brown", "Doe"} {
+				contact := testContact(owner)
+				contact.LastName = lastName
+				contact.FirstName = string(rune('A' + i))
+				contact.CreatedAt = createdAt.Add(time.Duration(i/2) * time.Hour)
+				contact.UpdatedAt = createdAt.Add(time.Duration(i%3) * time.Hour)
+				_, err := repo.Create(ctx, contact)
+				require.NoError(t, err)
+			}
+
+			for _, sort := range sorts {
+				all, err := repo.List(ctx, NewFilter(WithSort(sort)))
+				require.NoError(t, err)
+				require.Len(t, all, 7)
+				for i := 1; i < len(all); i++ {
+					assert.Negative(t, sort.Compare(sort.Key(all[i-1]), sort.Key(all[i])), "%v is not sorted", sort)
+				}
+
+				var paged []*domain.Contact
+				options := []FilterOption{WithSort(sort), WithLimit(3)}
+				for {
+					page, err := repo.List(ctx, NewFilter(options...))
+					require.NoError(t, err)
+					paged = append(paged, page...)
+					if len(page) < 3 {
+						break
+					}
+					options = []FilterOption{WithSort(sort), WithLimit(3), WithAfter(sort.Key(page[len(page)-1]))}
+				}
+
+				require.Len(t, paged, len(all), "%v pages", sort)
+				for i := range all {
+					assert.Equal(t, all[i].Id, paged[i].Id, "%v pages", sort)
+				}
+			}
+		})
+	}
+}
+
+type contactTrash interface {
+	contactLister
+	Get(ctx context.Context, id uuid.UUID) (*domain.Contact, error)
+	Update(ctx context.Context, id uuid.UUID, updateFn func(c domain.Contact) (domain.Contact, error)) (*domain.Contact, error)
+	Purge(ctx context.Context, deletedBefore time.Time) (int, error)
+}
+
+func TestCustomFieldFilter(t *testing.T) {
+	t.Parallel()
+
+	repositories := map[string]func(t *testing.T) contactLister{
+		"in memory": func(t *testing.T) contactLister { return NewInMemoryContactRepository() },
+		"file": func(t *testing.T) contactLister {
+			_, repo := openFileContactRepository(t, t.TempDir())
+			return repo
+		},
+		"sql": func(t *testing.T) contactLister { return testSQLContactRepository(t) },
+	}
+
+	for name, newRepo := range repositories {
+		newRepo := newRepo
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			repo := newRepo(t)
+			owner := uuid.New()
+
+			gold := testContact(owner)
+			gold.CustomFields = map[string]domain.CustomValue{
+				"tier":   {Type: domain.FieldTypeEnum, Text: "Gold"},
+				"rating": {Type: domain.FieldTypeNumber, Number: 4.5},
+			}
+			silver := testContact(owner)
+			silver.CustomFields = map[string]domain.CustomValue{"tier": {Type: domain.FieldTypeEnum, Text: "silver"}}
+			for _, contact := range []*domain.Contact{gold, silver, testContact(owner)} {
+				_, err := repo.Create(ctx, contact)
+				require.NoError(t, err)
+			}
+
+			contacts, err := repo.List(ctx, NewFilter(WithCustomField("tier", domain.FieldMatch{Value: "gold", Mode: domain.MatchExact})))
+			require.NoError(t, err)
+			require.Len(t, contacts, 1)
+			assert.Equal(t, gold.Id, contacts[0].Id)
+			assert.Equal(t, gold.CustomFields, contacts[0].CustomFields)
+
+			contacts, err = repo.List(ctx, NewFilter(WithCustomField("tier", domain.FieldMatch{Value: "s", Mode: domain.MatchPrefix})))
+			require.NoError(t, err)
+			require.Len(t, contacts, 1)
+			assert.Equal(t, silver.Id, contacts[0].Id)
+
+			contacts, err = repo.List(ctx, NewFilter(
+				WithCustomField("tier", domain.FieldMatch{Value: "gold", Mode: domain.MatchExact}),
+				WithCustomField("rating", domain.FieldMatch{Value: "4.5", Mode: domain.MatchExact}),
+			))
+			require.NoError(t, err)
+			assert.Len(t, contacts, 1)
+
+			contacts, err = repo.List(ctx, NewFilter(WithCustomField("rating", domain.FieldMatch{Value: "4", Mode: domain.MatchExact})))
+			require.NoError(t, err)
+			assert.Empty(t, contacts)
+		})
+	}
+}
+
+func TestTrashAndPurge(t *testing.T) {
+	t.Parallel()
+
+	repositories := map[string]func(t *testing.T) contactTrash{
+		"in memory": func(t *testing.T) contactTrash { return NewInMemoryContactRepository() },
+		"file": func(t *testing.T) contactTrash {
+			_, repo := openFileContactRepository(t, t.TempDir())
+			return repo
+		},
+		"sql": func(t *testing.T) contactTrash { return testSQLContactRepository(t) },
+	}
+
+	for name, newRepo := range repositories {
+		newRepo := newRepo
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			repo := newRepo(t)
+			owner := uuid.New()
+			deletedAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+			var contacts []*domain.Contact
+			for i := 0; i < 3; i++ {
+				contact, err := repo.Create(ctx, testContact(owner))
+				require.NoError(t, err)
+				contacts = append(contacts, contact)
+			}
+
+			// the first contact is deleted a day before the second one, the third one is kept
+			for i, contact := range contacts[:2] {
+				deletedAt := deletedAt.Add(time.Duration(i) * 24 * time.Hour)
+				_, err := repo.Update(ctx, contact.Id, func(c domain.Contact) (domain.Contact, error) {
+					c.DeletedAt = &deletedAt
+					return c, nil
+				})
+				require.NoError(t, err)
+			}
+
+			active, err := repo.List(ctx, NewFilter(WithCreatedBy(owner)))
+			require.NoError(t, err)
+			require.Len(t, active, 1)
+			assert.Equal(t, contacts[2].Id, active[0].Id)
+
+			trashed, err := repo.List(ctx, NewFilter(WithCreatedBy(owner), WithTrashed()))
+			require.NoError(t, err)
+			require.Len(t, trashed, 2)
+			require.NotNil(t, trashed[0].DeletedAt)
+
+			got, err := repo.Get(ctx, contacts[0].Id)
+			require.NoError(t, err)
+			require.True(t, got.IsDeleted())
+			assert.Equal(t, deletedAt, got.DeletedAt.UTC())
+
+			purged, err := repo.Purge(ctx, deletedAt.Add(time.Hour))
+			require.NoError(t, err)
+			assert.Equal(t, 1, purged)
+
+			_, err = repo.Get(ctx, contacts[0].Id)
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			trashed, err = repo.List(ctx, NewFilter(WithCreatedBy(owner), WithTrashed()))
+			require.NoError(t, err)
+			require.Len(t, trashed, 1)
+			assert.Equal(t, contacts[1].Id, trashed[0].Id)
+		})
+	}
+}
+
+func TestUpdateIncrementsVersion(t *testing.T) {
+	t.Parallel()
+
+	repositories := map[string]func(t *testing.T) contactTrash{
+		"in memory": func(t *testing.T) contactTrash { return NewInMemoryContactRepository() },
+		"file": func(t *testing.T) contactTrash {
+			_, repo := openFileContactRepository(t, t.TempDir())
+			return repo
+		},
+		"sql": func(t *testing.T) contactTrash { return testSQLContactRepository(t) },
+	}
+
+	for name, newRepo := range repositories {
+		newRepo := newRepo
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			repo := newRepo(t)
+
+			contact, err := repo.Create(ctx, testContact(uuid.New()))
+			require.NoError(t, err)
+			require.Equal(t, 1, contact.Version)
+
+			for _, expected := range []int{2, 3} {
+				updated, err := repo.Update(ctx, contact.Id, func(c domain.Contact) (domain.Contact, error) {
+					c.FirstName = fmt.Sprintf("John %d", expected)
+					return c, nil
+				})
+				require.NoError(t, err)
+				assert.Equal(t, expected, updated.Version)
+			}
+
+			got, err := repo.Get(ctx, contact.Id)
+			require.NoError(t, err)
+			assert.Equal(t, 3, got.Version)
+		})
+	}
+}
+
+type contactBatcher interface {
+	Get(ctx context.Context, id uuid.UUID) (*domain.Contact, error)
+	Create(ctx context.Context, contact *domain.Contact) (*domain.Contact, error)
+	Batch(ctx context.Context, ops []domain.ContactOperation) ([]*domain.Contact, error)
+	Pending(ctx context.Context, limit int) ([]domain.Event, error)
+}
+
+func TestBatch(t *testing.T) {
+	t.Parallel()
+
+	repositories := map[string]func(t *testing.T) contactBatcher{
+		"in memory": func(t *testing.T) contactBatcher { return NewInMemoryContactRepository() },
+		"file": func(t *testing.T) contactBatcher {
+			_, repo := openFileContactRepository(t, t.TempDir())
+			return repo
+		},
+		"sql": func(t *testing.T) contactBatcher { return testSQLContactRepository(t) },
+	}
+
+	rename := func(firstName string) func(c domain.Contact) (domain.Contact, error) {
+		return func(c domain.Contact) (domain.Contact, error) {
+			c.FirstName = firstName
+			return c, nil
+		}
+	}
+
+	for name, newRepo := range repositories {
+		newRepo := newRepo
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			t.Run("applies the operations in order", func(t *testing.T) {
+				ctx := context.Background()
+				repo := newRepo(t)
+				existing, err := repo.Create(ctx, testContact(uuid.New()))
+				require.NoError(t, err)
+
+				created := testContact(uuid.New())
+				created.Record(domain.NewContactCreated(*created))
+				contacts, err := repo.Batch(ctx, []domain.ContactOperation{
+					domain.CreateOperation(created),
+					domain.UpdateOperation(existing.Id, rename("Jane")),
+					domain.UpdateOperation(created.Id, rename("Jack")),
+				})
+				require.NoError(t, err)
+				require.Len(t, contacts, 3)
+				assert.Equal(t, 2, contacts[1].Version)
+				assert.Equal(t, 2, contacts[2].Version)
+
+				got, err := repo.Get(ctx, existing.Id)
+				require.NoError(t, err)
+				assert.Equal(t, "Jane", got.FirstName)
+
+				got, err = repo.Get(ctx, created.Id)
+				require.NoError(t, err)
+				assert.Equal(t, "Jack", got.FirstName)
+
+				events, err := repo.Pending(ctx, 10)
+				require.NoError(t, err)
+				assert.Len(t, events, 1)
+			})
+
+			t.Run("saves nothing when an operation fails", func(t *testing.T) {
+				ctx := context.Background()
+				repo := newRepo(t)
+				existing, err := repo.Create(ctx, testContact(uuid.New()))
+				require.NoError(t, err)
+
+				created := testContact(uuid.New())
+				failure := errors.New("failure")
+				_, err = repo.Batch(ctx, []domain.ContactOperation{
+					domain.CreateOperation(created),
+					domain.UpdateOperation(existing.Id, rename("Jane")),
+					domain.UpdateOperation(existing.Id, func(c domain.Contact) (domain.Contact, error) {
+						return c, failure
+					}),
+				})
+				assert.ErrorIs(t, err, failure)
+
+				_, err = repo.Get(ctx, created.Id)
+				assert.ErrorIs(t, err, ErrNotFound)
+
+				got, err := repo.Get(ctx, existing.Id)
+				require.NoError(t, err)
+				assert.Equal(t, "John", got.FirstName)
+				assert.Equal(t, 1, got.Version)
+			})
+
+			t.Run("fails on an unknown contact", func(t *testing.T) {
+				ctx := context.Background()
+				repo := newRepo(t)
+
+				_, err := repo.Batch(ctx, []domain.ContactOperation{
+					domain.UpdateOperation(uuid.New(), rename("Jane")),
+				})
+				assert.ErrorIs(t, err, ErrNotFound)
+			})
+		})
+	}
+}
+
+type contactUniqueness interface {
+	Create(ctx context.Context, contact *domain.Contact) (*domain.Contact, error)
+	Update(ctx context.Context, id uuid.UUID, updateFn func(c domain.Contact) (domain.Contact, error)) (*domain.Contact, error)
+	Batch(ctx context.Context, ops []domain.ContactOperation) ([]*domain.Contact, error)
+}
+
+func TestUniqueConstraints(t *testing.T) {
+	t.Parallel()
+
+	repositories := map[string]func(t *testing.T, options ...ContactConstraint) contactUniqueness{
+		"in memory": func(t *testing.T, options ...ContactConstraint) contactUniqueness {
+			return NewInMemoryContactRepository(options...)
+		},
+		"file": func(t *testing.T, options ...ContactConstraint) contactUniqueness {
+			_, repo := openFileContactRepository(t, t.TempDir(), options...)
+			return repo
+		},
+		"sql": func(t *testing.T, options ...ContactConstraint) contactUniqueness {
+			return testSQLContactRepository(t, options...)
+		},
+	}
+
+	for name, newRepo := range repositories {
+		newRepo := newRepo
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			t.Run("rejects the email and phone of another contact of the owner", func(t *testing.T) {
+				ctx := context.Background()
+				repo := newRepo(t, WithUniqueEmail(), WithUniquePhone())
+				owner := uuid.New()
+				existing, err := repo.Create(ctx, testContact(owner))
+				require.NoError(t, err)
+
+				sameEmail := testContact(owner)
+				sameEmail.Email = "JDoe@Contact.local"
+				sameEmail.Phone = "+33700000000"
+				_, err = repo.Create(ctx, sameEmail)
+				assert.ErrorIs(t, err, ErrAlreadyExists)
+
+				other := testContact(owner)
+				other.Email = "jack@contact.local"
+				other.Phone = "+33700000000"
+				other, err = repo.Create(ctx, other)
+				require.NoError(t, err)
+
+				_, err = repo.Update(ctx, other.Id, func(c domain.Contact) (domain.Contact, error) {
+					c.Phone = existing.Phone
+					return c, nil
+				})
+				assert.ErrorIs(t, err, ErrAlreadyExists)
+
+				_, err = repo.Create(ctx, testContact(uuid.New()))
+				assert.NoError(t, err, "contacts of other owners are not constrained")
+			})
+
+			t.Run("ignores the contacts in the trash", func(t *testing.T) {
+				ctx := context.Background()
+				repo := newRepo(t, WithUniqueEmail(), WithUniquePhone())
+				owner := uuid.New()
+				existing, err := repo.Create(ctx, testContact(owner))
+				require.NoError(t, err)
+
+				_, err = repo.Update(ctx, existing.Id, func(c domain.Contact) (domain.Contact, error) {
+					c.Trash()
+					return c, nil
+				})
+				require.NoError(t, err)
+
+				_, err = repo.Create(ctx, testContact(owner))
+				assert.NoError(t, err)
+			})
+
+			t.Run("batch fails on the operation breaking a constraint", func(t *testing.T) {
+				ctx := context.Background()
+				repo := newRepo(t, WithUniqueEmail())
+				owner := uuid.New()
+				first, second := testContact(owner), testContact(owner)
+				second.Phone = "+33700000000"
+
+				_, err := repo.Batch(ctx, []domain.ContactOperation{
+					domain.CreateOperation(first),
+					domain.CreateOperation(second),
+				})
+				assert.ErrorIs(t, err, ErrAlreadyExists)
+				var operationErr *domain.OperationError
+				require.ErrorAs(t, err, &operationErr)
+				assert.Equal(t, 1, operationErr.Index)
+
+				_, err = repo.Create(ctx, testContact(owner))
+				assert.NoError(t, err, "the batch saved nothing")
+			})
+
+			t.Run("accepts duplicates without constraints", func(t *testing.T) {
+				ctx := context.Background()
+				repo := newRepo(t)
+				owner := uuid.New()
+
+				_, err := repo.Create(ctx, testContact(owner))
+				require.NoError(t, err)
+				_, err = repo.Create(ctx, testContact(owner))
+				assert.NoError(t, err)
+			})
+		})
+	}
+}
+
+func TestRelatedToFilter(t *testing.T) {
+	t.Parallel()
+
+	repositories := map[string]func(t *testing.T) contactTrash{
+		"in memory": func(t *testing.T) contactTrash { return NewInMemoryContactRepository() },
+		"file": func(t *testing.T) contactTrash {
+			_, repo := openFileContactRepository(t, t.TempDir())
+			return repo
+		},
+		"sql": func(t *testing.T) contactTrash { return testSQLContactRepository(t) },
+	}
+
+	for name, newRepo := range repositories {
+		newRepo := newRepo
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			repo := newRepo(t)
+			owner := uuid.New()
+			createdAt := time.Now().UTC().Truncate(time.Second)
+
+			company := testContact(owner)
+			manager := testContact(owner)
+			employee := testContact(owner)
+			employee.Relate(domain.Relationship{Type: domain.RelationshipOrganization, ContactId: company.Id, OnDelete: domain.OnDeleteCascade, CreatedAt: createdAt})
+			employee.Relate(domain.Relationship{Type: domain.RelationshipManager, ContactId: manager.Id, OnDelete: domain.OnDeleteNullify, CreatedAt: createdAt})
+			for _, contact := range []*domain.Contact{company, manager, employee} {
+				_, err := repo.Create(ctx, contact)
+				require.NoError(t, err)
+			}
+
+			got, err := repo.Get(ctx, employee.Id)
+			require.NoError(t, err)
+			assert.Equal(t, employee.Relationships, got.Relationships)
+
+			listed, err := repo.List(ctx, NewFilter(WithRelatedTo(company.Id)))
+			require.NoError(t, err)
+			require.Len(t, listed, 1)
+			assert.Equal(t, employee.Id, listed[0].Id)
+			assert.Len(t, listed[0].Relationships, 2)
+
+			_, err = repo.Update(ctx, employee.Id, func(c domain.Contact) (domain.Contact, error) {
+				c.Unrelate(domain.RelationshipOrganization, company.Id)
+				return c, nil
+			})
+			require.NoError(t, err)
+
+			listed, err = repo.List(ctx, NewFilter(WithRelatedTo(company.Id)))
+			require.NoError(t, err)
+			assert.Empty(t, listed)
+
+			listed, err = repo.List(ctx, NewFilter(WithRelatedTo(manager.Id)))
+			require.NoError(t, err)
+			require.Len(t, listed, 1)
+			assert.Equal(t, []domain.Relationship{employee.Relationships[0]}, listed[0].Relationships)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package ports
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type contactSchemaRepository interface {
+	Get(ctx context.Context, ownerId uuid.UUID) (*domain.ContactSchema, error)
+	Save(ctx context.Context, schema *domain.ContactSchema) (*domain.ContactSchema, error)
+}
+
+func TestContactSchemaRepositories(t *testing.T) {
+	t.Parallel()
+
+	repositories := map[string]func(t *testing.T) contactSchemaRepository{
+		"in memory": func(t *testing.T) contactSchemaRepository { return NewInMemoryContactSchemaRepository() },
+		"file": func(t *testing.T) contactSchemaRepository {
+			store, _ := openFileContactRepository(t, t.TempDir())
+			return NewFileContactSchemaRepository(store)
+		},
+		"sql": func(t *testing.T) contactSchemaRepository {
+			repo, err := NewSQLContactSchemaRepository(testSQLContactRepository(t).db, DriverSQLite)
+			require.NoError(t, err)
+			return repo
+		},
+	}
+
+	for name, newRepo := range repositories {
+		newRepo := newRepo
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			repo := newRepo(t)
+			owner := uuid.New()
+			five := 5.0
+
+			_, err := repo.Get(ctx, owner)
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			schema, err := domain.NewContactSchema(owner, []domain.FieldDefinition{
+				{Name: "tier", Type: domain.FieldTypeEnum, Required: true, Options: []string{"gold", "silver"}},
+				{Name: "rating", Type: domain.FieldTypeNumber, Max: &five},
+			})
+			require.NoError(t, err)
+			schema.DefaultPhoneRegion = "FR"
+			_, err = repo.Save(ctx, schema)
+			require.NoError(t, err)
+
+			saved, err := repo.Get(ctx, owner)
+			require.NoError(t, err)
+			assert.Equal(t, schema.Fields, saved.Fields)
+			assert.Equal(t, "FR", saved.DefaultPhoneRegion)
+			assert.True(t, schema.UpdatedAt.Equal(saved.UpdatedAt))
+
+			schema.Fields = schema.Fields[:1]
+			_, err = repo.Save(ctx, schema)
+			require.NoError(t, err)
+
+			saved, err = repo.Get(ctx, owner)
+			require.NoError(t, err)
+			assert.Len(t, saved.Fields, 1)
+
+			_, err = repo.Get(ctx, uuid.New())
+			assert.ErrorIs(t, err, ErrNotFound)
+		})
+	}
+}
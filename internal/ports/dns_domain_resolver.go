@@ -0,0 +1,94 @@
+package ports
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// DNSDomainResolver resolves the mail servers of the domains with the system resolver. Domains without MX records
+// accept mails on their address, https://www.rfc-editor.org/rfc/rfc5321#section-5.1, while a null MX tells a domain
+// accepts none, https://www.rfc-editor.org/rfc/rfc7505.
+type DNSDomainResolver struct {
+	resolver *net.Resolver
+	timeout  time.Duration
+}
+
+func NewDNSDomainResolver(timeout time.Duration) *DNSDomainResolver {
+	return &DNSDomainResolver{
+		resolver: net.DefaultResolver,
+		timeout:  timeout,
+	}
+}
+
+func (r *DNSDomainResolver) Resolve(ctx context.Context, domain string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	records, err := r.resolver.LookupMX(ctx, domain)
+	if err == nil && len(records) > 0 {
+		return !(len(records) == 1 && records[0].Host == "."), nil
+	}
+	if err != nil && !isNotFound(err) {
+		return false, fmt.Errorf("failed to lookup the mail servers of %s: %w", domain, err)
+	}
+
+	addresses, err := r.resolver.LookupHost(ctx, domain)
+	if isNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to lookup the address of %s: %w", domain, err)
+	}
+
+	return len(addresses) > 0, nil
+}
+
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+// StaticDomainResolver resolves the domains from a fixed list, the ones not listed do not accept mails. It stands for
+// the DNS in tests and offline setups.
+type StaticDomainResolver struct {
+	domains map[string]bool
+}
+
+func NewStaticDomainResolver(domains map[string]bool) *StaticDomainResolver {
+	return &StaticDomainResolver{domains: domains}
+}
+
+func (r *StaticDomainResolver) Resolve(_ context.Context, domain string) (bool, error) {
+	return r.domains[strings.ToLower(domain)], nil
+}
+
+// LoadDisposableDomains reads the disposable domains file, one domain per line, blank lines and lines starting
+// with # being skipped
+func LoadDisposableDomains(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open disposable domains: %w", err)
+	}
+	defer file.Close()
+
+	var domains []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		domains = append(domains, strings.ToLower(line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read disposable domains: %w", err)
+	}
+
+	return domains, nil
+}
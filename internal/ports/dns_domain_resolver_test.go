@@ -0,0 +1,39 @@
+package ports
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDisposableDomains(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "disposable.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# disposable domains\nmailinator.com\n\n  Trash-Mail.com \n"), 0o600))
+
+	domains, err := LoadDisposableDomains(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mailinator.com", "trash-mail.com"}, domains)
+
+	_, err = LoadDisposableDomains(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Error(t, err)
+}
+
+func TestStaticDomainResolver(t *testing.T) {
+	t.Parallel()
+
+	resolver := NewStaticDomainResolver(map[string]bool{"contact.local": true})
+
+	ok, err := resolver.Resolve(context.Background(), "Contact.local")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = resolver.Resolve(context.Background(), "dead.local")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,78 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+)
+
+// EventHandler consumes the contact events delivered by the event bus
+type EventHandler func(ctx context.Context, event domain.Event) error
+
+// InProcessEventBus delivers the contact events synchronously to the handlers subscribed in the same process.
+// It remembers the handlers which accepted an event until all of them did, or until the event is given up on,
+// so that publishing again an event some handlers failed only hands it to those.
+type InProcessEventBus struct {
+	mtx      sync.Mutex
+	handlers []EventHandler
+	// delivered are the indexes of the handlers which accepted the events some other handlers failed
+	delivered map[uuid.UUID]map[int]bool
+}
+
+func NewInProcessEventBus() *InProcessEventBus {
+	return &InProcessEventBus{
+		delivered: make(map[uuid.UUID]map[int]bool),
+	}
+}
+
+func (b *InProcessEventBus) Subscribe(handler EventHandler) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.handlers = append(b.handlers, handler)
+}
+
+// Publish hands the event to every handler which has not accepted it yet, even when some of them fail, and returns
+// their joined errors
+func (b *InProcessEventBus) Publish(ctx context.Context, event domain.Event) error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	delivered := b.delivered[event.EventId()]
+	if delivered == nil {
+		delivered = make(map[int]bool, len(b.handlers))
+	}
+
+	var errs []error
+	for i, handler := range b.handlers {
+		if delivered[i] {
+			continue
+		}
+
+		err := handler(ctx, event)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		delivered[i] = true
+	}
+
+	if len(errs) > 0 {
+		b.delivered[event.EventId()] = delivered
+		return errors.Join(errs...)
+	}
+
+	delete(b.delivered, event.EventId())
+	return nil
+}
+
+// Forget drops the handlers which accepted an event given up on
+func (b *InProcessEventBus) Forget(_ context.Context, eventId uuid.UUID) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	delete(b.delivered, eventId)
+}
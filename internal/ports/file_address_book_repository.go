@@ -0,0 +1,134 @@
+package ports
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+)
+
+const addressBooksCollection = "address_books"
+
+// FileAddressBookRepository persists address books in a FileStore, next to the contacts.
+// It is thread safe: Update and Delete closures run while holding the store write lock.
+type FileAddressBookRepository struct {
+	store *FileStore
+}
+
+func NewFileAddressBookRepository(store *FileStore) *FileAddressBookRepository {
+	return &FileAddressBookRepository{
+		store: store,
+	}
+}
+
+func (r *FileAddressBookRepository) Get(_ context.Context, id uuid.UUID) (*domain.AddressBook, error) {
+	var addressBook *domain.AddressBook
+	err := r.store.view(func(tx *fileTx) error {
+		var err error
+		addressBook, err = getFileAddressBook(tx, id)
+		return err
+	})
+
+	return addressBook, err
+}
+
+func (r *FileAddressBookRepository) ListByMember(_ context.Context, userId uuid.UUID) ([]*domain.AddressBook, error) {
+	addressBooks := make([]*domain.AddressBook, 0)
+	err := r.store.view(func(tx *fileTx) error {
+		return tx.forEach(addressBooksCollection, func(_ string, raw json.RawMessage) error {
+			var addressBook domain.AddressBook
+			err := json.Unmarshal(raw, &addressBook)
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrCorruptedStore, err)
+			}
+
+			if _, ok := addressBook.Role(userId); ok {
+				addressBooks = append(addressBooks, &addressBook)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sortAddressBooks(addressBooks), nil
+}
+
+func (r *FileAddressBookRepository) Create(_ context.Context, addressBook *domain.AddressBook) (*domain.AddressBook, error) {
+	err := r.store.update(func(tx *fileTx) error {
+		return tx.put(addressBooksCollection, addressBook.Id.String(), addressBook)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return addressBook, nil
+}
+
+func (r *FileAddressBookRepository) Update(_ context.Context, id uuid.UUID, updateFn func(b domain.AddressBook) (domain.AddressBook, error)) (*domain.AddressBook, error) {
+	var updatedAddressBook domain.AddressBook
+	err := r.store.update(func(tx *fileTx) error {
+		originalAddressBook, err := getFileAddressBook(tx, id)
+		if err != nil {
+			return err
+		}
+
+		updatedAddressBook, err = updateFn(*originalAddressBook)
+		if err != nil {
+			return err
+		}
+
+		return tx.put(addressBooksCollection, id.String(), updatedAddressBook)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedAddressBook, nil
+}
+
+func (r *FileAddressBookRepository) Delete(_ context.Context, id uuid.UUID, deleterFn func(b domain.AddressBook) error) error {
+	return r.store.update(func(tx *fileTx) error {
+		addressBook, err := getFileAddressBook(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := deleterFn(*addressBook); err != nil {
+			return err
+		}
+
+		err = tx.forEach(contactsCollection, func(_ string, raw json.RawMessage) error {
+			var contact domain.Contact
+			err := json.Unmarshal(raw, &contact)
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrCorruptedStore, err)
+			}
+			if contact.AddressBookId == id {
+				return fmt.Errorf("%w: %s", ErrAddressBookNotEmpty, id)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		return tx.delete(addressBooksCollection, id.String())
+	})
+}
+
+func getFileAddressBook(tx *fileTx, id uuid.UUID) (*domain.AddressBook, error) {
+	var addressBook domain.AddressBook
+	ok, err := tx.get(addressBooksCollection, id.String(), &addressBook)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCorruptedStore, err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: address book %s", ErrNotFound, id)
+	}
+
+	return &addressBook, nil
+}
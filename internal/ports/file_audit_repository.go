@@ -0,0 +1,53 @@
+package ports
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+)
+
+const auditCollection = "audit_entries"
+
+// FileAuditRepository persists the contacts audit entries in a FileStore, next to the contacts.
+// It is thread safe.
+type FileAuditRepository struct {
+	store *FileStore
+}
+
+func NewFileAuditRepository(store *FileStore) *FileAuditRepository {
+	return &FileAuditRepository{
+		store: store,
+	}
+}
+
+func (r *FileAuditRepository) Append(_ context.Context, entry *domain.AuditEntry) error {
+	return r.store.update(func(tx *fileTx) error {
+		return tx.put(auditCollection, entry.Id.String(), toAuditRecord(entry))
+	})
+}
+
+func (r *FileAuditRepository) ListByContact(_ context.Context, contactId uuid.UUID) ([]*domain.AuditEntry, error) {
+	entries := make([]*domain.AuditEntry, 0)
+	err := r.store.view(func(tx *fileTx) error {
+		return tx.forEach(auditCollection, func(_ string, raw json.RawMessage) error {
+			var record auditRecord
+			err := json.Unmarshal(raw, &record)
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrCorruptedStore, err)
+			}
+
+			if record.ContactId == contactId {
+				entries = append(entries, record.toDomain())
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sortAuditEntries(entries), nil
+}
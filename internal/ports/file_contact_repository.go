@@ -0,0 +1,321 @@
+package ports
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	contactsCollection = "contacts"
+	outboxCollection   = "outbox"
+)
+
+// FileContactRepository persists contacts in a FileStore, along with the events recorded on them in its outbox.
+// It is thread safe: the constraints are checked and the Update and Delete closures run while holding the store write lock.
+type FileContactRepository struct {
+	store       *FileStore
+	constraints contactConstraints
+}
+
+func NewFileContactRepository(store *FileStore, options ...ContactConstraint) *FileContactRepository {
+	return &FileContactRepository{
+		store:       store,
+		constraints: newContactConstraints(options...),
+	}
+}
+
+func (r *FileContactRepository) Get(_ context.Context, id uuid.UUID) (*domain.Contact, error) {
+	var contact *domain.Contact
+	err := r.store.view(func(tx *fileTx) error {
+		var err error
+		contact, err = getFileContact(tx, id)
+		return err
+	})
+
+	return contact, err
+}
+
+func (r *FileContactRepository) List(_ context.Context, filter domain.Filter) ([]*domain.Contact, error) {
+	var contacts []*domain.Contact
+	err := r.store.view(func(tx *fileTx) error {
+		return tx.forEach(contactsCollection, func(_ string, raw json.RawMessage) error {
+			var contact domain.Contact
+			err := json.Unmarshal(raw, &contact)
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrCorruptedStore, err)
+			}
+
+			if filterBy(filter, &contact) {
+				contacts = append(contacts, &contact)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return paginate(filter, contacts), nil
+}
+
+func (r *FileContactRepository) Create(_ context.Context, contact *domain.Contact) (*domain.Contact, error) {
+	err := r.store.update(func(tx *fileTx) error {
+		return r.put(tx, contact)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return contact, nil
+}
+
+func (r *FileContactRepository) Update(_ context.Context, id uuid.UUID, updateFn func(c domain.Contact) (domain.Contact, error)) (*domain.Contact, error) {
+	var updatedContact *domain.Contact
+	err := r.store.update(func(tx *fileTx) error {
+		var err error
+		updatedContact, err = r.update(tx, id, updateFn)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedContact, nil
+}
+
+// Batch applies the operations within a single store transaction, written as one write-ahead log record
+func (r *FileContactRepository) Batch(_ context.Context, ops []domain.ContactOperation) ([]*domain.Contact, error) {
+	contacts := make([]*domain.Contact, 0, len(ops))
+	err := r.store.update(func(tx *fileTx) error {
+		for i, op := range ops {
+			if op.Create != nil {
+				err := r.put(tx, op.Create)
+				if err != nil {
+					return &domain.OperationError{Index: i, Err: err}
+				}
+				contacts = append(contacts, op.Create)
+				continue
+			}
+
+			updatedContact, err := r.update(tx, op.Id, op.UpdateFn)
+			if err != nil {
+				return &domain.OperationError{Index: i, Err: err}
+			}
+			contacts = append(contacts, updatedContact)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return contacts, nil
+}
+
+func (r *FileContactRepository) Delete(_ context.Context, id uuid.UUID, deleterFn func(c domain.Contact) error) error {
+	return r.store.update(func(tx *fileTx) error {
+		contact, err := getFileContact(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := deleterFn(*contact); err != nil {
+			return err
+		}
+
+		return tx.delete(contactsCollection, id.String())
+	})
+}
+
+func (r *FileContactRepository) Purge(_ context.Context, deletedBefore time.Time) (int, error) {
+	var purged int
+	err := r.store.update(func(tx *fileTx) error {
+		var ids []string
+		err := tx.forEach(contactsCollection, func(id string, raw json.RawMessage) error {
+			var contact domain.Contact
+			err := json.Unmarshal(raw, &contact)
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrCorruptedStore, err)
+			}
+
+			if contact.IsDeleted() && contact.DeletedAt.Before(deletedBefore) {
+				ids = append(ids, id)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			err := tx.delete(contactsCollection, id)
+			if err != nil {
+				return err
+			}
+		}
+		purged = len(ids)
+
+		return nil
+	})
+
+	return purged, err
+}
+
+func (r *FileContactRepository) Pending(_ context.Context, limit int) ([]domain.Event, error) {
+	var records []outboxRecord
+	err := r.store.view(func(tx *fileTx) error {
+		return tx.forEach(outboxCollection, func(_ string, raw json.RawMessage) error {
+			var record outboxRecord
+			err := json.Unmarshal(raw, &record)
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrCorruptedStore, err)
+			}
+
+			if record.DeadAt == nil {
+				records = append(records, record)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records = sortOutboxRecords(records)
+	if len(records) > limit {
+		records = records[:limit]
+	}
+
+	events := make([]domain.Event, 0, len(records))
+	for _, record := range records {
+		event, err := record.toDomain()
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (r *FileContactRepository) MarkPublished(_ context.Context, ids ...uuid.UUID) error {
+	return r.store.update(func(tx *fileTx) error {
+		for _, id := range ids {
+			err := tx.delete(outboxCollection, id.String())
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *FileContactRepository) MarkFailed(_ context.Context, id uuid.UUID, reason string, maxAttempts int) (bool, error) {
+	var dead bool
+	err := r.store.update(func(tx *fileTx) error {
+		var record outboxRecord
+		found, err := tx.get(outboxCollection, id.String(), &record)
+		if err != nil {
+			return err
+		}
+		if !found || record.DeadAt != nil {
+			return fmt.Errorf("%w: event %s", ErrNotFound, id)
+		}
+
+		record.Attempts++
+		record.LastError = reason
+		if record.Attempts >= maxAttempts {
+			now := time.Now().UTC()
+			record.DeadAt = &now
+			dead = true
+		}
+
+		return tx.put(outboxCollection, id.String(), record)
+	})
+
+	return dead, err
+}
+
+// putFileContact saves the contact along with its audit entries and moves its recorded events to the outbox within the same transaction
+func putFileContact(tx *fileTx, contact *domain.Contact) error {
+	for _, entry := range contact.PullAuditEntries() {
+		err := tx.put(auditCollection, entry.Id.String(), toAuditRecord(entry))
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, event := range contact.PullEvents() {
+		record, err := toOutboxRecord(event)
+		if err != nil {
+			return err
+		}
+
+		err = tx.put(outboxCollection, record.Id.String(), record)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.put(contactsCollection, contact.Id.String(), contact)
+}
+
+// put checks the constraints against the contacts seen by the transaction before saving the contact
+func (r *FileContactRepository) put(tx *fileTx, contact *domain.Contact) error {
+	err := r.constraints.checkAll(contact, func(fn func(other *domain.Contact) error) error {
+		return tx.forEachLatest(contactsCollection, func(_ string, raw json.RawMessage) error {
+			var other domain.Contact
+			err := json.Unmarshal(raw, &other)
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrCorruptedStore, err)
+			}
+
+			return fn(&other)
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	return putFileContact(tx, contact)
+}
+
+func (r *FileContactRepository) update(tx *fileTx, id uuid.UUID, updateFn func(c domain.Contact) (domain.Contact, error)) (*domain.Contact, error) {
+	originalContact, err := getFileContact(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedContact, err := updateFn(*originalContact)
+	if err != nil {
+		return nil, err
+	}
+	updatedContact.Version = originalContact.Version + 1
+
+	err = r.put(tx, &updatedContact)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedContact, nil
+}
+
+func getFileContact(tx *fileTx, id uuid.UUID) (*domain.Contact, error) {
+	var contact domain.Contact
+	ok, err := tx.get(contactsCollection, id.String(), &contact)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCorruptedStore, err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+
+	return &contact, nil
+}
@@ -0,0 +1,185 @@
+package ports
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileContactRepository(t *testing.T) {
+	t.Parallel()
+
+	t.Run("recovers contacts from the write-ahead log", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		dir := t.TempDir()
+		store, repo := openFileContactRepository(t, dir)
+
+		created := createFileContact(t, repo)
+		deleted := createFileContact(t, repo)
+		_, err := repo.Update(ctx, created.Id, func(c domain.Contact) (domain.Contact, error) {
+			c.FirstName = "Jane"
+			return c, nil
+		})
+		require.NoError(t, err)
+		require.NoError(t, repo.Delete(ctx, deleted.Id, func(c domain.Contact) error { return nil }))
+
+		simulateCrash(t, store)
+
+		_, repo = openFileContactRepository(t, dir)
+		contact, err := repo.Get(ctx, created.Id)
+		require.NoError(t, err)
+		assert.Equal(t, "Jane", contact.FirstName)
+
+		_, err = repo.Get(ctx, deleted.Id)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("recovers contacts from snapshot and log", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		dir := t.TempDir()
+		store, err := OpenFileStore(dir, WithSnapshotEvery(3))
+		require.NoError(t, err)
+		repo := NewFileContactRepository(store)
+
+		var ids []uuid.UUID
+		for i := 0; i < 5; i++ {
+			ids = append(ids, createFileContact(t, repo).Id)
+		}
+		require.FileExists(t, filepath.Join(dir, snapshotFileName))
+		simulateCrash(t, store)
+
+		_, repo = openFileContactRepository(t, dir)
+		contacts, err := repo.List(ctx, NewFilter())
+		require.NoError(t, err)
+		assert.Len(t, contacts, len(ids))
+	})
+
+	t.Run("discards a torn record at the end of the log", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		dir := t.TempDir()
+		store, repo := openFileContactRepository(t, dir)
+		contact := createFileContact(t, repo)
+		simulateCrash(t, store)
+
+		wal, err := os.OpenFile(filepath.Join(dir, walFileName), os.O_APPEND|os.O_WRONLY, 0)
+		require.NoError(t, err)
+		_, err = wal.WriteString(`0badc0de {"seq":2,"ops":[{"collection":"contacts"`)
+		require.NoError(t, err)
+		require.NoError(t, wal.Close())
+
+		_, repo = openFileContactRepository(t, dir)
+		_, err = repo.Get(ctx, contact.Id)
+		require.NoError(t, err)
+
+		created := createFileContact(t, repo)
+		_, repo = openFileContactRepository(t, dir)
+		_, err = repo.Get(ctx, created.Id)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects a corrupted record in the middle of the log", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		store, repo := openFileContactRepository(t, dir)
+		createFileContact(t, repo)
+		createFileContact(t, repo)
+		simulateCrash(t, store)
+
+		path := filepath.Join(dir, walFileName)
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		data[0] = 'x'
+		require.NoError(t, os.WriteFile(path, data, 0o640))
+
+		_, err = OpenFileStore(dir)
+		assert.ErrorIs(t, err, ErrCorruptedStore)
+	})
+
+	t.Run("runs concurrent updates atomically", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		_, repo := openFileContactRepository(t, t.TempDir())
+		contact := createFileContact(t, repo)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := repo.Update(ctx, contact.Id, func(c domain.Contact) (domain.Contact, error) {
+					c.LastName += "+"
+					return c, nil
+				})
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		updated, err := repo.Get(ctx, contact.Id)
+		require.NoError(t, err)
+		assert.Equal(t, "Doe"+strings.Repeat("+", 20), updated.LastName)
+	})
+
+	t.Run("does not persist failed updates", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		dir := t.TempDir()
+		_, repo := openFileContactRepository(t, dir)
+		contact := createFileContact(t, repo)
+
+		_, err := repo.Update(ctx, contact.Id, func(c domain.Contact) (domain.Contact, error) {
+			c.FirstName = "Jane"
+			return c, assert.AnError
+		})
+		assert.ErrorIs(t, err, assert.AnError)
+
+		_, repo = openFileContactRepository(t, dir)
+		stored, err := repo.Get(ctx, contact.Id)
+		require.NoError(t, err)
+		assert.Equal(t, "John", stored.FirstName)
+	})
+}
+
+func openFileContactRepository(t *testing.T, dir string, options ...ContactConstraint) (*FileStore, *FileContactRepository) {
+	t.Helper()
+
+	store, err := OpenFileStore(dir)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	return store, NewFileContactRepository(store, options...)
+}
+
+// simulateCrash releases the store files without taking a snapshot
+func simulateCrash(t *testing.T, store *FileStore) {
+	t.Helper()
+
+	require.NoError(t, store.wal.Close())
+	store.wal = nil
+}
+
+func createFileContact(t *testing.T, repo *FileContactRepository) *domain.Contact {
+	t.Helper()
+
+	created, err := repo.Create(context.Background(), testContact(uuid.New()))
+	require.NoError(t, err)
+
+	return created
+}
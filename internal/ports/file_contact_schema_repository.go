@@ -0,0 +1,53 @@
+package ports
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+)
+
+const contactSchemasCollection = "contact_schemas"
+
+// FileContactSchemaRepository persists the contact schemas in a FileStore, keyed by owner
+type FileContactSchemaRepository struct {
+	store *FileStore
+}
+
+func NewFileContactSchemaRepository(store *FileStore) *FileContactSchemaRepository {
+	return &FileContactSchemaRepository{
+		store: store,
+	}
+}
+
+func (r *FileContactSchemaRepository) Get(_ context.Context, ownerId uuid.UUID) (*domain.ContactSchema, error) {
+	var schema domain.ContactSchema
+	err := r.store.view(func(tx *fileTx) error {
+		ok, err := tx.get(contactSchemasCollection, ownerId.String(), &schema)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrCorruptedStore, err)
+		}
+		if !ok {
+			return fmt.Errorf("%w: contact schema of %s", ErrNotFound, ownerId)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema, nil
+}
+
+func (r *FileContactSchemaRepository) Save(_ context.Context, schema *domain.ContactSchema) (*domain.ContactSchema, error) {
+	err := r.store.update(func(tx *fileTx) error {
+		return tx.put(contactSchemasCollection, schema.OwnerId.String(), schema)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
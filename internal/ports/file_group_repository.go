@@ -0,0 +1,142 @@
+package ports
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+)
+
+const groupsCollection = "groups"
+
+// FileGroupRepository persists groups in a FileStore, next to the contacts it removes the deleted groups from.
+// It is thread safe: Update and Delete closures run while holding the store write lock.
+type FileGroupRepository struct {
+	store *FileStore
+}
+
+func NewFileGroupRepository(store *FileStore) *FileGroupRepository {
+	return &FileGroupRepository{
+		store: store,
+	}
+}
+
+func (r *FileGroupRepository) Get(_ context.Context, id uuid.UUID) (*domain.Group, error) {
+	var group *domain.Group
+	err := r.store.view(func(tx *fileTx) error {
+		var err error
+		group, err = getFileGroup(tx, id)
+		return err
+	})
+
+	return group, err
+}
+
+func (r *FileGroupRepository) ListByOwner(_ context.Context, userId uuid.UUID) ([]*domain.Group, error) {
+	groups := make([]*domain.Group, 0)
+	err := r.store.view(func(tx *fileTx) error {
+		return tx.forEach(groupsCollection, func(_ string, raw json.RawMessage) error {
+			var group domain.Group
+			err := json.Unmarshal(raw, &group)
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrCorruptedStore, err)
+			}
+
+			if group.CreatedBy == userId {
+				groups = append(groups, &group)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sortGroups(groups), nil
+}
+
+func (r *FileGroupRepository) Create(_ context.Context, group *domain.Group) (*domain.Group, error) {
+	err := r.store.update(func(tx *fileTx) error {
+		return tx.put(groupsCollection, group.Id.String(), group)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (r *FileGroupRepository) Update(_ context.Context, id uuid.UUID, updateFn func(g domain.Group) (domain.Group, error)) (*domain.Group, error) {
+	var updatedGroup domain.Group
+	err := r.store.update(func(tx *fileTx) error {
+		originalGroup, err := getFileGroup(tx, id)
+		if err != nil {
+			return err
+		}
+
+		updatedGroup, err = updateFn(*originalGroup)
+		if err != nil {
+			return err
+		}
+
+		return tx.put(groupsCollection, id.String(), updatedGroup)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedGroup, nil
+}
+
+func (r *FileGroupRepository) Delete(_ context.Context, id uuid.UUID, deleterFn func(g domain.Group) error) error {
+	return r.store.update(func(tx *fileTx) error {
+		group, err := getFileGroup(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := deleterFn(*group); err != nil {
+			return err
+		}
+
+		var members []*domain.Contact
+		err = tx.forEach(contactsCollection, func(_ string, raw json.RawMessage) error {
+			var contact domain.Contact
+			err := json.Unmarshal(raw, &contact)
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrCorruptedStore, err)
+			}
+			if contact.RemoveFromGroup(id) {
+				members = append(members, &contact)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, contact := range members {
+			err = putFileContact(tx, contact)
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.delete(groupsCollection, id.String())
+	})
+}
+
+func getFileGroup(tx *fileTx, id uuid.UUID) (*domain.Group, error) {
+	var group domain.Group
+	ok, err := tx.get(groupsCollection, id.String(), &group)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCorruptedStore, err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: group %s", ErrNotFound, id)
+	}
+
+	return &group, nil
+}
@@ -0,0 +1,467 @@
+package ports
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	walFileName      = "wal.log"
+	snapshotFileName = "snapshot.json"
+
+	// DefaultSnapshotEvery is the number of committed records after which the
+	// write-ahead log is compacted into a snapshot
+	DefaultSnapshotEvery = 1000
+)
+
+var ErrCorruptedStore = errors.New("corrupted store")
+
+// FileStore is a durable key/value store persisted in a data directory.
+// Every commit is appended to a write-ahead log and fsynced before being applied
+// to the in-memory state. The log is periodically compacted into a snapshot.
+// On startup the snapshot is loaded and the log replayed, a torn record at the
+// end of the log (crash during a write) is discarded.
+type FileStore struct {
+	mu sync.RWMutex
+
+	dir           string
+	wal           *os.File
+	seq           uint64
+	sinceSnapshot int
+	snapshotEvery int
+
+	collections map[string]map[string]json.RawMessage
+}
+
+type walOp struct {
+	Collection string          `json:"collection"`
+	Key        string          `json:"key"`
+	Value      json.RawMessage `json:"value,omitempty"`
+	Delete     bool            `json:"delete,omitempty"`
+}
+
+type walRecord struct {
+	Seq uint64  `json:"seq"`
+	Ops []walOp `json:"ops"`
+}
+
+type storeSnapshot struct {
+	Seq         uint64                                `json:"seq"`
+	Collections map[string]map[string]json.RawMessage `json:"collections"`
+}
+
+type withFileStore func(s *FileStore)
+
+// WithSnapshotEvery sets the number of committed records after which a snapshot is taken
+func WithSnapshotEvery(n int) withFileStore {
+	return func(s *FileStore) {
+		if n > 0 {
+			s.snapshotEvery = n
+		}
+	}
+}
+
+// OpenFileStore opens (or creates) a store in dir and recovers its state
+func OpenFileStore(dir string, options ...withFileStore) (*FileStore, error) {
+	s := &FileStore{
+		dir:           dir,
+		snapshotEvery: DefaultSnapshotEvery,
+		collections:   map[string]map[string]json.RawMessage{},
+	}
+	for _, o := range options {
+		o(s)
+	}
+
+	err := os.MkdirAll(dir, 0o750)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	err = s.loadSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	s.wal, err = os.OpenFile(filepath.Join(dir, walFileName), os.O_RDWR|os.O_CREATE, 0o640)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open write-ahead log: %w", err)
+	}
+
+	err = s.replay()
+	if err != nil {
+		s.wal.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Close compacts the write-ahead log into a snapshot and releases the files
+func (s *FileStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.wal == nil {
+		return nil
+	}
+
+	err := s.snapshot()
+	if err != nil {
+		return err
+	}
+
+	err = s.wal.Close()
+	s.wal = nil
+
+	return err
+}
+
+func (s *FileStore) loadSnapshot() error {
+	data, err := os.ReadFile(filepath.Join(s.dir, snapshotFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var snap storeSnapshot
+	err = json.Unmarshal(data, &snap)
+	if err != nil {
+		return fmt.Errorf("%w: invalid snapshot: %s", ErrCorruptedStore, err)
+	}
+
+	s.seq = snap.Seq
+	if snap.Collections != nil {
+		s.collections = snap.Collections
+	}
+
+	return nil
+}
+
+// replay applies the write-ahead log records which are not part of the snapshot.
+// A torn or corrupted last record is truncated, a corrupted record followed by
+// valid ones means the log cannot be trusted.
+func (s *FileStore) replay() error {
+	reader := bufio.NewReader(s.wal)
+	var offset int64
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			if len(line) > 0 {
+				return s.truncateWAL(offset)
+			}
+			_, err = s.wal.Seek(0, io.SeekEnd)
+			return err
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read write-ahead log: %w", err)
+		}
+
+		record, err := decodeWALRecord(line)
+		if err != nil {
+			if _, peekErr := reader.Peek(1); errors.Is(peekErr, io.EOF) {
+				return s.truncateWAL(offset)
+			}
+			return fmt.Errorf("%w: record at offset %d: %s", ErrCorruptedStore, offset, err)
+		}
+		offset += int64(len(line))
+
+		if record.Seq <= s.seq {
+			continue
+		}
+		s.apply(record.Ops)
+		s.seq = record.Seq
+		s.sinceSnapshot++
+	}
+}
+
+func (s *FileStore) truncateWAL(offset int64) error {
+	err := s.wal.Truncate(offset)
+	if err != nil {
+		return fmt.Errorf("failed to truncate write-ahead log: %w", err)
+	}
+
+	_, err = s.wal.Seek(offset, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("failed to truncate write-ahead log: %w", err)
+	}
+
+	return s.wal.Sync()
+}
+
+// encodeWALRecord serializes a record as a single line prefixed by its checksum
+func encodeWALRecord(record walRecord) ([]byte, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+
+	line := make([]byte, 0, len(data)+10)
+	line = fmt.Appendf(line, "%08x ", crc32.ChecksumIEEE(data))
+	line = append(line, data...)
+
+	return append(line, '\n'), nil
+}
+
+func decodeWALRecord(line []byte) (walRecord, error) {
+	var record walRecord
+
+	line = bytes.TrimSuffix(line, []byte("\n"))
+	checksum, data, ok := bytes.Cut(line, []byte(" "))
+	if !ok {
+		return record, errors.New("missing checksum")
+	}
+
+	if fmt.Sprintf("%08x", crc32.ChecksumIEEE(data)) != string(checksum) {
+		return record, errors.New("checksum mismatch")
+	}
+
+	err := json.Unmarshal(data, &record)
+	return record, err
+}
+
+func (s *FileStore) apply(ops []walOp) {
+	for _, op := range ops {
+		collection, ok := s.collections[op.Collection]
+		if !ok {
+			collection = map[string]json.RawMessage{}
+			s.collections[op.Collection] = collection
+		}
+
+		if op.Delete {
+			delete(collection, op.Key)
+			continue
+		}
+		collection[op.Key] = op.Value
+	}
+}
+
+// commit durably appends the operations to the write-ahead log as a single record
+// and applies them. The caller must hold the write lock.
+func (s *FileStore) commit(ops []walOp) error {
+	if len(ops) == 0 {
+		return nil
+	}
+	if s.wal == nil {
+		return errors.New("store is closed")
+	}
+
+	record := walRecord{Seq: s.seq + 1, Ops: ops}
+	line, err := encodeWALRecord(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode write-ahead log record: %w", err)
+	}
+
+	offset, err := s.wal.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("failed to write write-ahead log: %w", err)
+	}
+
+	_, err = s.wal.Write(line)
+	if err == nil {
+		err = s.wal.Sync()
+	}
+	if err != nil {
+		// drop a partially written record so that following ones are not appended to it
+		_ = s.truncateWAL(offset)
+		return fmt.Errorf("failed to write write-ahead log: %w", err)
+	}
+
+	s.apply(ops)
+	s.seq = record.Seq
+	s.sinceSnapshot++
+
+	if s.sinceSnapshot >= s.snapshotEvery {
+		// the record is durable in the log, a failed compaction is retried on next commit
+		_ = s.snapshot()
+	}
+
+	return nil
+}
+
+// snapshot writes the whole state atomically then truncates the write-ahead log.
+// The caller must hold the write lock.
+func (s *FileStore) snapshot() error {
+	if s.sinceSnapshot == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(storeSnapshot{Seq: s.seq, Collections: s.collections})
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	tmpPath := filepath.Join(s.dir, snapshotFileName+".tmp")
+	err = writeFileSync(tmpPath, data)
+	if err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	err = os.Rename(tmpPath, filepath.Join(s.dir, snapshotFileName))
+	if err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	err = syncDir(s.dir)
+	if err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	// records up to s.seq are now part of the snapshot and skipped on replay
+	// should the process crash before the log is truncated
+	err = s.truncateWAL(0)
+	if err != nil {
+		return err
+	}
+	s.sinceSnapshot = 0
+
+	return nil
+}
+
+func writeFileSync(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o640)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.Sync()
+}
+
+// view runs fn with a read-only transaction
+func (s *FileStore) view(fn func(tx *fileTx) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return fn(&fileTx{store: s})
+}
+
+// update runs fn within a read-write transaction. Writes are committed as a
+// single write-ahead log record if and only if fn succeeds.
+func (s *FileStore) update(fn func(tx *fileTx) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx := &fileTx{store: s, writable: true}
+	err := fn(tx)
+	if err != nil {
+		return err
+	}
+
+	return s.commit(tx.ops)
+}
+
+// fileTx gives access to the store state, reads observe the transaction own writes
+type fileTx struct {
+	store    *FileStore
+	writable bool
+	ops      []walOp
+}
+
+func (tx *fileTx) get(collection string, key string, v any) (bool, error) {
+	for i := len(tx.ops) - 1; i >= 0; i-- {
+		op := tx.ops[i]
+		if op.Collection == collection && op.Key == key {
+			if op.Delete {
+				return false, nil
+			}
+			return true, json.Unmarshal(op.Value, v)
+		}
+	}
+
+	raw, ok := tx.store.collections[collection][key]
+	if !ok {
+		return false, nil
+	}
+
+	return true, json.Unmarshal(raw, v)
+}
+
+// forEach iterates over the committed values of a collection
+func (tx *fileTx) forEach(collection string, fn func(key string, raw json.RawMessage) error) error {
+	for key, raw := range tx.store.collections[collection] {
+		err := fn(key, raw)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// forEachLatest iterates over the values of a collection as seen by the transaction, including its own writes
+func (tx *fileTx) forEachLatest(collection string, fn func(key string, raw json.RawMessage) error) error {
+	written := map[string]bool{}
+	for i := len(tx.ops) - 1; i >= 0; i-- {
+		op := tx.ops[i]
+		if op.Collection != collection || written[op.Key] {
+			continue
+		}
+		written[op.Key] = true
+
+		if !op.Delete {
+			err := fn(op.Key, op.Value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.forEach(collection, func(key string, raw json.RawMessage) error {
+		if written[key] {
+			return nil
+		}
+
+		return fn(key, raw)
+	})
+}
+
+func (tx *fileTx) put(collection string, key string, v any) error {
+	if !tx.writable {
+		return errors.New("read-only transaction")
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	tx.ops = append(tx.ops, walOp{Collection: collection, Key: key, Value: data})
+	return nil
+}
+
+func (tx *fileTx) delete(collection string, key string) error {
+	if !tx.writable {
+		return errors.New("read-only transaction")
+	}
+
+	tx.ops = append(tx.ops, walOp{Collection: collection, Key: key, Delete: true})
+	return nil
+}
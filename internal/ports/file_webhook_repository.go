@@ -0,0 +1,222 @@
+package ports
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	webhooksCollection   = "webhooks"
+	deliveriesCollection = "webhook_deliveries"
+)
+
+// FileWebhookRepository persists webhooks and their deliveries in a FileStore, next to the contacts.
+// It is thread safe: Update and Delete closures run while holding the store write lock.
+type FileWebhookRepository struct {
+	store *FileStore
+}
+
+func NewFileWebhookRepository(store *FileStore) *FileWebhookRepository {
+	return &FileWebhookRepository{
+		store: store,
+	}
+}
+
+func (r *FileWebhookRepository) Get(_ context.Context, id uuid.UUID) (*domain.Webhook, error) {
+	var webhook *domain.Webhook
+	err := r.store.view(func(tx *fileTx) error {
+		var err error
+		webhook, err = getFileWebhook(tx, id)
+		return err
+	})
+
+	return webhook, err
+}
+
+func (r *FileWebhookRepository) ListByOwner(_ context.Context, userId uuid.UUID) ([]*domain.Webhook, error) {
+	return r.list(func(w *domain.Webhook) bool { return w.CreatedBy == userId })
+}
+
+func (r *FileWebhookRepository) ListByEvent(_ context.Context, eventName string) ([]*domain.Webhook, error) {
+	return r.list(func(w *domain.Webhook) bool { return w.Subscribes(eventName) })
+}
+
+func (r *FileWebhookRepository) list(match func(w *domain.Webhook) bool) ([]*domain.Webhook, error) {
+	webhooks := make([]*domain.Webhook, 0)
+	err := r.store.view(func(tx *fileTx) error {
+		return tx.forEach(webhooksCollection, func(_ string, raw json.RawMessage) error {
+			var webhook domain.Webhook
+			err := json.Unmarshal(raw, &webhook)
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrCorruptedStore, err)
+			}
+
+			if match(&webhook) {
+				webhooks = append(webhooks, &webhook)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sortWebhooks(webhooks), nil
+}
+
+func (r *FileWebhookRepository) Create(_ context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+	err := r.store.update(func(tx *fileTx) error {
+		return tx.put(webhooksCollection, webhook.Id.String(), webhook)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (r *FileWebhookRepository) Update(_ context.Context, id uuid.UUID, updateFn func(w domain.Webhook) (domain.Webhook, error)) (*domain.Webhook, error) {
+	var updatedWebhook domain.Webhook
+	err := r.store.update(func(tx *fileTx) error {
+		originalWebhook, err := getFileWebhook(tx, id)
+		if err != nil {
+			return err
+		}
+
+		updatedWebhook, err = updateFn(*originalWebhook)
+		if err != nil {
+			return err
+		}
+
+		return tx.put(webhooksCollection, id.String(), updatedWebhook)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedWebhook, nil
+}
+
+func (r *FileWebhookRepository) Delete(_ context.Context, id uuid.UUID, deleterFn func(w domain.Webhook) error) error {
+	return r.store.update(func(tx *fileTx) error {
+		webhook, err := getFileWebhook(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := deleterFn(*webhook); err != nil {
+			return err
+		}
+
+		deliveries, err := listFileDeliveries(tx, func(d *domain.Delivery) bool { return d.WebhookId == id })
+		if err != nil {
+			return err
+		}
+		for _, delivery := range deliveries {
+			err := tx.delete(deliveriesCollection, delivery.Id.String())
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.delete(webhooksCollection, id.String())
+	})
+}
+
+func (r *FileWebhookRepository) CreateDelivery(_ context.Context, delivery *domain.Delivery) (*domain.Delivery, error) {
+	created := delivery
+	err := r.store.update(func(tx *fileTx) error {
+		existing, err := listFileDeliveries(tx, func(d *domain.Delivery) bool {
+			return d.WebhookId == delivery.WebhookId && d.EventId == delivery.EventId
+		})
+		if err != nil {
+			return err
+		}
+		if len(existing) > 0 {
+			created = existing[0]
+			return nil
+		}
+
+		return tx.put(deliveriesCollection, delivery.Id.String(), delivery)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (r *FileWebhookRepository) ListDueDeliveries(_ context.Context, at time.Time, limit int) ([]*domain.Delivery, error) {
+	var deliveries []*domain.Delivery
+	err := r.store.view(func(tx *fileTx) error {
+		var err error
+		deliveries, err = listFileDeliveries(tx, func(d *domain.Delivery) bool { return d.Status == domain.DeliveryStatusPending })
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dueDeliveries(deliveries, at, limit), nil
+}
+
+func (r *FileWebhookRepository) ListDeliveries(_ context.Context, webhookId uuid.UUID) ([]*domain.Delivery, error) {
+	var deliveries []*domain.Delivery
+	err := r.store.view(func(tx *fileTx) error {
+		var err error
+		deliveries, err = listFileDeliveries(tx, func(d *domain.Delivery) bool { return d.WebhookId == webhookId })
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sortDeliveries(deliveries), nil
+}
+
+func (r *FileWebhookRepository) SaveDelivery(_ context.Context, delivery *domain.Delivery) (*domain.Delivery, error) {
+	err := r.store.update(func(tx *fileTx) error {
+		return tx.put(deliveriesCollection, delivery.Id.String(), delivery)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+func getFileWebhook(tx *fileTx, id uuid.UUID) (*domain.Webhook, error) {
+	var webhook domain.Webhook
+	ok, err := tx.get(webhooksCollection, id.String(), &webhook)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCorruptedStore, err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: webhook %s", ErrNotFound, id)
+	}
+
+	return &webhook, nil
+}
+
+func listFileDeliveries(tx *fileTx, match func(d *domain.Delivery) bool) ([]*domain.Delivery, error) {
+	deliveries := make([]*domain.Delivery, 0)
+	err := tx.forEach(deliveriesCollection, func(_ string, raw json.RawMessage) error {
+		var delivery domain.Delivery
+		err := json.Unmarshal(raw, &delivery)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrCorruptedStore, err)
+		}
+
+		if match(&delivery) {
+			deliveries = append(deliveries, &delivery)
+		}
+		return nil
+	})
+
+	return deliveries, err
+}
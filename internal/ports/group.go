@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"sort"
+
+	"github.com/davidterranova/contacts/internal/domain"
+)
+
+// sortGroups orders groups by name then id, it is shared by the repositories listing in memory
+func sortGroups(groups []*domain.Group) []*domain.Group {
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Name != groups[j].Name {
+			return groups[i].Name < groups[j].Name
+		}
+		return groups[i].Id.String() < groups[j].Id.String()
+	})
+
+	return groups
+}
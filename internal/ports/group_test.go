@@ -0,0 +1,168 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type groupRepository interface {
+	Get(ctx context.Context, id uuid.UUID) (*domain.Group, error)
+	ListByOwner(ctx context.Context, userId uuid.UUID) ([]*domain.Group, error)
+	Create(ctx context.Context, group *domain.Group) (*domain.Group, error)
+	Update(ctx context.Context, id uuid.UUID, updateFn func(g domain.Group) (domain.Group, error)) (*domain.Group, error)
+	Delete(ctx context.Context, id uuid.UUID, deleteFn func(g domain.Group) error) error
+}
+
+// testGroupRepositories returns group and contact repositories sharing their storage
+func testGroupRepositories() map[string]func(t *testing.T) (groupRepository, contactTrash) {
+	return map[string]func(t *testing.T) (groupRepository, contactTrash){
+		"in memory": func(t *testing.T) (groupRepository, contactTrash) {
+			contacts := NewInMemoryContactRepository()
+			return NewInMemoryGroupRepository(contacts), contacts
+		},
+		"file": func(t *testing.T) (groupRepository, contactTrash) {
+			store, repo := openFileContactRepository(t, t.TempDir())
+			return NewFileGroupRepository(store), repo
+		},
+		"sql": func(t *testing.T) (groupRepository, contactTrash) {
+			contacts := testSQLContactRepository(t)
+			groups, err := NewSQLGroupRepository(contacts.db, DriverSQLite)
+			require.NoError(t, err)
+			return groups, contacts
+		},
+	}
+}
+
+func TestGroupRepositories(t *testing.T) {
+	t.Parallel()
+
+	for name, newRepo := range testGroupRepositories() {
+		newRepo := newRepo
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			repo, _ := newRepo(t)
+			owner := uuid.New()
+
+			friends := domain.NewGroup(owner, "friends")
+			colleagues := domain.NewGroup(owner, "colleagues")
+			for _, group := range []*domain.Group{friends, colleagues, domain.NewGroup(uuid.New(), "family")} {
+				_, err := repo.Create(ctx, group)
+				require.NoError(t, err)
+			}
+
+			got, err := repo.Get(ctx, friends.Id)
+			require.NoError(t, err)
+			assert.Equal(t, friends.Name, got.Name)
+			assert.Equal(t, owner, got.CreatedBy)
+
+			groups, err := repo.ListByOwner(ctx, owner)
+			require.NoError(t, err)
+			require.Len(t, groups, 2)
+			assert.Equal(t, colleagues.Id, groups[0].Id)
+			assert.Equal(t, friends.Id, groups[1].Id)
+
+			updated, err := repo.Update(ctx, friends.Id, func(g domain.Group) (domain.Group, error) {
+				g.Name = "close friends"
+				return g, nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, "close friends", updated.Name)
+
+			errFailed := errors.New("failed")
+			_, err = repo.Update(ctx, friends.Id, func(g domain.Group) (domain.Group, error) {
+				g.Name = "acquaintances"
+				return g, errFailed
+			})
+			assert.ErrorIs(t, err, errFailed)
+			assert.ErrorIs(t, repo.Delete(ctx, friends.Id, func(domain.Group) error { return errFailed }), errFailed)
+
+			got, err = repo.Get(ctx, friends.Id)
+			require.NoError(t, err)
+			assert.Equal(t, "close friends", got.Name)
+
+			require.NoError(t, repo.Delete(ctx, friends.Id, func(domain.Group) error { return nil }))
+			_, err = repo.Get(ctx, friends.Id)
+			assert.ErrorIs(t, err, ErrNotFound)
+			_, err = repo.Update(ctx, uuid.New(), func(g domain.Group) (domain.Group, error) { return g, nil })
+			assert.ErrorIs(t, err, ErrNotFound)
+		})
+	}
+}
+
+func TestGroupFilter(t *testing.T) {
+	t.Parallel()
+
+	for name, newRepo := range testGroupRepositories() {
+		newRepo := newRepo
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			groups, contacts := newRepo(t)
+			owner := uuid.New()
+
+			friends, err := groups.Create(ctx, domain.NewGroup(owner, "friends"))
+			require.NoError(t, err)
+			family, err := groups.Create(ctx, domain.NewGroup(owner, "family"))
+			require.NoError(t, err)
+
+			both := testContact(owner)
+			both.AddToGroup(friends.Id)
+			both.AddToGroup(family.Id)
+			friend := testContact(owner)
+			friend.AddToGroup(friends.Id)
+			for _, contact := range []*domain.Contact{both, friend, testContact(owner)} {
+				_, err := contacts.Create(ctx, contact)
+				require.NoError(t, err)
+			}
+
+			got, err := contacts.Get(ctx, both.Id)
+			require.NoError(t, err)
+			assert.Equal(t, both.GroupIds, got.GroupIds)
+
+			listed, err := contacts.List(ctx, NewFilter(WithGroup(friends.Id), WithSort(domain.Sort{Field: domain.SortByCreatedAt})))
+			require.NoError(t, err)
+			require.Len(t, listed, 2)
+			assert.ElementsMatch(t, []uuid.UUID{both.Id, friend.Id}, []uuid.UUID{listed[0].Id, listed[1].Id})
+
+			_, err = contacts.Update(ctx, both.Id, func(c domain.Contact) (domain.Contact, error) {
+				c.RemoveFromGroup(family.Id)
+				return c, nil
+			})
+			require.NoError(t, err)
+
+			listed, err = contacts.List(ctx, NewFilter(WithGroup(family.Id)))
+			require.NoError(t, err)
+			assert.Empty(t, listed)
+
+			got, err = contacts.Get(ctx, both.Id)
+			require.NoError(t, err)
+			assert.Equal(t, []uuid.UUID{friends.Id}, got.GroupIds)
+
+			_, err = contacts.Update(ctx, friend.Id, func(c domain.Contact) (domain.Contact, error) {
+				c.Trash()
+				return c, nil
+			})
+			require.NoError(t, err)
+
+			// deleting the group removes it from its contacts, the trashed ones included
+			require.NoError(t, groups.Delete(ctx, friends.Id, func(domain.Group) error { return nil }))
+			for _, id := range []uuid.UUID{both.Id, friend.Id} {
+				got, err = contacts.Get(ctx, id)
+				require.NoError(t, err)
+				assert.Empty(t, got.GroupIds)
+			}
+			listed, err = contacts.List(ctx, NewFilter(WithGroup(friends.Id), WithTrashed()))
+			require.NoError(t, err)
+			assert.Empty(t, listed)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package ports
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/davidterranova/contacts/internal/domain"
+)
+
+const (
+	HeaderWebhookEvent     = "X-Contacts-Event"
+	HeaderWebhookDelivery  = "X-Contacts-Delivery"
+	HeaderWebhookTimestamp = "X-Contacts-Timestamp"
+	// HeaderWebhookSignature holds the signature of the timestamp and the body, see domain.Webhook.Sign
+	HeaderWebhookSignature = "X-Contacts-Signature"
+)
+
+// HTTPWebhookSender posts the signed deliveries to the webhooks URL, any 2xx response acknowledges a delivery
+type HTTPWebhookSender struct {
+	client *http.Client
+}
+
+func NewHTTPWebhookSender(timeout time.Duration) *HTTPWebhookSender {
+	return &HTTPWebhookSender{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *HTTPWebhookSender) Send(ctx context.Context, webhook domain.Webhook, delivery domain.Delivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+
+	now := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderWebhookEvent, delivery.EventName)
+	req.Header.Set(HeaderWebhookDelivery, delivery.Id.String())
+	req.Header.Set(HeaderWebhookTimestamp, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(HeaderWebhookSignature, webhook.Sign(now, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	// drain the body so that the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,102 @@
+package ports
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+)
+
+// InMemoryAddressBookRepository reads the contacts of the address books it deletes from the contact repository.
+// It is thread safe: the closures run while holding its lock, the lock of the contact repository being taken after it.
+type InMemoryAddressBookRepository struct {
+	mu           sync.Mutex
+	addressBooks map[uuid.UUID]*domain.AddressBook
+	contacts     *InMemoryContactRepository
+}
+
+func NewInMemoryAddressBookRepository(contacts *InMemoryContactRepository) *InMemoryAddressBookRepository {
+	return &InMemoryAddressBookRepository{
+		addressBooks: map[uuid.UUID]*domain.AddressBook{},
+		contacts:     contacts,
+	}
+}
+
+func (r *InMemoryAddressBookRepository) Get(_ context.Context, id uuid.UUID) (*domain.AddressBook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.get(id)
+}
+
+func (r *InMemoryAddressBookRepository) get(id uuid.UUID) (*domain.AddressBook, error) {
+	addressBook, ok := r.addressBooks[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: address book %s", ErrNotFound, id)
+	}
+
+	return addressBook, nil
+}
+
+func (r *InMemoryAddressBookRepository) ListByMember(_ context.Context, userId uuid.UUID) ([]*domain.AddressBook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	addressBooks := make([]*domain.AddressBook, 0)
+	for _, addressBook := range r.addressBooks {
+		if _, ok := addressBook.Role(userId); ok {
+			addressBooks = append(addressBooks, addressBook)
+		}
+	}
+
+	return sortAddressBooks(addressBooks), nil
+}
+
+func (r *InMemoryAddressBookRepository) Create(_ context.Context, addressBook *domain.AddressBook) (*domain.AddressBook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.addressBooks[addressBook.Id] = addressBook
+	return addressBook, nil
+}
+
+func (r *InMemoryAddressBookRepository) Update(_ context.Context, id uuid.UUID, updateFn func(b domain.AddressBook) (domain.AddressBook, error)) (*domain.AddressBook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	originalAddressBook, err := r.get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedAddressBook, err := updateFn(*originalAddressBook)
+	if err != nil {
+		return nil, err
+	}
+
+	r.addressBooks[id] = &updatedAddressBook
+	return &updatedAddressBook, nil
+}
+
+func (r *InMemoryAddressBookRepository) Delete(_ context.Context, id uuid.UUID, deleterFn func(b domain.AddressBook) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	addressBook, err := r.get(id)
+	if err != nil {
+		return err
+	}
+
+	if err := deleterFn(*addressBook); err != nil {
+		return err
+	}
+	if r.contacts.ownsContacts(id) {
+		return fmt.Errorf("%w: %s", ErrAddressBookNotEmpty, id)
+	}
+
+	delete(r.addressBooks, id)
+
+	return nil
+}
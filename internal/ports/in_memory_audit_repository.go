@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+)
+
+// InMemoryAuditRepository reads and appends the audit entries the contact repository saves along with the contacts.
+// It is thread safe, the entries being guarded by the lock of the contact repository.
+type InMemoryAuditRepository struct {
+	contacts *InMemoryContactRepository
+}
+
+func NewInMemoryAuditRepository(contacts *InMemoryContactRepository) *InMemoryAuditRepository {
+	return &InMemoryAuditRepository{
+		contacts: contacts,
+	}
+}
+
+func (r *InMemoryAuditRepository) Append(_ context.Context, entry *domain.AuditEntry) error {
+	r.contacts.appendAudit(entry)
+	return nil
+}
+
+func (r *InMemoryAuditRepository) ListByContact(_ context.Context, contactId uuid.UUID) ([]*domain.AuditEntry, error) {
+	return sortAuditEntries(r.contacts.auditEntries(contactId)), nil
+}
@@ -4,59 +4,107 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/davidterranova/contacts/internal/domain"
 	"github.com/google/uuid"
 )
 
-var ErrNotFound = errors.New("contact not found")
+var ErrNotFound = errors.New("not found")
 
-// InMemoryContactRepository is not thread safe
+// InMemoryContactRepository is the outbox of the events recorded on the contacts it saves.
+// It is thread safe: the constraints are checked and the closures run while holding its lock.
 type InMemoryContactRepository struct {
+	mu       sync.Mutex
 	contacts map[uuid.UUID]*domain.Contact
+	outbox   []domain.Event
+	// failures counts the failed publications of the pending events, dead are the events given up on
+	failures map[uuid.UUID]int
+	dead     []domain.Event
+	// audit holds the audit entries saved along with the contacts, by contact
+	audit       map[uuid.UUID][]*domain.AuditEntry
+	constraints contactConstraints
 }
 
-type filter struct {
-	createdBy *uuid.UUID
+func NewInMemoryContactRepository(options ...ContactConstraint) *InMemoryContactRepository {
+	return &InMemoryContactRepository{
+		contacts:    map[uuid.UUID]*domain.Contact{},
+		failures:    map[uuid.UUID]int{},
+		audit:       map[uuid.UUID][]*domain.AuditEntry{},
+		constraints: newContactConstraints(options...),
+	}
 }
 
-func (f *filter) CreatedBy() *uuid.UUID {
-	return f.createdBy
+func (r *InMemoryContactRepository) Get(_ context.Context, id uuid.UUID) (*domain.Contact, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.get(id)
 }
 
-func WithCreatedBy(id uuid.UUID) withFilter {
-	return func(f *filter) {
-		f.createdBy = &id
+func (r *InMemoryContactRepository) get(id uuid.UUID) (*domain.Contact, error) {
+	contact, ok := r.contacts[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
 	}
+
+	return contact, nil
 }
 
-type withFilter func(f *filter)
+// ownsContacts tells whether contacts, trashed or not, belong to the address book
+func (r *InMemoryContactRepository) ownsContacts(addressBookId uuid.UUID) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-func NewFilter(filters ...withFilter) *filter {
-	filter := &filter{}
-	for _, f := range filters {
-		f(filter)
+	for _, contact := range r.contacts {
+		if contact.AddressBookId == addressBookId {
+			return true
+		}
 	}
 
-	return filter
+	return false
 }
 
-func NewInMemoryContactRepository() *InMemoryContactRepository {
-	return &InMemoryContactRepository{
-		contacts: map[uuid.UUID]*domain.Contact{},
+// leaveGroup removes the group from the contacts, trashed or not, holding it
+func (r *InMemoryContactRepository) leaveGroup(groupId uuid.UUID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, contact := range r.contacts {
+		if !contact.InGroup(groupId) {
+			continue
+		}
+
+		updated := *contact
+		updated.RemoveFromGroup(groupId)
+		r.contacts[id] = &updated
 	}
 }
 
-func (r *InMemoryContactRepository) Get(_ context.Context, id uuid.UUID) (*domain.Contact, error) {
-	contact, ok := r.contacts[id]
-	if !ok {
-		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
-	}
+// appendAudit saves an audit entry recorded apart from a contact change
+func (r *InMemoryContactRepository) appendAudit(entry *domain.AuditEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	return contact, nil
+	r.audit[entry.ContactId] = append(r.audit[entry.ContactId], entry)
+}
+
+// auditEntries returns a copy of the audit entries of the contact, in the order they were saved
+func (r *InMemoryContactRepository) auditEntries(contactId uuid.UUID) []*domain.AuditEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entries := make([]*domain.AuditEntry, len(r.audit[contactId]))
+	copy(entries, r.audit[contactId])
+
+	return entries
 }
 
 func (r *InMemoryContactRepository) List(ctx context.Context, filter domain.Filter) ([]*domain.Contact, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	contacts := make([]*domain.Contact, 0, len(r.contacts))
 	for _, contact := range r.contacts {
 		if filterBy(filter, contact) {
@@ -64,23 +112,26 @@ func (r *InMemoryContactRepository) List(ctx context.Context, filter domain.Filt
 		}
 	}
 
-	return contacts, nil
+	return paginate(filter, contacts), nil
 }
 
-func filterBy(filter domain.Filter, contact *domain.Contact) bool {
-	if filter.CreatedBy() != nil && *filter.CreatedBy() != contact.CreatedBy {
-		return false
-	}
+func (r *InMemoryContactRepository) Create(_ context.Context, contact *domain.Contact) (*domain.Contact, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	return true
-}
+	err := r.checkConstraints(contact, nil)
+	if err != nil {
+		return nil, err
+	}
 
-func (r *InMemoryContactRepository) Create(_ context.Context, contact *domain.Contact) (*domain.Contact, error) {
 	return r.save(contact)
 }
 
 func (r *InMemoryContactRepository) Update(ctx context.Context, id uuid.UUID, updateFn func(c domain.Contact) (domain.Contact, error)) (*domain.Contact, error) {
-	originalConact, err := r.Get(ctx, id)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	originalConact, err := r.get(id)
 	if err != nil {
 		return nil, err
 	}
@@ -89,16 +140,160 @@ func (r *InMemoryContactRepository) Update(ctx context.Context, id uuid.UUID, up
 	if err != nil {
 		return nil, err
 	}
+	updatedContact.Version = originalConact.Version + 1
+
+	err = r.checkConstraints(&updatedContact, nil)
+	if err != nil {
+		return nil, err
+	}
 
 	return r.save(&updatedContact)
 }
 
+// Batch applies the operations on staged copies of the contacts, they are saved once all the operations succeeded
+func (r *InMemoryContactRepository) Batch(ctx context.Context, ops []domain.ContactOperation) ([]*domain.Contact, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var (
+		staged   = make(map[uuid.UUID]*domain.Contact, len(ops))
+		contacts = make([]*domain.Contact, 0, len(ops))
+		events   []domain.Event
+		entries  []*domain.AuditEntry
+	)
+	for i, op := range ops {
+		contact := op.Create
+		if contact == nil {
+			originalContact, ok := staged[op.Id]
+			if !ok {
+				var err error
+				originalContact, err = r.get(op.Id)
+				if err != nil {
+					return nil, &domain.OperationError{Index: i, Err: err}
+				}
+			}
+
+			updatedContact, err := op.UpdateFn(*originalContact)
+			if err != nil {
+				return nil, &domain.OperationError{Index: i, Err: err}
+			}
+			updatedContact.Version = originalContact.Version + 1
+			contact = &updatedContact
+		}
+
+		err := r.checkConstraints(contact, staged)
+		if err != nil {
+			return nil, &domain.OperationError{Index: i, Err: err}
+		}
+
+		events = append(events, contact.PullEvents()...)
+		entries = append(entries, contact.PullAuditEntries()...)
+		staged[contact.Id] = contact
+		contacts = append(contacts, contact)
+	}
+
+	for id, contact := range staged {
+		r.contacts[id] = contact
+	}
+	r.outbox = append(r.outbox, events...)
+	for _, entry := range entries {
+		r.audit[entry.ContactId] = append(r.audit[entry.ContactId], entry)
+	}
+
+	return contacts, nil
+}
+
+// checkConstraints checks the contact against the saved contacts, replaced by their staged version if any
+func (r *InMemoryContactRepository) checkConstraints(contact *domain.Contact, staged map[uuid.UUID]*domain.Contact) error {
+	return r.constraints.checkAll(contact, func(fn func(other *domain.Contact) error) error {
+		for id, other := range r.contacts {
+			if _, ok := staged[id]; ok {
+				continue
+			}
+			if err := fn(other); err != nil {
+				return err
+			}
+		}
+		for _, other := range staged {
+			if err := fn(other); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
 func (r *InMemoryContactRepository) save(contact *domain.Contact) (*domain.Contact, error) {
+	r.outbox = append(r.outbox, contact.PullEvents()...)
+	for _, entry := range contact.PullAuditEntries() {
+		r.audit[entry.ContactId] = append(r.audit[entry.ContactId], entry)
+	}
 	r.contacts[contact.Id] = contact
 	return contact, nil
 }
 
+func (r *InMemoryContactRepository) Pending(_ context.Context, limit int) ([]domain.Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	events := r.outbox
+	if len(events) > limit {
+		events = events[:limit]
+	}
+
+	return append([]domain.Event{}, events...), nil
+}
+
+func (r *InMemoryContactRepository) MarkPublished(_ context.Context, ids ...uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	published := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		published[id] = true
+	}
+
+	pending := r.outbox[:0]
+	for _, event := range r.outbox {
+		if !published[event.EventId()] {
+			pending = append(pending, event)
+			continue
+		}
+		delete(r.failures, event.EventId())
+	}
+	r.outbox = pending
+
+	return nil
+}
+
+func (r *InMemoryContactRepository) MarkFailed(_ context.Context, id uuid.UUID, _ string, maxAttempts int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, event := range r.outbox {
+		if event.EventId() != id {
+			continue
+		}
+
+		r.failures[id]++
+		if r.failures[id] < maxAttempts {
+			return false, nil
+		}
+
+		delete(r.failures, id)
+		r.dead = append(r.dead, event)
+		r.outbox = append(r.outbox[:i], r.outbox[i+1:]...)
+		return true, nil
+	}
+
+	return false, fmt.Errorf("%w: event %s", ErrNotFound, id)
+}
+
 func (r *InMemoryContactRepository) Delete(_ context.Context, id uuid.UUID, deleterFn func(c domain.Contact) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	contact, ok := r.contacts[id]
 	if !ok {
 		return fmt.Errorf("%w: %s", ErrNotFound, id)
@@ -112,3 +307,18 @@ func (r *InMemoryContactRepository) Delete(_ context.Context, id uuid.UUID, dele
 
 	return nil
 }
+
+func (r *InMemoryContactRepository) Purge(_ context.Context, deletedBefore time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	purged := 0
+	for id, contact := range r.contacts {
+		if contact.IsDeleted() && contact.DeletedAt.Before(deletedBefore) {
+			delete(r.contacts, id)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,42 @@
+package ports
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+)
+
+// InMemoryContactSchemaRepository is thread safe
+type InMemoryContactSchemaRepository struct {
+	mu      sync.Mutex
+	schemas map[uuid.UUID]*domain.ContactSchema
+}
+
+func NewInMemoryContactSchemaRepository() *InMemoryContactSchemaRepository {
+	return &InMemoryContactSchemaRepository{
+		schemas: map[uuid.UUID]*domain.ContactSchema{},
+	}
+}
+
+func (r *InMemoryContactSchemaRepository) Get(_ context.Context, ownerId uuid.UUID) (*domain.ContactSchema, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	schema, ok := r.schemas[ownerId]
+	if !ok {
+		return nil, fmt.Errorf("%w: contact schema of %s", ErrNotFound, ownerId)
+	}
+
+	return schema, nil
+}
+
+func (r *InMemoryContactSchemaRepository) Save(_ context.Context, schema *domain.ContactSchema) (*domain.ContactSchema, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.schemas[schema.OwnerId] = schema
+	return schema, nil
+}
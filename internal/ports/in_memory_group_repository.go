@@ -0,0 +1,100 @@
+package ports
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+)
+
+// InMemoryGroupRepository removes the deleted groups from the contacts of the contact repository.
+// It is thread safe: the closures run while holding its lock, the lock of the contact repository being taken after it.
+type InMemoryGroupRepository struct {
+	mu       sync.Mutex
+	groups   map[uuid.UUID]*domain.Group
+	contacts *InMemoryContactRepository
+}
+
+func NewInMemoryGroupRepository(contacts *InMemoryContactRepository) *InMemoryGroupRepository {
+	return &InMemoryGroupRepository{
+		groups:   map[uuid.UUID]*domain.Group{},
+		contacts: contacts,
+	}
+}
+
+func (r *InMemoryGroupRepository) Get(_ context.Context, id uuid.UUID) (*domain.Group, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.get(id)
+}
+
+func (r *InMemoryGroupRepository) get(id uuid.UUID) (*domain.Group, error) {
+	group, ok := r.groups[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: group %s", ErrNotFound, id)
+	}
+
+	return group, nil
+}
+
+func (r *InMemoryGroupRepository) ListByOwner(_ context.Context, userId uuid.UUID) ([]*domain.Group, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	groups := make([]*domain.Group, 0)
+	for _, group := range r.groups {
+		if group.CreatedBy == userId {
+			groups = append(groups, group)
+		}
+	}
+
+	return sortGroups(groups), nil
+}
+
+func (r *InMemoryGroupRepository) Create(_ context.Context, group *domain.Group) (*domain.Group, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.groups[group.Id] = group
+	return group, nil
+}
+
+func (r *InMemoryGroupRepository) Update(_ context.Context, id uuid.UUID, updateFn func(g domain.Group) (domain.Group, error)) (*domain.Group, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	originalGroup, err := r.get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedGroup, err := updateFn(*originalGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	r.groups[id] = &updatedGroup
+	return &updatedGroup, nil
+}
+
+func (r *InMemoryGroupRepository) Delete(_ context.Context, id uuid.UUID, deleterFn func(g domain.Group) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	group, err := r.get(id)
+	if err != nil {
+		return err
+	}
+
+	if err := deleterFn(*group); err != nil {
+		return err
+	}
+
+	r.contacts.leaveGroup(id)
+	delete(r.groups, id)
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package ports
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+)
+
+// InMemoryWebhookRepository is thread safe: the closures run while holding its lock
+type InMemoryWebhookRepository struct {
+	mu         sync.Mutex
+	webhooks   map[uuid.UUID]*domain.Webhook
+	deliveries map[uuid.UUID]*domain.Delivery
+}
+
+func NewInMemoryWebhookRepository() *InMemoryWebhookRepository {
+	return &InMemoryWebhookRepository{
+		webhooks:   map[uuid.UUID]*domain.Webhook{},
+		deliveries: map[uuid.UUID]*domain.Delivery{},
+	}
+}
+
+func (r *InMemoryWebhookRepository) Get(_ context.Context, id uuid.UUID) (*domain.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.get(id)
+}
+
+func (r *InMemoryWebhookRepository) get(id uuid.UUID) (*domain.Webhook, error) {
+	webhook, ok := r.webhooks[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: webhook %s", ErrNotFound, id)
+	}
+
+	return webhook, nil
+}
+
+func (r *InMemoryWebhookRepository) ListByOwner(_ context.Context, userId uuid.UUID) ([]*domain.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.list(func(w *domain.Webhook) bool { return w.CreatedBy == userId }), nil
+}
+
+func (r *InMemoryWebhookRepository) ListByEvent(_ context.Context, eventName string) ([]*domain.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.list(func(w *domain.Webhook) bool { return w.Subscribes(eventName) }), nil
+}
+
+func (r *InMemoryWebhookRepository) list(match func(w *domain.Webhook) bool) []*domain.Webhook {
+	webhooks := make([]*domain.Webhook, 0)
+	for _, webhook := range r.webhooks {
+		if match(webhook) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+
+	return sortWebhooks(webhooks)
+}
+
+func (r *InMemoryWebhookRepository) Create(_ context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.webhooks[webhook.Id] = webhook
+	return webhook, nil
+}
+
+func (r *InMemoryWebhookRepository) Update(_ context.Context, id uuid.UUID, updateFn func(w domain.Webhook) (domain.Webhook, error)) (*domain.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	originalWebhook, err := r.get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedWebhook, err := updateFn(*originalWebhook)
+	if err != nil {
+		return nil, err
+	}
+
+	r.webhooks[id] = &updatedWebhook
+	return &updatedWebhook, nil
+}
+
+func (r *InMemoryWebhookRepository) Delete(_ context.Context, id uuid.UUID, deleterFn func(w domain.Webhook) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	webhook, err := r.get(id)
+	if err != nil {
+		return err
+	}
+
+	if err := deleterFn(*webhook); err != nil {
+		return err
+	}
+
+	delete(r.webhooks, id)
+	for deliveryId, delivery := range r.deliveries {
+		if delivery.WebhookId == id {
+			delete(r.deliveries, deliveryId)
+		}
+	}
+
+	return nil
+}
+
+func (r *InMemoryWebhookRepository) CreateDelivery(_ context.Context, delivery *domain.Delivery) (*domain.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.deliveries {
+		if existing.WebhookId == delivery.WebhookId && existing.EventId == delivery.EventId {
+			return existing, nil
+		}
+	}
+
+	r.deliveries[delivery.Id] = delivery
+	return delivery, nil
+}
+
+func (r *InMemoryWebhookRepository) ListDueDeliveries(_ context.Context, at time.Time, limit int) ([]*domain.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deliveries := make([]*domain.Delivery, 0, len(r.deliveries))
+	for _, delivery := range r.deliveries {
+		deliveries = append(deliveries, delivery)
+	}
+
+	return dueDeliveries(deliveries, at, limit), nil
+}
+
+func (r *InMemoryWebhookRepository) ListDeliveries(_ context.Context, webhookId uuid.UUID) ([]*domain.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deliveries := make([]*domain.Delivery, 0)
+	for _, delivery := range r.deliveries {
+		if delivery.WebhookId == webhookId {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+
+	return sortDeliveries(deliveries), nil
+}
+
+func (r *InMemoryWebhookRepository) SaveDelivery(_ context.Context, delivery *domain.Delivery) (*domain.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deliveries[delivery.Id] = delivery
+	return delivery, nil
+}
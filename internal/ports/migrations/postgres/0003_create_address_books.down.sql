@@ -0,0 +1,7 @@
+DROP INDEX contacts_address_book_id_idx;
+
+ALTER TABLE contacts DROP COLUMN address_book_id;
+
+DROP TABLE address_book_members;
+
+DROP TABLE address_books;
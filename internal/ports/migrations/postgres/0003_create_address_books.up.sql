@@ -0,0 +1,21 @@
+CREATE TABLE address_books (
+  id UUID PRIMARY KEY,
+  created_at TIMESTAMPTZ NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL,
+  created_by UUID NOT NULL,
+  name TEXT NOT NULL
+);
+
+CREATE TABLE address_book_members (
+  address_book_id UUID NOT NULL REFERENCES address_books (id) ON DELETE CASCADE,
+  user_id UUID NOT NULL,
+  role TEXT NOT NULL,
+  added_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (address_book_id, user_id)
+);
+
+CREATE INDEX address_book_members_user_id_idx ON address_book_members (user_id);
+
+ALTER TABLE contacts ADD COLUMN address_book_id UUID REFERENCES address_books (id);
+
+CREATE INDEX contacts_address_book_id_idx ON contacts (address_book_id);
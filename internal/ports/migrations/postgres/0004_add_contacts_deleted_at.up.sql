@@ -0,0 +1,3 @@
+ALTER TABLE contacts ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX contacts_deleted_at_idx ON contacts (deleted_at);
@@ -0,0 +1,11 @@
+CREATE TABLE contact_audit_entries (
+  id UUID PRIMARY KEY,
+  contact_id UUID NOT NULL,
+  actor_id UUID NOT NULL,
+  actor_type TEXT NOT NULL,
+  action TEXT NOT NULL,
+  recorded_at TIMESTAMPTZ NOT NULL,
+  changes TEXT NOT NULL
+);
+
+CREATE INDEX contact_audit_entries_contact_id_idx ON contact_audit_entries (contact_id, recorded_at);
@@ -0,0 +1,8 @@
+CREATE TABLE contact_outbox (
+  seq BIGSERIAL PRIMARY KEY,
+  id UUID NOT NULL UNIQUE,
+  contact_id UUID NOT NULL,
+  name TEXT NOT NULL,
+  occurred_at TIMESTAMPTZ NOT NULL,
+  payload TEXT NOT NULL
+);
@@ -0,0 +1,29 @@
+CREATE TABLE webhooks (
+  id UUID PRIMARY KEY,
+  created_at TIMESTAMPTZ NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL,
+  created_by UUID NOT NULL,
+  url TEXT NOT NULL,
+  events TEXT NOT NULL,
+  secret TEXT NOT NULL
+);
+
+CREATE INDEX webhooks_created_by_idx ON webhooks (created_by);
+
+CREATE TABLE webhook_deliveries (
+  id UUID PRIMARY KEY,
+  webhook_id UUID NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+  event_id UUID NOT NULL,
+  event_name TEXT NOT NULL,
+  payload TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  status TEXT NOT NULL,
+  attempts INTEGER NOT NULL,
+  next_attempt_at TIMESTAMPTZ NOT NULL,
+  last_attempt_at TIMESTAMPTZ,
+  response_status INTEGER NOT NULL,
+  last_error TEXT NOT NULL
+);
+
+CREATE INDEX webhook_deliveries_webhook_id_idx ON webhook_deliveries (webhook_id, created_at);
+CREATE INDEX webhook_deliveries_due_idx ON webhook_deliveries (status, next_attempt_at);
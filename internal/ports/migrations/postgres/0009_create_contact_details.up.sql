@@ -0,0 +1,36 @@
+CREATE TABLE contact_emails (
+  contact_id UUID NOT NULL REFERENCES contacts (id) ON DELETE CASCADE,
+  position INTEGER NOT NULL,
+  label TEXT NOT NULL,
+  address TEXT NOT NULL,
+  is_primary BOOLEAN NOT NULL,
+  PRIMARY KEY (contact_id, position)
+);
+
+CREATE TABLE contact_phones (
+  contact_id UUID NOT NULL REFERENCES contacts (id) ON DELETE CASCADE,
+  position INTEGER NOT NULL,
+  label TEXT NOT NULL,
+  number TEXT NOT NULL,
+  is_primary BOOLEAN NOT NULL,
+  PRIMARY KEY (contact_id, position)
+);
+
+CREATE TABLE contact_addresses (
+  contact_id UUID NOT NULL REFERENCES contacts (id) ON DELETE CASCADE,
+  position INTEGER NOT NULL,
+  label TEXT NOT NULL,
+  street TEXT NOT NULL,
+  locality TEXT NOT NULL,
+  region TEXT NOT NULL,
+  postal_code TEXT NOT NULL,
+  country TEXT NOT NULL,
+  is_primary BOOLEAN NOT NULL,
+  PRIMARY KEY (contact_id, position)
+);
+
+INSERT INTO contact_emails (contact_id, position, label, address, is_primary)
+  SELECT id, 0, 'other', email, TRUE FROM contacts WHERE email <> '';
+
+INSERT INTO contact_phones (contact_id, position, label, number, is_primary)
+  SELECT id, 0, 'other', phone, TRUE FROM contacts WHERE phone <> '';
@@ -0,0 +1,2 @@
+DROP TABLE contact_custom_fields;
+DROP TABLE contact_schemas;
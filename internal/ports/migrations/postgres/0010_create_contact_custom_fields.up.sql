@@ -0,0 +1,15 @@
+CREATE TABLE contact_schemas (
+  owner_id UUID PRIMARY KEY,
+  fields TEXT NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE contact_custom_fields (
+  contact_id UUID NOT NULL REFERENCES contacts (id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  type TEXT NOT NULL,
+  value TEXT NOT NULL,
+  PRIMARY KEY (contact_id, name)
+);
+
+CREATE INDEX contact_custom_fields_name_idx ON contact_custom_fields (name, value);
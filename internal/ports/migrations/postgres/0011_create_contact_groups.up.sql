@@ -0,0 +1,17 @@
+CREATE TABLE contact_groups (
+  id UUID PRIMARY KEY,
+  created_at TIMESTAMPTZ NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL,
+  created_by UUID NOT NULL,
+  name TEXT NOT NULL
+);
+
+CREATE INDEX contact_groups_created_by_idx ON contact_groups (created_by);
+
+CREATE TABLE contact_group_members (
+  contact_id UUID NOT NULL REFERENCES contacts (id) ON DELETE CASCADE,
+  group_id UUID NOT NULL REFERENCES contact_groups (id) ON DELETE CASCADE,
+  PRIMARY KEY (contact_id, group_id)
+);
+
+CREATE INDEX contact_group_members_group_id_idx ON contact_group_members (group_id);
@@ -0,0 +1,10 @@
+CREATE TABLE contact_relationships (
+  contact_id UUID NOT NULL REFERENCES contacts (id) ON DELETE CASCADE,
+  type TEXT NOT NULL,
+  related_id UUID NOT NULL REFERENCES contacts (id) ON DELETE CASCADE,
+  on_delete TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (contact_id, type, related_id)
+);
+
+CREATE INDEX contact_relationships_related_id_idx ON contact_relationships (related_id);
@@ -0,0 +1 @@
+ALTER TABLE contact_schemas DROP COLUMN default_phone_region;
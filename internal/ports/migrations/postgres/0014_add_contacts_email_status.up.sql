@@ -0,0 +1 @@
+ALTER TABLE contacts ADD COLUMN email_status TEXT NOT NULL DEFAULT '';
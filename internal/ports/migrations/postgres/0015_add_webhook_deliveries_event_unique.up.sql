@@ -0,0 +1,8 @@
+-- deliveries enqueued again for the same event are removed, the oldest one is kept
+DELETE FROM webhook_deliveries d
+USING webhook_deliveries o
+WHERE d.webhook_id = o.webhook_id
+  AND d.event_id = o.event_id
+  AND (d.created_at, d.ctid) > (o.created_at, o.ctid);
+
+CREATE UNIQUE INDEX webhook_deliveries_event_idx ON webhook_deliveries (webhook_id, event_id);
@@ -0,0 +1,5 @@
+DROP INDEX contacts_unique_phone;
+DROP INDEX contacts_unique_email;
+
+ALTER TABLE contacts DROP COLUMN phone_key;
+ALTER TABLE contacts DROP COLUMN email_key;
@@ -0,0 +1,3 @@
+ALTER TABLE contact_outbox DROP COLUMN dead_at;
+ALTER TABLE contact_outbox DROP COLUMN last_error;
+ALTER TABLE contact_outbox DROP COLUMN attempts;
@@ -0,0 +1,3 @@
+ALTER TABLE contact_outbox ADD COLUMN attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE contact_outbox ADD COLUMN last_error TEXT NOT NULL DEFAULT '';
+ALTER TABLE contact_outbox ADD COLUMN dead_at TIMESTAMPTZ;
@@ -0,0 +1 @@
+DROP TABLE contact_grants;
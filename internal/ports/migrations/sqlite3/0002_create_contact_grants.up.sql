@@ -0,0 +1,9 @@
+CREATE TABLE contact_grants (
+  contact_id TEXT NOT NULL REFERENCES contacts (id) ON DELETE CASCADE,
+  user_id TEXT NOT NULL,
+  permission TEXT NOT NULL,
+  granted_at TIMESTAMP NOT NULL,
+  PRIMARY KEY (contact_id, user_id)
+);
+
+CREATE INDEX contact_grants_user_id_idx ON contact_grants (user_id);
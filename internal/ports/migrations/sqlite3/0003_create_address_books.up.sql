@@ -0,0 +1,21 @@
+CREATE TABLE address_books (
+  id TEXT PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL,
+  updated_at TIMESTAMP NOT NULL,
+  created_by TEXT NOT NULL,
+  name TEXT NOT NULL
+);
+
+CREATE TABLE address_book_members (
+  address_book_id TEXT NOT NULL REFERENCES address_books (id) ON DELETE CASCADE,
+  user_id TEXT NOT NULL,
+  role TEXT NOT NULL,
+  added_at TIMESTAMP NOT NULL,
+  PRIMARY KEY (address_book_id, user_id)
+);
+
+CREATE INDEX address_book_members_user_id_idx ON address_book_members (user_id);
+
+ALTER TABLE contacts ADD COLUMN address_book_id TEXT REFERENCES address_books (id);
+
+CREATE INDEX contacts_address_book_id_idx ON contacts (address_book_id);
@@ -0,0 +1,3 @@
+DROP INDEX contacts_deleted_at_idx;
+
+ALTER TABLE contacts DROP COLUMN deleted_at;
@@ -0,0 +1 @@
+ALTER TABLE contacts ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
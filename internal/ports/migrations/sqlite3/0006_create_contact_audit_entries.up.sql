@@ -0,0 +1,11 @@
+CREATE TABLE contact_audit_entries (
+  id TEXT PRIMARY KEY,
+  contact_id TEXT NOT NULL,
+  actor_id TEXT NOT NULL,
+  actor_type TEXT NOT NULL,
+  action TEXT NOT NULL,
+  recorded_at TIMESTAMP NOT NULL,
+  changes TEXT NOT NULL
+);
+
+CREATE INDEX contact_audit_entries_contact_id_idx ON contact_audit_entries (contact_id, recorded_at);
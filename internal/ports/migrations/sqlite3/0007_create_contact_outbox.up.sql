@@ -0,0 +1,8 @@
+CREATE TABLE contact_outbox (
+  seq INTEGER PRIMARY KEY AUTOINCREMENT,
+  id TEXT NOT NULL UNIQUE,
+  contact_id TEXT NOT NULL,
+  name TEXT NOT NULL,
+  occurred_at TIMESTAMP NOT NULL,
+  payload TEXT NOT NULL
+);
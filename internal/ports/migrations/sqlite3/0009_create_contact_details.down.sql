@@ -0,0 +1,3 @@
+DROP TABLE contact_addresses;
+DROP TABLE contact_phones;
+DROP TABLE contact_emails;
@@ -0,0 +1,3 @@
+DROP TABLE contact_group_members;
+
+DROP TABLE contact_groups;
@@ -0,0 +1,17 @@
+CREATE TABLE contact_groups (
+  id TEXT PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL,
+  updated_at TIMESTAMP NOT NULL,
+  created_by TEXT NOT NULL,
+  name TEXT NOT NULL
+);
+
+CREATE INDEX contact_groups_created_by_idx ON contact_groups (created_by);
+
+CREATE TABLE contact_group_members (
+  contact_id TEXT NOT NULL REFERENCES contacts (id) ON DELETE CASCADE,
+  group_id TEXT NOT NULL REFERENCES contact_groups (id) ON DELETE CASCADE,
+  PRIMARY KEY (contact_id, group_id)
+);
+
+CREATE INDEX contact_group_members_group_id_idx ON contact_group_members (group_id);
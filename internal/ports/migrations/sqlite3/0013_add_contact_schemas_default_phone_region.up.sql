@@ -0,0 +1 @@
+ALTER TABLE contact_schemas ADD COLUMN default_phone_region TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,11 @@
+-- deliveries enqueued again for the same event are removed, the oldest one is kept
+DELETE FROM webhook_deliveries
+WHERE EXISTS (
+  SELECT 1 FROM webhook_deliveries o
+  WHERE o.webhook_id = webhook_deliveries.webhook_id
+    AND o.event_id = webhook_deliveries.event_id
+    AND (o.created_at < webhook_deliveries.created_at
+      OR (o.created_at = webhook_deliveries.created_at AND o.rowid < webhook_deliveries.rowid))
+);
+
+CREATE UNIQUE INDEX webhook_deliveries_event_idx ON webhook_deliveries (webhook_id, event_id);
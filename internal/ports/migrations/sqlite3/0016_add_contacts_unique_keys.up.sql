@@ -0,0 +1,10 @@
+-- replaces the unique indexes formerly created by the repository on startup
+DROP INDEX IF EXISTS contacts_unique_email;
+DROP INDEX IF EXISTS contacts_unique_phone;
+
+-- the keys are only set while the matching uniqueness constraint is enabled, see SQLContactRepository
+ALTER TABLE contacts ADD COLUMN email_key TEXT;
+ALTER TABLE contacts ADD COLUMN phone_key TEXT;
+
+CREATE UNIQUE INDEX contacts_unique_email ON contacts (created_by, email_key) WHERE deleted_at IS NULL AND email_key IS NOT NULL;
+CREATE UNIQUE INDEX contacts_unique_phone ON contacts (created_by, phone_key) WHERE deleted_at IS NULL AND phone_key IS NOT NULL;
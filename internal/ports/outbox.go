@@ -0,0 +1,78 @@
+package ports
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+)
+
+// outboxRecord is the serialized form of an event waiting in a repository outbox to be published
+type outboxRecord struct {
+	Id         uuid.UUID       `json:"id"`
+	ContactId  uuid.UUID       `json:"contact_id"`
+	Name       string          `json:"name"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Payload    json.RawMessage `json:"payload"`
+
+	// Attempts counts the failed publications of the event, LastError being the reason of the last one
+	Attempts  int    `json:"attempts,omitempty"`
+	LastError string `json:"last_error,omitempty"`
+	// DeadAt is set once the event failed too many times, it is kept but no longer pending
+	DeadAt *time.Time `json:"dead_at,omitempty"`
+}
+
+func toOutboxRecord(event domain.Event) (outboxRecord, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return outboxRecord{}, fmt.Errorf("failed to encode event %s: %w", event.EventId(), err)
+	}
+
+	return outboxRecord{
+		Id:         event.EventId(),
+		ContactId:  event.AggregateId(),
+		Name:       event.EventName(),
+		OccurredAt: event.OccurredAt().UTC(),
+		Payload:    payload,
+	}, nil
+}
+
+func (r outboxRecord) toDomain() (domain.Event, error) {
+	var (
+		event domain.Event
+		err   error
+	)
+	switch r.Name {
+	case domain.EventContactCreated:
+		var created domain.ContactCreated
+		err = json.Unmarshal(r.Payload, &created)
+		event = created
+	case domain.EventContactUpdated:
+		var updated domain.ContactUpdated
+		err = json.Unmarshal(r.Payload, &updated)
+		event = updated
+	case domain.EventContactDeleted:
+		var deleted domain.ContactDeleted
+		err = json.Unmarshal(r.Payload, &deleted)
+		event = deleted
+	default:
+		return nil, fmt.Errorf("%w: unknown event %q", ErrCorruptedStore, r.Name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCorruptedStore, err)
+	}
+
+	return event, nil
+}
+
+// sortOutboxRecords orders events from the oldest to the most recent, it is shared by the repositories listing in memory
+func sortOutboxRecords(records []outboxRecord) []outboxRecord {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].OccurredAt.Before(records[j].OccurredAt)
+	})
+
+	return records
+}
@@ -0,0 +1,173 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type outboxRepository interface {
+	Create(ctx context.Context, contact *domain.Contact) (*domain.Contact, error)
+	Update(ctx context.Context, id uuid.UUID, updateFn func(c domain.Contact) (domain.Contact, error)) (*domain.Contact, error)
+	Pending(ctx context.Context, limit int) ([]domain.Event, error)
+	MarkPublished(ctx context.Context, ids ...uuid.UUID) error
+	MarkFailed(ctx context.Context, id uuid.UUID, reason string, maxAttempts int) (bool, error)
+}
+
+func TestContactRepositoriesOutbox(t *testing.T) {
+	t.Parallel()
+
+	repositories := map[string]func(t *testing.T) outboxRepository{
+		"in memory": func(t *testing.T) outboxRepository { return NewInMemoryContactRepository() },
+		"file": func(t *testing.T) outboxRepository {
+			_, repo := openFileContactRepository(t, t.TempDir())
+			return repo
+		},
+		"sql": func(t *testing.T) outboxRepository { return testSQLContactRepository(t) },
+	}
+
+	for name, newRepo := range repositories {
+		newRepo := newRepo
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			repo := newRepo(t)
+
+			contact := testContact(uuid.New())
+			contact.Record(domain.NewContactCreated(*contact))
+			_, err := repo.Create(ctx, contact)
+			require.NoError(t, err)
+
+			_, err = repo.Update(ctx, contact.Id, func(c domain.Contact) (domain.Contact, error) {
+				updated := c
+				updated.FirstName = "Jane"
+				updated.Record(domain.NewContactUpdated(c.Id, domain.Diff(c, updated)))
+				return updated, nil
+			})
+			require.NoError(t, err)
+
+			events, err := repo.Pending(ctx, 10)
+			require.NoError(t, err)
+			require.Len(t, events, 2)
+
+			created, ok := events[0].(domain.ContactCreated)
+			require.True(t, ok)
+			assert.Equal(t, contact.Id, created.AggregateId())
+			assert.Equal(t, "John", created.Contact.FirstName)
+
+			updated, ok := events[1].(domain.ContactUpdated)
+			require.True(t, ok)
+			assert.Equal(t, []domain.FieldChange{{Field: "first_name", Before: "John", After: "Jane"}}, updated.Changes)
+
+			events, err = repo.Pending(ctx, 1)
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+			assert.Equal(t, created.EventId(), events[0].EventId())
+
+			require.NoError(t, repo.MarkPublished(ctx, created.EventId()))
+			events, err = repo.Pending(ctx, 10)
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+			assert.Equal(t, updated.EventId(), events[0].EventId())
+
+			dead, err := repo.MarkFailed(ctx, updated.EventId(), "broker unavailable", 2)
+			require.NoError(t, err)
+			assert.False(t, dead)
+			events, err = repo.Pending(ctx, 10)
+			require.NoError(t, err)
+			require.Len(t, events, 1, "the event is retried until it failed the max attempts")
+
+			dead, err = repo.MarkFailed(ctx, updated.EventId(), "broker unavailable", 2)
+			require.NoError(t, err)
+			assert.True(t, dead)
+			events, err = repo.Pending(ctx, 10)
+			require.NoError(t, err)
+			assert.Empty(t, events)
+
+			_, err = repo.MarkFailed(ctx, updated.EventId(), "broker unavailable", 2)
+			assert.ErrorIs(t, err, ErrNotFound, "a dead event is no longer pending")
+		})
+	}
+}
+
+func TestInProcessEventBus(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	event := domain.NewContactDeleted(uuid.New())
+	bus := NewInProcessEventBus()
+
+	var received []domain.Event
+	bus.Subscribe(func(_ context.Context, e domain.Event) error {
+		received = append(received, e)
+		return nil
+	})
+	errHandler := errors.New("handler failed")
+	bus.Subscribe(func(context.Context, domain.Event) error { return errHandler })
+	bus.Subscribe(func(_ context.Context, e domain.Event) error {
+		received = append(received, e)
+		return nil
+	})
+
+	err := bus.Publish(ctx, event)
+	assert.ErrorIs(t, err, errHandler)
+	assert.Equal(t, []domain.Event{event, event}, received)
+
+	// publishing the event again only hands it to the failed handler
+	err = bus.Publish(ctx, event)
+	assert.ErrorIs(t, err, errHandler)
+	assert.Equal(t, []domain.Event{event, event}, received)
+}
+
+func TestInProcessEventBusRetry(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	event := domain.NewContactDeleted(uuid.New())
+	bus := NewInProcessEventBus()
+
+	delivered, attempts := 0, 0
+	bus.Subscribe(func(context.Context, domain.Event) error {
+		delivered++
+		return nil
+	})
+	bus.Subscribe(func(context.Context, domain.Event) error {
+		attempts++
+		if attempts == 1 {
+			return errors.New("handler failed")
+		}
+		return nil
+	})
+
+	assert.Error(t, bus.Publish(ctx, event))
+	assert.NoError(t, bus.Publish(ctx, event))
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, 2, attempts)
+	assert.Empty(t, bus.delivered)
+
+	// an event accepted by every handler is forgotten, publishing it again delivers it again
+	assert.NoError(t, bus.Publish(ctx, event))
+	assert.Equal(t, 2, delivered)
+}
+
+func TestInProcessEventBusForget(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	event := domain.NewContactDeleted(uuid.New())
+	bus := NewInProcessEventBus()
+	bus.Subscribe(func(context.Context, domain.Event) error { return nil })
+	bus.Subscribe(func(context.Context, domain.Event) error { return errors.New("handler failed") })
+
+	assert.Error(t, bus.Publish(ctx, event))
+	assert.Len(t, bus.delivered, 1)
+
+	bus.Forget(ctx, event.EventId())
+	assert.Empty(t, bus.delivered)
+}
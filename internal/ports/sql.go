@@ -0,0 +1,129 @@
+package ports
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"           // postgres driver
+	"github.com/mattn/go-sqlite3" // sqlite driver
+)
+
+// postgresUniqueViolation is the PostgreSQL unique_violation error code
+const postgresUniqueViolation = "23505"
+
+const (
+	DriverSQLite   = "sqlite3"
+	DriverPostgres = "postgres"
+)
+
+// OpenSQL opens and checks a database connection.
+// SQLite transactions take the write lock when they begin so that rows read in a
+// transaction cannot be modified concurrently, PostgreSQL relies on SELECT ... FOR UPDATE.
+func OpenSQL(ctx context.Context, driver string, dsn string) (*sql.DB, error) {
+	switch driver {
+	case DriverSQLite:
+		dsn = withSQLiteParams(dsn, "_txlock=immediate", "_busy_timeout=5000", "_foreign_keys=on")
+	case DriverPostgres:
+	default:
+		return nil, fmt.Errorf("unsupported sql driver %q", driver)
+	}
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if driver == DriverSQLite {
+		// sqlite allows a single writer, it also keeps in-memory databases on a single connection
+		db.SetMaxOpenConns(1)
+	}
+
+	err = db.PingContext(ctx)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	return db, nil
+}
+
+func withSQLiteParams(dsn string, params ...string) string {
+	for _, param := range params {
+		key, _, _ := strings.Cut(param, "=")
+		if strings.Contains(dsn, key+"=") {
+			continue
+		}
+
+		if strings.Contains(dsn, "?") {
+			dsn += "&" + param
+		} else {
+			dsn += "?" + param
+		}
+	}
+
+	return dsn
+}
+
+type sqlDialect string
+
+func newSQLDialect(driver string) (sqlDialect, error) {
+	switch driver {
+	case DriverSQLite, DriverPostgres:
+		return sqlDialect(driver), nil
+	default:
+		return "", fmt.Errorf("unsupported sql driver %q", driver)
+	}
+}
+
+// rebind replaces the ? placeholders of a query with the dialect ones
+func (d sqlDialect) rebind(query string) string {
+	if d != DriverPostgres {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// forUpdate locks the selected rows until the end of the transaction
+func (d sqlDialect) forUpdate() string {
+	if d == DriverPostgres {
+		return " FOR UPDATE"
+	}
+
+	return ""
+}
+
+// uniqueViolation returns the description of the unique constraint err violates, the index name or the columns
+func uniqueViolation(err error) (string, bool) {
+	var (
+		sqliteErr sqlite3.Error
+		pqErr     *pq.Error
+	)
+	switch {
+	case errors.As(err, &sqliteErr) && (sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique || sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey):
+		return sqliteErr.Error(), true
+	case errors.As(err, &pqErr) && pqErr.Code == postgresUniqueViolation:
+		return pqErr.Constraint, true
+	default:
+		return "", false
+	}
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
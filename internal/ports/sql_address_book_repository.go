@@ -0,0 +1,264 @@
+package ports
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+)
+
+const addressBookColumns = "id, created_at, updated_at, created_by, name"
+
+// SQLAddressBookRepository stores address books and their members in a SQLite or PostgreSQL database.
+// Update and Delete closures run within a transaction holding a lock on the address book row.
+type SQLAddressBookRepository struct {
+	db      *sql.DB
+	dialect sqlDialect
+}
+
+func NewSQLAddressBookRepository(db *sql.DB, driver string) (*SQLAddressBookRepository, error) {
+	dialect, err := newSQLDialect(driver)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SQLAddressBookRepository{
+		db:      db,
+		dialect: dialect,
+	}, nil
+}
+
+func (r *SQLAddressBookRepository) Get(ctx context.Context, id uuid.UUID) (*domain.AddressBook, error) {
+	return r.get(ctx, r.db, id, false)
+}
+
+func (r *SQLAddressBookRepository) get(ctx context.Context, q sqlQuerier, id uuid.UUID, forUpdate bool) (*domain.AddressBook, error) {
+	query := "SELECT " + addressBookColumns + " FROM address_books WHERE id = ?"
+	if forUpdate {
+		query += r.dialect.forUpdate()
+	}
+
+	addressBook, err := scanAddressBook(q.QueryRowContext(ctx, r.dialect.rebind(query), id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: address book %s", ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get address book: %w", err)
+	}
+
+	err = r.loadMembers(ctx, q, addressBook)
+	if err != nil {
+		return nil, err
+	}
+
+	return addressBook, nil
+}
+
+func (r *SQLAddressBookRepository) ListByMember(ctx context.Context, userId uuid.UUID) ([]*domain.AddressBook, error) {
+	addressBooks, err := r.queryAddressBooks(
+		ctx,
+		"SELECT "+addressBookColumns+" FROM address_books WHERE id IN (SELECT address_book_id FROM address_book_members WHERE user_id = ?) ORDER BY name, id",
+		userId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list address books: %w", err)
+	}
+
+	err = r.loadMembers(ctx, r.db, addressBooks...)
+	if err != nil {
+		return nil, err
+	}
+
+	return addressBooks, nil
+}
+
+// queryAddressBooks reads all the rows before returning so that the connection can be reused right away
+func (r *SQLAddressBookRepository) queryAddressBooks(ctx context.Context, query string, args ...any) ([]*domain.AddressBook, error) {
+	rows, err := r.db.QueryContext(ctx, r.dialect.rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	addressBooks := []*domain.AddressBook{}
+	for rows.Next() {
+		addressBook, err := scanAddressBook(rows)
+		if err != nil {
+			return nil, err
+		}
+		addressBooks = append(addressBooks, addressBook)
+	}
+
+	return addressBooks, rows.Err()
+}
+
+// loadMembers sets the members of the given address books
+func (r *SQLAddressBookRepository) loadMembers(ctx context.Context, q sqlQuerier, addressBooks ...*domain.AddressBook) error {
+	if len(addressBooks) == 0 {
+		return nil
+	}
+
+	byId := make(map[uuid.UUID]*domain.AddressBook, len(addressBooks))
+	args := make([]any, 0, len(addressBooks))
+	for _, addressBook := range addressBooks {
+		byId[addressBook.Id] = addressBook
+		args = append(args, addressBook.Id)
+	}
+
+	rows, err := q.QueryContext(
+		ctx,
+		r.dialect.rebind("SELECT address_book_id, user_id, role, added_at FROM address_book_members WHERE address_book_id IN ("+placeholders(len(addressBooks))+") ORDER BY added_at, user_id"),
+		args...,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to load address book members: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			addressBookId uuid.UUID
+			member        domain.Member
+		)
+		err := rows.Scan(&addressBookId, &member.UserId, &member.Role, &member.AddedAt)
+		if err != nil {
+			return fmt.Errorf("failed to load address book members: %w", err)
+		}
+		member.AddedAt = member.AddedAt.UTC()
+
+		addressBook := byId[addressBookId]
+		addressBook.Members = append(addressBook.Members, member)
+	}
+
+	return rows.Err()
+}
+
+// saveMembers replaces the stored members of the address book
+func (r *SQLAddressBookRepository) saveMembers(ctx context.Context, tx *sql.Tx, addressBook *domain.AddressBook) error {
+	_, err := tx.ExecContext(ctx, r.dialect.rebind("DELETE FROM address_book_members WHERE address_book_id = ?"), addressBook.Id)
+	if err != nil {
+		return fmt.Errorf("failed to save address book members: %w", err)
+	}
+
+	for _, member := range addressBook.Members {
+		_, err := tx.ExecContext(
+			ctx,
+			r.dialect.rebind("INSERT INTO address_book_members (address_book_id, user_id, role, added_at) VALUES (?, ?, ?, ?)"),
+			addressBook.Id, member.UserId, member.Role, member.AddedAt.UTC(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save address book members: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *SQLAddressBookRepository) Create(ctx context.Context, addressBook *domain.AddressBook) (*domain.AddressBook, error) {
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(
+			ctx,
+			r.dialect.rebind("INSERT INTO address_books ("+addressBookColumns+") VALUES (?, ?, ?, ?, ?)"),
+			addressBook.Id,
+			addressBook.CreatedAt.UTC(),
+			addressBook.UpdatedAt.UTC(),
+			addressBook.CreatedBy,
+			addressBook.Name,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create address book: %w", err)
+		}
+
+		return r.saveMembers(ctx, tx, addressBook)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return addressBook, nil
+}
+
+func (r *SQLAddressBookRepository) Update(ctx context.Context, id uuid.UUID, updateFn func(b domain.AddressBook) (domain.AddressBook, error)) (*domain.AddressBook, error) {
+	var updatedAddressBook domain.AddressBook
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		originalAddressBook, err := r.get(ctx, tx, id, true)
+		if err != nil {
+			return err
+		}
+
+		updatedAddressBook, err = updateFn(*originalAddressBook)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			r.dialect.rebind("UPDATE address_books SET updated_at = ?, name = ? WHERE id = ?"),
+			updatedAddressBook.UpdatedAt.UTC(),
+			updatedAddressBook.Name,
+			id,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update address book: %w", err)
+		}
+
+		return r.saveMembers(ctx, tx, &updatedAddressBook)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedAddressBook, nil
+}
+
+func (r *SQLAddressBookRepository) Delete(ctx context.Context, id uuid.UUID, deleterFn func(b domain.AddressBook) error) error {
+	return inTx(ctx, r.db, func(tx *sql.Tx) error {
+		addressBook, err := r.get(ctx, tx, id, true)
+		if err != nil {
+			return err
+		}
+
+		if err := deleterFn(*addressBook); err != nil {
+			return err
+		}
+
+		// contacts reference their address book, creating one waits for the address book row locked above
+		var owned int
+		err = tx.QueryRowContext(ctx, r.dialect.rebind("SELECT COUNT(*) FROM contacts WHERE address_book_id = ?"), id).Scan(&owned)
+		if err != nil {
+			return fmt.Errorf("failed to count address book contacts: %w", err)
+		}
+		if owned > 0 {
+			return fmt.Errorf("%w: %s", ErrAddressBookNotEmpty, id)
+		}
+
+		_, err = tx.ExecContext(ctx, r.dialect.rebind("DELETE FROM address_books WHERE id = ?"), id)
+		if err != nil {
+			return fmt.Errorf("failed to delete address book: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func scanAddressBook(row rowScanner) (*domain.AddressBook, error) {
+	var addressBook domain.AddressBook
+	err := row.Scan(
+		&addressBook.Id,
+		&addressBook.CreatedAt,
+		&addressBook.UpdatedAt,
+		&addressBook.CreatedBy,
+		&addressBook.Name,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	addressBook.CreatedAt = addressBook.CreatedAt.UTC()
+	addressBook.UpdatedAt = addressBook.UpdatedAt.UTC()
+
+	return &addressBook, nil
+}
@@ -0,0 +1,81 @@
+package ports
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLAddressBookRepository(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db, err := OpenSQL(ctx, DriverSQLite, ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	migrator, err := NewMigrator(db, DriverSQLite)
+	require.NoError(t, err)
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+
+	repo, err := NewSQLAddressBookRepository(db, DriverSQLite)
+	require.NoError(t, err)
+	contactRepo, err := NewSQLContactRepository(db, DriverSQLite)
+	require.NoError(t, err)
+
+	owner, viewer := uuid.New(), uuid.New()
+	addressBook, err := repo.Create(ctx, domain.NewAddressBook(owner, "Team"))
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, domain.NewAddressBook(uuid.New(), "Other team"))
+	require.NoError(t, err)
+
+	_, err = repo.Update(ctx, addressBook.Id, func(b domain.AddressBook) (domain.AddressBook, error) {
+		b.Name = "Sales"
+		return b, b.SetMember(viewer, domain.RoleViewer)
+	})
+	require.NoError(t, err)
+
+	got, err := repo.Get(ctx, addressBook.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "Sales", got.Name)
+	require.Len(t, got.Members, 2)
+	role, ok := got.Role(viewer)
+	assert.True(t, ok)
+	assert.Equal(t, domain.RoleViewer, role)
+
+	addressBooks, err := repo.ListByMember(ctx, viewer)
+	require.NoError(t, err)
+	require.Len(t, addressBooks, 1)
+	assert.Equal(t, addressBook.Id, addressBooks[0].Id)
+	assert.Len(t, addressBooks[0].Members, 2)
+
+	contact := testContact(owner)
+	contact.AddressBookId = addressBook.Id
+	_, err = contactRepo.Create(ctx, contact)
+	require.NoError(t, err)
+	_, err = contactRepo.Create(ctx, testContact(owner))
+	require.NoError(t, err)
+
+	contacts, err := contactRepo.List(ctx, NewFilter(WithVisibleTo(domain.Visibility{UserId: viewer, AddressBookIds: []uuid.UUID{addressBook.Id}})))
+	require.NoError(t, err)
+	require.Len(t, contacts, 1)
+	assert.Equal(t, addressBook.Id, contacts[0].AddressBookId)
+
+	contacts, err = contactRepo.List(ctx, NewFilter(WithVisibleTo(domain.Visibility{UserId: owner}), WithAddressBook(addressBook.Id)))
+	require.NoError(t, err)
+	assert.Len(t, contacts, 1)
+
+	err = repo.Delete(ctx, addressBook.Id, func(b domain.AddressBook) error { return nil })
+	assert.ErrorIs(t, err, ErrAddressBookNotEmpty)
+
+	err = contactRepo.Delete(ctx, contact.Id, func(c domain.Contact) error { return nil })
+	require.NoError(t, err)
+	err = repo.Delete(ctx, addressBook.Id, func(b domain.AddressBook) error { return nil })
+	require.NoError(t, err)
+	_, err = repo.Get(ctx, addressBook.Id)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
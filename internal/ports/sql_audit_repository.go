@@ -0,0 +1,99 @@
+package ports
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+)
+
+const auditColumns = "id, contact_id, actor_id, actor_type, action, recorded_at, changes"
+
+// SQLAuditRepository stores the contacts audit entries in a SQLite or PostgreSQL database.
+// Entries outlive the contacts they describe, they are kept when a contact is purged.
+type SQLAuditRepository struct {
+	db      *sql.DB
+	dialect sqlDialect
+}
+
+func NewSQLAuditRepository(db *sql.DB, driver string) (*SQLAuditRepository, error) {
+	dialect, err := newSQLDialect(driver)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SQLAuditRepository{
+		db:      db,
+		dialect: dialect,
+	}, nil
+}
+
+func (r *SQLAuditRepository) Append(ctx context.Context, entry *domain.AuditEntry) error {
+	return insertAuditEntry(ctx, r.db, r.dialect, entry)
+}
+
+// sqlExecer runs statements on a database or within a transaction
+type sqlExecer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// insertAuditEntry is shared with the contact repository, which saves the entries recorded on the contacts within their transaction
+func insertAuditEntry(ctx context.Context, db sqlExecer, dialect sqlDialect, entry *domain.AuditEntry) error {
+	record := toAuditRecord(entry)
+	changes, err := json.Marshal(record.Changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry changes: %w", err)
+	}
+
+	_, err = db.ExecContext(
+		ctx,
+		dialect.rebind("INSERT INTO contact_audit_entries ("+auditColumns+") VALUES ("+placeholders(7)+")"),
+		record.Id,
+		record.ContactId,
+		record.ActorId,
+		record.ActorType,
+		record.Action,
+		record.At,
+		string(changes),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to append audit entry: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SQLAuditRepository) ListByContact(ctx context.Context, contactId uuid.UUID) ([]*domain.AuditEntry, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		r.dialect.rebind("SELECT "+auditColumns+" FROM contact_audit_entries WHERE contact_id = ? ORDER BY recorded_at, id"),
+		contactId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*domain.AuditEntry{}
+	for rows.Next() {
+		var (
+			record  auditRecord
+			changes string
+		)
+		err := rows.Scan(&record.Id, &record.ContactId, &record.ActorId, &record.ActorType, &record.Action, &record.At, &changes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list audit entries: %w", err)
+		}
+
+		err = json.Unmarshal([]byte(changes), &record.Changes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode audit entry changes: %w", err)
+		}
+		entries = append(entries, record.toDomain())
+	}
+
+	return entries, rows.Err()
+}
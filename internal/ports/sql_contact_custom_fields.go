@@ -0,0 +1,105 @@
+package ports
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+)
+
+// loadCustomFields sets the custom fields of the given contacts, the values are stored formatted by CustomValue.String
+func (r *SQLContactRepository) loadCustomFields(ctx context.Context, q sqlQuerier, contacts ...*domain.Contact) error {
+	if len(contacts) == 0 {
+		return nil
+	}
+
+	byId := make(map[uuid.UUID]*domain.Contact, len(contacts))
+	args := make([]any, 0, len(contacts))
+	for _, contact := range contacts {
+		byId[contact.Id] = contact
+		args = append(args, contact.Id)
+	}
+
+	query := "SELECT contact_id, name, type, value FROM contact_custom_fields WHERE contact_id IN (" + placeholders(len(contacts)) + ")"
+	err := r.queryDetails(ctx, q, query, args, func(rows *sql.Rows) error {
+		var (
+			contactId   uuid.UUID
+			name, value string
+			fieldType   domain.FieldType
+		)
+		err := rows.Scan(&contactId, &name, &fieldType, &value)
+		if err != nil {
+			return err
+		}
+
+		customValue, err := domain.ParseCustomValue(fieldType, value)
+		if err != nil {
+			return err
+		}
+
+		contact := byId[contactId]
+		if contact.CustomFields == nil {
+			contact.CustomFields = map[string]domain.CustomValue{}
+		}
+		contact.CustomFields[name] = customValue
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load contact custom fields: %w", err)
+	}
+
+	return nil
+}
+
+// saveCustomFields replaces the stored custom fields of the contact
+func (r *SQLContactRepository) saveCustomFields(ctx context.Context, tx *sql.Tx, contact *domain.Contact) error {
+	_, err := tx.ExecContext(ctx, r.dialect.rebind("DELETE FROM contact_custom_fields WHERE contact_id = ?"), contact.Id)
+	if err != nil {
+		return fmt.Errorf("failed to save contact custom fields: %w", err)
+	}
+
+	for name, value := range contact.CustomFields {
+		_, err := tx.ExecContext(
+			ctx,
+			r.dialect.rebind("INSERT INTO contact_custom_fields (contact_id, name, type, value) VALUES (?, ?, ?, ?)"),
+			contact.Id, name, value.Type, value.String(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save contact custom fields: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// customFieldConditions matches the custom fields of the filter like the other field matches, sorted by name so that
+// queries are stable
+func customFieldConditions(matches map[string]domain.FieldMatch) ([]string, []any) {
+	names := make([]string, 0, len(matches))
+	for name := range matches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var (
+		conditions []string
+		args       []any
+	)
+	for _, name := range names {
+		match := matches[name]
+		value := strings.ToLower(match.Value)
+		if match.Mode == domain.MatchPrefix {
+			conditions = append(conditions, "id IN (SELECT contact_id FROM contact_custom_fields WHERE name = ? AND LOWER(value) LIKE ? ESCAPE '\\')")
+			args = append(args, name, escapeLike(value)+"%")
+		} else {
+			conditions = append(conditions, "id IN (SELECT contact_id FROM contact_custom_fields WHERE name = ? AND LOWER(value) = ?)")
+			args = append(args, name, value)
+		}
+	}
+
+	return conditions, args
+}
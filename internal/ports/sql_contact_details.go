@@ -0,0 +1,141 @@
+package ports
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+)
+
+// loadDetails sets the emails, phones and postal addresses of the given contacts, in the order they were saved
+func (r *SQLContactRepository) loadDetails(ctx context.Context, q sqlQuerier, contacts ...*domain.Contact) error {
+	if len(contacts) == 0 {
+		return nil
+	}
+
+	byId := make(map[uuid.UUID]*domain.Contact, len(contacts))
+	args := make([]any, 0, len(contacts))
+	for _, contact := range contacts {
+		byId[contact.Id] = contact
+		args = append(args, contact.Id)
+	}
+	where := " WHERE contact_id IN (" + placeholders(len(contacts)) + ") ORDER BY contact_id, position"
+
+	err := r.queryDetails(ctx, q, "SELECT contact_id, label, address, is_primary FROM contact_emails"+where, args, func(rows *sql.Rows) error {
+		var (
+			contactId uuid.UUID
+			email     domain.EmailAddress
+		)
+		err := rows.Scan(&contactId, &email.Label, &email.Address, &email.Primary)
+		if err != nil {
+			return err
+		}
+
+		byId[contactId].Emails = append(byId[contactId].Emails, email)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load contact emails: %w", err)
+	}
+
+	err = r.queryDetails(ctx, q, "SELECT contact_id, label, number, is_primary FROM contact_phones"+where, args, func(rows *sql.Rows) error {
+		var (
+			contactId uuid.UUID
+			phone     domain.PhoneNumber
+		)
+		err := rows.Scan(&contactId, &phone.Label, &phone.Number, &phone.Primary)
+		if err != nil {
+			return err
+		}
+
+		byId[contactId].Phones = append(byId[contactId].Phones, phone)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load contact phones: %w", err)
+	}
+
+	err = r.queryDetails(ctx, q, "SELECT contact_id, label, street, locality, region, postal_code, country, is_primary FROM contact_addresses"+where, args, func(rows *sql.Rows) error {
+		var (
+			contactId uuid.UUID
+			address   domain.PostalAddress
+		)
+		err := rows.Scan(&contactId, &address.Label, &address.Street, &address.Locality, &address.Region, &address.PostalCode, &address.Country, &address.Primary)
+		if err != nil {
+			return err
+		}
+
+		byId[contactId].Addresses = append(byId[contactId].Addresses, address)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load contact addresses: %w", err)
+	}
+
+	return nil
+}
+
+// queryDetails reads all the rows before returning so that the next query can reuse the connection
+func (r *SQLContactRepository) queryDetails(ctx context.Context, q sqlQuerier, query string, args []any, scan func(rows *sql.Rows) error) error {
+	rows, err := q.QueryContext(ctx, r.dialect.rebind(query), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := scan(rows)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// saveDetails replaces the stored emails, phones and postal addresses of the contact
+func (r *SQLContactRepository) saveDetails(ctx context.Context, tx *sql.Tx, contact *domain.Contact) error {
+	for _, table := range []string{"contact_emails", "contact_phones", "contact_addresses"} {
+		_, err := tx.ExecContext(ctx, r.dialect.rebind("DELETE FROM "+table+" WHERE contact_id = ?"), contact.Id)
+		if err != nil {
+			return fmt.Errorf("failed to save contact details: %w", err)
+		}
+	}
+
+	for i, email := range contact.EmailAddresses() {
+		_, err := tx.ExecContext(
+			ctx,
+			r.dialect.rebind("INSERT INTO contact_emails (contact_id, position, label, address, is_primary) VALUES (?, ?, ?, ?, ?)"),
+			contact.Id, i, email.Label, email.Address, email.Primary,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save contact emails: %w", err)
+		}
+	}
+
+	for i, phone := range contact.PhoneNumbers() {
+		_, err := tx.ExecContext(
+			ctx,
+			r.dialect.rebind("INSERT INTO contact_phones (contact_id, position, label, number, is_primary) VALUES (?, ?, ?, ?, ?)"),
+			contact.Id, i, phone.Label, phone.Number, phone.Primary,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save contact phones: %w", err)
+		}
+	}
+
+	for i, address := range contact.Addresses {
+		_, err := tx.ExecContext(
+			ctx,
+			r.dialect.rebind("INSERT INTO contact_addresses (contact_id, position, label, street, locality, region, postal_code, country, is_primary) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)"),
+			contact.Id, i, address.Label, address.Street, address.Locality, address.Region, address.PostalCode, address.Country, address.Primary,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save contact addresses: %w", err)
+		}
+	}
+
+	return nil
+}
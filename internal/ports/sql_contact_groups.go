@@ -0,0 +1,62 @@
+package ports
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+)
+
+// loadGroups sets the groups of the given contacts, sorted by group id like domain.Contact keeps them
+func (r *SQLContactRepository) loadGroups(ctx context.Context, q sqlQuerier, contacts ...*domain.Contact) error {
+	if len(contacts) == 0 {
+		return nil
+	}
+
+	byId := make(map[uuid.UUID]*domain.Contact, len(contacts))
+	args := make([]any, 0, len(contacts))
+	for _, contact := range contacts {
+		byId[contact.Id] = contact
+		args = append(args, contact.Id)
+	}
+
+	query := "SELECT contact_id, group_id FROM contact_group_members WHERE contact_id IN (" + placeholders(len(contacts)) + ") ORDER BY contact_id, group_id"
+	err := r.queryDetails(ctx, q, query, args, func(rows *sql.Rows) error {
+		var contactId, groupId uuid.UUID
+		err := rows.Scan(&contactId, &groupId)
+		if err != nil {
+			return err
+		}
+
+		byId[contactId].AddToGroup(groupId)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load contact groups: %w", err)
+	}
+
+	return nil
+}
+
+// saveGroups replaces the stored groups of the contact
+func (r *SQLContactRepository) saveGroups(ctx context.Context, tx *sql.Tx, contact *domain.Contact) error {
+	_, err := tx.ExecContext(ctx, r.dialect.rebind("DELETE FROM contact_group_members WHERE contact_id = ?"), contact.Id)
+	if err != nil {
+		return fmt.Errorf("failed to save contact groups: %w", err)
+	}
+
+	for _, groupId := range contact.GroupIds {
+		_, err := tx.ExecContext(
+			ctx,
+			r.dialect.rebind("INSERT INTO contact_group_members (contact_id, group_id) VALUES (?, ?)"),
+			contact.Id, groupId,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save contact groups: %w", err)
+		}
+	}
+
+	return nil
+}
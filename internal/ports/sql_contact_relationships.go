@@ -0,0 +1,66 @@
+package ports
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+)
+
+// loadRelationships sets the relationships of the given contacts, sorted like domain.Contact keeps them
+func (r *SQLContactRepository) loadRelationships(ctx context.Context, q sqlQuerier, contacts ...*domain.Contact) error {
+	if len(contacts) == 0 {
+		return nil
+	}
+
+	byId := make(map[uuid.UUID]*domain.Contact, len(contacts))
+	args := make([]any, 0, len(contacts))
+	for _, contact := range contacts {
+		byId[contact.Id] = contact
+		args = append(args, contact.Id)
+	}
+
+	query := "SELECT contact_id, type, related_id, on_delete, created_at FROM contact_relationships WHERE contact_id IN (" + placeholders(len(contacts)) + ")"
+	err := r.queryDetails(ctx, q, query, args, func(rows *sql.Rows) error {
+		var (
+			contactId    uuid.UUID
+			relationship domain.Relationship
+		)
+		err := rows.Scan(&contactId, &relationship.Type, &relationship.ContactId, &relationship.OnDelete, &relationship.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		relationship.CreatedAt = relationship.CreatedAt.UTC()
+		byId[contactId].Relate(relationship)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load contact relationships: %w", err)
+	}
+
+	return nil
+}
+
+// saveRelationships replaces the stored relationships of the contact
+func (r *SQLContactRepository) saveRelationships(ctx context.Context, tx *sql.Tx, contact *domain.Contact) error {
+	_, err := tx.ExecContext(ctx, r.dialect.rebind("DELETE FROM contact_relationships WHERE contact_id = ?"), contact.Id)
+	if err != nil {
+		return fmt.Errorf("failed to save contact relationships: %w", err)
+	}
+
+	for _, relationship := range contact.Relationships {
+		_, err := tx.ExecContext(
+			ctx,
+			r.dialect.rebind("INSERT INTO contact_relationships (contact_id, type, related_id, on_delete, created_at) VALUES (?, ?, ?, ?, ?)"),
+			contact.Id, relationship.Type, relationship.ContactId, relationship.OnDelete, relationship.CreatedAt.UTC(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save contact relationships: %w", err)
+		}
+	}
+
+	return nil
+}
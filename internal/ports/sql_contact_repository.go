@@ -0,0 +1,901 @@
+package ports
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	contactColumns = "id, created_at, updated_at, created_by, address_book_id, first_name, last_name, email, phone, deleted_at, version, email_status"
+
+	// contactKeyColumns are the keys of the unique indexes, written with the contacts from their uniqueKeys
+	contactKeyColumns = "email_key, phone_key"
+	contactKeyValues  = "LOWER(CAST(? AS TEXT)), CAST(? AS TEXT)"
+)
+
+// SQLContactRepository stores contacts in a SQLite or PostgreSQL database, along with the events recorded on them in its outbox.
+// Update and Delete closures run within a transaction holding a lock on the contact row.
+// The constraints are enforced by the unique indexes of the schema over the keys of the contacts, set by the repository
+// to the constrained values when their constraint is enabled and left NULL otherwise.
+type SQLContactRepository struct {
+	db          *sql.DB
+	dialect     sqlDialect
+	constraints contactConstraints
+}
+
+// uniqueContactKeys are the keys of the unique indexes, partial ones so that the contacts in the trash are not constrained
+var uniqueContactKeys = []struct {
+	index  string
+	column string
+	key    string
+	// value is the expression of the key computed from the column
+	value   string
+	get     func(c *domain.Contact) string
+	enabled func(c contactConstraints) bool
+}{
+	{
+		"contacts_unique_email", "email", "email_key", "LOWER(email)",
+		func(c *domain.Contact) string { return c.Email },
+		func(c contactConstraints) bool { return c.uniqueEmail },
+	},
+	{
+		"contacts_unique_phone", "phone", "phone_key", "phone",
+		func(c *domain.Contact) string { return c.Phone },
+		func(c contactConstraints) bool { return c.uniquePhone },
+	},
+}
+
+// NewSQLContactRepository sets the keys of the existing contacts to follow the constraints, it fails naming the
+// contacts sharing a value when a constraint cannot be enabled
+func NewSQLContactRepository(db *sql.DB, driver string, options ...ContactConstraint) (*SQLContactRepository, error) {
+	dialect, err := newSQLDialect(driver)
+	if err != nil {
+		return nil, err
+	}
+
+	constraints := newContactConstraints(options...)
+	for _, unique := range uniqueContactKeys {
+		statement := fmt.Sprintf("UPDATE contacts SET %s = NULL WHERE %s IS NOT NULL", unique.key, unique.key)
+		if unique.enabled(constraints) {
+			statement = fmt.Sprintf(
+				"UPDATE contacts SET %[1]s = %[2]s WHERE %[3]s <> '' AND (%[1]s IS NULL OR %[1]s <> %[2]s)",
+				unique.key, unique.value, unique.column,
+			)
+		}
+
+		_, err := db.Exec(statement)
+		if _, ok := uniqueViolation(err); ok {
+			return nil, duplicateContacts(db, unique.column, unique.value)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to set unique contact %s constraint: %w", unique.column, err)
+		}
+	}
+
+	return &SQLContactRepository{
+		db:          db,
+		dialect:     dialect,
+		constraints: constraints,
+	}, nil
+}
+
+// duplicateContacts returns an ErrAlreadyExists naming the contacts out of the trash sharing their value with another
+// contact of their owner
+func duplicateContacts(db *sql.DB, column string, value string) error {
+	rows, err := db.Query(fmt.Sprintf(
+		`SELECT created_by, %[1]s, id FROM contacts
+		WHERE deleted_at IS NULL AND %[2]s <> '' AND (created_by, %[1]s) IN (
+			SELECT created_by, %[1]s FROM contacts WHERE deleted_at IS NULL AND %[2]s <> '' GROUP BY created_by, %[1]s HAVING COUNT(*) > 1
+		)
+		ORDER BY created_by, %[1]s, id`,
+		value, column,
+	))
+	if err != nil {
+		return fmt.Errorf("failed to find the contacts sharing their %s: %w", column, err)
+	}
+	defer rows.Close()
+
+	var (
+		duplicates []string
+		previous   string
+	)
+	for rows.Next() {
+		var owner, shared, id string
+		err := rows.Scan(&owner, &shared, &id)
+		if err != nil {
+			return fmt.Errorf("failed to find the contacts sharing their %s: %w", column, err)
+		}
+
+		if group := owner + " " + shared; group != previous {
+			duplicates = append(duplicates, fmt.Sprintf("%s of %s by %s", shared, owner, id))
+			previous = group
+		} else {
+			duplicates[len(duplicates)-1] += ", " + id
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to find the contacts sharing their %s: %w", column, err)
+	}
+
+	return fmt.Errorf(
+		"%w: the unique %s constraint cannot be enabled over the contacts sharing their %s, %s",
+		ErrAlreadyExists, column, column, strings.Join(duplicates, "; "),
+	)
+}
+
+// uniqueKeys returns the values the keys of the contact are computed from by contactKeyValues, nil for the constraints
+// not enabled
+func (r *SQLContactRepository) uniqueKeys(contact *domain.Contact) []any {
+	keys := make([]any, 0, len(uniqueContactKeys))
+	for _, unique := range uniqueContactKeys {
+		value := unique.get(contact)
+		if !unique.enabled(r.constraints) || value == "" {
+			keys = append(keys, nil)
+			continue
+		}
+		keys = append(keys, value)
+	}
+
+	return keys
+}
+
+// alreadyExists translates the violations of the unique contact indexes into ErrAlreadyExists
+func alreadyExists(err error, contact *domain.Contact) error {
+	constraint, ok := uniqueViolation(err)
+	if !ok {
+		return err
+	}
+
+	for _, unique := range uniqueContactKeys {
+		if strings.Contains(constraint, unique.index) || strings.Contains(constraint, "contacts."+unique.key) {
+			return fmt.Errorf("%w: %s is used by another contact of %s", ErrAlreadyExists, unique.column, contact.CreatedBy)
+		}
+	}
+
+	return fmt.Errorf("%w: contact %s", ErrAlreadyExists, contact.Id)
+}
+
+func (r *SQLContactRepository) Get(ctx context.Context, id uuid.UUID) (*domain.Contact, error) {
+	return r.get(ctx, r.db, id, false)
+}
+
+// sqlQuerier is implemented by both *sql.DB and *sql.Tx
+type sqlQuerier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func (r *SQLContactRepository) get(ctx context.Context, q sqlQuerier, id uuid.UUID, forUpdate bool) (*domain.Contact, error) {
+	query := "SELECT " + contactColumns + " FROM contacts WHERE id = ?"
+	if forUpdate {
+		query += r.dialect.forUpdate()
+	}
+
+	contact, err := scanContact(q.QueryRowContext(ctx, r.dialect.rebind(query), id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contact: %w", err)
+	}
+
+	err = r.loadGrants(ctx, q, contact)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.loadDetails(ctx, q, contact)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.loadCustomFields(ctx, q, contact)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.loadGroups(ctx, q, contact)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.loadRelationships(ctx, q, contact)
+	if err != nil {
+		return nil, err
+	}
+
+	return contact, nil
+}
+
+func (r *SQLContactRepository) List(ctx context.Context, filter domain.Filter) ([]*domain.Contact, error) {
+	conditions, args := contactFilterConditions(filter)
+
+	column, direction := sortColumn(filter.Sort())
+	if filter.After() != nil {
+		after := *filter.After()
+		comparison := ">"
+		if filter.Sort().Desc {
+			comparison = "<"
+		}
+
+		var value any = after.Text
+		if column == "created_at" || column == "updated_at" {
+			value = after.Time.UTC()
+		}
+		conditions = append(conditions, fmt.Sprintf("(%s %s ? OR (%s = ? AND id %s ?))", column, comparison, column, comparison))
+		args = append(args, value, value, after.Id)
+	}
+
+	query := "SELECT " + contactColumns + " FROM contacts"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += fmt.Sprintf(" ORDER BY %s %s, id %s", column, direction, direction)
+	if filter.Limit() > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit())
+	}
+
+	contacts, err := r.queryContacts(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contacts: %w", err)
+	}
+
+	err = r.loadGrants(ctx, r.db, contacts...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.loadDetails(ctx, r.db, contacts...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.loadCustomFields(ctx, r.db, contacts...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.loadGroups(ctx, r.db, contacts...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.loadRelationships(ctx, r.db, contacts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return contacts, nil
+}
+
+// queryContacts reads all the rows before returning so that the connection can be reused right away
+func (r *SQLContactRepository) queryContacts(ctx context.Context, query string, args ...any) ([]*domain.Contact, error) {
+	rows, err := r.db.QueryContext(ctx, r.dialect.rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	contacts := []*domain.Contact{}
+	for rows.Next() {
+		contact, err := scanContact(rows)
+		if err != nil {
+			return nil, err
+		}
+		contacts = append(contacts, contact)
+	}
+
+	return contacts, rows.Err()
+}
+
+// loadGrants sets the grants of the given contacts
+func (r *SQLContactRepository) loadGrants(ctx context.Context, q sqlQuerier, contacts ...*domain.Contact) error {
+	if len(contacts) == 0 {
+		return nil
+	}
+
+	byId := make(map[uuid.UUID]*domain.Contact, len(contacts))
+	args := make([]any, 0, len(contacts))
+	for _, contact := range contacts {
+		byId[contact.Id] = contact
+		args = append(args, contact.Id)
+	}
+
+	rows, err := q.QueryContext(
+		ctx,
+		r.dialect.rebind("SELECT contact_id, user_id, permission, granted_at FROM contact_grants WHERE contact_id IN ("+placeholders(len(contacts))+") ORDER BY granted_at, user_id"),
+		args...,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to load contact grants: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			contactId uuid.UUID
+			grant     domain.Grant
+		)
+		err := rows.Scan(&contactId, &grant.UserId, &grant.Permission, &grant.GrantedAt)
+		if err != nil {
+			return fmt.Errorf("failed to load contact grants: %w", err)
+		}
+		grant.GrantedAt = grant.GrantedAt.UTC()
+
+		contact := byId[contactId]
+		contact.Grants = append(contact.Grants, grant)
+	}
+
+	return rows.Err()
+}
+
+// saveGrants replaces the stored grants of the contact
+func (r *SQLContactRepository) saveGrants(ctx context.Context, tx *sql.Tx, contact *domain.Contact) error {
+	_, err := tx.ExecContext(ctx, r.dialect.rebind("DELETE FROM contact_grants WHERE contact_id = ?"), contact.Id)
+	if err != nil {
+		return fmt.Errorf("failed to save contact grants: %w", err)
+	}
+
+	for _, grant := range contact.Grants {
+		_, err := tx.ExecContext(
+			ctx,
+			r.dialect.rebind("INSERT INTO contact_grants (contact_id, user_id, permission, granted_at) VALUES (?, ?, ?, ?)"),
+			contact.Id, grant.UserId, grant.Permission, grant.GrantedAt.UTC(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save contact grants: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// contactFilterConditions translates a filter into SQL conditions, text comparisons are case insensitive
+func contactFilterConditions(filter domain.Filter) ([]string, []any) {
+	var (
+		conditions []string
+		args       []any
+	)
+
+	if filter.CreatedBy() != nil {
+		conditions = append(conditions, "created_by = ?")
+		args = append(args, *filter.CreatedBy())
+	}
+
+	if visibility := filter.VisibleTo(); visibility != nil {
+		visible := []string{"created_by = ?", "id IN (SELECT contact_id FROM contact_grants WHERE user_id = ?)"}
+		args = append(args, visibility.UserId, visibility.UserId)
+		if len(visibility.AddressBookIds) > 0 {
+			visible = append(visible, "address_book_id IN ("+placeholders(len(visibility.AddressBookIds))+")")
+			for _, id := range visibility.AddressBookIds {
+				args = append(args, id)
+			}
+		}
+		conditions = append(conditions, "("+strings.Join(visible, " OR ")+")")
+	}
+
+	if filter.AddressBook() != nil {
+		conditions = append(conditions, "address_book_id = ?")
+		args = append(args, *filter.AddressBook())
+	}
+
+	if filter.Group() != nil {
+		conditions = append(conditions, "id IN (SELECT contact_id FROM contact_group_members WHERE group_id = ?)")
+		args = append(args, *filter.Group())
+	}
+
+	if filter.RelatedTo() != nil {
+		conditions = append(conditions, "id IN (SELECT contact_id FROM contact_relationships WHERE related_id = ?)")
+		args = append(args, *filter.RelatedTo())
+	}
+
+	if filter.Trashed() {
+		conditions = append(conditions, "deleted_at IS NOT NULL")
+	} else {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+
+	if filter.Search() != "" {
+		pattern := "%" + escapeLike(strings.ToLower(filter.Search())) + "%"
+		var searches []string
+		for _, column := range []string{"first_name", "last_name", "email", "phone"} {
+			searches = append(searches, "LOWER("+column+") LIKE ? ESCAPE '\\'")
+			args = append(args, pattern)
+		}
+		conditions = append(conditions, "("+strings.Join(searches, " OR ")+")")
+	}
+
+	fieldMatches := []struct {
+		column string
+		match  *domain.FieldMatch
+	}{
+		{"first_name", filter.FirstName()},
+		{"last_name", filter.LastName()},
+		{"email", filter.Email()},
+		{"phone", filter.Phone()},
+	}
+	for _, f := range fieldMatches {
+		if f.match == nil {
+			continue
+		}
+
+		value := strings.ToLower(f.match.Value)
+		if f.match.Mode == domain.MatchPrefix {
+			conditions = append(conditions, "LOWER("+f.column+") LIKE ? ESCAPE '\\'")
+			args = append(args, escapeLike(value)+"%")
+		} else {
+			conditions = append(conditions, "LOWER("+f.column+") = ?")
+			args = append(args, value)
+		}
+	}
+
+	customConditions, customArgs := customFieldConditions(filter.CustomFields())
+	conditions = append(conditions, customConditions...)
+	args = append(args, customArgs...)
+
+	timeRanges := []struct {
+		column    string
+		timeRange *domain.TimeRange
+	}{
+		{"created_at", filter.CreatedAt()},
+		{"updated_at", filter.UpdatedAt()},
+	}
+	for _, t := range timeRanges {
+		if t.timeRange == nil {
+			continue
+		}
+		if t.timeRange.From != nil {
+			conditions = append(conditions, t.column+" >= ?")
+			args = append(args, t.timeRange.From.UTC())
+		}
+		if t.timeRange.To != nil {
+			conditions = append(conditions, t.column+" < ?")
+			args = append(args, t.timeRange.To.UTC())
+		}
+	}
+
+	return conditions, args
+}
+
+// sortColumn returns the column and direction matching the sort, ordering is completed by id like domain.Sort does
+func sortColumn(sort domain.Sort) (string, string) {
+	column := "created_at"
+	switch sort.Field {
+	case domain.SortByLastName:
+		column = "last_name"
+	case domain.SortByFirstName:
+		column = "first_name"
+	case domain.SortByUpdatedAt:
+		column = "updated_at"
+	}
+
+	if sort.Desc {
+		return column, "DESC"
+	}
+
+	return column, "ASC"
+}
+
+// placeholders returns n comma separated bind parameters
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_").Replace(value)
+}
+
+func (r *SQLContactRepository) Create(ctx context.Context, contact *domain.Contact) (*domain.Contact, error) {
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		return r.create(ctx, tx, contact)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return contact, nil
+}
+
+func (r *SQLContactRepository) create(ctx context.Context, tx *sql.Tx, contact *domain.Contact) error {
+	_, err := tx.ExecContext(
+		ctx,
+		r.dialect.rebind("INSERT INTO contacts ("+contactColumns+", "+contactKeyColumns+") VALUES ("+placeholders(12)+", "+contactKeyValues+")"),
+		append(contactValues(contact), r.uniqueKeys(contact)...)...,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create contact: %w", alreadyExists(err, contact))
+	}
+
+	err = r.saveEvents(ctx, tx, contact)
+	if err != nil {
+		return err
+	}
+
+	err = r.saveGrants(ctx, tx, contact)
+	if err != nil {
+		return err
+	}
+
+	err = r.saveDetails(ctx, tx, contact)
+	if err != nil {
+		return err
+	}
+
+	err = r.saveCustomFields(ctx, tx, contact)
+	if err != nil {
+		return err
+	}
+
+	err = r.saveGroups(ctx, tx, contact)
+	if err != nil {
+		return err
+	}
+
+	return r.saveRelationships(ctx, tx, contact)
+}
+
+func (r *SQLContactRepository) Update(ctx context.Context, id uuid.UUID, updateFn func(c domain.Contact) (domain.Contact, error)) (*domain.Contact, error) {
+	var updatedContact *domain.Contact
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		var err error
+		updatedContact, err = r.update(ctx, tx, id, updateFn)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedContact, nil
+}
+
+func (r *SQLContactRepository) update(ctx context.Context, tx *sql.Tx, id uuid.UUID, updateFn func(c domain.Contact) (domain.Contact, error)) (*domain.Contact, error) {
+	originalContact, err := r.get(ctx, tx, id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedContact, err := updateFn(*originalContact)
+	if err != nil {
+		return nil, err
+	}
+	updatedContact.Version = originalContact.Version + 1
+
+	_, err = tx.ExecContext(
+		ctx,
+		r.dialect.rebind(
+			"UPDATE contacts SET updated_at = ?, first_name = ?, last_name = ?, email = ?, phone = ?, deleted_at = ?, version = ?, email_status = ?, "+
+				"("+contactKeyColumns+") = ("+contactKeyValues+") WHERE id = ?",
+		),
+		append(
+			[]any{
+				updatedContact.UpdatedAt.UTC(),
+				updatedContact.FirstName,
+				updatedContact.LastName,
+				updatedContact.Email,
+				updatedContact.Phone,
+				nullTime(updatedContact.DeletedAt),
+				updatedContact.Version,
+				updatedContact.EmailStatus,
+			},
+			append(r.uniqueKeys(&updatedContact), id)...,
+		)...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update contact: %w", alreadyExists(err, &updatedContact))
+	}
+
+	err = r.saveEvents(ctx, tx, &updatedContact)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.saveGrants(ctx, tx, &updatedContact)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.saveDetails(ctx, tx, &updatedContact)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.saveCustomFields(ctx, tx, &updatedContact)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.saveGroups(ctx, tx, &updatedContact)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.saveRelationships(ctx, tx, &updatedContact)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedContact, nil
+}
+
+// Batch applies the operations within a single transaction, the updated contacts rows stay locked until it ends
+func (r *SQLContactRepository) Batch(ctx context.Context, ops []domain.ContactOperation) ([]*domain.Contact, error) {
+	contacts := make([]*domain.Contact, 0, len(ops))
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		for i, op := range ops {
+			if op.Create != nil {
+				err := r.create(ctx, tx, op.Create)
+				if err != nil {
+					return &domain.OperationError{Index: i, Err: err}
+				}
+				contacts = append(contacts, op.Create)
+				continue
+			}
+
+			updatedContact, err := r.update(ctx, tx, op.Id, op.UpdateFn)
+			if err != nil {
+				return &domain.OperationError{Index: i, Err: err}
+			}
+			contacts = append(contacts, updatedContact)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return contacts, nil
+}
+
+func (r *SQLContactRepository) Delete(ctx context.Context, id uuid.UUID, deleterFn func(c domain.Contact) error) error {
+	return inTx(ctx, r.db, func(tx *sql.Tx) error {
+		contact, err := r.get(ctx, tx, id, true)
+		if err != nil {
+			return err
+		}
+
+		if err := deleterFn(*contact); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, r.dialect.rebind("DELETE FROM contacts WHERE id = ?"), id)
+		if err != nil {
+			return fmt.Errorf("failed to delete contact: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Purge permanently removes the contacts deleted before the given time, their grants are removed in cascade
+func (r *SQLContactRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	result, err := r.db.ExecContext(
+		ctx,
+		r.dialect.rebind("DELETE FROM contacts WHERE deleted_at IS NOT NULL AND deleted_at < ?"),
+		deletedBefore.UTC(),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge contacts: %w", err)
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge contacts: %w", err)
+	}
+
+	return int(purged), nil
+}
+
+// inTx runs fn within a transaction committed if and only if fn succeeds
+// saveEvents moves the events recorded on the contact to the outbox, along with its audit entries
+func (r *SQLContactRepository) saveEvents(ctx context.Context, tx *sql.Tx, contact *domain.Contact) error {
+	for _, entry := range contact.PullAuditEntries() {
+		err := insertAuditEntry(ctx, tx, r.dialect, entry)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, event := range contact.PullEvents() {
+		record, err := toOutboxRecord(event)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			r.dialect.rebind("INSERT INTO contact_outbox (id, contact_id, name, occurred_at, payload) VALUES (?, ?, ?, ?, ?)"),
+			record.Id, record.ContactId, record.Name, record.OccurredAt, string(record.Payload),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save contact events: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *SQLContactRepository) Pending(ctx context.Context, limit int) ([]domain.Event, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		r.dialect.rebind("SELECT id, contact_id, name, occurred_at, payload FROM contact_outbox WHERE dead_at IS NULL ORDER BY seq LIMIT ?"),
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending events: %w", err)
+	}
+	defer rows.Close()
+
+	var records []outboxRecord
+	for rows.Next() {
+		var (
+			record  outboxRecord
+			payload string
+		)
+		err := rows.Scan(&record.Id, &record.ContactId, &record.Name, &record.OccurredAt, &payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pending events: %w", err)
+		}
+		record.Payload = []byte(payload)
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list pending events: %w", err)
+	}
+
+	events := make([]domain.Event, 0, len(records))
+	for _, record := range records {
+		event, err := record.toDomain()
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (r *SQLContactRepository) MarkPublished(ctx context.Context, ids ...uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	args := make([]any, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
+	_, err := r.db.ExecContext(ctx, r.dialect.rebind("DELETE FROM contact_outbox WHERE id IN ("+placeholders(len(ids))+")"), args...)
+	if err != nil {
+		return fmt.Errorf("failed to mark events published: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SQLContactRepository) MarkFailed(ctx context.Context, id uuid.UUID, reason string, maxAttempts int) (bool, error) {
+	var dead bool
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		var attempts int
+		err := tx.QueryRowContext(
+			ctx,
+			r.dialect.rebind("SELECT attempts FROM contact_outbox WHERE id = ? AND dead_at IS NULL"+r.dialect.forUpdate()),
+			id,
+		).Scan(&attempts)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: event %s", ErrNotFound, id)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to mark event failed: %w", err)
+		}
+
+		attempts++
+		var deadAt *time.Time
+		if attempts >= maxAttempts {
+			now := time.Now().UTC()
+			deadAt = &now
+			dead = true
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			r.dialect.rebind("UPDATE contact_outbox SET attempts = ?, last_error = ?, dead_at = ? WHERE id = ?"),
+			attempts, reason, deadAt, id,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to mark event failed: %w", err)
+		}
+
+		return nil
+	})
+
+	return dead, err
+}
+
+func inTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	err = fn(tx)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func scanContact(row rowScanner) (*domain.Contact, error) {
+	var (
+		contact       domain.Contact
+		addressBookId uuid.NullUUID
+		deletedAt     sql.NullTime
+	)
+	err := row.Scan(
+		&contact.Id,
+		&contact.CreatedAt,
+		&contact.UpdatedAt,
+		&contact.CreatedBy,
+		&addressBookId,
+		&contact.FirstName,
+		&contact.LastName,
+		&contact.Email,
+		&contact.Phone,
+		&deletedAt,
+		&contact.Version,
+		&contact.EmailStatus,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	contact.AddressBookId = addressBookId.UUID
+	if deletedAt.Valid {
+		deletedAt := deletedAt.Time.UTC()
+		contact.DeletedAt = &deletedAt
+	}
+	contact.CreatedAt = contact.CreatedAt.UTC()
+	contact.UpdatedAt = contact.UpdatedAt.UTC()
+
+	return &contact, nil
+}
+
+func contactValues(contact *domain.Contact) []any {
+	return []any{
+		contact.Id,
+		contact.CreatedAt.UTC(),
+		contact.UpdatedAt.UTC(),
+		contact.CreatedBy,
+		uuid.NullUUID{UUID: contact.AddressBookId, Valid: contact.AddressBookId != uuid.Nil},
+		contact.FirstName,
+		contact.LastName,
+		contact.Email,
+		contact.Phone,
+		nullTime(contact.DeletedAt),
+		contact.Version,
+		contact.EmailStatus,
+	}
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
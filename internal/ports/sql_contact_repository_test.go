@@ -0,0 +1,322 @@
+package ports
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLContactRepository(t *testing.T) {
+	t.Parallel()
+
+	t.Run("create, get and list contacts", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		repo := testSQLContactRepository(t)
+		owner := uuid.New()
+
+		created, err := repo.Create(ctx, testContact(owner))
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, testContact(uuid.New()))
+		require.NoError(t, err)
+
+		contact, err := repo.Get(ctx, created.Id)
+		require.NoError(t, err)
+		assert.Equal(t, created.Id, contact.Id)
+		assert.Equal(t, owner, contact.CreatedBy)
+		assert.Equal(t, created.CreatedAt, contact.CreatedAt)
+		assert.Equal(t, "jdoe@contact.local", contact.Email)
+
+		contacts, err := repo.List(ctx, NewFilter(WithCreatedBy(owner)))
+		require.NoError(t, err)
+		assert.Len(t, contacts, 1)
+
+		contacts, err = repo.List(ctx, NewFilter())
+		require.NoError(t, err)
+		assert.Len(t, contacts, 2)
+
+		_, err = repo.Get(ctx, uuid.New())
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("list contacts matching filters", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		repo := testSQLContactRepository(t)
+		owner := uuid.New()
+
+		_, err := repo.Create(ctx, testContact(owner))
+		require.NoError(t, err)
+		jane := testContact(owner)
+		jane.FirstName = "Jane"
+		jane.Email = "jane_smith@contact.local"
+		jane.CreatedAt = jane.CreatedAt.Add(-48 * time.Hour)
+		_, err = repo.Create(ctx, jane)
+		require.NoError(t, err)
+
+		yesterday := time.Now().UTC().Add(-24 * time.Hour)
+		testCases := []struct {
+			name     string
+			filter   domain.Filter
+			expected int
+		}{
+			{"search", NewFilter(WithSearch("SMITH")), 1},
+			{"search escapes wildcards", NewFilter(WithSearch("e_s")), 1},
+			{"exact match", NewFilter(WithFirstName(domain.FieldMatch{Value: "john", Mode: domain.MatchExact})), 1},
+			{"exact match does not match prefix", NewFilter(WithFirstName(domain.FieldMatch{Value: "J", Mode: domain.MatchExact})), 0},
+			{"prefix match", NewFilter(WithFirstName(domain.FieldMatch{Value: "J", Mode: domain.MatchPrefix})), 2},
+			{"created after", NewFilter(WithCreatedAt(domain.TimeRange{From: &yesterday})), 1},
+			{"created before", NewFilter(WithCreatedAt(domain.TimeRange{To: &yesterday})), 1},
+			{"combined", NewFilter(WithCreatedBy(owner), WithLastName(domain.FieldMatch{Value: "doe"}), WithUpdatedAt(domain.TimeRange{From: &yesterday})), 2},
+		}
+
+		for _, tc := range testCases {
+			contacts, err := repo.List(ctx, tc.filter)
+			require.NoError(t, err, tc.name)
+			assert.Len(t, contacts, tc.expected, tc.name)
+
+			for _, contact := range contacts {
+				assert.True(t, filterBy(tc.filter, contact), "%s: sql and in memory filtering differ", tc.name)
+			}
+		}
+	})
+
+	t.Run("update contact within a transaction", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		repo := testSQLContactRepository(t)
+		created, err := repo.Create(ctx, testContact(uuid.New()))
+		require.NoError(t, err)
+
+		_, err = repo.Update(ctx, created.Id, func(c domain.Contact) (domain.Contact, error) {
+			c.FirstName = "Jane"
+			return c, assert.AnError
+		})
+		assert.ErrorIs(t, err, assert.AnError)
+
+		updated, err := repo.Update(ctx, created.Id, func(c domain.Contact) (domain.Contact, error) {
+			c.LastName = "Smith"
+			c.EmailStatus = domain.EmailStatusDeliverable
+			return c, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Smith", updated.LastName)
+
+		contact, err := repo.Get(ctx, created.Id)
+		require.NoError(t, err)
+		assert.Equal(t, "John", contact.FirstName)
+		assert.Equal(t, "Smith", contact.LastName)
+		assert.Equal(t, domain.EmailStatusDeliverable, contact.EmailStatus)
+
+		_, err = repo.Update(ctx, uuid.New(), func(c domain.Contact) (domain.Contact, error) { return c, nil })
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("share contact", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		repo := testSQLContactRepository(t)
+		owner, reader, writer := uuid.New(), uuid.New(), uuid.New()
+
+		shared := testContact(owner)
+		shared.Share(reader, domain.PermissionRead)
+		_, err := repo.Create(ctx, shared)
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, testContact(owner))
+		require.NoError(t, err)
+
+		_, err = repo.Update(ctx, shared.Id, func(c domain.Contact) (domain.Contact, error) {
+			c.Share(writer, domain.PermissionWrite)
+			c.Unshare(reader)
+			return c, nil
+		})
+		require.NoError(t, err)
+
+		contact, err := repo.Get(ctx, shared.Id)
+		require.NoError(t, err)
+		require.Len(t, contact.Grants, 1)
+		assert.Equal(t, writer, contact.Grants[0].UserId)
+		assert.Equal(t, domain.PermissionWrite, contact.Grants[0].Permission)
+
+		contacts, err := repo.List(ctx, NewFilter(WithVisibleTo(domain.Visibility{UserId: writer})))
+		require.NoError(t, err)
+		require.Len(t, contacts, 1)
+		assert.Equal(t, shared.Id, contacts[0].Id)
+		assert.Len(t, contacts[0].Grants, 1)
+
+		contacts, err = repo.List(ctx, NewFilter(WithVisibleTo(domain.Visibility{UserId: reader})))
+		require.NoError(t, err)
+		assert.Empty(t, contacts)
+
+		contacts, err = repo.List(ctx, NewFilter(WithVisibleTo(domain.Visibility{UserId: owner})))
+		require.NoError(t, err)
+		assert.Len(t, contacts, 2)
+
+		err = repo.Delete(ctx, shared.Id, func(c domain.Contact) error { return nil })
+		require.NoError(t, err)
+	})
+
+	t.Run("emails, phones and postal addresses", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		repo := testSQLContactRepository(t)
+		contact := testContact(uuid.New())
+		contact.SetEmails([]domain.EmailAddress{
+			{Label: domain.LabelWork, Address: "jdoe@work.local"},
+			{Label: domain.LabelHome, Address: "jdoe@home.local", Primary: true},
+		})
+		contact.SetAddresses([]domain.PostalAddress{
+			{Label: domain.LabelHome, Street: "1 rue de la Paix", Locality: "Paris", PostalCode: "75002", Country: "FR"},
+		})
+		_, err := repo.Create(ctx, contact)
+		require.NoError(t, err)
+
+		created, err := repo.Get(ctx, contact.Id)
+		require.NoError(t, err)
+		assert.Equal(t, "jdoe@home.local", created.Email)
+		assert.Equal(t, contact.Emails, created.Emails)
+		assert.Equal(t, contact.PhoneNumbers(), created.Phones, "the single phone is saved as the primary one")
+		assert.Equal(t, contact.Addresses, created.Addresses)
+
+		_, err = repo.Update(ctx, contact.Id, func(c domain.Contact) (domain.Contact, error) {
+			c.SetEmails(c.Emails[:1])
+			c.SetPhone("+33112345678")
+			return c, nil
+		})
+		require.NoError(t, err)
+
+		contacts, err := repo.List(ctx, NewFilter(WithVisibleTo(domain.Visibility{UserId: contact.CreatedBy})))
+		require.NoError(t, err)
+		require.Len(t, contacts, 1)
+		assert.Equal(t, []domain.EmailAddress{{Label: domain.LabelWork, Address: "jdoe@work.local", Primary: true}}, contacts[0].Emails)
+		assert.Equal(t, "+33112345678", contacts[0].Phone)
+		assert.Len(t, contacts[0].Phones, 1)
+		assert.Len(t, contacts[0].Addresses, 1)
+	})
+
+	t.Run("delete contact", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		repo := testSQLContactRepository(t)
+		created, err := repo.Create(ctx, testContact(uuid.New()))
+		require.NoError(t, err)
+
+		err = repo.Delete(ctx, created.Id, func(c domain.Contact) error { return assert.AnError })
+		assert.ErrorIs(t, err, assert.AnError)
+		_, err = repo.Get(ctx, created.Id)
+		require.NoError(t, err)
+
+		err = repo.Delete(ctx, created.Id, func(c domain.Contact) error { return nil })
+		require.NoError(t, err)
+		_, err = repo.Get(ctx, created.Id)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("unique keys follow the constraints", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		repo := testSQLContactRepository(t, WithUniqueEmail())
+		owner := uuid.New()
+		first, err := repo.Create(ctx, testContact(owner))
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, testContact(owner))
+		require.ErrorIs(t, err, ErrAlreadyExists)
+
+		repo, err = NewSQLContactRepository(repo.db, DriverSQLite)
+		require.NoError(t, err)
+		second, err := repo.Create(ctx, testContact(owner))
+		require.NoError(t, err, "the keys are cleared without constraint")
+
+		_, err = NewSQLContactRepository(repo.db, DriverSQLite, WithUniqueEmail())
+		require.ErrorIs(t, err, ErrAlreadyExists, "the constraint cannot be enabled over duplicates")
+		assert.Contains(t, err.Error(), fmt.Sprintf("%s of %s by ", first.Email, owner))
+		assert.Contains(t, err.Error(), first.Id.String())
+		assert.Contains(t, err.Error(), second.Id.String())
+
+		_, err = repo.Update(ctx, second.Id, func(c domain.Contact) (domain.Contact, error) {
+			c.Email = "JACK@contact.local"
+			return c, nil
+		})
+		require.NoError(t, err)
+		repo, err = NewSQLContactRepository(repo.db, DriverSQLite, WithUniqueEmail())
+		require.NoError(t, err, "the duplicates are resolved")
+		_, err = repo.Create(ctx, &domain.Contact{Id: uuid.New(), CreatedBy: owner, FirstName: "Jack", LastName: "Doe", Email: "jack@contact.local"})
+		assert.ErrorIs(t, err, ErrAlreadyExists, "the keys of the existing contacts are set")
+	})
+}
+
+func TestMigrator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db, err := OpenSQL(ctx, DriverSQLite, ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := NewMigrator(db, DriverSQLite)
+	require.NoError(t, err)
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, statuses)
+	for _, status := range statuses {
+		assert.Nil(t, status.AppliedAt)
+	}
+
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, applied, len(statuses))
+
+	applied, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+
+	reverted, err := migrator.Down(ctx, len(statuses))
+	require.NoError(t, err)
+	assert.Len(t, reverted, len(statuses))
+
+	_, err = db.ExecContext(ctx, "SELECT 1 FROM contacts")
+	assert.Error(t, err)
+}
+
+func testSQLContactRepository(t *testing.T, options ...ContactConstraint) *SQLContactRepository {
+	t.Helper()
+
+	ctx := context.Background()
+	db, err := OpenSQL(ctx, DriverSQLite, ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := NewMigrator(db, DriverSQLite)
+	require.NoError(t, err)
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+
+	repo, err := NewSQLContactRepository(db, DriverSQLite, options...)
+	require.NoError(t, err)
+
+	return repo
+}
+
+func testContact(createdBy uuid.UUID) *domain.Contact {
+	contact := domain.New(createdBy)
+	contact.FirstName = "John"
+	contact.LastName = "Doe"
+	contact.Email = "jdoe@contact.local"
+	contact.Phone = "+33612345678"
+
+	return contact
+}
@@ -0,0 +1,75 @@
+package ports
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+)
+
+// SQLContactSchemaRepository stores the contact schemas in a SQLite or PostgreSQL database, the field definitions
+// of an owner are stored as a JSON array
+type SQLContactSchemaRepository struct {
+	db      *sql.DB
+	dialect sqlDialect
+}
+
+func NewSQLContactSchemaRepository(db *sql.DB, driver string) (*SQLContactSchemaRepository, error) {
+	dialect, err := newSQLDialect(driver)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SQLContactSchemaRepository{
+		db:      db,
+		dialect: dialect,
+	}, nil
+}
+
+func (r *SQLContactSchemaRepository) Get(ctx context.Context, ownerId uuid.UUID) (*domain.ContactSchema, error) {
+	var (
+		schema = domain.ContactSchema{OwnerId: ownerId}
+		fields string
+	)
+	err := r.db.QueryRowContext(
+		ctx,
+		r.dialect.rebind("SELECT fields, default_phone_region, updated_at FROM contact_schemas WHERE owner_id = ?"),
+		ownerId,
+	).Scan(&fields, &schema.DefaultPhoneRegion, &schema.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: contact schema of %s", ErrNotFound, ownerId)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contact schema: %w", err)
+	}
+
+	err = json.Unmarshal([]byte(fields), &schema.Fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode contact schema fields: %w", err)
+	}
+	schema.UpdatedAt = schema.UpdatedAt.UTC()
+
+	return &schema, nil
+}
+
+func (r *SQLContactSchemaRepository) Save(ctx context.Context, schema *domain.ContactSchema) (*domain.ContactSchema, error) {
+	fields, err := json.Marshal(schema.Fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode contact schema fields: %w", err)
+	}
+
+	_, err = r.db.ExecContext(
+		ctx,
+		r.dialect.rebind("INSERT INTO contact_schemas (owner_id, fields, default_phone_region, updated_at) VALUES (?, ?, ?, ?) ON CONFLICT (owner_id) DO UPDATE SET fields = excluded.fields, default_phone_region = excluded.default_phone_region, updated_at = excluded.updated_at"),
+		schema.OwnerId, string(fields), schema.DefaultPhoneRegion, schema.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save contact schema: %w", err)
+	}
+
+	return schema, nil
+}
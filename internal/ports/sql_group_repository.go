@@ -0,0 +1,171 @@
+package ports
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+)
+
+const groupColumns = "id, created_at, updated_at, created_by, name"
+
+// SQLGroupRepository stores groups in a SQLite or PostgreSQL database, the contacts they hold are stored along with
+// the contacts. Update and Delete closures run within a transaction holding a lock on the group row.
+type SQLGroupRepository struct {
+	db      *sql.DB
+	dialect sqlDialect
+}
+
+func NewSQLGroupRepository(db *sql.DB, driver string) (*SQLGroupRepository, error) {
+	dialect, err := newSQLDialect(driver)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SQLGroupRepository{
+		db:      db,
+		dialect: dialect,
+	}, nil
+}
+
+func (r *SQLGroupRepository) Get(ctx context.Context, id uuid.UUID) (*domain.Group, error) {
+	return r.get(ctx, r.db, id, false)
+}
+
+func (r *SQLGroupRepository) get(ctx context.Context, q sqlQuerier, id uuid.UUID, forUpdate bool) (*domain.Group, error) {
+	query := "SELECT " + groupColumns + " FROM contact_groups WHERE id = ?"
+	if forUpdate {
+		query += r.dialect.forUpdate()
+	}
+
+	group, err := scanGroup(q.QueryRowContext(ctx, r.dialect.rebind(query), id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: group %s", ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group: %w", err)
+	}
+
+	return group, nil
+}
+
+func (r *SQLGroupRepository) ListByOwner(ctx context.Context, userId uuid.UUID) ([]*domain.Group, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		r.dialect.rebind("SELECT "+groupColumns+" FROM contact_groups WHERE created_by = ? ORDER BY name, id"),
+		userId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups: %w", err)
+	}
+	defer rows.Close()
+
+	groups := []*domain.Group{}
+	for rows.Next() {
+		group, err := scanGroup(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list groups: %w", err)
+		}
+		groups = append(groups, group)
+	}
+
+	return groups, rows.Err()
+}
+
+func (r *SQLGroupRepository) Create(ctx context.Context, group *domain.Group) (*domain.Group, error) {
+	_, err := r.db.ExecContext(
+		ctx,
+		r.dialect.rebind("INSERT INTO contact_groups ("+groupColumns+") VALUES (?, ?, ?, ?, ?)"),
+		group.Id,
+		group.CreatedAt.UTC(),
+		group.UpdatedAt.UTC(),
+		group.CreatedBy,
+		group.Name,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create group: %w", err)
+	}
+
+	return group, nil
+}
+
+func (r *SQLGroupRepository) Update(ctx context.Context, id uuid.UUID, updateFn func(g domain.Group) (domain.Group, error)) (*domain.Group, error) {
+	var updatedGroup domain.Group
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		originalGroup, err := r.get(ctx, tx, id, true)
+		if err != nil {
+			return err
+		}
+
+		updatedGroup, err = updateFn(*originalGroup)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			r.dialect.rebind("UPDATE contact_groups SET updated_at = ?, name = ? WHERE id = ?"),
+			updatedGroup.UpdatedAt.UTC(),
+			updatedGroup.Name,
+			id,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update group: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedGroup, nil
+}
+
+// Delete also removes the group from the contacts still holding it, within the same transaction
+func (r *SQLGroupRepository) Delete(ctx context.Context, id uuid.UUID, deleterFn func(g domain.Group) error) error {
+	return inTx(ctx, r.db, func(tx *sql.Tx) error {
+		group, err := r.get(ctx, tx, id, true)
+		if err != nil {
+			return err
+		}
+
+		if err := deleterFn(*group); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, r.dialect.rebind("DELETE FROM contact_group_members WHERE group_id = ?"), id)
+		if err != nil {
+			return fmt.Errorf("failed to delete group: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, r.dialect.rebind("DELETE FROM contact_groups WHERE id = ?"), id)
+		if err != nil {
+			return fmt.Errorf("failed to delete group: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func scanGroup(row rowScanner) (*domain.Group, error) {
+	var group domain.Group
+	err := row.Scan(
+		&group.Id,
+		&group.CreatedAt,
+		&group.UpdatedAt,
+		&group.CreatedBy,
+		&group.Name,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	group.CreatedAt = group.CreatedAt.UTC()
+	group.UpdatedAt = group.UpdatedAt.UTC()
+
+	return &group, nil
+}
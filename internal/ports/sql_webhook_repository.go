@@ -0,0 +1,368 @@
+package ports
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	webhookColumns  = "id, created_at, updated_at, created_by, url, events, secret"
+	deliveryColumns = "id, webhook_id, event_id, event_name, payload, created_at, status, attempts, next_attempt_at, last_attempt_at, response_status, last_error"
+)
+
+// SQLWebhookRepository stores webhooks and their deliveries in a SQLite or PostgreSQL database.
+// Update and Delete closures run within a transaction holding a lock on the webhook row.
+type SQLWebhookRepository struct {
+	db      *sql.DB
+	dialect sqlDialect
+}
+
+func NewSQLWebhookRepository(db *sql.DB, driver string) (*SQLWebhookRepository, error) {
+	dialect, err := newSQLDialect(driver)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SQLWebhookRepository{
+		db:      db,
+		dialect: dialect,
+	}, nil
+}
+
+func (r *SQLWebhookRepository) Get(ctx context.Context, id uuid.UUID) (*domain.Webhook, error) {
+	return r.get(ctx, r.db, id, false)
+}
+
+func (r *SQLWebhookRepository) get(ctx context.Context, q sqlQuerier, id uuid.UUID, forUpdate bool) (*domain.Webhook, error) {
+	query := "SELECT " + webhookColumns + " FROM webhooks WHERE id = ?"
+	if forUpdate {
+		query += r.dialect.forUpdate()
+	}
+
+	webhook, err := scanWebhook(q.QueryRowContext(ctx, r.dialect.rebind(query), id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: webhook %s", ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+func (r *SQLWebhookRepository) ListByOwner(ctx context.Context, userId uuid.UUID) ([]*domain.Webhook, error) {
+	webhooks, err := r.queryWebhooks(ctx, "SELECT "+webhookColumns+" FROM webhooks WHERE created_by = ? ORDER BY created_at, id", userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func (r *SQLWebhookRepository) ListByEvent(ctx context.Context, eventName string) ([]*domain.Webhook, error) {
+	// events are stored as a JSON array, the LIKE pattern narrows the rows before they are checked one by one
+	candidates, err := r.queryWebhooks(ctx, "SELECT "+webhookColumns+" FROM webhooks WHERE events LIKE ? ORDER BY created_at, id", `%"`+eventName+`"%`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	webhooks := make([]*domain.Webhook, 0, len(candidates))
+	for _, webhook := range candidates {
+		if webhook.Subscribes(eventName) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+
+	return webhooks, nil
+}
+
+// queryWebhooks reads all the rows before returning so that the connection can be reused right away
+func (r *SQLWebhookRepository) queryWebhooks(ctx context.Context, query string, args ...any) ([]*domain.Webhook, error) {
+	rows, err := r.db.QueryContext(ctx, r.dialect.rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := []*domain.Webhook{}
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, rows.Err()
+}
+
+func (r *SQLWebhookRepository) Create(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+	events, err := json.Marshal(webhook.Events)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode webhook events: %w", err)
+	}
+
+	_, err = r.db.ExecContext(
+		ctx,
+		r.dialect.rebind("INSERT INTO webhooks ("+webhookColumns+") VALUES ("+placeholders(7)+")"),
+		webhook.Id,
+		webhook.CreatedAt.UTC(),
+		webhook.UpdatedAt.UTC(),
+		webhook.CreatedBy,
+		webhook.URL,
+		string(events),
+		webhook.Secret,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+func (r *SQLWebhookRepository) Update(ctx context.Context, id uuid.UUID, updateFn func(w domain.Webhook) (domain.Webhook, error)) (*domain.Webhook, error) {
+	var updatedWebhook domain.Webhook
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		originalWebhook, err := r.get(ctx, tx, id, true)
+		if err != nil {
+			return err
+		}
+
+		updatedWebhook, err = updateFn(*originalWebhook)
+		if err != nil {
+			return err
+		}
+
+		events, err := json.Marshal(updatedWebhook.Events)
+		if err != nil {
+			return fmt.Errorf("failed to encode webhook events: %w", err)
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			r.dialect.rebind("UPDATE webhooks SET updated_at = ?, url = ?, events = ?, secret = ? WHERE id = ?"),
+			updatedWebhook.UpdatedAt.UTC(),
+			updatedWebhook.URL,
+			string(events),
+			updatedWebhook.Secret,
+			id,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update webhook: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedWebhook, nil
+}
+
+// Delete removes the webhook, its deliveries are removed by the database
+func (r *SQLWebhookRepository) Delete(ctx context.Context, id uuid.UUID, deleterFn func(w domain.Webhook) error) error {
+	return inTx(ctx, r.db, func(tx *sql.Tx) error {
+		webhook, err := r.get(ctx, tx, id, true)
+		if err != nil {
+			return err
+		}
+
+		if err := deleterFn(*webhook); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, r.dialect.rebind("DELETE FROM webhooks WHERE id = ?"), id)
+		if err != nil {
+			return fmt.Errorf("failed to delete webhook: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *SQLWebhookRepository) CreateDelivery(ctx context.Context, delivery *domain.Delivery) (*domain.Delivery, error) {
+	result, err := r.db.ExecContext(
+		ctx,
+		r.dialect.rebind("INSERT INTO webhook_deliveries ("+deliveryColumns+") VALUES ("+placeholders(12)+") ON CONFLICT (webhook_id, event_id) DO NOTHING"),
+		delivery.Id,
+		delivery.WebhookId,
+		delivery.EventId,
+		delivery.EventName,
+		string(delivery.Payload),
+		delivery.CreatedAt.UTC(),
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt.UTC(),
+		nullTime(delivery.LastAttemptAt),
+		delivery.ResponseStatus,
+		delivery.LastError,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+	created, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+	if created > 0 {
+		return delivery, nil
+	}
+
+	// the event was already enqueued for the webhook
+	deliveries, err := r.queryDeliveries(
+		ctx,
+		"SELECT "+deliveryColumns+" FROM webhook_deliveries WHERE webhook_id = ? AND event_id = ?",
+		delivery.WebhookId, delivery.EventId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	if len(deliveries) == 0 {
+		return nil, fmt.Errorf("%w: delivery of event %s to webhook %s", ErrNotFound, delivery.EventId, delivery.WebhookId)
+	}
+
+	return deliveries[0], nil
+}
+
+func (r *SQLWebhookRepository) ListDueDeliveries(ctx context.Context, at time.Time, limit int) ([]*domain.Delivery, error) {
+	deliveries, err := r.queryDeliveries(
+		ctx,
+		"SELECT "+deliveryColumns+" FROM webhook_deliveries WHERE status = ? AND next_attempt_at <= ? ORDER BY next_attempt_at, id LIMIT ?",
+		domain.DeliveryStatusPending, at.UTC(), limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *SQLWebhookRepository) ListDeliveries(ctx context.Context, webhookId uuid.UUID) ([]*domain.Delivery, error) {
+	deliveries, err := r.queryDeliveries(
+		ctx,
+		"SELECT "+deliveryColumns+" FROM webhook_deliveries WHERE webhook_id = ? ORDER BY created_at DESC, id",
+		webhookId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *SQLWebhookRepository) queryDeliveries(ctx context.Context, query string, args ...any) ([]*domain.Delivery, error) {
+	rows, err := r.db.QueryContext(ctx, r.dialect.rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []*domain.Delivery{}
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func (r *SQLWebhookRepository) SaveDelivery(ctx context.Context, delivery *domain.Delivery) (*domain.Delivery, error) {
+	result, err := r.db.ExecContext(
+		ctx,
+		r.dialect.rebind("UPDATE webhook_deliveries SET status = ?, attempts = ?, next_attempt_at = ?, last_attempt_at = ?, response_status = ?, last_error = ? WHERE id = ?"),
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt.UTC(),
+		nullTime(delivery.LastAttemptAt),
+		delivery.ResponseStatus,
+		delivery.LastError,
+		delivery.Id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save webhook delivery: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to save webhook delivery: %w", err)
+	}
+	if n == 0 {
+		return nil, fmt.Errorf("%w: webhook delivery %s", ErrNotFound, delivery.Id)
+	}
+
+	return delivery, nil
+}
+
+func scanWebhook(row rowScanner) (*domain.Webhook, error) {
+	var (
+		webhook domain.Webhook
+		events  string
+	)
+	err := row.Scan(
+		&webhook.Id,
+		&webhook.CreatedAt,
+		&webhook.UpdatedAt,
+		&webhook.CreatedBy,
+		&webhook.URL,
+		&events,
+		&webhook.Secret,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal([]byte(events), &webhook.Events)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode webhook events: %w", err)
+	}
+	webhook.CreatedAt = webhook.CreatedAt.UTC()
+	webhook.UpdatedAt = webhook.UpdatedAt.UTC()
+
+	return &webhook, nil
+}
+
+func scanDelivery(row rowScanner) (*domain.Delivery, error) {
+	var (
+		delivery      domain.Delivery
+		payload       string
+		lastAttemptAt sql.NullTime
+	)
+	err := row.Scan(
+		&delivery.Id,
+		&delivery.WebhookId,
+		&delivery.EventId,
+		&delivery.EventName,
+		&payload,
+		&delivery.CreatedAt,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&lastAttemptAt,
+		&delivery.ResponseStatus,
+		&delivery.LastError,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.Payload = []byte(payload)
+	if lastAttemptAt.Valid {
+		lastAttemptAt := lastAttemptAt.Time.UTC()
+		delivery.LastAttemptAt = &lastAttemptAt
+	}
+	delivery.CreatedAt = delivery.CreatedAt.UTC()
+	delivery.NextAttemptAt = delivery.NextAttemptAt.UTC()
+
+	return &delivery, nil
+}
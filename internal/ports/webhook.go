@@ -0,0 +1,54 @@
+package ports
+
+import (
+	"sort"
+	"time"
+
+	"github.com/davidterranova/contacts/internal/domain"
+)
+
+// sortWebhooks orders webhooks from the oldest to the most recent, it is shared by the repositories listing in memory
+func sortWebhooks(webhooks []*domain.Webhook) []*domain.Webhook {
+	sort.Slice(webhooks, func(i, j int) bool {
+		if !webhooks[i].CreatedAt.Equal(webhooks[j].CreatedAt) {
+			return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt)
+		}
+		return webhooks[i].Id.String() < webhooks[j].Id.String()
+	})
+
+	return webhooks
+}
+
+// sortDeliveries orders deliveries from the most recent to the oldest
+func sortDeliveries(deliveries []*domain.Delivery) []*domain.Delivery {
+	sort.Slice(deliveries, func(i, j int) bool {
+		if !deliveries[i].CreatedAt.Equal(deliveries[j].CreatedAt) {
+			return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt)
+		}
+		return deliveries[i].Id.String() < deliveries[j].Id.String()
+	})
+
+	return deliveries
+}
+
+// dueDeliveries returns up to limit pending deliveries due at the given time, the oldest due first
+func dueDeliveries(deliveries []*domain.Delivery, at time.Time, limit int) []*domain.Delivery {
+	due := make([]*domain.Delivery, 0)
+	for _, delivery := range deliveries {
+		if delivery.Status == domain.DeliveryStatusPending && !delivery.NextAttemptAt.After(at) {
+			due = append(due, delivery)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].NextAttemptAt.Equal(due[j].NextAttemptAt) {
+			return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+		}
+		return due[i].Id.String() < due[j].Id.String()
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	return due
+}
@@ -0,0 +1,196 @@
+package ports
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type webhookRepository interface {
+	Get(ctx context.Context, id uuid.UUID) (*domain.Webhook, error)
+	ListByOwner(ctx context.Context, userId uuid.UUID) ([]*domain.Webhook, error)
+	ListByEvent(ctx context.Context, eventName string) ([]*domain.Webhook, error)
+	Create(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error)
+	Update(ctx context.Context, id uuid.UUID, updateFn func(w domain.Webhook) (domain.Webhook, error)) (*domain.Webhook, error)
+	Delete(ctx context.Context, id uuid.UUID, deleterFn func(w domain.Webhook) error) error
+	CreateDelivery(ctx context.Context, delivery *domain.Delivery) (*domain.Delivery, error)
+	ListDueDeliveries(ctx context.Context, at time.Time, limit int) ([]*domain.Delivery, error)
+	ListDeliveries(ctx context.Context, webhookId uuid.UUID) ([]*domain.Delivery, error)
+	SaveDelivery(ctx context.Context, delivery *domain.Delivery) (*domain.Delivery, error)
+}
+
+func TestWebhookRepositories(t *testing.T) {
+	t.Parallel()
+
+	repositories := map[string]func(t *testing.T) webhookRepository{
+		"in memory": func(t *testing.T) webhookRepository { return NewInMemoryWebhookRepository() },
+		"file": func(t *testing.T) webhookRepository {
+			store, _ := openFileContactRepository(t, t.TempDir())
+			return NewFileWebhookRepository(store)
+		},
+		"sql": func(t *testing.T) webhookRepository {
+			repo, err := NewSQLWebhookRepository(testSQLContactRepository(t).db, DriverSQLite)
+			require.NoError(t, err)
+			return repo
+		},
+	}
+
+	for name, newRepo := range repositories {
+		newRepo := newRepo
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			repo := newRepo(t)
+			owner := uuid.New()
+
+			webhook := domain.NewWebhook(owner, "https://crm.local/hooks", []string{domain.EventContactCreated}, "0123456789abcdef")
+			_, err := repo.Create(ctx, webhook)
+			require.NoError(t, err)
+			other := domain.NewWebhook(uuid.New(), "https://mailing.local/hooks", []string{domain.EventContactUpdated}, "0123456789abcdef")
+			_, err = repo.Create(ctx, other)
+			require.NoError(t, err)
+
+			webhooks, err := repo.ListByOwner(ctx, owner)
+			require.NoError(t, err)
+			require.Len(t, webhooks, 1)
+			assert.Equal(t, []string{domain.EventContactCreated}, webhooks[0].Events)
+
+			webhooks, err = repo.ListByEvent(ctx, domain.EventContactUpdated)
+			require.NoError(t, err)
+			require.Len(t, webhooks, 1)
+			assert.Equal(t, other.Id, webhooks[0].Id)
+
+			updated, err := repo.Update(ctx, webhook.Id, func(w domain.Webhook) (domain.Webhook, error) {
+				w.Events = []string{domain.EventContactCreated, domain.EventContactUpdated}
+				return w, nil
+			})
+			require.NoError(t, err)
+			assert.True(t, updated.Subscribes(domain.EventContactUpdated))
+			webhooks, err = repo.ListByEvent(ctx, domain.EventContactUpdated)
+			require.NoError(t, err)
+			assert.Len(t, webhooks, 2)
+
+			event := domain.NewContactDeleted(uuid.New())
+			due := domain.NewDelivery(webhook.Id, event, []byte(`{"event":"contact.deleted"}`))
+			later := domain.NewDelivery(webhook.Id, domain.NewContactDeleted(uuid.New()), []byte(`{}`))
+			later.NextAttemptAt = due.NextAttemptAt.Add(time.Hour)
+			later.CreatedAt = due.CreatedAt.Add(time.Second)
+			for _, delivery := range []*domain.Delivery{due, later, domain.NewDelivery(other.Id, event, []byte(`{}`))} {
+				_, err = repo.CreateDelivery(ctx, delivery)
+				require.NoError(t, err)
+			}
+
+			// an event enqueued again for a webhook returns its existing delivery
+			enqueued, err := repo.CreateDelivery(ctx, domain.NewDelivery(webhook.Id, event, []byte(`{}`)))
+			require.NoError(t, err)
+			assert.Equal(t, due.Id, enqueued.Id)
+
+			deliveries, err := repo.ListDueDeliveries(ctx, due.NextAttemptAt.Add(time.Minute), 1)
+			require.NoError(t, err)
+			require.Len(t, deliveries, 1)
+			assert.Equal(t, due.Id, deliveries[0].Id)
+			assert.JSONEq(t, `{"event":"contact.deleted"}`, string(deliveries[0].Payload))
+
+			delivery := deliveries[0]
+			delivery.Fail(time.Now().UTC(), http.StatusBadGateway, "bad gateway", domain.RetryPolicy{MaxAttempts: 1})
+			_, err = repo.SaveDelivery(ctx, delivery)
+			require.NoError(t, err)
+
+			deliveries, err = repo.ListDeliveries(ctx, webhook.Id)
+			require.NoError(t, err)
+			require.Len(t, deliveries, 2)
+			assert.Equal(t, later.Id, deliveries[0].Id)
+			assert.Equal(t, due.Id, deliveries[1].Id)
+			assert.Equal(t, domain.DeliveryStatusDead, deliveries[1].Status)
+			assert.Equal(t, 1, deliveries[1].Attempts)
+			assert.Equal(t, http.StatusBadGateway, deliveries[1].ResponseStatus)
+			assert.NotNil(t, deliveries[1].LastAttemptAt)
+
+			deliveries, err = repo.ListDueDeliveries(ctx, due.NextAttemptAt.Add(time.Minute), 10)
+			require.NoError(t, err)
+			assert.Len(t, deliveries, 1)
+
+			require.NoError(t, repo.Delete(ctx, webhook.Id, func(domain.Webhook) error { return nil }))
+			_, err = repo.Get(ctx, webhook.Id)
+			assert.ErrorIs(t, err, ErrNotFound)
+			deliveries, err = repo.ListDeliveries(ctx, webhook.Id)
+			require.NoError(t, err)
+			assert.Empty(t, deliveries)
+		})
+	}
+}
+
+func TestHTTPWebhookSender(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	webhook := domain.NewWebhook(uuid.New(), "", []string{domain.EventContactDeleted}, "0123456789abcdef")
+	delivery := domain.NewDelivery(webhook.Id, domain.NewContactDeleted(uuid.New()), []byte(`{"event":"contact.deleted"}`))
+
+	t.Run("signed delivery", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			headers http.Header
+			body    []byte
+		)
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			headers = r.Header.Clone()
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer receiver.Close()
+
+		webhook := *webhook
+		webhook.URL = receiver.URL
+		status, err := NewHTTPWebhookSender(time.Second).Send(ctx, webhook, *delivery)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, status)
+
+		assert.Equal(t, delivery.Payload, body)
+		assert.Equal(t, "application/json", headers.Get("Content-Type"))
+		assert.Equal(t, domain.EventContactDeleted, headers.Get(HeaderWebhookEvent))
+		assert.Equal(t, delivery.Id.String(), headers.Get(HeaderWebhookDelivery))
+		timestamp, err := strconv.ParseInt(headers.Get(HeaderWebhookTimestamp), 10, 64)
+		require.NoError(t, err)
+		assert.Equal(t, webhook.Sign(time.Unix(timestamp, 0), body), headers.Get(HeaderWebhookSignature))
+	})
+
+	t.Run("receiver error", func(t *testing.T) {
+		t.Parallel()
+
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer receiver.Close()
+
+		webhook := *webhook
+		webhook.URL = receiver.URL
+		status, err := NewHTTPWebhookSender(time.Second).Send(ctx, webhook, *delivery)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, status)
+	})
+
+	t.Run("unreachable receiver", func(t *testing.T) {
+		t.Parallel()
+
+		receiver := httptest.NewServer(http.NotFoundHandler())
+		receiver.Close()
+
+		webhook := *webhook
+		webhook.URL = receiver.URL
+		status, err := NewHTTPWebhookSender(time.Second).Send(ctx, webhook, *delivery)
+		assert.Error(t, err)
+		assert.Zero(t, status)
+	})
+}
@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/davidterranova/contacts/pkg/user"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/go-playground/validator"
+	uuid "github.com/google/uuid"
+)
+
+type CmdCreateAddressBook struct {
+	Creator user.User `validate:"required"`
+	Name    string    `validate:"required,max=255"`
+}
+
+type QueryGetAddressBook struct {
+	Requester     user.User `validate:"required"`
+	AddressBookId string    `validate:"required,uuid"`
+}
+
+type QueryListAddressBooks struct {
+	Member user.User `validate:"required"`
+}
+
+type CmdUpdateAddressBook struct {
+	Updater       user.User `validate:"required"`
+	AddressBookId string    `validate:"required,uuid"`
+	Name          string    `validate:"required,max=255"`
+}
+
+type CmdDeleteAddressBook struct {
+	Deleter       user.User `validate:"required"`
+	AddressBookId string    `validate:"required,uuid"`
+}
+
+// AddressBookHandler manages address books, members may read them and only owners may change them
+type AddressBookHandler struct {
+	books     AddressBookRepository
+	validator *validator.Validate
+}
+
+func NewAddressBookHandler(books AddressBookRepository) AddressBookHandler {
+	return AddressBookHandler{
+		books:     books,
+		validator: validator.New(),
+	}
+}
+
+func (h AddressBookHandler) Create(ctx context.Context, cmd CmdCreateAddressBook) (*domain.AddressBook, error) {
+	err := h.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	return handleRepositoryError(h.books.Create(ctx, domain.NewAddressBook(cmd.Creator.Id(), cmd.Name)))
+}
+
+func (h AddressBookHandler) Get(ctx context.Context, query QueryGetAddressBook) (*domain.AddressBook, error) {
+	err := h.validator.Struct(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	addressBookUUID, err := uuid.Parse(query.AddressBookId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	addressBook, err := handleRepositoryError(h.books.Get(ctx, addressBookUUID))
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := addressBook.Role(query.Requester.Id()); !ok {
+		return nil, fmt.Errorf("%w: %s", ErrForbidden, "address book can only be read by its members")
+	}
+
+	return addressBook, nil
+}
+
+func (h AddressBookHandler) List(ctx context.Context, query QueryListAddressBooks) ([]*domain.AddressBook, error) {
+	err := h.validator.Struct(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	return handleRepositoryError(h.books.ListByMember(ctx, query.Member.Id()))
+}
+
+func (h AddressBookHandler) Update(ctx context.Context, cmd CmdUpdateAddressBook) (*domain.AddressBook, error) {
+	err := h.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	addressBookUUID, err := uuid.Parse(cmd.AddressBookId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	addressBook, err := h.books.Update(ctx, addressBookUUID, func(b domain.AddressBook) (domain.AddressBook, error) {
+		if role, _ := b.Role(cmd.Updater.Id()); role != domain.RoleOwner {
+			return b, fmt.Errorf("%w: %s", ErrForbidden, "address book can only be updated by its owners")
+		}
+
+		b.Name = cmd.Name
+		b.UpdatedAt = time.Now().UTC()
+		return b, nil
+	})
+
+	return handleRepositoryError(addressBook, err)
+}
+
+// Delete fails with ErrInvalidCommand when the address book still owns contacts, the ones in the trash included until
+// they are purged. The repository checks it within the deletion, no contact being added meanwhile.
+func (h AddressBookHandler) Delete(ctx context.Context, cmd CmdDeleteAddressBook) error {
+	err := h.validator.Struct(cmd)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	addressBookUUID, err := uuid.Parse(cmd.AddressBookId)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	_, err = handleRepositoryError[*domain.AddressBook](nil, h.books.Delete(ctx, addressBookUUID, func(b domain.AddressBook) error {
+		if role, _ := b.Role(cmd.Deleter.Id()); role != domain.RoleOwner {
+			return fmt.Errorf("%w: %s", ErrForbidden, "address book can only be deleted by its owners")
+		}
+
+		return nil
+	}))
+	return err
+}
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/davidterranova/contacts/pkg/user"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/go-playground/validator"
+	uuid "github.com/google/uuid"
+)
+
+type CmdSetAddressBookMember struct {
+	Updater       user.User `validate:"required"`
+	AddressBookId string    `validate:"required,uuid"`
+	UserId        string    `validate:"required,uuid"`
+	Role          string    `validate:"required,oneof=owner editor viewer"`
+}
+
+type CmdRemoveAddressBookMember struct {
+	Updater       user.User `validate:"required"`
+	AddressBookId string    `validate:"required,uuid"`
+	UserId        string    `validate:"required,uuid"`
+}
+
+// AddressBookMemberHandler manages address book members, only owners may add members or change their role,
+// members may also leave an address book
+type AddressBookMemberHandler struct {
+	books     AddressBookRepository
+	validator *validator.Validate
+}
+
+func NewAddressBookMemberHandler(books AddressBookRepository) AddressBookMemberHandler {
+	return AddressBookMemberHandler{
+		books:     books,
+		validator: validator.New(),
+	}
+}
+
+func (h AddressBookMemberHandler) Set(ctx context.Context, cmd CmdSetAddressBookMember) (*domain.AddressBook, error) {
+	err := h.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	addressBookUUID, userUUID, err := parseMemberIds(cmd.AddressBookId, cmd.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	addressBook, err := h.books.Update(ctx, addressBookUUID, func(b domain.AddressBook) (domain.AddressBook, error) {
+		if role, _ := b.Role(cmd.Updater.Id()); role != domain.RoleOwner {
+			return b, fmt.Errorf("%w: %s", ErrForbidden, "address book members can only be managed by its owners")
+		}
+
+		return b, memberError(b.SetMember(userUUID, domain.Role(cmd.Role)))
+	})
+
+	return handleRepositoryError(addressBook, err)
+}
+
+func (h AddressBookMemberHandler) Remove(ctx context.Context, cmd CmdRemoveAddressBookMember) (*domain.AddressBook, error) {
+	err := h.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	addressBookUUID, userUUID, err := parseMemberIds(cmd.AddressBookId, cmd.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	addressBook, err := h.books.Update(ctx, addressBookUUID, func(b domain.AddressBook) (domain.AddressBook, error) {
+		if role, _ := b.Role(cmd.Updater.Id()); role != domain.RoleOwner && cmd.Updater.Id() != userUUID {
+			return b, fmt.Errorf("%w: %s", ErrForbidden, "address book members can only be removed by its owners")
+		}
+
+		return b, memberError(b.RemoveMember(userUUID))
+	})
+
+	return handleRepositoryError(addressBook, err)
+}
+
+func memberError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, domain.ErrMemberNotFound):
+		return fmt.Errorf("%w: %s", ErrNotFound, err)
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+}
+
+func parseMemberIds(addressBookId string, userId string) (uuid.UUID, uuid.UUID, error) {
+	addressBookUUID, err := uuid.Parse(addressBookId)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	userUUID, err := uuid.Parse(userId)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	return addressBookUUID, userUUID, nil
+}
@@ -0,0 +1,18 @@
+//go:generate mockgen -destination=mock_address_book_repository.go -package=usecase . AddressBookRepository
+package usecase
+
+import (
+	"context"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	uuid "github.com/google/uuid"
+)
+
+type AddressBookRepository interface {
+	Get(ctx context.Context, id uuid.UUID) (*domain.AddressBook, error)
+	// ListByMember returns the address books the user is a member of, whatever their role
+	ListByMember(ctx context.Context, userId uuid.UUID) ([]*domain.AddressBook, error)
+	Create(ctx context.Context, addressBook *domain.AddressBook) (*domain.AddressBook, error)
+	Update(ctx context.Context, id uuid.UUID, updateFn func(b domain.AddressBook) (domain.AddressBook, error)) (*domain.AddressBook, error)
+	Delete(ctx context.Context, id uuid.UUID, deleterFn func(b domain.AddressBook) error) error
+}
@@ -0,0 +1,303 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/internal/ports"
+	"github.com/davidterranova/contacts/pkg/user"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressBook(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	owner := user.New(uuid.New(), user.UserTypeAuthenticated)
+	viewer := user.New(uuid.New(), user.UserTypeAuthenticated)
+	stranger := user.New(uuid.New(), user.UserTypeAuthenticated)
+
+	newAddressBook := func() *domain.AddressBook {
+		addressBook := domain.NewAddressBook(owner.Id(), "family")
+		_ = addressBook.SetMember(viewer.Id(), domain.RoleViewer)
+		return addressBook
+	}
+
+	t.Run("create", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		handler := NewAddressBookHandler(container.addressBookRepo)
+
+		container.addressBookRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, b *domain.AddressBook) (*domain.AddressBook, error) {
+				return b, nil
+			})
+
+		created, err := handler.Create(ctx, CmdCreateAddressBook{Creator: owner, Name: "family"})
+		require.NoError(t, err)
+		role, ok := created.Role(owner.Id())
+		assert.True(t, ok)
+		assert.Equal(t, domain.RoleOwner, role)
+
+		_, err = handler.Create(ctx, CmdCreateAddressBook{Creator: owner})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		t.Parallel()
+
+		addressBook := newAddressBook()
+		container := testContainer(t)
+		handler := NewAddressBookHandler(container.addressBookRepo)
+
+		container.addressBookRepo.EXPECT().
+			Get(ctx, addressBook.Id).
+			Times(2).
+			Return(addressBook, nil)
+
+		got, err := handler.Get(ctx, QueryGetAddressBook{Requester: viewer, AddressBookId: addressBook.Id.String()})
+		require.NoError(t, err)
+		assert.Equal(t, addressBook, got)
+
+		_, err = handler.Get(ctx, QueryGetAddressBook{Requester: stranger, AddressBookId: addressBook.Id.String()})
+		assert.ErrorIs(t, err, ErrForbidden)
+	})
+
+	testCases := []struct {
+		name          string
+		updater       user.User
+		notEmpty      bool
+		expectedError error
+	}{
+		{
+			name:    "owner",
+			updater: owner,
+		},
+		{
+			name:          "viewer",
+			updater:       viewer,
+			expectedError: ErrForbidden,
+		},
+		{
+			name:          "stranger",
+			updater:       stranger,
+			expectedError: ErrForbidden,
+		},
+		{
+			name:          "owner of an address book with contacts",
+			updater:       owner,
+			notEmpty:      true,
+			expectedError: ErrInvalidCommand,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run("delete by "+tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			addressBook := newAddressBook()
+			container := testContainer(t)
+			handler := NewAddressBookHandler(container.addressBookRepo)
+
+			container.addressBookRepo.EXPECT().
+				Delete(ctx, addressBook.Id, gomock.Any()).
+				Times(1).
+				DoAndReturn(func(_ context.Context, _ uuid.UUID, deleteFn func(b domain.AddressBook) error) error {
+					if err := deleteFn(*addressBook); err != nil {
+						return err
+					}
+					if tc.notEmpty {
+						return ports.ErrAddressBookNotEmpty
+					}
+					return nil
+				})
+
+			err := handler.Delete(ctx, CmdDeleteAddressBook{Deleter: tc.updater, AddressBookId: addressBook.Id.String()})
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+
+	t.Run("delete unknown address book", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		handler := NewAddressBookHandler(container.addressBookRepo)
+
+		container.addressBookRepo.EXPECT().
+			Delete(ctx, gomock.Any(), gomock.Any()).
+			Times(1).
+			Return(ports.ErrNotFound)
+
+		err := handler.Delete(ctx, CmdDeleteAddressBook{Deleter: owner, AddressBookId: uuid.NewString()})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+// TestAddressBookDeleteStores deletes address books with the persistent stores, whose deletion runs while holding their
+// lock or their single connection
+func TestAddressBookDeleteStores(t *testing.T) {
+	t.Parallel()
+
+	stores := map[string]func(t *testing.T) (AddressBookRepository, ContactRepository){
+		"file": func(t *testing.T) (AddressBookRepository, ContactRepository) {
+			store, err := ports.OpenFileStore(t.TempDir())
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = store.Close() })
+			return ports.NewFileAddressBookRepository(store), ports.NewFileContactRepository(store)
+		},
+		"sql": func(t *testing.T) (AddressBookRepository, ContactRepository) {
+			ctx := context.Background()
+			db, err := ports.OpenSQL(ctx, ports.DriverSQLite, ":memory:")
+			require.NoError(t, err)
+			t.Cleanup(func() { db.Close() })
+			migrator, err := ports.NewMigrator(db, ports.DriverSQLite)
+			require.NoError(t, err)
+			_, err = migrator.Up(ctx)
+			require.NoError(t, err)
+
+			books, err := ports.NewSQLAddressBookRepository(db, ports.DriverSQLite)
+			require.NoError(t, err)
+			contacts, err := ports.NewSQLContactRepository(db, ports.DriverSQLite)
+			require.NoError(t, err)
+			return books, contacts
+		},
+	}
+
+	for name, newStore := range stores {
+		newStore := newStore
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			owner := user.New(uuid.New(), user.UserTypeAuthenticated)
+			books, contacts := newStore(t)
+			handler := NewAddressBookHandler(books)
+
+			addressBook, err := handler.Create(ctx, CmdCreateAddressBook{Creator: owner, Name: "Team"})
+			require.NoError(t, err)
+			contact := domain.New(owner.Id())
+			contact.AddressBookId = addressBook.Id
+			_, err = contacts.Create(ctx, contact)
+			require.NoError(t, err)
+
+			err = handler.Delete(ctx, CmdDeleteAddressBook{Deleter: owner, AddressBookId: addressBook.Id.String()})
+			assert.ErrorIs(t, err, ErrInvalidCommand)
+
+			// the store is still usable once the deletion failed
+			err = contacts.Delete(ctx, contact.Id, func(c domain.Contact) error { return nil })
+			require.NoError(t, err)
+			err = handler.Delete(ctx, CmdDeleteAddressBook{Deleter: owner, AddressBookId: addressBook.Id.String()})
+			require.NoError(t, err)
+			_, err = books.Get(ctx, addressBook.Id)
+			assert.ErrorIs(t, err, ports.ErrNotFound)
+		})
+	}
+}
+
+func TestAddressBookMember(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	owner := user.New(uuid.New(), user.UserTypeAuthenticated)
+	viewer := user.New(uuid.New(), user.UserTypeAuthenticated)
+	other := user.New(uuid.New(), user.UserTypeAuthenticated)
+
+	testCases := []struct {
+		name          string
+		set           *CmdSetAddressBookMember
+		remove        *CmdRemoveAddressBookMember
+		expectedError error
+	}{
+		{
+			name: "owner adds an editor",
+			set:  &CmdSetAddressBookMember{Updater: owner, UserId: other.Id().String(), Role: "editor"},
+		},
+		{
+			name:          "viewer adds a member",
+			set:           &CmdSetAddressBookMember{Updater: viewer, UserId: other.Id().String(), Role: "viewer"},
+			expectedError: ErrForbidden,
+		},
+		{
+			name:          "invalid command: unknown role",
+			set:           &CmdSetAddressBookMember{Updater: owner, UserId: other.Id().String(), Role: "admin"},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name:          "last owner demotes themselves",
+			set:           &CmdSetAddressBookMember{Updater: owner, UserId: owner.Id().String(), Role: "viewer"},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name:   "owner removes a viewer",
+			remove: &CmdRemoveAddressBookMember{Updater: owner, UserId: viewer.Id().String()},
+		},
+		{
+			name:   "viewer leaves",
+			remove: &CmdRemoveAddressBookMember{Updater: viewer, UserId: viewer.Id().String()},
+		},
+		{
+			name:          "viewer removes the owner",
+			remove:        &CmdRemoveAddressBookMember{Updater: viewer, UserId: owner.Id().String()},
+			expectedError: ErrForbidden,
+		},
+		{
+			name:          "last owner leaves",
+			remove:        &CmdRemoveAddressBookMember{Updater: owner, UserId: owner.Id().String()},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name:          "remove a user who is not a member",
+			remove:        &CmdRemoveAddressBookMember{Updater: owner, UserId: other.Id().String()},
+			expectedError: ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			addressBook := domain.NewAddressBook(owner.Id(), "family")
+			_ = addressBook.SetMember(viewer.Id(), domain.RoleViewer)
+			container := testContainer(t)
+			handler := NewAddressBookMemberHandler(container.addressBookRepo)
+
+			container.addressBookRepo.EXPECT().
+				Update(ctx, addressBook.Id, gomock.Any()).
+				MaxTimes(1).
+				DoAndReturn(func(_ context.Context, _ uuid.UUID, updateFn func(b domain.AddressBook) (domain.AddressBook, error)) (*domain.AddressBook, error) {
+					updated, err := updateFn(*addressBook)
+					if err != nil {
+						return nil, err
+					}
+					return &updated, nil
+				})
+
+			var (
+				updated *domain.AddressBook
+				err     error
+			)
+			if tc.set != nil {
+				tc.set.AddressBookId = addressBook.Id.String()
+				updated, err = handler.Set(ctx, *tc.set)
+			} else {
+				tc.remove.AddressBookId = addressBook.Id.String()
+				updated, err = handler.Remove(ctx, *tc.remove)
+			}
+			assert.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError != nil {
+				assert.Nil(t, updated)
+				return
+			}
+			assert.NotNil(t, updated)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+//go:generate mockgen -destination=mock_audit_repository.go -package=usecase . AuditRepository
+package usecase
+
+import (
+	"context"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	uuid "github.com/google/uuid"
+)
+
+// AuditRepository reads the history of the contacts, the entries recorded on a contact are saved by the
+// ContactRepository along with it and are never updated nor removed
+type AuditRepository interface {
+	// ListByContact returns the entries of a contact from the oldest to the most recent
+	ListByContact(ctx context.Context, contactId uuid.UUID) ([]*domain.AuditEntry, error)
+}
@@ -0,0 +1,358 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/davidterranova/contacts/pkg/user"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/go-playground/validator"
+	uuid "github.com/google/uuid"
+)
+
+// MaxBatchSize is the maximum number of commands of a batch
+const MaxBatchSize = 1000
+
+type CmdBatchContacts struct {
+	Requester user.User `validate:"required"`
+	// Atomic applies all the commands or none of them, every command is applied on its own otherwise
+	Atomic bool
+	// Create, Update and Delete commands are run on behalf of the requester, their own user is ignored
+	Create []CmdCreateContact
+	Update []CmdUpdateContact
+	Delete []CmdDeleteContact
+}
+
+type BatchStatus string
+
+const (
+	BatchStatusApplied BatchStatus = "applied"
+	BatchStatusFailed  BatchStatus = "failed"
+	// BatchStatusAborted commands are valid but were not applied because another command of the atomic batch failed
+	BatchStatusAborted BatchStatus = "aborted"
+)
+
+// BatchResult is the outcome of a command, Contact is set once applied and Err tells why it failed
+type BatchResult struct {
+	Status  BatchStatus
+	Contact *domain.Contact
+	Err     error
+}
+
+// BatchResults are in the order of the commands of the batch
+type BatchResults struct {
+	Create []BatchResult
+	Update []BatchResult
+	Delete []BatchResult
+}
+
+// Applied tells whether every command of the batch was applied
+func (r BatchResults) Applied() bool {
+	for _, results := range [][]BatchResult{r.Create, r.Update, r.Delete} {
+		for _, result := range results {
+			if result.Status != BatchStatusApplied {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// BatchContactsHandler applies many contact commands with the rules of their own use cases
+type BatchContactsHandler struct {
+	repo      ContactRepository
+	books     AddressBookRepository
+	create    CreateContact
+	update    UpdateContact
+	delete    DeleteContactHandler
+	validator *validator.Validate
+}
+
+func NewBatchContacts(repo ContactRepository, books AddressBookRepository, schemas ContactSchemaRepository, emails EmailVerifier) BatchContactsHandler {
+	return BatchContactsHandler{
+		repo:      repo,
+		books:     books,
+		create:    NewCreateContact(repo, books, schemas, emails),
+		update:    NewUpdateContact(repo, books, schemas, emails),
+		delete:    NewDeleteContact(repo, books),
+		validator: validator.New(),
+	}
+}
+
+// batchOperation is a command of the batch turned into a repository operation
+type batchOperation struct {
+	result *BatchResult
+	op     domain.ContactOperation
+	// related operations are applied along with the operation, their failures are the operation ones
+	related []*batchOperation
+}
+
+// all returns the operation followed by its related operations
+func (o *batchOperation) all() []*batchOperation {
+	return append([]*batchOperation{o}, o.related...)
+}
+
+// apply applies the operation, within a single repository batch along with its related operations if any
+func (o *batchOperation) apply(ctx context.Context, repo ContactRepository) error {
+	var (
+		contacts []*domain.Contact
+		contact  *domain.Contact
+		err      error
+	)
+	switch {
+	case o.op.Create != nil:
+		contact, err = handleRepositoryError(repo.Create(ctx, o.op.Create))
+		contacts = []*domain.Contact{contact}
+	case len(o.related) == 0:
+		contact, err = handleRepositoryError(repo.Update(ctx, o.op.Id, o.op.UpdateFn))
+		contacts = []*domain.Contact{contact}
+	default:
+		contacts, err = handleRepositoryError(repo.Batch(ctx, ops(o.all())))
+	}
+	if err != nil {
+		return err
+	}
+
+	for i, operation := range o.all() {
+		*operation.result = BatchResult{Status: BatchStatusApplied, Contact: contacts[i]}
+	}
+
+	return nil
+}
+
+// Apply validates every command before applying any of them. It only fails when the batch itself is invalid
+// or on internal errors, the failures of the commands are reported by their results.
+func (h BatchContactsHandler) Apply(ctx context.Context, cmd CmdBatchContacts) (BatchResults, error) {
+	err := h.validator.Struct(cmd)
+	if err != nil {
+		return BatchResults{}, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	size := len(cmd.Create) + len(cmd.Update) + len(cmd.Delete)
+	if size == 0 || size > MaxBatchSize {
+		return BatchResults{}, fmt.Errorf("%w: a batch holds between 1 and %d commands, got %d", ErrInvalidCommand, MaxBatchSize, size)
+	}
+
+	access, err := loadContactAccess(ctx, h.books, cmd.Requester)
+	if err != nil {
+		return BatchResults{}, err
+	}
+
+	results := BatchResults{
+		Create: make([]BatchResult, len(cmd.Create)),
+		Update: make([]BatchResult, len(cmd.Update)),
+		Delete: make([]BatchResult, len(cmd.Delete)),
+	}
+	operations, err := h.operations(ctx, cmd, access, results)
+	if err != nil {
+		return BatchResults{}, err
+	}
+
+	if cmd.Atomic {
+		err = h.applyAll(ctx, operations, results)
+	} else {
+		err = h.applyEach(ctx, operations)
+	}
+	if err != nil {
+		return BatchResults{}, err
+	}
+
+	return results, nil
+}
+
+// operations returns the operations of the valid commands, the invalid ones are reported as failed
+func (h BatchContactsHandler) operations(ctx context.Context, cmd CmdBatchContacts, access contactAccess, results BatchResults) ([]*batchOperation, error) {
+	operations := make([]*batchOperation, 0, len(cmd.Create)+len(cmd.Update)+len(cmd.Delete))
+
+	for i, create := range cmd.Create {
+		create.CreatedBy = cmd.Requester
+		contact, err := h.create.newContact(ctx, create)
+		if failed(&results.Create[i], err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		contact.Record(domain.NewContactCreated(*contact))
+		contact.Audit(domain.NewAuditEntry(cmd.Requester, domain.AuditActionCreate, domain.Contact{}, *contact))
+
+		operations = append(operations, &batchOperation{
+			result: &results.Create[i],
+			op:     domain.CreateOperation(contact),
+		})
+	}
+
+	for i, update := range cmd.Update {
+		update.Updater = cmd.Requester
+		id, err := h.update.contactId(update)
+		if failed(&results.Update[i], err) {
+			continue
+		}
+		schema, err := h.update.schema(ctx, id, update)
+		if failed(&results.Update[i], err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		statuses := h.update.emails.statuses(ctx, commandEmails(update.Email, update.Emails)...)
+		operations = append(operations, &batchOperation{
+			result: &results.Update[i],
+			op:     domain.UpdateOperation(id, updater(update, access, schema, statuses)),
+		})
+	}
+
+	// an atomic batch deletes all its contacts at once, the relationships between them are left alone
+	deleted := make(map[uuid.UUID]bool)
+	ids := make([]uuid.UUID, len(cmd.Delete))
+	for i, del := range cmd.Delete {
+		del.Deleter = cmd.Requester
+		id, err := h.delete.contactId(del)
+		if failed(&results.Delete[i], err) {
+			continue
+		}
+
+		ids[i] = id
+		if cmd.Atomic {
+			deleted[id] = true
+		}
+	}
+
+	for i, del := range cmd.Delete {
+		if results.Delete[i].Status == BatchStatusFailed {
+			continue
+		}
+
+		del.Deleter = cmd.Requester
+		related, err := relatedOperations(ctx, h.repo, cmd.Requester, ids[i], deleted)
+		if err != nil {
+			return nil, err
+		}
+
+		operations = append(operations, &batchOperation{
+			result:  &results.Delete[i],
+			op:      domain.UpdateOperation(ids[i], deleter(del, access)),
+			related: related,
+		})
+	}
+
+	return operations, nil
+}
+
+// applyAll checks every operation against the current contacts, then applies them within a single repository batch
+func (h BatchContactsHandler) applyAll(ctx context.Context, operations []*batchOperation, results BatchResults) error {
+	// related operations are flattened along with the operations, their failures are reported on the command
+	var all, commands []*batchOperation
+	for _, operation := range operations {
+		for _, op := range operation.all() {
+			all = append(all, op)
+			commands = append(commands, operation)
+		}
+	}
+
+	for i, operation := range all {
+		if operation.op.Create != nil {
+			continue
+		}
+
+		contact, err := handleRepositoryError(h.repo.Get(ctx, operation.op.Id))
+		if err == nil {
+			_, err = operation.op.UpdateFn(*contact)
+		}
+		if failed(commands[i].result, err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if results.abort() {
+		return nil
+	}
+
+	contacts, err := h.repo.Batch(ctx, ops(all))
+	if err != nil {
+		// the contacts changed since they were checked or break a repository constraint, the failed operation is reported
+		var operationErr *domain.OperationError
+		isOperationErr := errors.As(err, &operationErr) && operationErr.Index < len(all)
+
+		_, err = handleRepositoryError(contacts, err)
+		if isOperationErr {
+			failed(commands[operationErr.Index].result, err)
+		}
+		if results.abort() {
+			return nil
+		}
+
+		return err
+	}
+
+	for i, operation := range all {
+		*operation.result = BatchResult{Status: BatchStatusApplied, Contact: contacts[i]}
+	}
+
+	return nil
+}
+
+// applyEach applies the operations one at a time
+func (h BatchContactsHandler) applyEach(ctx context.Context, operations []*batchOperation) error {
+	for _, operation := range operations {
+		err := operation.apply(ctx, h.repo)
+		if failed(operation.result, err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// failed reports the command as failed when err is caused by the command rather than by an internal error
+func failed(result *BatchResult, err error) bool {
+	if err == nil || errors.Is(err, ErrInternal) {
+		return false
+	}
+
+	*result = BatchResult{Status: BatchStatusFailed, Err: err}
+	return true
+}
+
+// abort reports the commands as aborted when one of them failed
+func (r BatchResults) abort() bool {
+	all := [][]BatchResult{r.Create, r.Update, r.Delete}
+
+	abort := false
+	for _, results := range all {
+		for _, result := range results {
+			abort = abort || result.Status == BatchStatusFailed
+		}
+	}
+	if !abort {
+		return false
+	}
+
+	for _, results := range all {
+		for i := range results {
+			if results[i].Status != BatchStatusFailed {
+				results[i] = BatchResult{Status: BatchStatusAborted}
+			}
+		}
+	}
+
+	return true
+}
+
+func ops(operations []*batchOperation) []domain.ContactOperation {
+	ops := make([]domain.ContactOperation, 0, len(operations))
+	for _, operation := range operations {
+		ops = append(ops, operation.op)
+	}
+
+	return ops
+}
@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/internal/ports"
+	"github.com/davidterranova/contacts/pkg/user"
+	"github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchContacts(t *testing.T) {
+	t.Parallel()
+
+	requester := user.New(uuid.New(), user.UserTypeAuthenticated)
+	newContact := func() *domain.Contact {
+		contact := domain.New(requester.Id())
+		contact.FirstName = "John"
+		contact.LastName = "Doe"
+		return contact
+	}
+	validCreate := CmdCreateContact{FirstName: "John", LastName: "Doe", Email: "jdoe@contact.local", Phone: "+33612345678"}
+	invalidCreate := CmdCreateContact{FirstName: "Jack", LastName: "Doe", Email: "jack@contact.local", Phone: "0612345678"}
+
+	t.Run("rejects empty and oversized batches", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.expectSchema(nil)
+		batch := NewBatchContacts(container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails)
+
+		_, err := batch.Apply(context.Background(), CmdBatchContacts{Requester: requester})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+
+		_, err = batch.Apply(context.Background(), CmdBatchContacts{
+			Requester: requester,
+			Delete:    make([]CmdDeleteContact, MaxBatchSize+1),
+		})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+
+	t.Run("atomic batch applies every command in a single repository batch", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		container := testContainer(t)
+		container.expectSchema(nil)
+		container.expectAddressBooks()
+		container.expectNoRelatedContacts()
+		existing := newContact()
+		container.contactRepo.EXPECT().Get(ctx, existing.Id).Times(2).Return(existing, nil)
+		container.contactRepo.EXPECT().
+			Batch(ctx, gomock.Len(3)).
+			Times(1).
+			DoAndReturn(func(_ context.Context, ops []domain.ContactOperation) ([]*domain.Contact, error) {
+				require.NotNil(t, ops[0].Create)
+				contacts := []*domain.Contact{ops[0].Create}
+				for _, op := range ops[1:] {
+					updated, err := op.UpdateFn(*existing)
+					require.NoError(t, err)
+					contacts = append(contacts, &updated)
+				}
+				return contacts, nil
+			})
+
+		results, err := NewBatchContacts(container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails).
+			Apply(ctx, CmdBatchContacts{
+				Requester: requester,
+				Atomic:    true,
+				Create:    []CmdCreateContact{validCreate},
+				Update:    []CmdUpdateContact{{ContactId: existing.Id.String(), FirstName: "Jane"}},
+				Delete:    []CmdDeleteContact{{ContactId: existing.Id.String()}},
+			})
+		require.NoError(t, err)
+		assert.True(t, results.Applied())
+		assert.Equal(t, "jdoe@contact.local", results.Create[0].Contact.Email)
+		assert.Equal(t, "Jane", results.Update[0].Contact.FirstName)
+		assert.True(t, results.Delete[0].Contact.IsDeleted())
+	})
+
+	t.Run("atomic batch applies nothing when a command fails", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		container := testContainer(t)
+		container.expectSchema(nil)
+		container.expectAddressBooks()
+		container.expectNoRelatedContacts()
+		existing := newContact()
+		missing := uuid.New()
+		container.contactRepo.EXPECT().Get(ctx, existing.Id).Times(1).Return(existing, nil)
+		container.contactRepo.EXPECT().Get(ctx, missing).Times(1).Return(nil, fmt.Errorf("%w: %s", ports.ErrNotFound, missing))
+
+		results, err := NewBatchContacts(container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails).
+			Apply(ctx, CmdBatchContacts{
+				Requester: requester,
+				Atomic:    true,
+				Create:    []CmdCreateContact{validCreate, invalidCreate},
+				Update:    []CmdUpdateContact{{ContactId: existing.Id.String(), FirstName: "Jane"}},
+				Delete:    []CmdDeleteContact{{ContactId: missing.String()}},
+			})
+		require.NoError(t, err)
+		assert.False(t, results.Applied())
+		assert.Equal(t, BatchStatusAborted, results.Create[0].Status)
+		assert.Equal(t, BatchStatusFailed, results.Create[1].Status)
+		assert.ErrorIs(t, results.Create[1].Err, ErrInvalidCommand)
+		assert.Equal(t, BatchStatusAborted, results.Update[0].Status)
+		assert.Equal(t, BatchStatusFailed, results.Delete[0].Status)
+		assert.ErrorIs(t, results.Delete[0].Err, ErrNotFound)
+	})
+
+	t.Run("atomic batch reports the command breaking a repository constraint", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		container := testContainer(t)
+		container.expectSchema(nil)
+		container.expectAddressBooks()
+		container.expectNoRelatedContacts()
+		container.contactRepo.EXPECT().
+			Batch(ctx, gomock.Len(2)).
+			Times(1).
+			Return(nil, &domain.OperationError{Index: 1, Err: fmt.Errorf("%w: email jdoe@contact.local", ports.ErrAlreadyExists)})
+
+		results, err := NewBatchContacts(container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails).
+			Apply(ctx, CmdBatchContacts{
+				Requester: requester,
+				Atomic:    true,
+				Create:    []CmdCreateContact{validCreate, validCreate},
+			})
+		require.NoError(t, err)
+		assert.Equal(t, BatchStatusAborted, results.Create[0].Status)
+		assert.Equal(t, BatchStatusFailed, results.Create[1].Status)
+		assert.ErrorIs(t, results.Create[1].Err, ErrAlreadyExists)
+	})
+
+	t.Run("best effort batch applies the valid commands", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		container := testContainer(t)
+		container.expectSchema(nil)
+		container.expectAddressBooks()
+		container.expectNoRelatedContacts()
+		existing := newContact()
+		other := newContact()
+		other.CreatedBy = uuid.New()
+		container.contactRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, contact *domain.Contact) (*domain.Contact, error) {
+				return contact, nil
+			})
+		for _, contact := range []*domain.Contact{existing, other} {
+			contact := contact
+			container.contactRepo.EXPECT().
+				Update(ctx, contact.Id, gomock.Any()).
+				Times(1).
+				DoAndReturn(func(_ context.Context, _ uuid.UUID, updateFn func(c domain.Contact) (domain.Contact, error)) (*domain.Contact, error) {
+					updated, err := updateFn(*contact)
+					if err != nil {
+						return nil, err
+					}
+					return &updated, nil
+				})
+		}
+
+		results, err := NewBatchContacts(container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails).
+			Apply(ctx, CmdBatchContacts{
+				Requester: requester,
+				Create:    []CmdCreateContact{validCreate, invalidCreate},
+				Delete: []CmdDeleteContact{
+					{ContactId: existing.Id.String()},
+					{ContactId: other.Id.String()},
+				},
+			})
+		require.NoError(t, err)
+		assert.False(t, results.Applied())
+		assert.Equal(t, BatchStatusApplied, results.Create[0].Status)
+		assert.Equal(t, BatchStatusFailed, results.Create[1].Status)
+		assert.Equal(t, BatchStatusApplied, results.Delete[0].Status)
+		assert.True(t, results.Delete[0].Contact.IsDeleted())
+		assert.Equal(t, BatchStatusFailed, results.Delete[1].Status)
+		assert.ErrorIs(t, results.Delete[1].Err, ErrForbidden)
+	})
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/pkg/user"
+	uuid "github.com/google/uuid"
+)
+
+// contactAccess tells what a user may do with contacts: contacts they created or that are shared with them
+// are handled by the contact grants, address book contacts by the user role in the address book
+type contactAccess struct {
+	userId uuid.UUID
+	roles  map[uuid.UUID]domain.Role
+}
+
+// loadContactAccess reads the user address book roles once, so that access can be checked
+// within repository closures without querying another repository
+func loadContactAccess(ctx context.Context, books AddressBookRepository, u user.User) (contactAccess, error) {
+	addressBooks, err := handleRepositoryError(books.ListByMember(ctx, u.Id()))
+	if err != nil {
+		return contactAccess{}, err
+	}
+
+	access := contactAccess{
+		userId: u.Id(),
+		roles:  make(map[uuid.UUID]domain.Role, len(addressBooks)),
+	}
+	for _, addressBook := range addressBooks {
+		access.roles[addressBook.Id], _ = addressBook.Role(u.Id())
+	}
+
+	return access, nil
+}
+
+func (a contactAccess) canRead(c domain.Contact) bool {
+	if c.CanRead(a.userId) {
+		return true
+	}
+
+	_, ok := a.roles[c.AddressBookId]
+	return c.AddressBookId != uuid.Nil && ok
+}
+
+func (a contactAccess) canWrite(c domain.Contact) bool {
+	if c.CanWrite(a.userId) {
+		return true
+	}
+
+	role, ok := a.roles[c.AddressBookId]
+	return c.AddressBookId != uuid.Nil && ok && role.CanWrite()
+}
+
+func (a contactAccess) role(addressBookId uuid.UUID) (domain.Role, bool) {
+	role, ok := a.roles[addressBookId]
+	return role, ok
+}
+
+func (a contactAccess) visibility() domain.Visibility {
+	visibility := domain.Visibility{UserId: a.userId}
+	for id := range a.roles {
+		visibility.AddressBookIds = append(visibility.AddressBookIds, id)
+	}
+
+	return visibility
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/google/uuid"
+)
+
+var errCursorSortMismatch = errors.New("cursor was issued for another sort order")
+
+// cursor is the opaque pagination token handed to clients, it records the sort it was issued for
+// so that it cannot be replayed with another order
+type cursor struct {
+	Field domain.SortField `json:"f"`
+	Desc  bool             `json:"d,omitempty"`
+	Text  string           `json:"t,omitempty"`
+	Time  *time.Time       `json:"ts,omitempty"`
+	Id    uuid.UUID        `json:"id"`
+}
+
+func encodeCursor(sort domain.Sort, contact *domain.Contact) string {
+	key := sort.Key(contact)
+	c := cursor{
+		Field: sort.Field,
+		Desc:  sort.Desc,
+		Text:  key.Text,
+		Id:    key.Id,
+	}
+	if !key.Time.IsZero() {
+		c.Time = &key.Time
+	}
+
+	raw, _ := json.Marshal(c)
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(sort domain.Sort, token string) (domain.SortKey, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return domain.SortKey{}, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	var c cursor
+	err = json.Unmarshal(raw, &c)
+	if err != nil {
+		return domain.SortKey{}, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	if c.Field != sort.Field || c.Desc != sort.Desc {
+		return domain.SortKey{}, errCursorSortMismatch
+	}
+
+	key := domain.SortKey{Text: c.Text, Id: c.Id}
+	if c.Time != nil {
+		key.Time = *c.Time
+	}
+
+	return key, nil
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/pkg/phone"
+)
+
+// ContactEmail is an email of a contact, its label defaults to other. At most one email is primary, the first one
+// is when none is.
+type ContactEmail struct {
+	Label   string `validate:"omitempty,oneof=work home mobile other"`
+	Address string `validate:"required,email"`
+	Primary bool
+}
+
+// ContactPhone is a phone of a contact, labeled like ContactEmail. Its number is read in the region of the command,
+// see phone.Parse, and stored in its E.164 form.
+type ContactPhone struct {
+	Label   string `validate:"omitempty,oneof=work home mobile other"`
+	Number  string `validate:"required,max=32"`
+	Primary bool
+}
+
+// ContactAddress is a postal address of a contact, labeled like ContactEmail
+type ContactAddress struct {
+	Label      string `validate:"omitempty,oneof=work home mobile other"`
+	Street     string `validate:"required,max=255"`
+	Locality   string `validate:"required,max=255"`
+	Region     string `validate:"max=255"`
+	PostalCode string `validate:"max=32"`
+	// Country is an ISO 3166-1 alpha-2 code
+	Country string `validate:"omitempty,len=2,alpha"`
+	Primary bool
+}
+
+func toEmailAddresses(emails []ContactEmail) ([]domain.EmailAddress, error) {
+	result := make([]domain.EmailAddress, 0, len(emails))
+	for _, email := range emails {
+		result = append(result, domain.EmailAddress{Label: toLabel(email.Label), Address: normalizeEmail(email.Address), Primary: email.Primary})
+	}
+
+	return result, checkPrimary("emails", len(emails), func(i int) bool { return emails[i].Primary })
+}
+
+func toPhoneNumbers(phones []ContactPhone, region string) ([]domain.PhoneNumber, error) {
+	result := make([]domain.PhoneNumber, 0, len(phones))
+	for _, p := range phones {
+		number, err := normalizePhone(p.Number, region)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, domain.PhoneNumber{Label: toLabel(p.Label), Number: number, Primary: p.Primary})
+	}
+
+	return result, checkPrimary("phones", len(phones), func(i int) bool { return phones[i].Primary })
+}
+
+func toPostalAddresses(addresses []ContactAddress) ([]domain.PostalAddress, error) {
+	result := make([]domain.PostalAddress, 0, len(addresses))
+	for _, address := range addresses {
+		result = append(result, domain.PostalAddress{
+			Label:      toLabel(address.Label),
+			Street:     address.Street,
+			Locality:   address.Locality,
+			Region:     address.Region,
+			PostalCode: address.PostalCode,
+			Country:    address.Country,
+			Primary:    address.Primary,
+		})
+	}
+
+	return result, checkPrimary("addresses", len(addresses), func(i int) bool { return addresses[i].Primary })
+}
+
+func toLabel(label string) domain.Label {
+	if label == "" {
+		return domain.LabelOther
+	}
+
+	return domain.Label(label)
+}
+
+func checkPrimary(field string, n int, primary func(i int) bool) error {
+	count := 0
+	for i := 0; i < n; i++ {
+		if primary(i) {
+			count++
+		}
+	}
+	if count > 1 {
+		return fmt.Errorf("%w: only one of the %s can be primary", ErrInvalidCommand, field)
+	}
+
+	return nil
+}
+
+// normalizePhone returns the E.164 form of the number, numbers without international prefix are read in the region
+func normalizePhone(number string, region string) (string, error) {
+	n, err := phone.Parse(number, region)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	return n.E164(), nil
+}
+
+// hasNationalPhone tells whether one of the phones has no international prefix and so needs a region to be read
+func hasNationalPhone(number string, phones []ContactPhone) bool {
+	if number != "" && !phone.IsInternational(number) {
+		return true
+	}
+	for _, p := range phones {
+		if !phone.IsInternational(p.Number) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// phoneRegion returns the region of the command when set, the default phone region of the schema otherwise
+func phoneRegion(region string, schema *domain.ContactSchema) string {
+	if region != "" || schema == nil {
+		return region
+	}
+
+	return schema.DefaultPhoneRegion
+}
+
+// setContactDetails replaces the lists of the contact which are not empty, then makes the single email and phone
+// primary. Emails are normalized and phones are read in the region.
+func setContactDetails(c *domain.Contact, email string, number string, emails []ContactEmail, phones []ContactPhone, addresses []ContactAddress, region string) error {
+	emailAddresses, err := toEmailAddresses(emails)
+	if err != nil {
+		return err
+	}
+	phoneNumbers, err := toPhoneNumbers(phones, region)
+	if err != nil {
+		return err
+	}
+	postalAddresses, err := toPostalAddresses(addresses)
+	if err != nil {
+		return err
+	}
+
+	if len(emailAddresses) > 0 {
+		c.SetEmails(emailAddresses)
+	}
+	if email != "" {
+		c.SetEmail(normalizeEmail(email))
+	}
+	if len(phoneNumbers) > 0 {
+		c.SetPhones(phoneNumbers)
+	}
+	if number != "" {
+		number, err = normalizePhone(number, region)
+		if err != nil {
+			return err
+		}
+		c.SetPhone(number)
+	}
+	if len(postalAddresses) > 0 {
+		c.SetAddresses(postalAddresses)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/davidterranova/contacts/pkg/user"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/go-playground/validator"
+	uuid "github.com/google/uuid"
+)
+
+type QueryContactHistory struct {
+	Requester user.User `validate:"required"`
+	ContactId string    `validate:"required,uuid"`
+}
+
+// ContactHistoryHandler lists the audit entries of a contact, including the ones of a contact in the trash
+type ContactHistoryHandler struct {
+	repo      ContactRepository
+	books     AddressBookRepository
+	audit     AuditRepository
+	validator *validator.Validate
+}
+
+func NewContactHistory(repo ContactRepository, books AddressBookRepository, audit AuditRepository) ContactHistoryHandler {
+	return ContactHistoryHandler{
+		repo:      repo,
+		books:     books,
+		audit:     audit,
+		validator: validator.New(),
+	}
+}
+
+func (h ContactHistoryHandler) History(ctx context.Context, query QueryContactHistory) ([]*domain.AuditEntry, error) {
+	err := h.validator.Struct(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	contactUUID, err := uuid.Parse(query.ContactId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	access, err := loadContactAccess(ctx, h.books, query.Requester)
+	if err != nil {
+		return nil, err
+	}
+
+	contact, err := handleRepositoryError(h.repo.Get(ctx, contactUUID))
+	if err != nil {
+		return nil, err
+	}
+
+	if !access.canRead(*contact) {
+		return nil, fmt.Errorf("%w: %s", ErrForbidden, "contact history can only be read by its creator, users it is shared with and its address book members")
+	}
+
+	return handleRepositoryError(h.audit.ListByContact(ctx, contactUUID))
+}
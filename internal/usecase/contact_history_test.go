@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/pkg/user"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactHistory(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	owner := user.New(uuid.New(), user.UserTypeAuthenticated)
+
+	t.Run("update records the changed fields", func(t *testing.T) {
+		t.Parallel()
+
+		contact := domain.New(owner.Id())
+		contact.FirstName = "John"
+		contact.LastName = "Doe"
+
+		container := testContainer(t)
+		container.expectSchema(nil)
+		container.expectAddressBooks()
+		var entries []*domain.AuditEntry
+		container.contactRepo.EXPECT().
+			Update(ctx, contact.Id, gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, _ uuid.UUID, updateFn func(c domain.Contact) (domain.Contact, error)) (*domain.Contact, error) {
+				updated, err := updateFn(*contact)
+				entries = updated.PullAuditEntries()
+				return &updated, err
+			})
+
+		_, err := NewUpdateContact(container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails).
+			Update(ctx, CmdUpdateContact{Updater: owner, ContactId: contact.Id.String(), FirstName: "Jane", LastName: "Doe"})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, contact.Id, entries[0].ContactId)
+		assert.Equal(t, owner, entries[0].Actor)
+		assert.Equal(t, domain.AuditActionUpdate, entries[0].Action)
+		assert.Equal(t, []domain.FieldChange{{Field: "first_name", Before: "John", After: "Jane"}}, entries[0].Changes)
+	})
+
+	t.Run("share records the grants", func(t *testing.T) {
+		t.Parallel()
+
+		contact := domain.New(owner.Id())
+		reader := uuid.New()
+
+		container := testContainer(t)
+		var entries []*domain.AuditEntry
+		container.contactRepo.EXPECT().
+			Update(ctx, contact.Id, gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, _ uuid.UUID, updateFn func(c domain.Contact) (domain.Contact, error)) (*domain.Contact, error) {
+				updated, err := updateFn(*contact)
+				entries = updated.PullAuditEntries()
+				return &updated, err
+			})
+
+		_, err := NewShareContact(container.contactRepo).
+			Share(ctx, CmdShareContact{Sharer: owner, ContactId: contact.Id.String(), UserId: reader.String(), Permission: "read"})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, domain.AuditActionShare, entries[0].Action)
+		assert.Equal(t, []domain.FieldChange{{Field: "grants", After: reader.String() + ":read"}}, entries[0].Changes)
+	})
+
+	testCases := []struct {
+		name          string
+		requester     user.User
+		expectedError error
+	}{
+		{
+			name:      "list the history of a contact",
+			requester: owner,
+		},
+		{
+			name:          "list the history of another user contact",
+			requester:     user.New(uuid.New(), user.UserTypeAuthenticated),
+			expectedError: ErrForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			contact := domain.New(owner.Id())
+			entries := []*domain.AuditEntry{domain.NewAuditEntry(owner, domain.AuditActionCreate, domain.Contact{}, *contact)}
+
+			container := testContainer(t)
+			container.expectSchema(nil)
+			container.expectAddressBooks()
+			container.contactRepo.EXPECT().
+				Get(ctx, contact.Id).
+				Times(1).
+				Return(contact, nil)
+			if tc.expectedError == nil {
+				container.auditRepo.EXPECT().
+					ListByContact(ctx, contact.Id).
+					Times(1).
+					Return(entries, nil)
+			}
+
+			history, err := NewContactHistory(container.contactRepo, container.addressBookRepo, container.auditRepo).
+				History(ctx, QueryContactHistory{Requester: tc.requester, ContactId: contact.Id.String()})
+			assert.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError == nil {
+				assert.Equal(t, entries, history)
+			}
+		})
+	}
+}
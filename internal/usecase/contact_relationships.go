@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/davidterranova/contacts/pkg/user"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/internal/ports"
+	"github.com/go-playground/validator"
+	uuid "github.com/google/uuid"
+)
+
+type CmdRelateContacts struct {
+	Requester user.User               `validate:"required"`
+	ContactId string                  `validate:"required,uuid"`
+	RelatedId string                  `validate:"required,uuid,nefield=ContactId"`
+	Type      domain.RelationshipType `validate:"required"`
+	// OnDelete tells what happens to the contact when the related contact is deleted, domain.OnDeleteNullify when empty
+	OnDelete domain.OnDelete
+}
+
+type CmdUnrelateContacts struct {
+	Requester user.User               `validate:"required"`
+	ContactId string                  `validate:"required,uuid"`
+	RelatedId string                  `validate:"required,uuid"`
+	Type      domain.RelationshipType `validate:"required"`
+}
+
+type QueryContactRelationships struct {
+	Requester user.User `validate:"required"`
+	ContactId string    `validate:"required,uuid"`
+}
+
+// RelatedContact is the contact at the other end of a relationship
+type RelatedContact struct {
+	Relationship domain.Relationship
+	Contact      *domain.Contact
+}
+
+// ContactRelationships are the relationships of a contact to other contacts and the ones of other contacts to it,
+// the related contacts the requester cannot read are left out
+type ContactRelationships struct {
+	Outgoing []RelatedContact
+	Incoming []RelatedContact
+}
+
+// ContactRelationshipsHandler relates a contact the requester can update to contacts they can read
+type ContactRelationshipsHandler struct {
+	repo      ContactRepository
+	books     AddressBookRepository
+	validator *validator.Validate
+}
+
+func NewContactRelationships(repo ContactRepository, books AddressBookRepository) ContactRelationshipsHandler {
+	return ContactRelationshipsHandler{
+		repo:      repo,
+		books:     books,
+		validator: validator.New(),
+	}
+}
+
+// Relate adds the relationship to the contact, it replaces the relationship of the same type to the same contact
+func (h ContactRelationshipsHandler) Relate(ctx context.Context, cmd CmdRelateContacts) (*domain.Contact, error) {
+	err := h.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+	if cmd.OnDelete == "" {
+		cmd.OnDelete = domain.OnDeleteNullify
+	}
+	if !cmd.Type.IsValid() {
+		return nil, fmt.Errorf("%w: unknown relationship type %q", ErrInvalidCommand, cmd.Type)
+	}
+	if !cmd.OnDelete.IsValid() {
+		return nil, fmt.Errorf("%w: unknown on delete rule %q", ErrInvalidCommand, cmd.OnDelete)
+	}
+
+	relatedUUID, err := uuid.Parse(cmd.RelatedId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	access, err := loadContactAccess(ctx, h.books, cmd.Requester)
+	if err != nil {
+		return nil, err
+	}
+
+	related, err := handleRepositoryError(h.repo.Get(ctx, relatedUUID))
+	if err != nil {
+		return nil, err
+	}
+	if related.IsDeleted() {
+		return nil, errTrashed(*related)
+	}
+	if !access.canRead(*related) {
+		return nil, fmt.Errorf("%w: %s", ErrForbidden, "contacts can only be related to the contacts the requester can read")
+	}
+	// restrict and cascade relationships change how the related contact is deleted
+	if cmd.OnDelete != domain.OnDeleteNullify && !access.canWrite(*related) {
+		return nil, fmt.Errorf("%w: %s", ErrForbidden, "restrict and cascade relationships can only be made to the contacts the requester can update")
+	}
+
+	return h.update(ctx, cmd.Requester, access, domain.AuditActionRelate, cmd.ContactId, func(c *domain.Contact) error {
+		// the ids were compared as strings by the validation, whatever their case
+		if c.Id == relatedUUID {
+			return fmt.Errorf("%w: contact %s cannot be related to itself", ErrInvalidCommand, c.Id)
+		}
+
+		relationship := domain.Relationship{
+			Type:      cmd.Type,
+			ContactId: relatedUUID,
+			OnDelete:  cmd.OnDelete,
+			CreatedAt: time.Now().UTC(),
+		}
+		for _, existing := range c.RelationshipsTo(relatedUUID) {
+			if existing.Type == cmd.Type {
+				relationship.CreatedAt = existing.CreatedAt
+			}
+		}
+
+		c.Relate(relationship)
+		return nil
+	})
+}
+
+func (h ContactRelationshipsHandler) Unrelate(ctx context.Context, cmd CmdUnrelateContacts) (*domain.Contact, error) {
+	err := h.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	relatedUUID, err := uuid.Parse(cmd.RelatedId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	access, err := loadContactAccess(ctx, h.books, cmd.Requester)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.update(ctx, cmd.Requester, access, domain.AuditActionUnrelate, cmd.ContactId, func(c *domain.Contact) error {
+		if !c.Unrelate(cmd.Type, relatedUUID) {
+			return fmt.Errorf("%w: contact %s has no %s relationship to contact %s", ErrNotFound, c.Id, cmd.Type, relatedUUID)
+		}
+
+		return nil
+	})
+}
+
+// update changes the relationships of a contact the requester can update, the change is audited as action and
+// recorded as a domain.ContactUpdated event
+func (h ContactRelationshipsHandler) update(ctx context.Context, requester user.User, access contactAccess, action domain.AuditAction, contactId string, fn func(c *domain.Contact) error) (*domain.Contact, error) {
+	contactUUID, err := uuid.Parse(contactId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	contact, err := h.repo.Update(ctx, contactUUID, func(c domain.Contact) (domain.Contact, error) {
+		if c.IsDeleted() {
+			return c, errTrashed(c)
+		}
+		if !access.canWrite(c) {
+			return c, fmt.Errorf("%w: %s", ErrForbidden, "relationships can only be changed by the users who can update the contact")
+		}
+
+		updated := c
+		if err := fn(&updated); err != nil {
+			return c, err
+		}
+
+		if changes := domain.RelationshipChanges(c, updated); len(changes) > 0 {
+			updated.Record(domain.NewContactUpdated(c.Id, changes))
+		}
+		updated.Audit(domain.NewAuditEntry(requester, action, c, updated))
+		return updated, nil
+	})
+
+	return handleRepositoryError(contact, err)
+}
+
+// Relationships returns the relationships of a contact the requester can read, sorted by type and related contact
+// for the outgoing ones and by related contact creation date for the incoming ones
+func (h ContactRelationshipsHandler) Relationships(ctx context.Context, query QueryContactRelationships) (*ContactRelationships, error) {
+	err := h.validator.Struct(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	contactUUID, err := uuid.Parse(query.ContactId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	access, err := loadContactAccess(ctx, h.books, query.Requester)
+	if err != nil {
+		return nil, err
+	}
+
+	contact, err := handleRepositoryError(h.repo.Get(ctx, contactUUID))
+	if err != nil {
+		return nil, err
+	}
+	if contact.IsDeleted() {
+		return nil, errTrashed(*contact)
+	}
+	if !access.canRead(*contact) {
+		return nil, fmt.Errorf("%w: %s", ErrForbidden, "contact can only be read by its creator, users it is shared with and its address book members")
+	}
+
+	relationships := &ContactRelationships{Outgoing: []RelatedContact{}, Incoming: []RelatedContact{}}
+	for _, relationship := range contact.Relationships {
+		related, err := handleRepositoryError(h.repo.Get(ctx, relationship.ContactId))
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if !related.IsDeleted() && access.canRead(*related) {
+			relationships.Outgoing = append(relationships.Outgoing, RelatedContact{Relationship: relationship, Contact: related})
+		}
+	}
+
+	relating, err := handleRepositoryError(h.repo.List(ctx, ports.NewFilter(
+		ports.WithRelatedTo(contact.Id),
+		ports.WithVisibleTo(access.visibility()),
+		ports.WithSort(domain.Sort{Field: domain.SortByCreatedAt}),
+	)))
+	if err != nil {
+		return nil, err
+	}
+	for _, related := range relating {
+		for _, relationship := range related.RelationshipsTo(contact.Id) {
+			relationships.Incoming = append(relationships.Incoming, RelatedContact{Relationship: relationship, Contact: related})
+		}
+	}
+
+	return relationships, nil
+}
@@ -0,0 +1,344 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/pkg/user"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// expectStoredContacts serves the given contacts to Get, List, Update and Batch, changes are saved to the contacts
+// only once applied so that a failing batch leaves them untouched
+func expectStoredContacts(container *container, contacts ...*domain.Contact) {
+	byId := make(map[uuid.UUID]*domain.Contact, len(contacts))
+	for _, contact := range contacts {
+		byId[contact.Id] = contact
+	}
+
+	container.contactRepo.EXPECT().
+		Get(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(_ context.Context, id uuid.UUID) (*domain.Contact, error) {
+			contact := *byId[id]
+			return &contact, nil
+		})
+	container.contactRepo.EXPECT().
+		List(gomock.Any(), relatedToFilter{}).
+		AnyTimes().
+		DoAndReturn(func(_ context.Context, filter domain.Filter) ([]*domain.Contact, error) {
+			var listed []*domain.Contact
+			for _, contact := range contacts {
+				if !contact.IsDeleted() && contact.RelatesTo(*filter.RelatedTo()) {
+					if filter.VisibleTo() == nil || filter.VisibleTo().Contains(contact) {
+						listed = append(listed, contact)
+					}
+				}
+			}
+			return listed, nil
+		})
+	container.contactRepo.EXPECT().
+		Update(gomock.Any(), gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(_ context.Context, id uuid.UUID, updateFn func(c domain.Contact) (domain.Contact, error)) (*domain.Contact, error) {
+			updated, err := updateFn(*byId[id])
+			if err != nil {
+				return nil, err
+			}
+			*byId[id] = updated
+			return byId[id], nil
+		})
+	container.contactRepo.EXPECT().
+		Batch(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(_ context.Context, ops []domain.ContactOperation) ([]*domain.Contact, error) {
+			staged := make(map[uuid.UUID]domain.Contact)
+			saved := make([]*domain.Contact, 0, len(ops))
+			for i, op := range ops {
+				contact, ok := staged[op.Id]
+				if !ok {
+					contact = *byId[op.Id]
+				}
+				updated, err := op.UpdateFn(contact)
+				if err != nil {
+					return nil, &domain.OperationError{Index: i, Err: err}
+				}
+				staged[op.Id] = updated
+				saved = append(saved, &updated)
+			}
+			for id, contact := range staged {
+				*byId[id] = contact
+			}
+			return saved, nil
+		})
+}
+
+func relate(contact *domain.Contact, relationshipType domain.RelationshipType, related *domain.Contact, onDelete domain.OnDelete) {
+	contact.Relate(domain.Relationship{Type: relationshipType, ContactId: related.Id, OnDelete: onDelete})
+}
+
+func TestContactRelationships(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	owner := user.New(uuid.New(), user.UserTypeAuthenticated)
+	stranger := user.New(uuid.New(), user.UserTypeAuthenticated)
+
+	t.Run("relate and unrelate", func(t *testing.T) {
+		t.Parallel()
+
+		employee := domain.New(owner.Id())
+		company := domain.New(owner.Id())
+		private := domain.New(stranger.Id())
+		readOnly := domain.New(stranger.Id())
+		readOnly.Share(owner.Id(), domain.PermissionRead)
+		container := testContainer(t)
+		container.expectAddressBooks()
+		expectStoredContacts(container, employee, company, private, readOnly)
+		handler := NewContactRelationships(container.contactRepo, container.addressBookRepo)
+
+		related, err := handler.Relate(ctx, CmdRelateContacts{
+			Requester: owner,
+			ContactId: employee.Id.String(),
+			RelatedId: company.Id.String(),
+			Type:      domain.RelationshipOrganization,
+		})
+		require.NoError(t, err)
+		require.Len(t, related.Relationships, 1)
+		assert.Equal(t, company.Id, related.Relationships[0].ContactId)
+		assert.Equal(t, domain.OnDeleteNullify, related.Relationships[0].OnDelete)
+		events := related.PullEvents()
+		require.Len(t, events, 1)
+		assert.Equal(t, domain.EventContactUpdated, events[0].EventName())
+
+		related, err = handler.Relate(ctx, CmdRelateContacts{
+			Requester: owner,
+			ContactId: employee.Id.String(),
+			RelatedId: company.Id.String(),
+			Type:      domain.RelationshipOrganization,
+			OnDelete:  domain.OnDeleteCascade,
+		})
+		require.NoError(t, err)
+		require.Len(t, related.Relationships, 1)
+		assert.Equal(t, domain.OnDeleteCascade, related.Relationships[0].OnDelete)
+
+		for _, cmd := range []CmdRelateContacts{
+			{Requester: owner, ContactId: employee.Id.String(), RelatedId: employee.Id.String(), Type: domain.RelationshipManager},
+			{Requester: owner, ContactId: employee.Id.String(), RelatedId: strings.ToUpper(employee.Id.String()), Type: domain.RelationshipManager},
+			{Requester: owner, ContactId: employee.Id.String(), RelatedId: company.Id.String(), Type: "cousin"},
+			{Requester: owner, ContactId: employee.Id.String(), RelatedId: company.Id.String(), Type: domain.RelationshipManager, OnDelete: "ignore"},
+		} {
+			_, err = handler.Relate(ctx, cmd)
+			assert.ErrorIs(t, err, ErrInvalidCommand)
+		}
+		assert.Len(t, employee.Relationships, 1, "a contact is not related to itself")
+
+		_, err = handler.Relate(ctx, CmdRelateContacts{Requester: owner, ContactId: employee.Id.String(), RelatedId: private.Id.String(), Type: domain.RelationshipSpouse})
+		assert.ErrorIs(t, err, ErrForbidden)
+		_, err = handler.Relate(ctx, CmdRelateContacts{Requester: stranger, ContactId: private.Id.String(), RelatedId: employee.Id.String(), Type: domain.RelationshipSpouse})
+		assert.ErrorIs(t, err, ErrForbidden)
+
+		// restrict and cascade relationships change the deletion of the related contact
+		for _, onDelete := range []domain.OnDelete{domain.OnDeleteRestrict, domain.OnDeleteCascade} {
+			_, err = handler.Relate(ctx, CmdRelateContacts{Requester: owner, ContactId: employee.Id.String(), RelatedId: readOnly.Id.String(), Type: domain.RelationshipManager, OnDelete: onDelete})
+			assert.ErrorIs(t, err, ErrForbidden)
+		}
+		_, err = handler.Relate(ctx, CmdRelateContacts{Requester: owner, ContactId: employee.Id.String(), RelatedId: readOnly.Id.String(), Type: domain.RelationshipManager})
+		require.NoError(t, err)
+		_, err = handler.Unrelate(ctx, CmdUnrelateContacts{Requester: owner, ContactId: employee.Id.String(), RelatedId: readOnly.Id.String(), Type: domain.RelationshipManager})
+		require.NoError(t, err)
+
+		unrelated, err := handler.Unrelate(ctx, CmdUnrelateContacts{Requester: owner, ContactId: employee.Id.String(), RelatedId: company.Id.String(), Type: domain.RelationshipOrganization})
+		require.NoError(t, err)
+		assert.Empty(t, unrelated.Relationships)
+		entries := unrelated.PullAuditEntries()
+		require.NotEmpty(t, entries)
+		assert.Equal(t, domain.AuditActionUnrelate, entries[len(entries)-1].Action)
+
+		_, err = handler.Unrelate(ctx, CmdUnrelateContacts{Requester: owner, ContactId: employee.Id.String(), RelatedId: company.Id.String(), Type: domain.RelationshipOrganization})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("relationships leave out the contacts the requester cannot read", func(t *testing.T) {
+		t.Parallel()
+
+		employee := domain.New(owner.Id())
+		company := domain.New(owner.Id())
+		assistant := domain.New(owner.Id())
+		spouse := domain.New(stranger.Id())
+		trashed := domain.New(owner.Id())
+		relate(employee, domain.RelationshipOrganization, company, domain.OnDeleteCascade)
+		relate(employee, domain.RelationshipSpouse, spouse, domain.OnDeleteNullify)
+		relate(employee, domain.RelationshipManager, trashed, domain.OnDeleteNullify)
+		relate(assistant, domain.RelationshipAssistant, employee, domain.OnDeleteNullify)
+		relate(spouse, domain.RelationshipSpouse, employee, domain.OnDeleteNullify)
+		trashed.Trash()
+
+		container := testContainer(t)
+		container.expectAddressBooks()
+		expectStoredContacts(container, employee, company, assistant, spouse, trashed)
+
+		relationships, err := NewContactRelationships(container.contactRepo, container.addressBookRepo).
+			Relationships(ctx, QueryContactRelationships{Requester: owner, ContactId: employee.Id.String()})
+		require.NoError(t, err)
+		require.Len(t, relationships.Outgoing, 1)
+		assert.Equal(t, domain.RelationshipOrganization, relationships.Outgoing[0].Relationship.Type)
+		assert.Equal(t, company.Id, relationships.Outgoing[0].Contact.Id)
+		require.Len(t, relationships.Incoming, 1)
+		assert.Equal(t, domain.RelationshipAssistant, relationships.Incoming[0].Relationship.Type)
+		assert.Equal(t, assistant.Id, relationships.Incoming[0].Contact.Id)
+	})
+}
+
+func TestDeleteRelatedContacts(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	owner := user.New(uuid.New(), user.UserTypeAuthenticated)
+	stranger := user.New(uuid.New(), user.UserTypeAuthenticated)
+	deleteContact := func(container *container, contact *domain.Contact) error {
+		return NewDeleteContact(container.contactRepo, container.addressBookRepo).
+			Delete(ctx, CmdDeleteContact{Deleter: owner, ContactId: contact.Id.String()})
+	}
+
+	t.Run("cascade and nullify", func(t *testing.T) {
+		t.Parallel()
+
+		company := domain.New(owner.Id())
+		department := domain.New(owner.Id())
+		employee := domain.New(owner.Id())
+		supplier := domain.New(owner.Id())
+		relate(department, domain.RelationshipOrganization, company, domain.OnDeleteCascade)
+		relate(employee, domain.RelationshipOrganization, department, domain.OnDeleteCascade)
+		relate(supplier, domain.RelationshipOrganization, company, domain.OnDeleteNullify)
+		relate(supplier, domain.RelationshipManager, employee, domain.OnDeleteNullify)
+
+		container := testContainer(t)
+		container.expectAddressBooks()
+		expectStoredContacts(container, company, department, employee, supplier)
+
+		require.NoError(t, deleteContact(container, company))
+		assert.True(t, company.IsDeleted())
+		assert.True(t, department.IsDeleted())
+		assert.True(t, employee.IsDeleted())
+		assert.False(t, supplier.IsDeleted())
+		assert.Empty(t, supplier.Relationships)
+		for _, contact := range []*domain.Contact{company, department, employee} {
+			entries := contact.PullAuditEntries()
+			require.Len(t, entries, 1)
+			assert.Equal(t, domain.AuditActionDelete, entries[0].Action)
+		}
+		entries := supplier.PullAuditEntries()
+		require.Len(t, entries, 1)
+		assert.Equal(t, domain.AuditActionUnrelate, entries[0].Action)
+		assert.Equal(t, "relationships", entries[0].Changes[0].Field)
+		assert.Empty(t, entries[0].Changes[0].After)
+	})
+
+	t.Run("restrict", func(t *testing.T) {
+		t.Parallel()
+
+		manager := domain.New(owner.Id())
+		assistant := domain.New(owner.Id())
+		relate(assistant, domain.RelationshipManager, manager, domain.OnDeleteRestrict)
+
+		container := testContainer(t)
+		container.expectAddressBooks()
+		expectStoredContacts(container, manager, assistant)
+
+		err := deleteContact(container, manager)
+		assert.ErrorIs(t, err, ErrRestricted)
+		assert.NotContains(t, err.Error(), assistant.Id.String(), "the restricting contact may belong to another user")
+		assert.False(t, manager.IsDeleted())
+
+		// an atomic batch deleting both contacts is not restricted
+		results, err := NewBatchContacts(container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails).
+			Apply(ctx, CmdBatchContacts{
+				Requester: owner,
+				Atomic:    true,
+				Delete:    []CmdDeleteContact{{ContactId: manager.Id.String()}, {ContactId: assistant.Id.String()}},
+			})
+		require.NoError(t, err)
+		assert.True(t, results.Applied())
+		assert.True(t, manager.IsDeleted())
+		assert.True(t, assistant.IsDeleted())
+	})
+
+	t.Run("restrict fails the command of a batch", func(t *testing.T) {
+		t.Parallel()
+
+		manager := domain.New(owner.Id())
+		assistant := domain.New(owner.Id())
+		other := domain.New(owner.Id())
+		relate(assistant, domain.RelationshipManager, manager, domain.OnDeleteRestrict)
+
+		container := testContainer(t)
+		container.expectAddressBooks()
+		expectStoredContacts(container, manager, assistant, other)
+
+		results, err := NewBatchContacts(container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails).
+			Apply(ctx, CmdBatchContacts{
+				Requester: owner,
+				Delete:    []CmdDeleteContact{{ContactId: manager.Id.String()}, {ContactId: other.Id.String()}},
+			})
+		require.NoError(t, err)
+		assert.Equal(t, BatchStatusFailed, results.Delete[0].Status)
+		assert.ErrorIs(t, results.Delete[0].Err, ErrRestricted)
+		assert.Equal(t, BatchStatusApplied, results.Delete[1].Status)
+		assert.False(t, manager.IsDeleted())
+	})
+
+	t.Run("merge relates the contacts relating to the duplicate to the survivor", func(t *testing.T) {
+		t.Parallel()
+
+		survivor := domain.New(owner.Id())
+		duplicate := domain.New(owner.Id())
+		company := domain.New(owner.Id())
+		employee := domain.New(owner.Id())
+		relate(duplicate, domain.RelationshipOrganization, company, domain.OnDeleteNullify)
+		relate(employee, domain.RelationshipManager, duplicate, domain.OnDeleteRestrict)
+
+		container := testContainer(t)
+		container.expectAddressBooks()
+		expectStoredContacts(container, survivor, duplicate, company, employee)
+
+		merged, err := NewMergeContacts(container.contactRepo, container.addressBookRepo).
+			Merge(ctx, CmdMergeContacts{Merger: owner, SurvivorId: survivor.Id.String(), DuplicateId: duplicate.Id.String()})
+		require.NoError(t, err)
+		assert.True(t, merged.RelatesTo(company.Id))
+		assert.True(t, duplicate.IsDeleted())
+		require.Len(t, employee.Relationships, 1)
+		assert.Equal(t, survivor.Id, employee.Relationships[0].ContactId)
+		assert.Equal(t, domain.OnDeleteRestrict, employee.Relationships[0].OnDelete)
+		events := employee.PullEvents()
+		require.Len(t, events, 1)
+		assert.Equal(t, domain.EventContactUpdated, events[0].EventName())
+		entries := employee.PullAuditEntries()
+		require.Len(t, entries, 1)
+		assert.Equal(t, domain.AuditActionRelate, entries[0].Action)
+	})
+
+	t.Run("merge fails when a contact relating to the duplicate cannot be updated", func(t *testing.T) {
+		t.Parallel()
+
+		survivor := domain.New(owner.Id())
+		duplicate := domain.New(owner.Id())
+		assistant := domain.New(stranger.Id())
+		relate(assistant, domain.RelationshipManager, duplicate, domain.OnDeleteNullify)
+
+		container := testContainer(t)
+		container.expectAddressBooks()
+		expectStoredContacts(container, survivor, duplicate, assistant)
+
+		_, err := NewMergeContacts(container.contactRepo, container.addressBookRepo).
+			Merge(ctx, CmdMergeContacts{Merger: owner, SurvivorId: survivor.Id.String(), DuplicateId: duplicate.Id.String()})
+		assert.ErrorIs(t, err, ErrForbidden)
+		assert.False(t, duplicate.IsDeleted())
+		assert.Equal(t, duplicate.Id, assistant.Relationships[0].ContactId)
+	})
+}
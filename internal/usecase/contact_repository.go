@@ -3,6 +3,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/davidterranova/contacts/internal/domain"
 	_ "github.com/golang/mock/mockgen/model"
@@ -10,6 +11,7 @@ import (
 )
 
 type ContactRepository interface {
+	Get(ctx context.Context, id uuid.UUID) (*domain.Contact, error)
 	List(ctx context.Context, filter domain.Filter) ([]*domain.Contact, error)
 	Create(ctx context.Context, contact *domain.Contact) (*domain.Contact, error)
 
@@ -18,4 +20,9 @@ type ContactRepository interface {
 	// while pushing atomicity to the persistence layer
 	Update(ctx context.Context, id uuid.UUID, updateFn func(c domain.Contact) (domain.Contact, error)) (*domain.Contact, error)
 	Delete(ctx context.Context, id uuid.UUID, deleterFn func(c domain.Contact) error) error
+	// Batch applies the operations in order within a single transaction and returns the saved contacts in the same order,
+	// nothing is saved when one of them fails
+	Batch(ctx context.Context, ops []domain.ContactOperation) ([]*domain.Contact, error)
+	// Purge permanently removes the contacts in the trash since before the given time and returns how many were removed
+	Purge(ctx context.Context, deletedBefore time.Time) (int, error)
 }
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/davidterranova/contacts/pkg/phone"
+	"github.com/davidterranova/contacts/pkg/user"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/internal/ports"
+	"github.com/go-playground/validator"
+	uuid "github.com/google/uuid"
+)
+
+type QueryGetContactSchema struct {
+	Owner user.User `validate:"required"`
+}
+
+// CmdSetContactSchema replaces the custom fields of the owner contacts, the values of removed fields are kept on the
+// contacts until they are updated
+type CmdSetContactSchema struct {
+	Owner  user.User     `validate:"required"`
+	Fields []CustomField `validate:"max=50,dive"`
+	// DefaultPhoneRegion is the region the phones of the owner contacts are read in when they have no international
+	// prefix, one of phone.Regions, none when empty
+	DefaultPhoneRegion string `validate:"omitempty,len=2,alpha"`
+}
+
+// CustomField defines a custom field, see domain.FieldDefinition for the meaning of the validation rules
+type CustomField struct {
+	Name     string `validate:"required,max=63"`
+	Type     string `validate:"required,oneof=string number date enum url"`
+	Required bool
+	Min      *float64
+	Max      *float64
+	Pattern  string   `validate:"max=255"`
+	Options  []string `validate:"max=100,dive,max=255"`
+}
+
+// ContactSchemaHandler manages the contact schema of a user, it defines the custom fields of the contacts they create
+type ContactSchemaHandler struct {
+	schemas   ContactSchemaRepository
+	validator *validator.Validate
+}
+
+func NewContactSchemaHandler(schemas ContactSchemaRepository) ContactSchemaHandler {
+	return ContactSchemaHandler{
+		schemas:   schemas,
+		validator: validator.New(),
+	}
+}
+
+// Get returns the schema of the owner, an empty one when they did not define any custom field
+func (h ContactSchemaHandler) Get(ctx context.Context, query QueryGetContactSchema) (*domain.ContactSchema, error) {
+	err := h.validator.Struct(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	return loadSchema(ctx, h.schemas, query.Owner.Id())
+}
+
+func (h ContactSchemaHandler) Set(ctx context.Context, cmd CmdSetContactSchema) (*domain.ContactSchema, error) {
+	err := h.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+	if cmd.DefaultPhoneRegion != "" && !phone.IsRegion(cmd.DefaultPhoneRegion) {
+		return nil, fmt.Errorf("%w: %s: %s", ErrInvalidCommand, phone.ErrUnknownRegion, cmd.DefaultPhoneRegion)
+	}
+
+	fields := make([]domain.FieldDefinition, 0, len(cmd.Fields))
+	for _, field := range cmd.Fields {
+		fields = append(fields, domain.FieldDefinition{
+			Name:     field.Name,
+			Type:     domain.FieldType(field.Type),
+			Required: field.Required,
+			Min:      field.Min,
+			Max:      field.Max,
+			Pattern:  field.Pattern,
+			Options:  field.Options,
+		})
+	}
+
+	schema, err := domain.NewContactSchema(cmd.Owner.Id(), fields)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+	schema.DefaultPhoneRegion = strings.ToUpper(cmd.DefaultPhoneRegion)
+
+	return handleRepositoryError(h.schemas.Save(ctx, schema))
+}
+
+// loadSchema returns the contact schema of the owner, an empty one when they did not define any
+func loadSchema(ctx context.Context, schemas ContactSchemaRepository, ownerId uuid.UUID) (*domain.ContactSchema, error) {
+	schema, err := schemas.Get(ctx, ownerId)
+	if errors.Is(err, ports.ErrNotFound) {
+		return &domain.ContactSchema{OwnerId: ownerId}, nil
+	}
+
+	return handleRepositoryError(schema, err)
+}
+
+// setCustomFields checks the values against the schema before setting them on the contact
+func setCustomFields(c *domain.Contact, schema *domain.ContactSchema, values map[string]any) error {
+	err := c.SetCustomFields(*schema, values)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	return nil
+}
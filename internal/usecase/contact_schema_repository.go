@@ -0,0 +1,16 @@
+//go:generate mockgen -destination=mock_contact_schema_repository.go -package=usecase . ContactSchemaRepository
+package usecase
+
+import (
+	"context"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	uuid "github.com/google/uuid"
+)
+
+// ContactSchemaRepository stores the contact schema of every owner, Get fails with ports.ErrNotFound when the owner
+// has not defined one
+type ContactSchemaRepository interface {
+	Get(ctx context.Context, ownerId uuid.UUID) (*domain.ContactSchema, error)
+	Save(ctx context.Context, schema *domain.ContactSchema) (*domain.ContactSchema, error)
+}
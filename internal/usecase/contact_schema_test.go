@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/internal/ports"
+	"github.com/davidterranova/contacts/pkg/user"
+	"github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactSchema(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	owner := user.New(uuid.New(), user.UserTypeAuthenticated)
+	one, five := 1.0, 5.0
+
+	setCases := []struct {
+		name               string
+		fields             []CustomField
+		defaultPhoneRegion string
+		expectedError      error
+	}{
+		{
+			name: "valid schema",
+			fields: []CustomField{
+				{Name: "tier", Type: "enum", Options: []string{"gold", "silver"}, Required: true},
+				{Name: "rating", Type: "number", Min: &one, Max: &five},
+				{Name: "birthday", Type: "date"},
+				{Name: "website", Type: "url"},
+				{Name: "code", Type: "string", Pattern: "^[A-Z]{3}$"},
+			},
+		},
+		{
+			name:               "valid schema: default phone region",
+			defaultPhoneRegion: "fr",
+		},
+		{
+			name:               "invalid schema: unknown default phone region",
+			defaultPhoneRegion: "ZZ",
+			expectedError:      ErrInvalidCommand,
+		},
+		{
+			name:          "invalid schema: unknown type",
+			fields:        []CustomField{{Name: "tier", Type: "color"}},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name:          "invalid schema: invalid name",
+			fields:        []CustomField{{Name: "Tier", Type: "string"}},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name:          "invalid schema: field defined twice",
+			fields:        []CustomField{{Name: "tier", Type: "string"}, {Name: "tier", Type: "number"}},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name:          "invalid schema: enum without options",
+			fields:        []CustomField{{Name: "tier", Type: "enum"}},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name:          "invalid schema: invalid pattern",
+			fields:        []CustomField{{Name: "code", Type: "string", Pattern: "[A-Z"}},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name:          "invalid schema: min greater than max",
+			fields:        []CustomField{{Name: "rating", Type: "number", Min: &five, Max: &one}},
+			expectedError: ErrInvalidCommand,
+		},
+	}
+
+	for _, tc := range setCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := NewMockContactSchemaRepository(gomock.NewController(t))
+			if tc.expectedError == nil {
+				repo.EXPECT().
+					Save(ctx, gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, schema *domain.ContactSchema) (*domain.ContactSchema, error) {
+						return schema, nil
+					})
+			}
+
+			schema, err := NewContactSchemaHandler(repo).Set(ctx, CmdSetContactSchema{Owner: owner, Fields: tc.fields, DefaultPhoneRegion: tc.defaultPhoneRegion})
+			assert.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError == nil {
+				assert.Equal(t, owner.Id(), schema.OwnerId)
+				assert.Len(t, schema.Fields, len(tc.fields))
+				assert.Equal(t, strings.ToUpper(tc.defaultPhoneRegion), schema.DefaultPhoneRegion)
+			}
+		})
+	}
+
+	t.Run("owner without schema", func(t *testing.T) {
+		t.Parallel()
+
+		repo := NewMockContactSchemaRepository(gomock.NewController(t))
+		repo.EXPECT().
+			Get(ctx, owner.Id()).
+			Times(1).
+			Return(nil, ports.ErrNotFound)
+
+		schema, err := NewContactSchemaHandler(repo).Get(ctx, QueryGetContactSchema{Owner: owner})
+		require.NoError(t, err)
+		assert.Equal(t, owner.Id(), schema.OwnerId)
+		assert.Empty(t, schema.Fields)
+	})
+
+	schema := &domain.ContactSchema{
+		OwnerId: owner.Id(),
+		Fields: []domain.FieldDefinition{
+			{Name: "tier", Type: domain.FieldTypeEnum, Options: []string{"gold", "silver"}, Required: true},
+			{Name: "rating", Type: domain.FieldTypeNumber, Min: &one, Max: &five},
+			{Name: "birthday", Type: domain.FieldTypeDate},
+			{Name: "website", Type: domain.FieldTypeURL},
+		},
+	}
+
+	valueCases := []struct {
+		name          string
+		values        map[string]any
+		expectedError error
+	}{
+		{
+			name:   "valid values",
+			values: map[string]any{"tier": "gold", "rating": 4.5, "birthday": "1990-04-01", "website": "https://jdoe.local"},
+		},
+		{
+			name:          "missing required field",
+			values:        map[string]any{"rating": 4.5},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name:          "unknown field",
+			values:        map[string]any{"tier": "gold", "color": "blue"},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name:          "option out of the enum",
+			values:        map[string]any{"tier": "bronze"},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name:          "number out of bounds",
+			values:        map[string]any{"tier": "gold", "rating": 6.0},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name:          "number given as a string",
+			values:        map[string]any{"tier": "gold", "rating": "4"},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name:          "invalid date",
+			values:        map[string]any{"tier": "gold", "birthday": "01/04/1990"},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name:          "invalid url",
+			values:        map[string]any{"tier": "gold", "website": "ftp://jdoe.local"},
+			expectedError: ErrInvalidCommand,
+		},
+	}
+
+	for _, tc := range valueCases {
+		tc := tc
+		t.Run("create contact: "+tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			container := testContainer(t)
+			container.expectSchema(schema)
+			if tc.expectedError == nil {
+				container.contactRepo.EXPECT().
+					Create(ctx, gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, c *domain.Contact) (*domain.Contact, error) {
+						return c, nil
+					})
+			}
+
+			contact, err := NewCreateContact(container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails).
+				Create(ctx, CmdCreateContact{
+					CreatedBy:    owner,
+					FirstName:    "John",
+					LastName:     "Doe",
+					Email:        "jdoe@contact.local",
+					Phone:        "+33612345678",
+					CustomFields: tc.values,
+				})
+			assert.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError == nil {
+				assert.Equal(t, map[string]domain.CustomValue{
+					"tier":     {Type: domain.FieldTypeEnum, Text: "gold"},
+					"rating":   {Type: domain.FieldTypeNumber, Number: 4.5},
+					"birthday": {Type: domain.FieldTypeDate, Text: "1990-04-01"},
+					"website":  {Type: domain.FieldTypeURL, Text: "https://jdoe.local"},
+				}, contact.CustomFields)
+			}
+		})
+	}
+
+	t.Run("update contact custom fields", func(t *testing.T) {
+		t.Parallel()
+
+		contact := domain.New(owner.Id())
+		contact.FirstName = "John"
+		contact.CustomFields = map[string]domain.CustomValue{
+			"tier":   {Type: domain.FieldTypeEnum, Text: "gold"},
+			"rating": {Type: domain.FieldTypeNumber, Number: 4.5},
+		}
+
+		container := testContainer(t)
+		container.expectAddressBooks()
+		container.expectSchema(schema)
+		container.contactRepo.EXPECT().Get(ctx, contact.Id).AnyTimes().Return(contact, nil)
+		container.contactRepo.EXPECT().
+			Update(ctx, contact.Id, gomock.Any()).
+			AnyTimes().
+			DoAndReturn(func(_ context.Context, _ uuid.UUID, fn func(domain.Contact) (domain.Contact, error)) (*domain.Contact, error) {
+				updated, err := fn(*contact)
+				return &updated, err
+			})
+		updater := NewUpdateContact(container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails)
+
+		updated, err := updater.Update(ctx, CmdUpdateContact{
+			Updater:      owner,
+			ContactId:    contact.Id.String(),
+			CustomFields: map[string]any{"rating": nil, "website": "https://jdoe.local"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]domain.CustomValue{
+			"tier":    {Type: domain.FieldTypeEnum, Text: "gold"},
+			"website": {Type: domain.FieldTypeURL, Text: "https://jdoe.local"},
+		}, updated.CustomFields)
+		// the stored contact is left untouched
+		assert.Len(t, contact.CustomFields, 2)
+
+		_, err = updater.Update(ctx, CmdUpdateContact{
+			Updater:      owner,
+			ContactId:    contact.Id.String(),
+			CustomFields: map[string]any{"tier": nil},
+		})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
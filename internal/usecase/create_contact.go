@@ -8,40 +8,110 @@ import (
 
 	"github.com/davidterranova/contacts/internal/domain"
 	"github.com/go-playground/validator"
+	uuid "github.com/google/uuid"
 )
 
 type CmdCreateContact struct {
 	CreatedBy user.User `validate:"required"`
+	// AddressBookId creates the contact in an address book the creator can edit, personal contact when empty
+	AddressBookId string `validate:"omitempty,uuid"`
 
 	FirstName string `validate:"min=2,max=255"`
 	LastName  string `validate:"min=2,max=255"`
-	Email     string `validate:"required,email"`
-	Phone     string `validate:"e164"` // https://en.wikipedia.org/wiki/E.164
+	// Email and Phone are the primary email and phone, they are required unless Emails and Phones are set
+	Email string `validate:"required_without=Emails,omitempty,email"`
+	Phone string `validate:"required_without=Phones,omitempty,max=32"`
+	// PhoneRegion is the ISO 3166-1 alpha-2 code of the region phones without international prefix are read in,
+	// the default phone region of the creator when empty
+	PhoneRegion string `validate:"omitempty,len=2,alpha"`
+
+	Emails    []ContactEmail   `validate:"max=20,dive"`
+	Phones    []ContactPhone   `validate:"max=20,dive"`
+	Addresses []ContactAddress `validate:"max=20,dive"`
+
+	// CustomFields are checked against the schema of the creator, numbers are float64 and the other values strings
+	CustomFields map[string]any `validate:"max=50"`
 }
 
 type CreateContact struct {
 	repo      ContactRepository
+	books     AddressBookRepository
+	schemas   ContactSchemaRepository
+	emails    EmailVerifier
 	validator *validator.Validate
 }
 
-func NewCreateContact(repo ContactRepository) CreateContact {
+func NewCreateContact(repo ContactRepository, books AddressBookRepository, schemas ContactSchemaRepository, emails EmailVerifier) CreateContact {
 	return CreateContact{
 		repo:      repo,
+		books:     books,
+		schemas:   schemas,
+		emails:    emails,
 		validator: validator.New(),
 	}
 }
 
 func (h CreateContact) Create(ctx context.Context, cmd CmdCreateContact) (*domain.Contact, error) {
+	contact, err := h.newContact(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	contact.Record(domain.NewContactCreated(*contact))
+	contact.Audit(domain.NewAuditEntry(cmd.CreatedBy, domain.AuditActionCreate, domain.Contact{}, *contact))
+
+	return handleRepositoryError(h.repo.Create(ctx, contact))
+}
+
+// Check fails like Create would, without creating the contact
+func (h CreateContact) Check(ctx context.Context, cmd CmdCreateContact) error {
+	_, err := h.newContact(ctx, cmd)
+	return err
+}
+
+func (h CreateContact) newContact(ctx context.Context, cmd CmdCreateContact) (*domain.Contact, error) {
 	err := h.validator.Struct(cmd)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
 	}
+	err = h.emails.check(commandEmails(cmd.Email, cmd.Emails)...)
+	if err != nil {
+		return nil, err
+	}
 
 	contact := domain.New(cmd.CreatedBy.Id())
+	if cmd.AddressBookId != "" {
+		addressBookId, err := uuid.Parse(cmd.AddressBookId)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+		}
+
+		addressBook, err := handleRepositoryError(h.books.Get(ctx, addressBookId))
+		if err != nil {
+			return nil, err
+		}
+
+		role, _ := addressBook.Role(cmd.CreatedBy.Id())
+		if !role.CanWrite() {
+			return nil, fmt.Errorf("%w: %s", ErrForbidden, "contacts can only be added to an address book by its owners and editors")
+		}
+		contact.AddressBookId = addressBook.Id
+	}
 	contact.FirstName = cmd.FirstName
 	contact.LastName = cmd.LastName
-	contact.Email = cmd.Email
-	contact.Phone = cmd.Phone
 
-	return handleRepositoryError(h.repo.Create(ctx, contact))
+	schema, err := loadSchema(ctx, h.schemas, contact.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+	err = setContactDetails(contact, cmd.Email, cmd.Phone, cmd.Emails, cmd.Phones, cmd.Addresses, phoneRegion(cmd.PhoneRegion, schema))
+	if err != nil {
+		return nil, err
+	}
+	setEmailStatus(contact, "", h.emails.statuses(ctx, contact.Email))
+	err = setCustomFields(contact, schema, cmd.CustomFields)
+	if err != nil {
+		return nil, err
+	}
+
+	return contact, nil
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/internal/ports"
 	"github.com/davidterranova/contacts/pkg/user"
 	"github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -13,7 +14,12 @@ import (
 )
 
 type container struct {
-	contactRepo *MockContactRepository
+	contactRepo     *MockContactRepository
+	addressBookRepo *MockAddressBookRepository
+	auditRepo       *MockAuditRepository
+	schemaRepo      *MockContactSchemaRepository
+	resolver        *MockDomainResolver
+	emails          EmailVerifier
 }
 
 func testContainer(t *testing.T) *container {
@@ -21,10 +27,52 @@ func testContainer(t *testing.T) *container {
 
 	controller := gomock.NewController(t)
 	return &container{
-		contactRepo: NewMockContactRepository(controller),
+		contactRepo:     NewMockContactRepository(controller),
+		addressBookRepo: NewMockAddressBookRepository(controller),
+		auditRepo:       NewMockAuditRepository(controller),
+		schemaRepo:      NewMockContactSchemaRepository(controller),
+		resolver:        NewMockDomainResolver(controller),
 	}
 }
 
+// expectAddressBooks makes the given address books the ones the requester is a member of
+func (c *container) expectAddressBooks(addressBooks ...*domain.AddressBook) {
+	c.addressBookRepo.EXPECT().
+		ListByMember(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(addressBooks, nil)
+}
+
+// expectSchema makes the given schema the one of every owner, nil meaning owners have no schema
+func (c *container) expectSchema(schema *domain.ContactSchema) {
+	if schema == nil {
+		c.schemaRepo.EXPECT().Get(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, ports.ErrNotFound)
+		return
+	}
+
+	c.schemaRepo.EXPECT().Get(gomock.Any(), gomock.Any()).AnyTimes().Return(schema, nil)
+}
+
+// expectNoRelatedContacts makes the contacts related to by no other contact, as looked up by the deletions
+func (c *container) expectNoRelatedContacts() {
+	c.contactRepo.EXPECT().
+		List(gomock.Any(), relatedToFilter{}).
+		AnyTimes().
+		Return([]*domain.Contact{}, nil)
+}
+
+// relatedToFilter matches the filters looking up the contacts related to another one
+type relatedToFilter struct{}
+
+func (relatedToFilter) Matches(x any) bool {
+	filter, ok := x.(domain.Filter)
+	return ok && filter.RelatedTo() != nil
+}
+
+func (relatedToFilter) String() string {
+	return "is a related to filter"
+}
+
 func TestCreateContac(t *testing.T) {
 	t.Parallel()
 
@@ -35,7 +83,8 @@ func TestCreateContac(t *testing.T) {
 func testCreateContactValidation(t *testing.T) {
 	ctx := context.Background()
 	container := testContainer(t)
-	contactCreator := NewCreateContact(container.contactRepo)
+	container.expectSchema(nil)
+	contactCreator := NewCreateContact(container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails)
 
 	testCases := []struct {
 		name          string
@@ -74,6 +123,102 @@ func testCreateContactValidation(t *testing.T) {
 			},
 			expectedError: ErrInvalidCommand,
 		},
+		{
+			name: "valid command: national phone read in the region",
+			command: CmdCreateContact{
+				CreatedBy:   user.New(uuid.New(), user.UserTypeAuthenticated),
+				FirstName:   "John",
+				LastName:    "Doe",
+				Email:       "test@contact.local",
+				Phone:       "06 12 34 56 78",
+				PhoneRegion: "FR",
+			},
+			expectedError: nil,
+		},
+		{
+			name: "invalid command: phone not valid in the region",
+			command: CmdCreateContact{
+				CreatedBy:   user.New(uuid.New(), user.UserTypeAuthenticated),
+				FirstName:   "John",
+				LastName:    "Doe",
+				Email:       "test@contact.local",
+				Phone:       "06 12 34",
+				PhoneRegion: "FR",
+			},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name: "invalid command: unknown region",
+			command: CmdCreateContact{
+				CreatedBy:   user.New(uuid.New(), user.UserTypeAuthenticated),
+				FirstName:   "John",
+				LastName:    "Doe",
+				Email:       "test@contact.local",
+				Phone:       "06 12 34 56 78",
+				PhoneRegion: "ZZ",
+			},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name: "valid command: emails and phones lists",
+			command: CmdCreateContact{
+				CreatedBy: user.New(uuid.New(), user.UserTypeAuthenticated),
+				FirstName: "John",
+				LastName:  "Doe",
+				Emails:    []ContactEmail{{Label: "work", Address: "test@contact.local"}},
+				Phones:    []ContactPhone{{Number: "+33612345678"}},
+				Addresses: []ContactAddress{{Street: "1 rue de la Paix", Locality: "Paris", Country: "FR"}},
+			},
+			expectedError: nil,
+		},
+		{
+			name: "invalid command: invalid email in list",
+			command: CmdCreateContact{
+				CreatedBy: user.New(uuid.New(), user.UserTypeAuthenticated),
+				FirstName: "John",
+				LastName:  "Doe",
+				Emails:    []ContactEmail{{Address: "invalid email"}},
+				Phone:     "+33612345678",
+			},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name: "invalid command: unknown label",
+			command: CmdCreateContact{
+				CreatedBy: user.New(uuid.New(), user.UserTypeAuthenticated),
+				FirstName: "John",
+				LastName:  "Doe",
+				Email:     "test@contact.local",
+				Phones:    []ContactPhone{{Label: "fax", Number: "+33612345678"}},
+			},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name: "invalid command: several primary emails",
+			command: CmdCreateContact{
+				CreatedBy: user.New(uuid.New(), user.UserTypeAuthenticated),
+				FirstName: "John",
+				LastName:  "Doe",
+				Emails: []ContactEmail{
+					{Address: "test@contact.local", Primary: true},
+					{Address: "other@contact.local", Primary: true},
+				},
+				Phone: "+33612345678",
+			},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name: "invalid command: invalid country",
+			command: CmdCreateContact{
+				CreatedBy: user.New(uuid.New(), user.UserTypeAuthenticated),
+				FirstName: "John",
+				LastName:  "Doe",
+				Email:     "test@contact.local",
+				Phone:     "+33612345678",
+				Addresses: []ContactAddress{{Street: "1 rue de la Paix", Locality: "Paris", Country: "France"}},
+			},
+			expectedError: ErrInvalidCommand,
+		},
 		{
 			name: "invalid command: invalid uuid",
 			command: CmdCreateContact{
@@ -96,7 +241,7 @@ func testCreateContactValidation(t *testing.T) {
 				container.contactRepo.EXPECT().
 					Create(ctx, gomock.Any()).
 					Times(1).
-					Return(nil, nil)
+					Return(&domain.Contact{}, nil)
 			}
 
 			_, err := contactCreator.Create(ctx, tc.command)
@@ -108,7 +253,8 @@ func testCreateContactValidation(t *testing.T) {
 func testCreateContact(t *testing.T) {
 	ctx := context.Background()
 	container := testContainer(t)
-	contactCreator := NewCreateContact(container.contactRepo)
+	container.expectSchema(nil)
+	contactCreator := NewCreateContact(container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails)
 
 	t.Run("successful contact creation", func(t *testing.T) {
 		cmd := CmdCreateContact{
@@ -161,4 +307,162 @@ func testCreateContact(t *testing.T) {
 		assert.ErrorIs(t, err, ErrInternal)
 		assert.Nil(t, contact)
 	})
+	t.Run("contact creation in an address book", func(t *testing.T) {
+		editor := user.New(uuid.New(), user.UserTypeAuthenticated)
+		viewer := user.New(uuid.New(), user.UserTypeAuthenticated)
+		addressBook := domain.NewAddressBook(uuid.New(), "family")
+		_ = addressBook.SetMember(editor.Id(), domain.RoleEditor)
+		_ = addressBook.SetMember(viewer.Id(), domain.RoleViewer)
+		cmd := CmdCreateContact{
+			CreatedBy:     editor,
+			AddressBookId: addressBook.Id.String(),
+			FirstName:     "John",
+			LastName:      "Doe",
+			Email:         "jdoe@contact.local",
+			Phone:         "+33612345678",
+		}
+
+		container.addressBookRepo.EXPECT().
+			Get(ctx, addressBook.Id).
+			Times(2).
+			Return(addressBook, nil)
+		container.contactRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, c *domain.Contact) (*domain.Contact, error) {
+				return c, nil
+			})
+
+		createdContact, err := contactCreator.Create(ctx, cmd)
+		assert.NoError(t, err)
+		assert.Equal(t, addressBook.Id, createdContact.AddressBookId)
+
+		cmd.CreatedBy = viewer
+		_, err = contactCreator.Create(ctx, cmd)
+		assert.ErrorIs(t, err, ErrForbidden)
+	})
+
+	t.Run("contact creation with emails and phones lists", func(t *testing.T) {
+		cmd := CmdCreateContact{
+			CreatedBy: user.New(uuid.New(), user.UserTypeAuthenticated),
+			FirstName: "John",
+			LastName:  "Doe",
+			Email:     "jdoe@home.local",
+			Emails: []ContactEmail{
+				{Label: "work", Address: "jdoe@work.local", Primary: true},
+				{Label: "home", Address: "jdoe@home.local"},
+			},
+			Phones: []ContactPhone{
+				{Label: "mobile", Number: "+33612345678"},
+				{Number: "+33112345678"},
+			},
+		}
+
+		container.contactRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, c *domain.Contact) (*domain.Contact, error) {
+				return c, nil
+			})
+
+		createdContact, err := contactCreator.Create(ctx, cmd)
+		assert.NoError(t, err)
+		// the single email is made primary, the first phone is when none is marked
+		assert.Equal(t, "jdoe@home.local", createdContact.Email)
+		assert.Equal(t, []domain.EmailAddress{
+			{Label: domain.LabelWork, Address: "jdoe@work.local"},
+			{Label: domain.LabelHome, Address: "jdoe@home.local", Primary: true},
+		}, createdContact.Emails)
+		assert.Equal(t, "+33612345678", createdContact.Phone)
+		assert.Equal(t, []domain.PhoneNumber{
+			{Label: domain.LabelMobile, Number: "+33612345678", Primary: true},
+			{Label: domain.LabelOther, Number: "+33112345678"},
+		}, createdContact.Phones)
+	})
+	t.Run("phones read in the region of the command or the default one of the creator", func(t *testing.T) {
+		creator := user.New(uuid.New(), user.UserTypeAuthenticated)
+		container := testContainer(t)
+		container.expectSchema(&domain.ContactSchema{OwnerId: creator.Id(), DefaultPhoneRegion: "US"})
+		container.contactRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			Times(2).
+			DoAndReturn(func(_ context.Context, c *domain.Contact) (*domain.Contact, error) {
+				return c, nil
+			})
+		contactCreator := NewCreateContact(container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails)
+
+		createdContact, err := contactCreator.Create(ctx, CmdCreateContact{
+			CreatedBy: creator,
+			FirstName: "John",
+			LastName:  "Doe",
+			Email:     "jdoe@contact.local",
+			Phones: []ContactPhone{
+				{Label: "work", Number: "(415) 555-0100"},
+				{Label: "mobile", Number: "+33 6 12 34 56 78"},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "+14155550100", createdContact.Phone)
+		assert.Equal(t, "+33612345678", createdContact.Phones[1].Number)
+
+		createdContact, err = contactCreator.Create(ctx, CmdCreateContact{
+			CreatedBy:   creator,
+			FirstName:   "John",
+			LastName:    "Doe",
+			Email:       "jdoe@contact.local",
+			Phone:       "020 7946 0018",
+			PhoneRegion: "gb",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "+442079460018", createdContact.Phone)
+	})
+	t.Run("emails normalized and checked against the resolver and the disposable domains", func(t *testing.T) {
+		creator := user.New(uuid.New(), user.UserTypeAuthenticated)
+		container := testContainer(t)
+		container.expectSchema(nil)
+		container.contactRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			Times(2).
+			DoAndReturn(func(_ context.Context, c *domain.Contact) (*domain.Contact, error) {
+				return c, nil
+			})
+		container.resolver.EXPECT().Resolve(ctx, "contact.local").Return(true, nil)
+		container.resolver.EXPECT().Resolve(ctx, "dead.local").Return(false, nil)
+		contactCreator := NewCreateContact(container.contactRepo, container.addressBookRepo, container.schemaRepo, NewEmailVerifier(container.resolver, []string{"Trash.local"}))
+
+		createdContact, err := contactCreator.Create(ctx, CmdCreateContact{
+			CreatedBy: creator,
+			FirstName: "John",
+			LastName:  "Doe",
+			Phone:     "+33612345678",
+			Emails: []ContactEmail{
+				{Label: "work", Address: "John.Doe@Work.local"},
+				{Label: "home", Address: "JDoe@Contact.local", Primary: true},
+			},
+		})
+		assert.NoError(t, err)
+		// only the primary email is checked
+		assert.Equal(t, "jdoe@contact.local", createdContact.Email)
+		assert.Equal(t, domain.EmailStatusDeliverable, createdContact.EmailStatus)
+		assert.Equal(t, "john.doe@work.local", createdContact.Emails[0].Address)
+
+		createdContact, err = contactCreator.Create(ctx, CmdCreateContact{
+			CreatedBy: creator,
+			FirstName: "John",
+			LastName:  "Doe",
+			Email:     "jdoe@dead.local",
+			Phone:     "+33612345678",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.EmailStatusUndeliverable, createdContact.EmailStatus)
+
+		_, err = contactCreator.Create(ctx, CmdCreateContact{
+			CreatedBy: creator,
+			FirstName: "John",
+			LastName:  "Doe",
+			Email:     "jdoe@mail.trash.local",
+			Phone:     "+33612345678",
+		})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
 }
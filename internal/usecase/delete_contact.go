@@ -7,6 +7,7 @@ import (
 	"github.com/davidterranova/contacts/pkg/user"
 
 	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/internal/ports"
 	"github.com/go-playground/validator"
 	uuid "github.com/google/uuid"
 )
@@ -14,37 +15,180 @@ import (
 type CmdDeleteContact struct {
 	Deleter   user.User `validate:"required"`
 	ContactId string    `validate:"required,uuid"`
+	// Version is the expected contact version, 0 deletes whatever the current version
+	Version int `validate:"min=0"`
 }
 
 type DeleteContactHandler struct {
 	repo      ContactRepository
+	books     AddressBookRepository
 	validator *validator.Validate
 }
 
-func NewDeleteContact(repo ContactRepository) DeleteContactHandler {
+func NewDeleteContact(repo ContactRepository, books AddressBookRepository) DeleteContactHandler {
 	return DeleteContactHandler{
 		repo:      repo,
+		books:     books,
 		validator: validator.New(),
 	}
 }
 
 func (h DeleteContactHandler) Delete(ctx context.Context, cmd CmdDeleteContact) error {
+	contactUUID, err := h.contactId(cmd)
+	if err != nil {
+		return err
+	}
+
+	access, err := loadContactAccess(ctx, h.books, cmd.Deleter)
+	if err != nil {
+		return err
+	}
+
+	related, err := relatedOperations(ctx, h.repo, cmd.Deleter, contactUUID, nil)
+	if err != nil {
+		return err
+	}
+
+	operation := &batchOperation{result: &BatchResult{}, related: related}
+	operation.op = domain.UpdateOperation(contactUUID, deleter(cmd, access))
+	return operation.apply(ctx, h.repo)
+}
+
+// contactId validates the command and returns the id of the contact to delete
+func (h DeleteContactHandler) contactId(cmd CmdDeleteContact) (uuid.UUID, error) {
 	err := h.validator.Struct(cmd)
 	if err != nil {
-		return fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+		return uuid.Nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
 	}
 
 	contactUUID, err := uuid.Parse(cmd.ContactId)
 	if err != nil {
-		return fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+		return uuid.Nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
 	}
 
-	_, err = handleRepositoryError[*domain.Contact](nil, h.repo.Delete(ctx, contactUUID, func(c domain.Contact) error {
-		if c.CreatedBy != cmd.Deleter.Id() {
-			return fmt.Errorf("%w: %s", ErrForbidden, "contact can only be deleted by its creator")
+	return contactUUID, nil
+}
+
+// deleter returns the repository update function of the command, the audit entry is saved along with the contact.
+// Contacts are moved to the trash, they are permanently removed by PurgeTrashHandler.
+func deleter(cmd CmdDeleteContact, access contactAccess) func(c domain.Contact) (domain.Contact, error) {
+	return func(c domain.Contact) (domain.Contact, error) {
+		if c.IsDeleted() {
+			return c, errTrashed(c)
+		}
+		if !access.canWrite(c) {
+			return c, fmt.Errorf("%w: %s", ErrForbidden, "contact can only be deleted by its creator, users it is shared with for writing and its address book editors")
+		}
+		if err := checkVersion(c, cmd.Version); err != nil {
+			return c, err
 		}
 
-		return nil
-	}))
-	return err
+		return trash(cmd.Deleter, c), nil
+	}
+}
+
+// trash moves the contact to the trash on behalf of the actor
+func trash(actor user.User, c domain.Contact) domain.Contact {
+	trashed := c
+	trashed.Trash()
+	trashed.Record(domain.NewContactDeleted(c.Id))
+	trashed.Audit(domain.NewAuditEntry(actor, domain.AuditActionDelete, c, trashed))
+
+	return trashed
+}
+
+// relatedOperations returns the operations keeping the relationships to the deleted contact consistent, they are
+// applied along with its deletion: the contacts relating to it with domain.OnDeleteCascade are deleted as well,
+// domain.OnDeleteNullify relationships are removed and domain.OnDeleteRestrict ones fail the deletion with ErrRestricted.
+// The contacts in deleted are deleted by other operations of the same batch, they are left alone. The changes are
+// audited on behalf of the deleter.
+func relatedOperations(ctx context.Context, repo ContactRepository, deleter user.User, id uuid.UUID, deleted map[uuid.UUID]bool) ([]*batchOperation, error) {
+	deleting := map[uuid.UUID]bool{id: true}
+	for deletedId := range deleted {
+		deleting[deletedId] = true
+	}
+
+	var (
+		queue    = []uuid.UUID{id}
+		relating []uuid.UUID
+		seen     = make(map[uuid.UUID]bool)
+		cascaded = make(map[uuid.UUID]bool)
+	)
+	for len(queue) > 0 {
+		target := queue[0]
+		queue = queue[1:]
+
+		contacts, err := handleRepositoryError(repo.List(ctx, ports.NewFilter(
+			ports.WithRelatedTo(target),
+			ports.WithSort(domain.Sort{Field: domain.SortByCreatedAt}),
+		)))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, contact := range contacts {
+			if contact.Id == id || deleted[contact.Id] {
+				continue
+			}
+			if !seen[contact.Id] {
+				seen[contact.Id] = true
+				relating = append(relating, contact.Id)
+			}
+
+			for _, relationship := range contact.RelationshipsTo(target) {
+				if relationship.OnDelete == domain.OnDeleteCascade && !deleting[contact.Id] {
+					deleting[contact.Id] = true
+					cascaded[contact.Id] = true
+					queue = append(queue, contact.Id)
+				}
+			}
+		}
+	}
+
+	operations := make([]*batchOperation, 0, len(relating))
+	for _, contactId := range relating {
+		operation := &batchOperation{result: &BatchResult{}}
+		if cascaded[contactId] {
+			operation.op = domain.UpdateOperation(contactId, cascadeDeleter(deleter))
+		} else {
+			operation.op = domain.UpdateOperation(contactId, nullifier(deleter, deleting))
+		}
+		operations = append(operations, operation)
+	}
+
+	return operations, nil
+}
+
+// cascadeDeleter moves a contact relating to a deleted contact with domain.OnDeleteCascade to the trash, the choice
+// was made by the users who can update it so it does not depend on the deleter access
+func cascadeDeleter(deleter user.User) func(c domain.Contact) (domain.Contact, error) {
+	return func(c domain.Contact) (domain.Contact, error) {
+		if c.IsDeleted() {
+			return c, errTrashed(c)
+		}
+
+		return trash(deleter, c), nil
+	}
+}
+
+// nullifier removes the relationships of a contact to the deleted contacts, unless one of them restricts the deletion.
+// The contact may belong to another user, the error does not tell which one it is.
+func nullifier(deleter user.User, deleting map[uuid.UUID]bool) func(c domain.Contact) (domain.Contact, error) {
+	return func(c domain.Contact) (domain.Contact, error) {
+		for _, relationship := range c.Relationships {
+			if deleting[relationship.ContactId] && relationship.OnDelete == domain.OnDeleteRestrict {
+				return c, fmt.Errorf("%w: a %s relationship of another contact restricts the deletion", ErrRestricted, relationship.Type)
+			}
+		}
+
+		nullified, unrelated := c, false
+		for deletedId := range deleting {
+			unrelated = nullified.UnrelateFrom(deletedId) || unrelated
+		}
+		if unrelated {
+			nullified.Record(domain.NewContactUpdated(c.Id, domain.RelationshipChanges(c, nullified)))
+			nullified.Audit(domain.NewAuditEntry(deleter, domain.AuditActionUnrelate, c, nullified))
+		}
+		return nullified, nil
+	}
 }
@@ -0,0 +1,124 @@
+//go:generate mockgen -destination=mock_email_verifier.go -package=usecase . DomainResolver
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/davidterranova/contacts/internal/domain"
+)
+
+// DomainResolver tells whether a domain accepts mails, it fails when the lookup could not be done
+type DomainResolver interface {
+	// Resolve returns true when the domain has a mail server, its MX records or its address when it has none
+	Resolve(ctx context.Context, domain string) (bool, error)
+}
+
+// EmailVerifier rejects the emails of disposable domains and checks the deliverability of the others with its
+// resolver. The zero value accepts every email and leaves them unverified.
+type EmailVerifier struct {
+	resolver   DomainResolver
+	disposable map[string]bool
+}
+
+// NewEmailVerifier returns a verifier rejecting the emails of the disposable domains and their subdomains, a nil
+// resolver disables the deliverability checks
+func NewEmailVerifier(resolver DomainResolver, disposableDomains []string) EmailVerifier {
+	disposable := make(map[string]bool, len(disposableDomains))
+	for _, d := range disposableDomains {
+		disposable[normalizeEmail(d)] = true
+	}
+
+	return EmailVerifier{
+		resolver:   resolver,
+		disposable: disposable,
+	}
+}
+
+// check fails when one of the addresses belongs to a disposable domain
+func (v EmailVerifier) check(addresses ...string) error {
+	for _, address := range addresses {
+		for d := emailDomain(address); d != ""; d = parentDomain(d) {
+			if v.disposable[d] {
+				return fmt.Errorf("%w: %s belongs to the disposable domain %s", ErrInvalidCommand, address, d)
+			}
+		}
+	}
+
+	return nil
+}
+
+// status returns the deliverability of the address, unknown when its domain could not be resolved
+func (v EmailVerifier) status(ctx context.Context, address string) domain.EmailStatus {
+	if v.resolver == nil || address == "" {
+		return domain.EmailStatusUnverified
+	}
+
+	ok, err := v.resolver.Resolve(ctx, emailDomain(address))
+	switch {
+	case err != nil:
+		return domain.EmailStatusUnknown
+	case ok:
+		return domain.EmailStatusDeliverable
+	default:
+		return domain.EmailStatusUndeliverable
+	}
+}
+
+// statuses returns the deliverability of each address, the domains being resolved once
+func (v EmailVerifier) statuses(ctx context.Context, addresses ...string) map[string]domain.EmailStatus {
+	statuses := make(map[string]domain.EmailStatus, len(addresses))
+	for _, address := range addresses {
+		address = normalizeEmail(address)
+		if _, ok := statuses[address]; !ok {
+			statuses[address] = v.status(ctx, address)
+		}
+	}
+
+	return statuses
+}
+
+// normalizeEmail trims and lowercases the address, mail servers handling the local parts case insensitively
+func normalizeEmail(address string) string {
+	return strings.ToLower(strings.TrimSpace(address))
+}
+
+// commandEmails returns the single email and the addresses of the emails of a command, skipping the empty single email
+func commandEmails(email string, emails []ContactEmail) []string {
+	addresses := make([]string, 0, len(emails)+1)
+	if email != "" {
+		addresses = append(addresses, email)
+	}
+	for _, e := range emails {
+		addresses = append(addresses, e.Address)
+	}
+
+	return addresses
+}
+
+// setEmailStatus sets the status of the primary email of the contact when it is no longer the previous one
+func setEmailStatus(c *domain.Contact, previous string, statuses map[string]domain.EmailStatus) {
+	if c.Email != previous {
+		c.EmailStatus = statuses[c.Email]
+	}
+}
+
+func emailDomain(address string) string {
+	at := strings.LastIndex(address, "@")
+	if at < 0 {
+		return ""
+	}
+
+	return normalizeEmail(address[at+1:])
+}
+
+// parentDomain returns the domain without its first label, empty for top level domains
+func parentDomain(d string) string {
+	dot := strings.Index(d, ".")
+	if dot < 0 {
+		return ""
+	}
+
+	return d[dot+1:]
+}
@@ -13,20 +13,52 @@ var (
 	ErrInvalidCommand = errors.New("invalid command")
 	ErrNotFound       = errors.New("not found")
 	ErrForbidden      = errors.New("forbidden")
+	ErrConflict       = errors.New("conflict")
+	// ErrAlreadyExists is returned when the contact email or phone is already used by another contact of its owner
+	ErrAlreadyExists = errors.New("already exists")
+	// ErrRestricted is returned when a contact cannot be deleted because of a domain.OnDeleteRestrict relationship to it
+	ErrRestricted = errors.New("restricted")
 )
 
-type contactResponse interface {
-	*domain.Contact | []*domain.Contact
+type repositoryResponse interface {
+	*domain.Contact | []*domain.Contact | *domain.AddressBook | []*domain.AddressBook | []*domain.AuditEntry | *domain.Webhook | []*domain.Webhook | *domain.Delivery | []*domain.Delivery | *domain.ContactSchema | *domain.Group | []*domain.Group
 }
 
-func handleRepositoryError[T contactResponse](c T, err error) (T, error) {
+// checkVersion fails with ErrConflict when the contact is not at the expected version, 0 skips the check
+func checkVersion(c domain.Contact, expected int) error {
+	if expected != 0 && c.Version != expected {
+		return fmt.Errorf("%w: contact %s is at version %d, expected %d", ErrConflict, c.Id, c.Version, expected)
+	}
+
+	return nil
+}
+
+// errTrashed hides the contacts in the trash from every use case but restore
+func errTrashed(c domain.Contact) error {
+	return fmt.Errorf("%w: contact %s is in the trash", ErrNotFound, c.Id)
+}
+
+func handleRepositoryError[T repositoryResponse](c T, err error) (T, error) {
 	if err == nil {
 		return c, nil
 	}
 
+	// batches callers needing to know which operation failed read it before handling the error
+	var operationErr *domain.OperationError
+	if errors.As(err, &operationErr) {
+		err = operationErr.Err
+	}
+
 	switch {
 	case errors.Is(err, ports.ErrNotFound):
 		return nil, fmt.Errorf("%w: %s", ErrNotFound, err)
+	case errors.Is(err, ports.ErrAlreadyExists):
+		return nil, fmt.Errorf("%w: %s", ErrAlreadyExists, err)
+	case errors.Is(err, ports.ErrAddressBookNotEmpty):
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	case errors.Is(err, ErrForbidden), errors.Is(err, ErrInvalidCommand), errors.Is(err, ErrNotFound), errors.Is(err, ErrConflict), errors.Is(err, ErrRestricted):
+		// use case errors returned by the update and delete closures
+		return nil, err
 	default:
 		return nil, fmt.Errorf("%w: %s", ErrInternal, err)
 	}
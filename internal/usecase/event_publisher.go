@@ -0,0 +1,26 @@
+//go:generate mockgen -destination=mock_event_publisher.go -package=usecase . EventPublisher,EventOutbox
+package usecase
+
+import (
+	"context"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	uuid "github.com/google/uuid"
+)
+
+// EventPublisher delivers the contact events to their consumers, a broker or an in-process bus
+type EventPublisher interface {
+	Publish(ctx context.Context, event domain.Event) error
+	// Forget drops what the publisher remembers of an event it failed, once the event is given up on
+	Forget(ctx context.Context, eventId uuid.UUID)
+}
+
+// EventOutbox holds the events recorded on the contacts, saved by the contact repository within the same transaction as the contacts
+type EventOutbox interface {
+	// Pending returns up to limit events not published yet, from the oldest to the most recent
+	Pending(ctx context.Context, limit int) ([]domain.Event, error)
+	MarkPublished(ctx context.Context, ids ...uuid.UUID) error
+	// MarkFailed records a failed publication of a pending event, the event is dead and no longer pending
+	// once it failed maxAttempts times
+	MarkFailed(ctx context.Context, id uuid.UUID, reason string, maxAttempts int) (dead bool, err error)
+}
@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/davidterranova/contacts/pkg/user"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/internal/ports"
+	"github.com/go-playground/validator"
+	uuid "github.com/google/uuid"
+)
+
+// DefaultNameSimilarity is the name similarity from which two contacts are reported as duplicates
+const DefaultNameSimilarity = 0.85
+
+type QueryFindDuplicates struct {
+	Requester user.User `validate:"required"`
+	// AddressBookId restricts the search to an address book the requester is a member of
+	AddressBookId string `validate:"omitempty,uuid"`
+	// NameSimilarity is the minimum name similarity of duplicates, between 0 and 1, DefaultNameSimilarity when 0
+	NameSimilarity float64 `validate:"min=0,max=1"`
+}
+
+// Duplicate is a pair of contacts which may be the same person, Contacts are sorted by creation date
+// so that the oldest one is the suggested survivor of a merge
+type Duplicate struct {
+	Contacts       [2]*domain.Contact
+	Reasons        []domain.DuplicateReason
+	NameSimilarity float64
+}
+
+// FindDuplicatesHandler looks for duplicates among the contacts a user can read
+type FindDuplicatesHandler struct {
+	repo      ContactRepository
+	books     AddressBookRepository
+	validator *validator.Validate
+}
+
+func NewFindDuplicates(repo ContactRepository, books AddressBookRepository) FindDuplicatesHandler {
+	return FindDuplicatesHandler{
+		repo:      repo,
+		books:     books,
+		validator: validator.New(),
+	}
+}
+
+// Find reports the contacts sharing an email or a phone once normalized, or with similar names.
+// The duplicates matching for the most reasons come first.
+func (h FindDuplicatesHandler) Find(ctx context.Context, query QueryFindDuplicates) ([]Duplicate, error) {
+	err := h.validator.Struct(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	access, err := loadContactAccess(ctx, h.books, query.Requester)
+	if err != nil {
+		return nil, err
+	}
+	filters := []ports.FilterOption{ports.WithVisibleTo(access.visibility())}
+
+	if query.AddressBookId != "" {
+		addressBookId, err := uuid.Parse(query.AddressBookId)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+		}
+		if _, ok := access.role(addressBookId); !ok {
+			return nil, fmt.Errorf("%w: %s", ErrForbidden, "duplicates can only be searched by address book members")
+		}
+		filters = append(filters, ports.WithAddressBook(addressBookId))
+	}
+
+	contacts, err := handleRepositoryError(h.repo.List(ctx, ports.NewFilter(filters...)))
+	if err != nil {
+		return nil, err
+	}
+
+	minSimilarity := query.NameSimilarity
+	if minSimilarity == 0 {
+		minSimilarity = DefaultNameSimilarity
+	}
+
+	return findDuplicates(contacts, minSimilarity), nil
+}
+
+type duplicateKey struct {
+	a, b uuid.UUID
+}
+
+func findDuplicates(contacts []*domain.Contact, minSimilarity float64) []Duplicate {
+	// contacts are ordered by creation date so that the first contact of a pair is the oldest one
+	sort.SliceStable(contacts, func(i, j int) bool {
+		return contacts[i].CreatedAt.Before(contacts[j].CreatedAt)
+	})
+
+	var (
+		duplicates = map[duplicateKey]*Duplicate{}
+		keys       []duplicateKey
+	)
+	add := func(a, b *domain.Contact, reason domain.DuplicateReason) {
+		key := duplicateKey{a.Id, b.Id}
+		duplicate, ok := duplicates[key]
+		if !ok {
+			duplicate = &Duplicate{
+				Contacts:       [2]*domain.Contact{a, b},
+				NameSimilarity: a.NameSimilarity(*b),
+			}
+			duplicates[key] = duplicate
+			keys = append(keys, key)
+		}
+		duplicate.Reasons = append(duplicate.Reasons, reason)
+	}
+
+	groups := []struct {
+		reason domain.DuplicateReason
+		key    func(c *domain.Contact) string
+	}{
+		{domain.DuplicateEmail, func(c *domain.Contact) string { return c.NormalizedEmail() }},
+		{domain.DuplicatePhone, func(c *domain.Contact) string { return c.NormalizedPhone() }},
+		// names are only compared within the same initial, which keeps the comparisons count down
+		{domain.DuplicateName, func(c *domain.Contact) string { return initial(c.NormalizedName()) }},
+	}
+	for _, group := range groups {
+		byKey := map[string][]*domain.Contact{}
+		for _, contact := range contacts {
+			if key := group.key(contact); key != "" {
+				byKey[key] = append(byKey[key], contact)
+			}
+		}
+
+		for _, contact := range contacts {
+			key := group.key(contact)
+			if key == "" {
+				continue
+			}
+
+			// the contacts of a group are in the same order, the contact is paired with the ones left after it
+			byKey[key] = byKey[key][1:]
+			for _, other := range byKey[key] {
+				if group.reason == domain.DuplicateName && contact.NameSimilarity(*other) < minSimilarity {
+					continue
+				}
+				add(contact, other, group.reason)
+			}
+		}
+	}
+
+	result := make([]Duplicate, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, *duplicates[key])
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if len(result[i].Reasons) != len(result[j].Reasons) {
+			return len(result[i].Reasons) > len(result[j].Reasons)
+		}
+		return result[i].NameSimilarity > result[j].NameSimilarity
+	})
+
+	return result
+}
+
+func initial(name string) string {
+	for _, r := range name {
+		return string(r)
+	}
+
+	return ""
+}
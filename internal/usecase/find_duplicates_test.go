@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/pkg/user"
+	"github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	t.Parallel()
+
+	requester := user.New(uuid.New(), user.UserTypeAuthenticated)
+	newContact := func(createdAt time.Time, firstName string, lastName string, email string, phone string) *domain.Contact {
+		contact := domain.New(requester.Id())
+		contact.CreatedAt = createdAt
+		contact.FirstName = firstName
+		contact.LastName = lastName
+		contact.Email = email
+		contact.Phone = phone
+		return contact
+	}
+
+	now := time.Now().UTC()
+	john := newContact(now, "John", "Doe", "John.Doe+work@Mail.com", "+33612345678")
+	swapped := newContact(now.Add(time.Minute), "Doe", "John", "john.doe@mail.com", "0033 6 12 34 56 78")
+	jon := newContact(now.Add(2*time.Minute), "Jon", "Doe", "jon@contact.local", "")
+	alice := newContact(now.Add(3*time.Minute), "Alice", "Martin", "alice@contact.local", "+33700000000")
+
+	t.Run("pairs the contacts by email, phone and name", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		container := testContainer(t)
+		container.expectAddressBooks()
+		container.contactRepo.EXPECT().
+			List(ctx, gomock.Any()).
+			Times(1).
+			Return([]*domain.Contact{alice, jon, swapped, john}, nil)
+
+		duplicates, err := NewFindDuplicates(container.contactRepo, container.addressBookRepo).
+			Find(ctx, QueryFindDuplicates{Requester: requester})
+		require.NoError(t, err)
+		require.Len(t, duplicates, 3)
+
+		assert.Equal(t, [2]*domain.Contact{john, swapped}, duplicates[0].Contacts)
+		assert.Equal(t, []domain.DuplicateReason{domain.DuplicateEmail, domain.DuplicatePhone, domain.DuplicateName}, duplicates[0].Reasons)
+		assert.Equal(t, 1.0, duplicates[0].NameSimilarity)
+		for _, duplicate := range duplicates[1:] {
+			assert.Equal(t, jon, duplicate.Contacts[1])
+			assert.Equal(t, []domain.DuplicateReason{domain.DuplicateName}, duplicate.Reasons)
+			assert.InDelta(t, 0.875, duplicate.NameSimilarity, 0.001)
+		}
+	})
+
+	t.Run("name similarity threshold", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		container := testContainer(t)
+		container.expectAddressBooks()
+		container.contactRepo.EXPECT().
+			List(ctx, gomock.Any()).
+			Times(1).
+			Return([]*domain.Contact{john, jon}, nil)
+
+		duplicates, err := NewFindDuplicates(container.contactRepo, container.addressBookRepo).
+			Find(ctx, QueryFindDuplicates{Requester: requester, NameSimilarity: 0.9})
+		require.NoError(t, err)
+		assert.Empty(t, duplicates)
+	})
+
+	t.Run("address book of another user", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.expectAddressBooks()
+
+		_, err := NewFindDuplicates(container.contactRepo, container.addressBookRepo).
+			Find(context.Background(), QueryFindDuplicates{Requester: requester, AddressBookId: uuid.NewString()})
+		assert.ErrorIs(t, err, ErrForbidden)
+	})
+}
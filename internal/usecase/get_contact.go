@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/davidterranova/contacts/pkg/user"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/go-playground/validator"
+	uuid "github.com/google/uuid"
+)
+
+type QueryGetContact struct {
+	Requester user.User `validate:"required"`
+	ContactId string    `validate:"required,uuid"`
+}
+
+type GetContactHandler struct {
+	repo      ContactRepository
+	books     AddressBookRepository
+	validator *validator.Validate
+}
+
+func NewGetContact(repo ContactRepository, books AddressBookRepository) GetContactHandler {
+	return GetContactHandler{
+		repo:      repo,
+		books:     books,
+		validator: validator.New(),
+	}
+}
+
+func (h GetContactHandler) Get(ctx context.Context, query QueryGetContact) (*domain.Contact, error) {
+	err := h.validator.Struct(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	contactUUID, err := uuid.Parse(query.ContactId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	access, err := loadContactAccess(ctx, h.books, query.Requester)
+	if err != nil {
+		return nil, err
+	}
+
+	contact, err := handleRepositoryError(h.repo.Get(ctx, contactUUID))
+	if err != nil {
+		return nil, err
+	}
+
+	if contact.IsDeleted() {
+		return nil, errTrashed(*contact)
+	}
+
+	if !access.canRead(*contact) {
+		return nil, fmt.Errorf("%w: %s", ErrForbidden, "contact can only be read by its creator, users it is shared with and its address book members")
+	}
+
+	return contact, nil
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/internal/ports"
+	"github.com/davidterranova/contacts/pkg/user"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetContact(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	owner := user.New(uuid.New(), user.UserTypeAuthenticated)
+	contact := domain.New(owner.Id())
+	member := user.New(uuid.New(), user.UserTypeAuthenticated)
+	addressBook := domain.NewAddressBook(uuid.New(), "family")
+	_ = addressBook.SetMember(member.Id(), domain.RoleViewer)
+	bookContact := domain.New(addressBook.CreatedBy)
+	bookContact.AddressBookId = addressBook.Id
+
+	testCases := []struct {
+		name          string
+		query         QueryGetContact
+		addressBooks  []*domain.AddressBook
+		repoContact   *domain.Contact
+		repoError     error
+		expectedError error
+	}{
+		{
+			name:        "get contact",
+			query:       QueryGetContact{Requester: owner, ContactId: contact.Id.String()},
+			repoContact: contact,
+		},
+		{
+			name:          "invalid query: invalid contact id",
+			query:         QueryGetContact{Requester: owner, ContactId: "invalid-uuid"},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name:          "invalid query: missing requester",
+			query:         QueryGetContact{ContactId: contact.Id.String()},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name:          "contact not found",
+			query:         QueryGetContact{Requester: owner, ContactId: contact.Id.String()},
+			repoError:     ports.ErrNotFound,
+			expectedError: ErrNotFound,
+		},
+		{
+			name:          "unauthorized requester",
+			query:         QueryGetContact{Requester: user.New(uuid.New(), user.UserTypeAuthenticated), ContactId: contact.Id.String()},
+			repoContact:   contact,
+			expectedError: ErrForbidden,
+		},
+		{
+			name:         "address book member",
+			query:        QueryGetContact{Requester: member, ContactId: bookContact.Id.String()},
+			addressBooks: []*domain.AddressBook{addressBook},
+			repoContact:  bookContact,
+		},
+		{
+			name:          "unexpected repository error",
+			query:         QueryGetContact{Requester: owner, ContactId: contact.Id.String()},
+			repoError:     errors.New("internal error"),
+			expectedError: ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			container := testContainer(t)
+			container.expectAddressBooks(tc.addressBooks...)
+			contactGetter := NewGetContact(container.contactRepo, container.addressBookRepo)
+
+			if tc.repoContact != nil || tc.repoError != nil {
+				container.contactRepo.EXPECT().
+					Get(ctx, gomock.Any()).
+					Times(1).
+					Return(tc.repoContact, tc.repoError)
+			}
+
+			got, err := contactGetter.Get(ctx, tc.query)
+			assert.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError == nil {
+				assert.Equal(t, tc.repoContact, got)
+			} else {
+				assert.Nil(t, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/davidterranova/contacts/pkg/user"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/go-playground/validator"
+	uuid "github.com/google/uuid"
+)
+
+type CmdCreateGroup struct {
+	Creator user.User `validate:"required"`
+	Name    string    `validate:"required,max=255"`
+}
+
+type QueryGetGroup struct {
+	Requester user.User `validate:"required"`
+	GroupId   string    `validate:"required,uuid"`
+}
+
+type QueryListGroups struct {
+	Owner user.User `validate:"required"`
+}
+
+type CmdUpdateGroup struct {
+	Updater user.User `validate:"required"`
+	GroupId string    `validate:"required,uuid"`
+	Name    string    `validate:"required,max=255"`
+}
+
+type CmdDeleteGroup struct {
+	Deleter user.User `validate:"required"`
+	GroupId string    `validate:"required,uuid"`
+}
+
+// GroupHandler manages groups, a group is only visible to its creator
+type GroupHandler struct {
+	groups    GroupRepository
+	validator *validator.Validate
+}
+
+func NewGroupHandler(groups GroupRepository) GroupHandler {
+	return GroupHandler{
+		groups:    groups,
+		validator: validator.New(),
+	}
+}
+
+func (h GroupHandler) Create(ctx context.Context, cmd CmdCreateGroup) (*domain.Group, error) {
+	err := h.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	return handleRepositoryError(h.groups.Create(ctx, domain.NewGroup(cmd.Creator.Id(), cmd.Name)))
+}
+
+func (h GroupHandler) Get(ctx context.Context, query QueryGetGroup) (*domain.Group, error) {
+	err := h.validator.Struct(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	return getGroup(ctx, h.groups, query.Requester, query.GroupId)
+}
+
+func (h GroupHandler) List(ctx context.Context, query QueryListGroups) ([]*domain.Group, error) {
+	err := h.validator.Struct(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	return handleRepositoryError(h.groups.ListByOwner(ctx, query.Owner.Id()))
+}
+
+func (h GroupHandler) Update(ctx context.Context, cmd CmdUpdateGroup) (*domain.Group, error) {
+	err := h.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	groupUUID, err := uuid.Parse(cmd.GroupId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	group, err := h.groups.Update(ctx, groupUUID, func(g domain.Group) (domain.Group, error) {
+		if g.CreatedBy != cmd.Updater.Id() {
+			return g, fmt.Errorf("%w: %s", ErrForbidden, "group can only be updated by its creator")
+		}
+
+		g.Name = cmd.Name
+		g.UpdatedAt = time.Now().UTC()
+		return g, nil
+	})
+
+	return handleRepositoryError(group, err)
+}
+
+// Delete removes the group from its contacts, trashed or not, and deletes it. The contacts themselves are kept.
+// The repository removes the memberships within the deletion, a failure leaving both in place.
+func (h GroupHandler) Delete(ctx context.Context, cmd CmdDeleteGroup) error {
+	err := h.validator.Struct(cmd)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	group, err := getGroup(ctx, h.groups, cmd.Deleter, cmd.GroupId)
+	if err != nil {
+		return err
+	}
+
+	_, err = handleRepositoryError[*domain.Group](nil, h.groups.Delete(ctx, group.Id, func(g domain.Group) error {
+		if g.CreatedBy != cmd.Deleter.Id() {
+			return fmt.Errorf("%w: %s", ErrForbidden, "group can only be deleted by its creator")
+		}
+
+		return nil
+	}))
+	return err
+}
+
+// getGroup returns the group when the requester created it
+func getGroup(ctx context.Context, groups GroupRepository, requester user.User, groupId string) (*domain.Group, error) {
+	groupUUID, err := uuid.Parse(groupId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	group, err := handleRepositoryError(groups.Get(ctx, groupUUID))
+	if err != nil {
+		return nil, err
+	}
+
+	if group.CreatedBy != requester.Id() {
+		return nil, fmt.Errorf("%w: %s", ErrForbidden, "group can only be used by its creator")
+	}
+
+	return group, nil
+}
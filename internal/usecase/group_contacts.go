@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/davidterranova/contacts/pkg/user"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/internal/ports"
+	"github.com/go-playground/validator"
+	uuid "github.com/google/uuid"
+)
+
+type CmdAddGroupContact struct {
+	Requester user.User `validate:"required"`
+	GroupId   string    `validate:"required,uuid"`
+	ContactId string    `validate:"required,uuid"`
+}
+
+type CmdRemoveGroupContact struct {
+	Requester user.User `validate:"required"`
+	GroupId   string    `validate:"required,uuid"`
+	ContactId string    `validate:"required,uuid"`
+}
+
+type CmdDeleteGroupContacts struct {
+	Deleter user.User `validate:"required"`
+	GroupId string    `validate:"required,uuid"`
+	// Atomic deletes all the contacts or none of them, like CmdBatchContacts
+	Atomic bool
+}
+
+type CmdShareGroupContacts struct {
+	Sharer     user.User `validate:"required"`
+	GroupId    string    `validate:"required,uuid"`
+	UserId     string    `validate:"required,uuid"`
+	Permission string    `validate:"required,oneof=read write"`
+}
+
+// GroupContactsHandler adds contacts the group creator can read to the group and applies the contact use cases to
+// all the contacts of a group at once
+type GroupContactsHandler struct {
+	groups    GroupRepository
+	contacts  ContactRepository
+	books     AddressBookRepository
+	batch     BatchContactsHandler
+	share     ShareContactHandler
+	validator *validator.Validate
+}
+
+func NewGroupContactsHandler(groups GroupRepository, contacts ContactRepository, books AddressBookRepository, schemas ContactSchemaRepository, emails EmailVerifier) GroupContactsHandler {
+	return GroupContactsHandler{
+		groups:    groups,
+		contacts:  contacts,
+		books:     books,
+		batch:     NewBatchContacts(contacts, books, schemas, emails),
+		share:     NewShareContact(contacts),
+		validator: validator.New(),
+	}
+}
+
+func (h GroupContactsHandler) Add(ctx context.Context, cmd CmdAddGroupContact) (*domain.Contact, error) {
+	err := h.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	return h.update(ctx, cmd.Requester, domain.AuditActionGroup, cmd.GroupId, cmd.ContactId, func(c *domain.Contact, groupId uuid.UUID) error {
+		c.AddToGroup(groupId)
+		return nil
+	})
+}
+
+func (h GroupContactsHandler) Remove(ctx context.Context, cmd CmdRemoveGroupContact) (*domain.Contact, error) {
+	err := h.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	return h.update(ctx, cmd.Requester, domain.AuditActionUngroup, cmd.GroupId, cmd.ContactId, func(c *domain.Contact, groupId uuid.UUID) error {
+		if !c.RemoveFromGroup(groupId) {
+			return fmt.Errorf("%w: contact %s is not in group %s", ErrNotFound, c.Id, groupId)
+		}
+
+		return nil
+	})
+}
+
+// update changes the groups of a contact the requester can write, on behalf of the group creator, the groups being
+// part of the contact and of its version. The change is audited as action.
+func (h GroupContactsHandler) update(ctx context.Context, requester user.User, action domain.AuditAction, groupId string, contactId string, fn func(c *domain.Contact, groupId uuid.UUID) error) (*domain.Contact, error) {
+	group, err := getGroup(ctx, h.groups, requester, groupId)
+	if err != nil {
+		return nil, err
+	}
+
+	contactUUID, err := uuid.Parse(contactId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	access, err := loadContactAccess(ctx, h.books, requester)
+	if err != nil {
+		return nil, err
+	}
+
+	contact, err := h.contacts.Update(ctx, contactUUID, func(c domain.Contact) (domain.Contact, error) {
+		if c.IsDeleted() {
+			return c, errTrashed(c)
+		}
+		if !access.canWrite(c) {
+			return c, fmt.Errorf("%w: %s", ErrForbidden, "only the contacts the group creator can update can be grouped")
+		}
+
+		updated := c
+		if err := fn(&updated, group.Id); err != nil {
+			return c, err
+		}
+		// adding a contact already in the group changes nothing
+		if updated.InGroup(group.Id) == c.InGroup(group.Id) {
+			return updated, nil
+		}
+
+		updated.Audit(domain.NewAuditEntry(requester, action, c, updated))
+		return updated, nil
+	})
+
+	return handleRepositoryError(contact, err)
+}
+
+// DeleteContacts moves the contacts of the group to the trash as a batch of delete commands, the contacts the deleter
+// cannot write are reported as failed
+func (h GroupContactsHandler) DeleteContacts(ctx context.Context, cmd CmdDeleteGroupContacts) (BatchResults, error) {
+	err := h.validator.Struct(cmd)
+	if err != nil {
+		return BatchResults{}, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	contacts, err := h.groupContacts(ctx, cmd.Deleter, cmd.GroupId)
+	if err != nil {
+		return BatchResults{}, err
+	}
+	if len(contacts) == 0 {
+		return BatchResults{Delete: []BatchResult{}}, nil
+	}
+
+	batch := CmdBatchContacts{Requester: cmd.Deleter, Atomic: cmd.Atomic}
+	for _, contact := range contacts {
+		batch.Delete = append(batch.Delete, CmdDeleteContact{ContactId: contact.Id.String()})
+	}
+
+	return h.batch.Apply(ctx, batch)
+}
+
+// ShareContacts shares every contact of the group with the user, the results are in the order of the contacts.
+// The contacts the sharer did not create are reported as failed.
+func (h GroupContactsHandler) ShareContacts(ctx context.Context, cmd CmdShareGroupContacts) ([]BatchResult, error) {
+	err := h.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	contacts, err := h.groupContacts(ctx, cmd.Sharer, cmd.GroupId)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]BatchResult, len(contacts))
+	for i, contact := range contacts {
+		shared, err := h.share.Share(ctx, CmdShareContact{
+			Sharer:     cmd.Sharer,
+			ContactId:  contact.Id.String(),
+			UserId:     cmd.UserId,
+			Permission: cmd.Permission,
+		})
+		if failed(&results[i], err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		results[i] = BatchResult{Status: BatchStatusApplied, Contact: shared}
+	}
+
+	return results, nil
+}
+
+// groupContacts returns the contacts of the group the requester can read, oldest first
+func (h GroupContactsHandler) groupContacts(ctx context.Context, requester user.User, groupId string) ([]*domain.Contact, error) {
+	group, err := getGroup(ctx, h.groups, requester, groupId)
+	if err != nil {
+		return nil, err
+	}
+
+	access, err := loadContactAccess(ctx, h.books, requester)
+	if err != nil {
+		return nil, err
+	}
+
+	return handleRepositoryError(h.contacts.List(ctx, ports.NewFilter(
+		ports.WithGroup(group.Id),
+		ports.WithVisibleTo(access.visibility()),
+		ports.WithSort(domain.Sort{Field: domain.SortByCreatedAt}),
+	)))
+}
@@ -0,0 +1,19 @@
+//go:generate mockgen -destination=mock_group_repository.go -package=usecase . GroupRepository
+package usecase
+
+import (
+	"context"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	uuid "github.com/google/uuid"
+)
+
+// GroupRepository stores the groups, the contacts they hold are stored by the ContactRepository
+type GroupRepository interface {
+	Get(ctx context.Context, id uuid.UUID) (*domain.Group, error)
+	ListByOwner(ctx context.Context, userId uuid.UUID) ([]*domain.Group, error)
+	Create(ctx context.Context, group *domain.Group) (*domain.Group, error)
+	Update(ctx context.Context, id uuid.UUID, updateFn func(g domain.Group) (domain.Group, error)) (*domain.Group, error)
+	// Delete removes the group from the contacts holding it, trashed or not, within the deletion
+	Delete(ctx context.Context, id uuid.UUID, deleterFn func(g domain.Group) error) error
+}
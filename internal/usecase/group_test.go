@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/pkg/user"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	owner := user.New(uuid.New(), user.UserTypeAuthenticated)
+	stranger := user.New(uuid.New(), user.UserTypeAuthenticated)
+
+	// expectContacts serves the given contacts to Get and Update, updates are applied to the contacts
+	expectContacts := func(container *container, contacts ...*domain.Contact) {
+		for _, contact := range contacts {
+			contact := contact
+			container.contactRepo.EXPECT().Get(ctx, contact.Id).AnyTimes().Return(contact, nil)
+			container.contactRepo.EXPECT().
+				Update(ctx, contact.Id, gomock.Any()).
+				AnyTimes().
+				DoAndReturn(func(_ context.Context, _ uuid.UUID, updateFn func(c domain.Contact) (domain.Contact, error)) (*domain.Contact, error) {
+					updated, err := updateFn(*contact)
+					if err != nil {
+						return nil, err
+					}
+					*contact = updated
+					return contact, nil
+				})
+		}
+	}
+
+	t.Run("create and get", func(t *testing.T) {
+		t.Parallel()
+
+		groupRepo := NewMockGroupRepository(gomock.NewController(t))
+		handler := NewGroupHandler(groupRepo)
+
+		var created *domain.Group
+		groupRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, g *domain.Group) (*domain.Group, error) {
+				created = g
+				return g, nil
+			})
+
+		group, err := handler.Create(ctx, CmdCreateGroup{Creator: owner, Name: "friends"})
+		require.NoError(t, err)
+		assert.Equal(t, owner.Id(), group.CreatedBy)
+		assert.Equal(t, "friends", group.Name)
+
+		_, err = handler.Create(ctx, CmdCreateGroup{Creator: owner})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+
+		groupRepo.EXPECT().Get(ctx, created.Id).Times(2).Return(created, nil)
+		got, err := handler.Get(ctx, QueryGetGroup{Requester: owner, GroupId: created.Id.String()})
+		require.NoError(t, err)
+		assert.Equal(t, created, got)
+
+		_, err = handler.Get(ctx, QueryGetGroup{Requester: stranger, GroupId: created.Id.String()})
+		assert.ErrorIs(t, err, ErrForbidden)
+	})
+
+	t.Run("add and remove contacts", func(t *testing.T) {
+		t.Parallel()
+
+		group := domain.NewGroup(owner.Id(), "friends")
+		mine := domain.New(owner.Id())
+		shared := domain.New(stranger.Id())
+		shared.Share(owner.Id(), domain.PermissionWrite)
+		readOnly := domain.New(stranger.Id())
+		readOnly.Share(owner.Id(), domain.PermissionRead)
+		private := domain.New(stranger.Id())
+		trashed := domain.New(owner.Id())
+		trashed.Trash()
+
+		container := testContainer(t)
+		container.expectAddressBooks()
+		expectContacts(container, mine, shared, readOnly, private, trashed)
+		groupRepo := NewMockGroupRepository(gomock.NewController(t))
+		groupRepo.EXPECT().Get(ctx, group.Id).AnyTimes().Return(group, nil)
+		handler := NewGroupContactsHandler(groupRepo, container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails)
+
+		for _, contact := range []*domain.Contact{mine, shared, mine} {
+			added, err := handler.Add(ctx, CmdAddGroupContact{Requester: owner, GroupId: group.Id.String(), ContactId: contact.Id.String()})
+			require.NoError(t, err)
+			assert.Equal(t, []uuid.UUID{group.Id}, added.GroupIds)
+		}
+
+		_, err := handler.Add(ctx, CmdAddGroupContact{Requester: owner, GroupId: group.Id.String(), ContactId: private.Id.String()})
+		assert.ErrorIs(t, err, ErrForbidden)
+		_, err = handler.Add(ctx, CmdAddGroupContact{Requester: owner, GroupId: group.Id.String(), ContactId: readOnly.Id.String()})
+		assert.ErrorIs(t, err, ErrForbidden, "grouping a contact changes it")
+		assert.Empty(t, readOnly.GroupIds)
+		_, err = handler.Add(ctx, CmdAddGroupContact{Requester: owner, GroupId: group.Id.String(), ContactId: trashed.Id.String()})
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = handler.Add(ctx, CmdAddGroupContact{Requester: stranger, GroupId: group.Id.String(), ContactId: private.Id.String()})
+		assert.ErrorIs(t, err, ErrForbidden)
+
+		removed, err := handler.Remove(ctx, CmdRemoveGroupContact{Requester: owner, GroupId: group.Id.String(), ContactId: mine.Id.String()})
+		require.NoError(t, err)
+		assert.Empty(t, removed.GroupIds)
+
+		_, err = handler.Remove(ctx, CmdRemoveGroupContact{Requester: owner, GroupId: group.Id.String(), ContactId: mine.Id.String()})
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		// adding the contact to the group twice is audited once
+		var actions []domain.AuditAction
+		for _, entry := range mine.PullAuditEntries() {
+			actions = append(actions, entry.Action)
+		}
+		assert.Equal(t, []domain.AuditAction{domain.AuditActionGroup, domain.AuditActionUngroup}, actions)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		t.Parallel()
+
+		group := domain.NewGroup(owner.Id(), "friends")
+		groupRepo := NewMockGroupRepository(gomock.NewController(t))
+		groupRepo.EXPECT().Get(ctx, group.Id).AnyTimes().Return(group, nil)
+		groupRepo.EXPECT().
+			Delete(ctx, group.Id, gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, _ uuid.UUID, deleteFn func(g domain.Group) error) error {
+				return deleteFn(*group)
+			})
+		handler := NewGroupHandler(groupRepo)
+
+		err := handler.Delete(ctx, CmdDeleteGroup{Deleter: stranger, GroupId: group.Id.String()})
+		assert.ErrorIs(t, err, ErrForbidden)
+
+		err = handler.Delete(ctx, CmdDeleteGroup{Deleter: owner, GroupId: group.Id.String()})
+		require.NoError(t, err)
+	})
+
+	t.Run("delete contacts", func(t *testing.T) {
+		t.Parallel()
+
+		group := domain.NewGroup(owner.Id(), "friends")
+		mine := domain.New(owner.Id())
+		shared := domain.New(stranger.Id())
+		shared.Share(owner.Id(), domain.PermissionRead)
+
+		container := testContainer(t)
+		container.expectAddressBooks()
+		container.expectSchema(nil)
+		container.expectNoRelatedContacts()
+		expectContacts(container, mine, shared)
+		container.contactRepo.EXPECT().List(ctx, gomock.Any()).Times(1).Return([]*domain.Contact{mine, shared}, nil)
+		groupRepo := NewMockGroupRepository(gomock.NewController(t))
+		groupRepo.EXPECT().Get(ctx, group.Id).AnyTimes().Return(group, nil)
+		handler := NewGroupContactsHandler(groupRepo, container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails)
+
+		results, err := handler.DeleteContacts(ctx, CmdDeleteGroupContacts{Deleter: owner, GroupId: group.Id.String()})
+		require.NoError(t, err)
+		require.Len(t, results.Delete, 2)
+		assert.Equal(t, BatchStatusApplied, results.Delete[0].Status)
+		assert.True(t, mine.IsDeleted())
+		assert.Equal(t, BatchStatusFailed, results.Delete[1].Status)
+		assert.ErrorIs(t, results.Delete[1].Err, ErrForbidden)
+		assert.False(t, shared.IsDeleted())
+	})
+
+	t.Run("delete the contacts of an empty group", func(t *testing.T) {
+		t.Parallel()
+
+		group := domain.NewGroup(owner.Id(), "friends")
+		container := testContainer(t)
+		container.expectAddressBooks()
+		container.contactRepo.EXPECT().List(ctx, gomock.Any()).Times(1).Return([]*domain.Contact{}, nil)
+		groupRepo := NewMockGroupRepository(gomock.NewController(t))
+		groupRepo.EXPECT().Get(ctx, group.Id).AnyTimes().Return(group, nil)
+		handler := NewGroupContactsHandler(groupRepo, container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails)
+
+		results, err := handler.DeleteContacts(ctx, CmdDeleteGroupContacts{Deleter: owner, GroupId: group.Id.String(), Atomic: true})
+		require.NoError(t, err)
+		assert.True(t, results.Applied())
+		assert.Empty(t, results.Delete)
+	})
+
+	t.Run("share contacts", func(t *testing.T) {
+		t.Parallel()
+
+		other := user.New(uuid.New(), user.UserTypeAuthenticated)
+		group := domain.NewGroup(owner.Id(), "friends")
+		mine := domain.New(owner.Id())
+		shared := domain.New(stranger.Id())
+		shared.Share(owner.Id(), domain.PermissionWrite)
+
+		container := testContainer(t)
+		container.expectAddressBooks()
+		expectContacts(container, mine, shared)
+		container.contactRepo.EXPECT().List(ctx, gomock.Any()).Times(1).Return([]*domain.Contact{mine, shared}, nil)
+		groupRepo := NewMockGroupRepository(gomock.NewController(t))
+		groupRepo.EXPECT().Get(ctx, group.Id).AnyTimes().Return(group, nil)
+		handler := NewGroupContactsHandler(groupRepo, container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails)
+
+		results, err := handler.ShareContacts(ctx, CmdShareGroupContacts{
+			Sharer:     owner,
+			GroupId:    group.Id.String(),
+			UserId:     other.Id().String(),
+			Permission: "read",
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, BatchStatusApplied, results[0].Status)
+		assert.True(t, mine.CanRead(other.Id()))
+		assert.Equal(t, BatchStatusFailed, results[1].Status)
+		assert.ErrorIs(t, results[1].Err, ErrForbidden)
+		assert.False(t, shared.CanRead(other.Id()))
+
+		_, err = handler.ShareContacts(ctx, CmdShareGroupContacts{
+			Sharer:     stranger,
+			GroupId:    group.Id.String(),
+			UserId:     other.Id().String(),
+			Permission: "read",
+		})
+		assert.ErrorIs(t, err, ErrForbidden)
+	})
+}
@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/davidterranova/contacts/pkg/user"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/go-playground/validator"
+)
+
+type ImportedContact struct {
+	FirstName string
+	LastName  string
+	// Email and Phone are the primary email and phone, Emails, Phones and Addresses all the values of the file
+	Email     string
+	Phone     string
+	Emails    []ContactEmail
+	Phones    []ContactPhone
+	Addresses []ContactAddress
+	// Err tells why the contact could not be read from the imported file, it is reported as failed
+	Err error
+}
+
+// primaryEmail returns the email the duplicates are looked up by, the primary one of Emails when Email is empty
+func (c ImportedContact) primaryEmail() string {
+	if c.Email != "" || len(c.Emails) == 0 {
+		return c.Email
+	}
+	for _, email := range c.Emails {
+		if email.Primary {
+			return email.Address
+		}
+	}
+
+	return c.Emails[0].Address
+}
+
+type CmdImportContacts struct {
+	Importer user.User `validate:"required"`
+	// AddressBookId imports the contacts in an address book the importer can edit, personal contacts when empty
+	AddressBookId string            `validate:"omitempty,uuid"`
+	Contacts      []ImportedContact `validate:"required,min=1,max=1000"`
+	// DryRun checks the contacts without creating them
+	DryRun bool
+}
+
+type ImportStatus string
+
+const (
+	ImportStatusCreated ImportStatus = "created"
+	// ImportStatusValid contacts would be created if the import was not a dry run
+	ImportStatusValid ImportStatus = "valid"
+	// ImportStatusSkipped contacts have the email of a contact the importer can already read,
+	// or of a previous contact of the import, or break the uniqueness constraints of the repository
+	ImportStatusSkipped ImportStatus = "skipped"
+	// ImportStatusFailed contacts do not satisfy the contact creation rules, or could not be saved and may be imported again
+	ImportStatusFailed ImportStatus = "failed"
+)
+
+// ImportResult is the outcome of the import of a contact, Contact is set once created and Reason explains the other statuses
+type ImportResult struct {
+	Status  ImportStatus
+	Contact *domain.Contact
+	Reason  string
+}
+
+// ImportContactsHandler creates contacts in bulk with the contact creation rules, one contact failing does not stop the import
+type ImportContactsHandler struct {
+	create    CreateContact
+	list      ListContactHandler
+	validator *validator.Validate
+}
+
+func NewImportContacts(repo ContactRepository, books AddressBookRepository, schemas ContactSchemaRepository, emails EmailVerifier) ImportContactsHandler {
+	return ImportContactsHandler{
+		create:    NewCreateContact(repo, books, schemas, emails),
+		list:      NewListContact(repo, books),
+		validator: validator.New(),
+	}
+}
+
+// Import returns the results in the order of the imported contacts, it only fails when no contact can be imported.
+// A contact the repository fails to save is reported as failed, the contacts imported before and after it are kept.
+func (h ImportContactsHandler) Import(ctx context.Context, cmd CmdImportContacts) ([]ImportResult, error) {
+	err := h.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	results := make([]ImportResult, 0, len(cmd.Contacts))
+	emails := make(map[string]int, len(cmd.Contacts))
+	for i, imported := range cmd.Contacts {
+		if imported.Err != nil {
+			results = append(results, ImportResult{Status: ImportStatusFailed, Reason: imported.Err.Error()})
+			continue
+		}
+		email := normalizeEmail(imported.primaryEmail())
+		if previous, ok := emails[email]; ok && email != "" {
+			results = append(results, ImportResult{
+				Status: ImportStatusSkipped,
+				Reason: fmt.Sprintf("contact %d of the import already has this email", previous+1),
+			})
+			continue
+		}
+
+		result, err := h.importContact(ctx, cmd, imported)
+		if errors.Is(err, ErrInternal) {
+			result = ImportResult{Status: ImportStatusFailed, Reason: err.Error()}
+		} else if err != nil {
+			return nil, err
+		}
+		if result.Status != ImportStatusFailed {
+			emails[email] = i
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (h ImportContactsHandler) importContact(ctx context.Context, cmd CmdImportContacts, imported ImportedContact) (ImportResult, error) {
+	if email := imported.primaryEmail(); email != "" {
+		page, err := h.list.List(ctx, QueryListContact{
+			Requester:     cmd.Importer,
+			AddressBookId: cmd.AddressBookId,
+			Email:         domain.FieldMatch{Value: normalizeEmail(email), Mode: domain.MatchExact},
+			Limit:         1,
+		})
+		if err != nil {
+			return ImportResult{}, err
+		}
+		if len(page.Contacts) > 0 {
+			return ImportResult{
+				Status: ImportStatusSkipped,
+				Reason: fmt.Sprintf("contact %s already has this email", page.Contacts[0].Id),
+			}, nil
+		}
+	}
+
+	create := CmdCreateContact{
+		CreatedBy:     cmd.Importer,
+		AddressBookId: cmd.AddressBookId,
+		FirstName:     imported.FirstName,
+		LastName:      imported.LastName,
+		Email:         imported.Email,
+		Phone:         imported.Phone,
+		Emails:        imported.Emails,
+		Phones:        imported.Phones,
+		Addresses:     imported.Addresses,
+	}
+	var (
+		result = ImportResult{Status: ImportStatusValid}
+		err    error
+	)
+	if cmd.DryRun {
+		err = h.create.Check(ctx, create)
+	} else {
+		result.Status = ImportStatusCreated
+		result.Contact, err = h.create.Create(ctx, create)
+	}
+	if errors.Is(err, ErrInvalidCommand) {
+		return ImportResult{Status: ImportStatusFailed, Reason: err.Error()}, nil
+	}
+	if errors.Is(err, ErrAlreadyExists) {
+		return ImportResult{Status: ImportStatusSkipped, Reason: err.Error()}, nil
+	}
+	if err != nil {
+		return ImportResult{}, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/pkg/user"
+	"github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportContacts(t *testing.T) {
+	t.Parallel()
+
+	importer := user.New(uuid.New(), user.UserTypeAuthenticated)
+	existing := domain.New(importer.Id())
+	existing.Email = "existing@contact.local"
+
+	t.Run("report the created, skipped and failed contacts", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		container := testContainer(t)
+		container.expectSchema(nil)
+		container.expectAddressBooks()
+		container.contactRepo.EXPECT().
+			List(ctx, gomock.Any()).
+			AnyTimes().
+			DoAndReturn(func(_ context.Context, filter domain.Filter) ([]*domain.Contact, error) {
+				if filter.Email().Value == existing.Email {
+					return []*domain.Contact{existing}, nil
+				}
+				return []*domain.Contact{}, nil
+			})
+		container.contactRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, contact *domain.Contact) (*domain.Contact, error) {
+				return contact, nil
+			})
+
+		results, err := NewImportContacts(container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails).
+			Import(ctx, CmdImportContacts{
+				Importer: importer,
+				Contacts: []ImportedContact{
+					{FirstName: "John", LastName: "Doe", Email: "jdoe@contact.local", Phone: "+33612345678"},
+					{FirstName: "Jane", LastName: "Doe", Email: existing.Email, Phone: "+33612345679"},
+					{FirstName: "Jack", LastName: "Doe", Email: "jack@contact.local", Phone: "0612345678"},
+					{Err: errors.New("line 12: invalid vcard: missing value separator")},
+				},
+			})
+		require.NoError(t, err)
+		require.Len(t, results, 4)
+
+		assert.Equal(t, ImportStatusCreated, results[0].Status)
+		require.NotNil(t, results[0].Contact)
+		assert.Equal(t, "jdoe@contact.local", results[0].Contact.Email)
+		assert.Equal(t, ImportStatusSkipped, results[1].Status)
+		assert.Contains(t, results[1].Reason, existing.Id.String())
+		assert.Equal(t, ImportStatusFailed, results[2].Status)
+		assert.Contains(t, results[2].Reason, "no region is given")
+		assert.Equal(t, ImportStatusFailed, results[3].Status)
+		assert.Equal(t, "line 12: invalid vcard: missing value separator", results[3].Reason)
+	})
+
+	t.Run("dry run checks the contacts without creating them", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		container := testContainer(t)
+		container.expectSchema(nil)
+		container.expectAddressBooks()
+		container.contactRepo.EXPECT().
+			List(ctx, gomock.Any()).
+			AnyTimes().
+			Return([]*domain.Contact{}, nil)
+
+		results, err := NewImportContacts(container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails).
+			Import(ctx, CmdImportContacts{
+				Importer: importer,
+				DryRun:   true,
+				Contacts: []ImportedContact{
+					{FirstName: "John", LastName: "Doe", Email: "jdoe@contact.local", Phone: "+33612345678"},
+					{FirstName: "Johnny", LastName: "Doe", Email: "JDoe@contact.local", Phone: "+33612345679"},
+					{FirstName: "J", LastName: "Doe", Email: "j@contact.local", Phone: "+33612345670"},
+				},
+			})
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+
+		assert.Equal(t, ImportStatusValid, results[0].Status)
+		assert.Nil(t, results[0].Contact)
+		assert.Equal(t, ImportStatusSkipped, results[1].Status)
+		assert.Equal(t, "contact 1 of the import already has this email", results[1].Reason)
+		assert.Equal(t, ImportStatusFailed, results[2].Status)
+		assert.Contains(t, results[2].Reason, "FirstName")
+	})
+
+	t.Run("report the contacts the repository fails to save and import the others", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		container := testContainer(t)
+		container.expectSchema(nil)
+		container.expectAddressBooks()
+		container.contactRepo.EXPECT().
+			List(ctx, gomock.Any()).
+			AnyTimes().
+			Return([]*domain.Contact{}, nil)
+		container.contactRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			Times(3).
+			DoAndReturn(func(_ context.Context, contact *domain.Contact) (*domain.Contact, error) {
+				if contact.FirstName == "Jane" {
+					return nil, errors.New("connection lost")
+				}
+				return contact, nil
+			})
+
+		results, err := NewImportContacts(container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails).
+			Import(ctx, CmdImportContacts{
+				Importer: importer,
+				Contacts: []ImportedContact{
+					{FirstName: "John", LastName: "Doe", Email: "jdoe@contact.local", Phone: "+33612345678"},
+					{FirstName: "Jane", LastName: "Doe", Email: "jane@contact.local", Phone: "+33612345679"},
+					{FirstName: "Jack", LastName: "Doe", Email: "jack@contact.local", Phone: "+33612345670"},
+				},
+			})
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+
+		assert.Equal(t, ImportStatusCreated, results[0].Status)
+		assert.Equal(t, ImportStatusFailed, results[1].Status)
+		assert.Contains(t, results[1].Reason, "connection lost")
+		assert.Nil(t, results[1].Contact)
+		assert.Equal(t, ImportStatusCreated, results[2].Status)
+	})
+
+	t.Run("stop when the address book cannot be imported into", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		container := testContainer(t)
+		container.expectSchema(nil)
+		container.expectAddressBooks()
+
+		_, err := NewImportContacts(container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails).
+			Import(ctx, CmdImportContacts{
+				Importer:      importer,
+				AddressBookId: uuid.NewString(),
+				Contacts:      []ImportedContact{{Email: "jdoe@contact.local"}},
+			})
+		assert.ErrorIs(t, err, ErrForbidden)
+	})
+
+	t.Run("invalid command", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.expectSchema(nil)
+		_, err := NewImportContacts(container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails).
+			Import(context.Background(), CmdImportContacts{Importer: importer})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
@@ -2,30 +2,213 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/davidterranova/contacts/pkg/user"
 
 	"github.com/davidterranova/contacts/internal/domain"
 	"github.com/davidterranova/contacts/internal/ports"
+	"github.com/go-playground/validator"
+	uuid "github.com/google/uuid"
 )
 
+const DefaultPageSize = 50
+
 type QueryListContact struct {
-	CreatedBy user.User
+	// Requester lists the contacts they created, that have been shared with them
+	// or that belong to their address books
+	Requester user.User
+	// AddressBookId restricts the list to an address book the requester is a member of
+	AddressBookId string `validate:"omitempty,uuid"`
+	// GroupId restricts the list to the contacts of a group
+	GroupId string `validate:"omitempty,uuid"`
+	// Trash lists the deleted contacts instead of the active ones
+	Trash bool
+
+	// Search looks for a text in first name, last name, email and phone
+	Search    string `validate:"max=255"`
+	FirstName domain.FieldMatch
+	LastName  domain.FieldMatch
+	Email     domain.FieldMatch
+	Phone     domain.FieldMatch
+	CreatedAt domain.TimeRange
+	UpdatedAt domain.TimeRange
+
+	// CustomFields match the values of custom fields by name, numbers are formatted with the fewest digits needed
+	CustomFields map[string]domain.FieldMatch `validate:"max=10"`
+
+	// Sort defaults to creation date ascending
+	Sort domain.Sort
+	// Limit is the page size, at most 200 and DefaultPageSize when 0
+	Limit int `validate:"min=0,max=200"`
+	// Cursor is the NextCursor of the previous page
+	Cursor string
+}
+
+// ContactPage is a page of contacts, NextCursor is empty on the last page
+type ContactPage struct {
+	Contacts   []*domain.Contact
+	NextCursor string
+
+	sort domain.Sort
+}
+
+// Cursor returns the cursor pointing right after the given contact of the page
+func (p ContactPage) Cursor(contact *domain.Contact) string {
+	return encodeCursor(p.sort, contact)
 }
 
 type ListContactHandler struct {
-	repo ContactRepository
+	repo      ContactRepository
+	books     AddressBookRepository
+	validator *validator.Validate
 }
 
-func NewListContact(repo ContactRepository) ListContactHandler {
+func NewListContact(repo ContactRepository, books AddressBookRepository) ListContactHandler {
 	return ListContactHandler{
-		repo: repo,
+		repo:      repo,
+		books:     books,
+		validator: validator.New(),
+	}
+}
+
+func (h ListContactHandler) List(ctx context.Context, query QueryListContact) (*ContactPage, error) {
+	err := h.validator.Struct(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	filters, err := queryFilters(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	access, err := loadContactAccess(ctx, h.books, query.Requester)
+	if err != nil {
+		return nil, err
+	}
+	filters = append(filters, ports.WithVisibleTo(access.visibility()))
+
+	if query.AddressBookId != "" {
+		addressBookId, err := uuid.Parse(query.AddressBookId)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+		}
+		if _, ok := access.role(addressBookId); !ok {
+			return nil, fmt.Errorf("%w: %s", ErrForbidden, "contacts can only be listed by address book members")
+		}
+		filters = append(filters, ports.WithAddressBook(addressBookId))
+	}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = DefaultPageSize
+	}
+	// fetching one more contact than requested tells whether there is a next page
+	filters = append(filters, ports.WithLimit(limit+1))
+
+	contacts, err := handleRepositoryError(h.repo.List(ctx, ports.NewFilter(filters...)))
+	if err != nil {
+		return nil, err
+	}
+
+	page := &ContactPage{
+		Contacts: contacts,
+		sort:     querySort(query),
+	}
+	if len(contacts) > limit {
+		page.Contacts = contacts[:limit]
+		page.NextCursor = page.Cursor(page.Contacts[limit-1])
 	}
+
+	return page, nil
 }
 
-func (h ListContactHandler) List(ctx context.Context, query QueryListContact) ([]*domain.Contact, error) {
-	return handleRepositoryError(h.repo.List(
-		ctx,
-		ports.NewFilter(ports.WithCreatedBy(query.CreatedBy.Id())),
-	))
+func querySort(query QueryListContact) domain.Sort {
+	sort := query.Sort
+	if sort.Field == "" {
+		sort.Field = domain.SortByCreatedAt
+	}
+
+	return sort
+}
+
+func queryFilters(query QueryListContact) ([]ports.FilterOption, error) {
+	var filters []ports.FilterOption
+
+	if query.Trash {
+		filters = append(filters, ports.WithTrashed())
+	}
+
+	if query.GroupId != "" {
+		groupId, err := uuid.Parse(query.GroupId)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, ports.WithGroup(groupId))
+	}
+
+	if query.Search != "" {
+		filters = append(filters, ports.WithSearch(query.Search))
+	}
+
+	fieldMatches := []struct {
+		match  domain.FieldMatch
+		filter func(domain.FieldMatch) ports.FilterOption
+	}{
+		{query.FirstName, ports.WithFirstName},
+		{query.LastName, ports.WithLastName},
+		{query.Email, ports.WithEmail},
+		{query.Phone, ports.WithPhone},
+	}
+	for _, f := range fieldMatches {
+		if f.match.IsZero() {
+			continue
+		}
+		if f.match.Mode != domain.MatchExact && f.match.Mode != domain.MatchPrefix {
+			return nil, fmt.Errorf("unknown match mode %q", f.match.Mode)
+		}
+		filters = append(filters, f.filter(f.match))
+	}
+
+	for name, match := range query.CustomFields {
+		if match.IsZero() {
+			continue
+		}
+		if match.Mode != domain.MatchExact && match.Mode != domain.MatchPrefix {
+			return nil, fmt.Errorf("unknown match mode %q", match.Mode)
+		}
+		filters = append(filters, ports.WithCustomField(name, match))
+	}
+
+	if !query.CreatedAt.IsZero() {
+		if !query.CreatedAt.IsValid() {
+			return nil, errors.New("created at range must end after it starts")
+		}
+		filters = append(filters, ports.WithCreatedAt(query.CreatedAt))
+	}
+
+	if !query.UpdatedAt.IsZero() {
+		if !query.UpdatedAt.IsValid() {
+			return nil, errors.New("updated at range must end after it starts")
+		}
+		filters = append(filters, ports.WithUpdatedAt(query.UpdatedAt))
+	}
+
+	sort := querySort(query)
+	if !sort.Field.IsValid() {
+		return nil, fmt.Errorf("unknown sort field %q", sort.Field)
+	}
+	filters = append(filters, ports.WithSort(sort))
+
+	if query.Cursor != "" {
+		after, err := decodeCursor(sort, query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, ports.WithAfter(after))
+	}
+
+	return filters, nil
 }
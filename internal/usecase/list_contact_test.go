@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/pkg/user"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListContact(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+	yesterday := now.Add(-24 * time.Hour)
+	owner := user.New(uuid.New(), user.UserTypeAuthenticated)
+	addressBook := domain.NewAddressBook(uuid.New(), "family")
+	_ = addressBook.SetMember(owner.Id(), domain.RoleViewer)
+
+	testCases := []struct {
+		name          string
+		query         QueryListContact
+		expectedError error
+		expectFilter  func(t *testing.T, filter domain.Filter)
+	}{
+		{
+			name:  "list contacts visible to the requester",
+			query: QueryListContact{Requester: owner},
+			expectFilter: func(t *testing.T, filter domain.Filter) {
+				assert.Equal(t, domain.Visibility{UserId: owner.Id(), AddressBookIds: []uuid.UUID{addressBook.Id}}, *filter.VisibleTo())
+				assert.Nil(t, filter.AddressBook())
+				assert.Nil(t, filter.CreatedBy())
+				assert.Nil(t, filter.FirstName())
+				assert.Nil(t, filter.CreatedAt())
+			},
+		},
+		{
+			name:  "list contacts of an address book",
+			query: QueryListContact{Requester: owner, AddressBookId: addressBook.Id.String()},
+			expectFilter: func(t *testing.T, filter domain.Filter) {
+				assert.Equal(t, domain.Visibility{UserId: owner.Id(), AddressBookIds: []uuid.UUID{addressBook.Id}}, *filter.VisibleTo())
+				assert.Equal(t, addressBook.Id, *filter.AddressBook())
+			},
+		},
+		{
+			name:          "list contacts of an address book the requester is not a member of",
+			query:         QueryListContact{Requester: owner, AddressBookId: uuid.NewString()},
+			expectedError: ErrForbidden,
+		},
+		{
+			name: "list with filters",
+			query: QueryListContact{
+				Requester: owner,
+				Search:    "doe",
+				FirstName: domain.FieldMatch{Value: "Jo", Mode: domain.MatchPrefix},
+				Email:     domain.FieldMatch{Value: "jdoe@contact.local", Mode: domain.MatchExact},
+				CreatedAt: domain.TimeRange{From: &yesterday, To: &now},
+			},
+			expectFilter: func(t *testing.T, filter domain.Filter) {
+				assert.Equal(t, "doe", filter.Search())
+				assert.Equal(t, &domain.FieldMatch{Value: "Jo", Mode: domain.MatchPrefix}, filter.FirstName())
+				assert.Equal(t, &domain.FieldMatch{Value: "jdoe@contact.local", Mode: domain.MatchExact}, filter.Email())
+				assert.Nil(t, filter.LastName())
+				assert.Equal(t, &domain.TimeRange{From: &yesterday, To: &now}, filter.CreatedAt())
+				assert.Nil(t, filter.UpdatedAt())
+			},
+		},
+		{
+			name: "invalid query: unknown match mode",
+			query: QueryListContact{
+				Requester: owner,
+				LastName:  domain.FieldMatch{Value: "Doe", Mode: "suffix"},
+			},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name: "invalid query: empty time range",
+			query: QueryListContact{
+				Requester: owner,
+				UpdatedAt: domain.TimeRange{From: &now, To: &yesterday},
+			},
+			expectedError: ErrInvalidCommand,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			container := testContainer(t)
+			container.expectAddressBooks(addressBook)
+			contactLister := NewListContact(container.contactRepo, container.addressBookRepo)
+
+			if tc.expectedError == nil {
+				container.contactRepo.EXPECT().
+					List(ctx, gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, filter domain.Filter) ([]*domain.Contact, error) {
+						tc.expectFilter(t, filter)
+						return []*domain.Contact{}, nil
+					})
+			}
+
+			_, err := contactLister.List(ctx, tc.query)
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestListContactPagination(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	owner := user.New(uuid.New(), user.UserTypeAuthenticated)
+	sort := domain.Sort{Field: domain.SortByLastName}
+	contacts := []*domain.Contact{domain.New(owner.Id()), domain.New(owner.Id()), domain.New(owner.Id())}
+
+	container := testContainer(t)
+	container.expectAddressBooks()
+	contactLister := NewListContact(container.contactRepo, container.addressBookRepo)
+
+	container.contactRepo.EXPECT().
+		List(ctx, gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, filter domain.Filter) ([]*domain.Contact, error) {
+			assert.Equal(t, sort, filter.Sort())
+			assert.Equal(t, 3, filter.Limit())
+			assert.Nil(t, filter.After())
+			return contacts, nil
+		})
+
+	page, err := contactLister.List(ctx, QueryListContact{Requester: owner, Sort: sort, Limit: 2})
+	require.NoError(t, err)
+	assert.Len(t, page.Contacts, 2)
+	require.NotEmpty(t, page.NextCursor)
+	assert.Equal(t, page.Cursor(contacts[1]), page.NextCursor)
+
+	container.contactRepo.EXPECT().
+		List(ctx, gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, filter domain.Filter) ([]*domain.Contact, error) {
+			assert.Equal(t, &domain.SortKey{Text: contacts[1].LastName, Id: contacts[1].Id}, filter.After())
+			return contacts[2:], nil
+		})
+
+	page, err = contactLister.List(ctx, QueryListContact{Requester: owner, Sort: sort, Limit: 2, Cursor: page.NextCursor})
+	require.NoError(t, err)
+	assert.Len(t, page.Contacts, 1)
+	assert.Empty(t, page.NextCursor)
+
+	t.Run("cursor issued for another sort", func(t *testing.T) {
+		_, err := contactLister.List(ctx, QueryListContact{
+			Requester: owner,
+			Sort:      domain.Sort{Field: domain.SortByCreatedAt},
+			Cursor:    page.Cursor(contacts[0]),
+		})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		_, err := contactLister.List(ctx, QueryListContact{Requester: owner, Cursor: "not a cursor"})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+
+	t.Run("page size too large", func(t *testing.T) {
+		_, err := contactLister.List(ctx, QueryListContact{Requester: owner, Limit: 1000})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/davidterranova/contacts/pkg/user"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/internal/ports"
+	"github.com/go-playground/validator"
+	uuid "github.com/google/uuid"
+)
+
+// MergeRule chooses the value of a field of the merged contact
+type MergeRule string
+
+const (
+	// MergeFillEmpty keeps the survivor value, the duplicate one is taken when the survivor has none
+	MergeFillEmpty     MergeRule = "fill_empty"
+	MergeKeepSurvivor  MergeRule = "survivor"
+	MergeTakeDuplicate MergeRule = "duplicate"
+)
+
+func (r MergeRule) IsValid() bool {
+	return r == MergeFillEmpty || r == MergeKeepSurvivor || r == MergeTakeDuplicate
+}
+
+type CmdMergeContacts struct {
+	Merger user.User `validate:"required"`
+	// SurvivorId is the contact which is kept, the duplicate is moved to the trash
+	SurvivorId  string `validate:"required,uuid"`
+	DuplicateId string `validate:"required,uuid,nefield=SurvivorId"`
+	// Rules are indexed by field name: first_name, last_name, email or phone, MergeFillEmpty applies to the other fields
+	Rules map[string]MergeRule
+	// Version is the expected survivor version, 0 merges whatever the current version
+	Version int `validate:"min=0"`
+}
+
+// MergeContactsHandler combines a duplicate into a survivor contact, the survivor keeps its id, its address book and
+// gains the shares, when the merger created it, the groups and the relationships of the duplicate, the contacts
+// relating to the duplicate relate to the survivor instead. The contacts are saved within a single repository batch.
+type MergeContactsHandler struct {
+	repo      ContactRepository
+	books     AddressBookRepository
+	validator *validator.Validate
+}
+
+func NewMergeContacts(repo ContactRepository, books AddressBookRepository) MergeContactsHandler {
+	return MergeContactsHandler{
+		repo:      repo,
+		books:     books,
+		validator: validator.New(),
+	}
+}
+
+func (h MergeContactsHandler) Merge(ctx context.Context, cmd CmdMergeContacts) (*domain.Contact, error) {
+	err := h.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+	for field, rule := range cmd.Rules {
+		if _, ok := mergeFields[field]; !ok {
+			return nil, fmt.Errorf("%w: unknown merge field %q", ErrInvalidCommand, field)
+		}
+		if !rule.IsValid() {
+			return nil, fmt.Errorf("%w: unknown merge rule %q of field %s", ErrInvalidCommand, rule, field)
+		}
+	}
+
+	survivorUUID, err := uuid.Parse(cmd.SurvivorId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+	duplicateUUID, err := uuid.Parse(cmd.DuplicateId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	access, err := loadContactAccess(ctx, h.books, cmd.Merger)
+	if err != nil {
+		return nil, err
+	}
+
+	relating, err := h.relating(ctx, cmd.Merger, access, duplicateUUID, survivorUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the duplicate is trashed first, the survivor update reads it as it was within the same batch
+	var duplicateBefore domain.Contact
+	contacts, err := handleRepositoryError(h.repo.Batch(ctx, append([]domain.ContactOperation{
+		domain.UpdateOperation(duplicateUUID, func(c domain.Contact) (domain.Contact, error) {
+			duplicateBefore = c
+			if err := checkMergeable(c, access); err != nil {
+				return c, err
+			}
+
+			trashed := c
+			trashed.Trash()
+			trashed.Record(domain.NewContactDeleted(c.Id))
+			trashed.Audit(domain.NewMergeAuditEntry(cmd.Merger, c, trashed, domain.FieldMergedInto, survivorUUID))
+			return trashed, nil
+		}),
+		domain.UpdateOperation(survivorUUID, func(c domain.Contact) (domain.Contact, error) {
+			if err := checkMergeable(c, access); err != nil {
+				return c, err
+			}
+			if err := checkVersion(c, cmd.Version); err != nil {
+				return c, err
+			}
+
+			merged := mergeContacts(c, duplicateBefore, cmd.Merger, cmd.Rules)
+			if changes := domain.Diff(c, merged); len(changes) > 0 {
+				merged.Record(domain.NewContactUpdated(merged.Id, changes))
+			}
+			merged.Audit(domain.NewMergeAuditEntry(cmd.Merger, c, merged, domain.FieldMergedFrom, duplicateUUID))
+			return merged, nil
+		}),
+	}, relating...)))
+	if err != nil {
+		return nil, err
+	}
+
+	return contacts[1], nil
+}
+
+// relating returns the operations relating the contacts which relate to the duplicate to the survivor instead, the
+// merger must be able to update them
+func (h MergeContactsHandler) relating(ctx context.Context, merger user.User, access contactAccess, duplicateId uuid.UUID, survivorId uuid.UUID) ([]domain.ContactOperation, error) {
+	contacts, err := handleRepositoryError(h.repo.List(ctx, ports.NewFilter(
+		ports.WithRelatedTo(duplicateId),
+		ports.WithSort(domain.Sort{Field: domain.SortByCreatedAt}),
+	)))
+	if err != nil {
+		return nil, err
+	}
+
+	ops := make([]domain.ContactOperation, 0, len(contacts))
+	for _, contact := range contacts {
+		if contact.Id == survivorId {
+			continue
+		}
+
+		ops = append(ops, domain.UpdateOperation(contact.Id, func(c domain.Contact) (domain.Contact, error) {
+			if !access.canWrite(c) {
+				return c, fmt.Errorf("%w: %s", ErrForbidden, "contacts can only be merged by users who can update the contacts relating to the duplicate")
+			}
+
+			repointed := c
+			repointed.Repoint(duplicateId, survivorId)
+			if changes := domain.RelationshipChanges(c, repointed); len(changes) > 0 {
+				repointed.Record(domain.NewContactUpdated(c.Id, changes))
+				repointed.Audit(domain.NewAuditEntry(merger, domain.AuditActionRelate, c, repointed))
+			}
+			return repointed, nil
+		}))
+	}
+
+	return ops, nil
+}
+
+func checkMergeable(c domain.Contact, access contactAccess) error {
+	if c.IsDeleted() {
+		return errTrashed(c)
+	}
+	if !access.canWrite(c) {
+		return fmt.Errorf("%w: %s", ErrForbidden, "contacts can only be merged by users who can update both of them")
+	}
+
+	return nil
+}
+
+type mergeField struct {
+	get func(c domain.Contact) string
+	set func(c *domain.Contact, value string)
+}
+
+// mergeFields are the fields whose value is chosen by the merge rules, email and phone are the primary ones
+var mergeFields = map[string]mergeField{
+	"first_name": {
+		get: func(c domain.Contact) string { return c.FirstName },
+		set: func(c *domain.Contact, value string) { c.FirstName = value },
+	},
+	"last_name": {
+		get: func(c domain.Contact) string { return c.LastName },
+		set: func(c *domain.Contact, value string) { c.LastName = value },
+	},
+	"email": {
+		get: func(c domain.Contact) string { return c.Email },
+		set: (*domain.Contact).SetEmail,
+	},
+	"phone": {
+		get: func(c domain.Contact) string { return c.Phone },
+		set: (*domain.Contact).SetPhone,
+	},
+}
+
+// mergeContacts applies the rules to the fields of the survivor, it also gains the emails, phones and postal
+// addresses of the duplicate it does not have. The grants of the duplicate are only carried over when the merger may
+// share the survivor, being its creator.
+func mergeContacts(survivor domain.Contact, duplicate domain.Contact, merger user.User, rules map[string]MergeRule) domain.Contact {
+	merged := survivor
+	merged.MergeDetails(duplicate)
+	// custom fields are defined by the contact creator, values of another creator may not fit the schema
+	if survivor.CreatedBy == duplicate.CreatedBy {
+		merged.MergeCustomFields(duplicate)
+	}
+	for name, field := range mergeFields {
+		rule, ok := rules[name]
+		if !ok {
+			rule = MergeFillEmpty
+		}
+
+		if value := field.get(duplicate); value != "" && (rule == MergeTakeDuplicate || (rule == MergeFillEmpty && field.get(survivor) == "")) {
+			field.set(&merged, value)
+		}
+	}
+	// the email status follows the primary email, the duplicate one having been checked along with it
+	setEmailStatus(&merged, survivor.Email, map[string]domain.EmailStatus{duplicate.Email: duplicate.EmailStatus})
+	if survivor.CreatedBy == merger.Id() {
+		merged.MergeGrants(duplicate)
+	}
+	merged.MergeGroups(duplicate)
+	merged.MergeRelationships(duplicate)
+	merged.UnrelateFrom(duplicate.Id)
+	merged.UpdatedAt = time.Now().UTC()
+
+	return merged
+}
@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/pkg/user"
+	"github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeContacts(t *testing.T) {
+	t.Parallel()
+
+	merger := user.New(uuid.New(), user.UserTypeAuthenticated)
+	newContacts := func(duplicateCreator uuid.UUID) (*domain.Contact, *domain.Contact) {
+		survivor := domain.New(merger.Id())
+		survivor.FirstName = "John"
+		survivor.LastName = "Doe"
+		survivor.Email = "jdoe@contact.local"
+
+		duplicate := domain.New(duplicateCreator)
+		duplicate.FirstName = "Johnny"
+		duplicate.LastName = "Doe"
+		duplicate.Email = "johnny@contact.local"
+		duplicate.Phone = "+33612345678"
+		duplicate.Share(merger.Id(), domain.PermissionWrite)
+		return survivor, duplicate
+	}
+	// expectBatch applies the operations on the given contacts, no other contact relates to the duplicate. It returns
+	// the audit entries saved along with the contacts.
+	expectBatch := func(container *container, contacts ...*domain.Contact) *[]*domain.AuditEntry {
+		container.expectNoRelatedContacts()
+		byId := map[uuid.UUID]*domain.Contact{}
+		for _, contact := range contacts {
+			byId[contact.Id] = contact
+		}
+
+		var entries []*domain.AuditEntry
+		container.contactRepo.EXPECT().
+			Batch(gomock.Any(), gomock.Len(2)).
+			Times(1).
+			DoAndReturn(func(_ context.Context, ops []domain.ContactOperation) ([]*domain.Contact, error) {
+				saved := make([]*domain.Contact, 0, len(ops))
+				for _, op := range ops {
+					updated, err := op.UpdateFn(*byId[op.Id])
+					if err != nil {
+						return nil, err
+					}
+					saved = append(saved, &updated)
+				}
+				for _, contact := range saved {
+					entries = append(entries, contact.PullAuditEntries()...)
+				}
+				return saved, nil
+			})
+		return &entries
+	}
+
+	t.Run("merges the duplicate into the survivor", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		container := testContainer(t)
+		container.expectAddressBooks()
+		otherUser := uuid.New()
+		survivor, duplicate := newContacts(otherUser)
+		entries := expectBatch(container, survivor, duplicate)
+
+		merged, err := NewMergeContacts(container.contactRepo, container.addressBookRepo).
+			Merge(ctx, CmdMergeContacts{
+				Merger:      merger,
+				SurvivorId:  survivor.Id.String(),
+				DuplicateId: duplicate.Id.String(),
+				Rules:       map[string]MergeRule{"first_name": MergeTakeDuplicate, "email": MergeKeepSurvivor},
+			})
+		require.NoError(t, err)
+		assert.Equal(t, survivor.Id, merged.Id)
+		assert.Equal(t, "Johnny", merged.FirstName)
+		assert.Equal(t, "jdoe@contact.local", merged.Email)
+		assert.Equal(t, "+33612345678", merged.Phone)
+		// the survivor gains the email of the duplicate it does not have
+		assert.Equal(t, []domain.EmailAddress{
+			{Label: domain.LabelOther, Address: "jdoe@contact.local", Primary: true},
+			{Label: domain.LabelOther, Address: "johnny@contact.local"},
+		}, merged.Emails)
+		assert.Equal(t, []domain.PhoneNumber{{Label: domain.LabelOther, Number: "+33612345678", Primary: true}}, merged.Phones)
+		assert.True(t, merged.CanWrite(otherUser))
+		assert.False(t, merged.IsDeleted())
+
+		require.Len(t, *entries, 2)
+		duplicateEntry, survivorEntry := (*entries)[0], (*entries)[1]
+		assert.Equal(t, domain.AuditActionMerge, survivorEntry.Action)
+		assert.Equal(t, survivor.Id, survivorEntry.ContactId)
+		assert.Contains(t, survivorEntry.Changes, domain.FieldChange{Field: domain.FieldMergedFrom, After: duplicate.Id.String()})
+		assert.Equal(t, domain.AuditActionMerge, duplicateEntry.Action)
+		assert.Equal(t, duplicate.Id, duplicateEntry.ContactId)
+		assert.Contains(t, duplicateEntry.Changes, domain.FieldChange{Field: domain.FieldMergedInto, After: survivor.Id.String()})
+	})
+
+	t.Run("custom fields of contacts of the same creator", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		container := testContainer(t)
+		container.expectAddressBooks()
+		survivor, duplicate := newContacts(merger.Id())
+		survivor.CustomFields = map[string]domain.CustomValue{"tier": {Type: domain.FieldTypeEnum, Text: "gold"}}
+		duplicate.CustomFields = map[string]domain.CustomValue{
+			"tier":   {Type: domain.FieldTypeEnum, Text: "silver"},
+			"rating": {Type: domain.FieldTypeNumber, Number: 4},
+		}
+		expectBatch(container, survivor, duplicate)
+
+		merged, err := NewMergeContacts(container.contactRepo, container.addressBookRepo).
+			Merge(ctx, CmdMergeContacts{
+				Merger:      merger,
+				SurvivorId:  survivor.Id.String(),
+				DuplicateId: duplicate.Id.String(),
+			})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]domain.CustomValue{
+			"tier":   {Type: domain.FieldTypeEnum, Text: "gold"},
+			"rating": {Type: domain.FieldTypeNumber, Number: 4},
+		}, merged.CustomFields)
+	})
+
+	t.Run("grants are only carried over by the survivor creator", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		container := testContainer(t)
+		container.expectAddressBooks()
+		survivorCreator := uuid.New()
+		survivor, duplicate := newContacts(uuid.New())
+		survivor.CreatedBy = survivorCreator
+		survivor.Share(merger.Id(), domain.PermissionWrite)
+		expectBatch(container, survivor, duplicate)
+
+		merged, err := NewMergeContacts(container.contactRepo, container.addressBookRepo).
+			Merge(ctx, CmdMergeContacts{
+				Merger:      merger,
+				SurvivorId:  survivor.Id.String(),
+				DuplicateId: duplicate.Id.String(),
+			})
+		require.NoError(t, err)
+		assert.False(t, merged.CanRead(duplicate.CreatedBy))
+		assert.Equal(t, survivor.Grants, merged.Grants)
+	})
+
+	t.Run("duplicate the merger cannot update", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		container := testContainer(t)
+		container.expectAddressBooks()
+		survivor, duplicate := newContacts(uuid.New())
+		duplicate.Share(merger.Id(), domain.PermissionRead)
+		expectBatch(container, survivor, duplicate)
+
+		_, err := NewMergeContacts(container.contactRepo, container.addressBookRepo).
+			Merge(ctx, CmdMergeContacts{
+				Merger:      merger,
+				SurvivorId:  survivor.Id.String(),
+				DuplicateId: duplicate.Id.String(),
+			})
+		assert.ErrorIs(t, err, ErrForbidden)
+	})
+
+	t.Run("invalid rules", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		merge := NewMergeContacts(container.contactRepo, container.addressBookRepo)
+		id := uuid.NewString()
+
+		for _, cmd := range []CmdMergeContacts{
+			{Merger: merger, SurvivorId: id, DuplicateId: uuid.NewString(), Rules: map[string]MergeRule{"address": MergeTakeDuplicate}},
+			{Merger: merger, SurvivorId: id, DuplicateId: uuid.NewString(), Rules: map[string]MergeRule{"email": "longest"}},
+			{Merger: merger, SurvivorId: id, DuplicateId: id},
+		} {
+			_, err := merge.Merge(context.Background(), cmd)
+			assert.ErrorIs(t, err, ErrInvalidCommand)
+		}
+	})
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/davidterranova/contacts/internal/usecase (interfaces: AddressBookRepository)
+
+// Package usecase is a generated GoMock package.
+package usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/davidterranova/contacts/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockAddressBookRepository is a mock of AddressBookRepository interface.
+type MockAddressBookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAddressBookRepositoryMockRecorder
+}
+
+// MockAddressBookRepositoryMockRecorder is the mock recorder for MockAddressBookRepository.
+type MockAddressBookRepositoryMockRecorder struct {
+	mock *MockAddressBookRepository
+}
+
+// NewMockAddressBookRepository creates a new mock instance.
+func NewMockAddressBookRepository(ctrl *gomock.Controller) *MockAddressBookRepository {
+	mock := &MockAddressBookRepository{ctrl: ctrl}
+	mock.recorder = &MockAddressBookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAddressBookRepository) EXPECT() *MockAddressBookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAddressBookRepository) Create(arg0 context.Context, arg1 *domain.AddressBook) (*domain.AddressBook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*domain.AddressBook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAddressBookRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAddressBookRepository)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockAddressBookRepository) Delete(arg0 context.Context, arg1 uuid.UUID, arg2 func(domain.AddressBook) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAddressBookRepositoryMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAddressBookRepository)(nil).Delete), arg0, arg1, arg2)
+}
+
+// Get mocks base method.
+func (m *MockAddressBookRepository) Get(arg0 context.Context, arg1 uuid.UUID) (*domain.AddressBook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*domain.AddressBook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAddressBookRepositoryMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAddressBookRepository)(nil).Get), arg0, arg1)
+}
+
+// ListByMember mocks base method.
+func (m *MockAddressBookRepository) ListByMember(arg0 context.Context, arg1 uuid.UUID) ([]*domain.AddressBook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByMember", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.AddressBook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByMember indicates an expected call of ListByMember.
+func (mr *MockAddressBookRepositoryMockRecorder) ListByMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByMember", reflect.TypeOf((*MockAddressBookRepository)(nil).ListByMember), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockAddressBookRepository) Update(arg0 context.Context, arg1 uuid.UUID, arg2 func(domain.AddressBook) (domain.AddressBook, error)) (*domain.AddressBook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.AddressBook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAddressBookRepositoryMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAddressBookRepository)(nil).Update), arg0, arg1, arg2)
+}
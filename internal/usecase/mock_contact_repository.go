@@ -7,6 +7,7 @@ package usecase
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/davidterranova/contacts/internal/domain"
 	gomock "github.com/golang/mock/gomock"
@@ -36,6 +37,21 @@ func (m *MockContactRepository) EXPECT() *MockContactRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Batch mocks base method.
+func (m *MockContactRepository) Batch(arg0 context.Context, arg1 []domain.ContactOperation) ([]*domain.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Batch", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Batch indicates an expected call of Batch.
+func (mr *MockContactRepositoryMockRecorder) Batch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Batch", reflect.TypeOf((*MockContactRepository)(nil).Batch), arg0, arg1)
+}
+
 // Create mocks base method.
 func (m *MockContactRepository) Create(arg0 context.Context, arg1 *domain.Contact) (*domain.Contact, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +81,21 @@ func (mr *MockContactRepositoryMockRecorder) Delete(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockContactRepository)(nil).Delete), arg0, arg1, arg2)
 }
 
+// Get mocks base method.
+func (m *MockContactRepository) Get(arg0 context.Context, arg1 uuid.UUID) (*domain.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockContactRepositoryMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockContactRepository)(nil).Get), arg0, arg1)
+}
+
 // List mocks base method.
 func (m *MockContactRepository) List(arg0 context.Context, arg1 domain.Filter) ([]*domain.Contact, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +111,21 @@ func (mr *MockContactRepositoryMockRecorder) List(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockContactRepository)(nil).List), arg0, arg1)
 }
 
+// Purge mocks base method.
+func (m *MockContactRepository) Purge(arg0 context.Context, arg1 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockContactRepositoryMockRecorder) Purge(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockContactRepository)(nil).Purge), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *MockContactRepository) Update(arg0 context.Context, arg1 uuid.UUID, arg2 func(domain.Contact) (domain.Contact, error)) (*domain.Contact, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/davidterranova/contacts/internal/usecase (interfaces: ContactSchemaRepository)
+
+// Package usecase is a generated GoMock package.
+package usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/davidterranova/contacts/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockContactSchemaRepository is a mock of ContactSchemaRepository interface.
+type MockContactSchemaRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockContactSchemaRepositoryMockRecorder
+}
+
+// MockContactSchemaRepositoryMockRecorder is the mock recorder for MockContactSchemaRepository.
+type MockContactSchemaRepositoryMockRecorder struct {
+	mock *MockContactSchemaRepository
+}
+
+// NewMockContactSchemaRepository creates a new mock instance.
+func NewMockContactSchemaRepository(ctrl *gomock.Controller) *MockContactSchemaRepository {
+	mock := &MockContactSchemaRepository{ctrl: ctrl}
+	mock.recorder = &MockContactSchemaRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContactSchemaRepository) EXPECT() *MockContactSchemaRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockContactSchemaRepository) Get(arg0 context.Context, arg1 uuid.UUID) (*domain.ContactSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*domain.ContactSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockContactSchemaRepositoryMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockContactSchemaRepository)(nil).Get), arg0, arg1)
+}
+
+// Save mocks base method.
+func (m *MockContactSchemaRepository) Save(arg0 context.Context, arg1 *domain.ContactSchema) (*domain.ContactSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(*domain.ContactSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockContactSchemaRepositoryMockRecorder) Save(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockContactSchemaRepository)(nil).Save), arg0, arg1)
+}
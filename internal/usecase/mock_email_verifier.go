@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/davidterranova/contacts/internal/usecase (interfaces: DomainResolver)
+
+// Package usecase is a generated GoMock package.
+package usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDomainResolver is a mock of DomainResolver interface.
+type MockDomainResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockDomainResolverMockRecorder
+}
+
+// MockDomainResolverMockRecorder is the mock recorder for MockDomainResolver.
+type MockDomainResolverMockRecorder struct {
+	mock *MockDomainResolver
+}
+
+// NewMockDomainResolver creates a new mock instance.
+func NewMockDomainResolver(ctrl *gomock.Controller) *MockDomainResolver {
+	mock := &MockDomainResolver{ctrl: ctrl}
+	mock.recorder = &MockDomainResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDomainResolver) EXPECT() *MockDomainResolverMockRecorder {
+	return m.recorder
+}
+
+// Resolve mocks base method.
+func (m *MockDomainResolver) Resolve(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockDomainResolverMockRecorder) Resolve(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockDomainResolver)(nil).Resolve), arg0, arg1)
+}
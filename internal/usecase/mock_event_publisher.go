@@ -0,0 +1,135 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/davidterranova/contacts/internal/usecase (interfaces: EventPublisher,EventOutbox)
+
+// Package usecase is a generated GoMock package.
+package usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/davidterranova/contacts/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockEventPublisher is a mock of EventPublisher interface.
+type MockEventPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventPublisherMockRecorder
+}
+
+// MockEventPublisherMockRecorder is the mock recorder for MockEventPublisher.
+type MockEventPublisherMockRecorder struct {
+	mock *MockEventPublisher
+}
+
+// NewMockEventPublisher creates a new mock instance.
+func NewMockEventPublisher(ctrl *gomock.Controller) *MockEventPublisher {
+	mock := &MockEventPublisher{ctrl: ctrl}
+	mock.recorder = &MockEventPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventPublisher) EXPECT() *MockEventPublisherMockRecorder {
+	return m.recorder
+}
+
+// Forget mocks base method.
+func (m *MockEventPublisher) Forget(arg0 context.Context, arg1 uuid.UUID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Forget", arg0, arg1)
+}
+
+// Forget indicates an expected call of Forget.
+func (mr *MockEventPublisherMockRecorder) Forget(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Forget", reflect.TypeOf((*MockEventPublisher)(nil).Forget), arg0, arg1)
+}
+
+// Publish mocks base method.
+func (m *MockEventPublisher) Publish(arg0 context.Context, arg1 domain.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockEventPublisherMockRecorder) Publish(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockEventPublisher)(nil).Publish), arg0, arg1)
+}
+
+// MockEventOutbox is a mock of EventOutbox interface.
+type MockEventOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventOutboxMockRecorder
+}
+
+// MockEventOutboxMockRecorder is the mock recorder for MockEventOutbox.
+type MockEventOutboxMockRecorder struct {
+	mock *MockEventOutbox
+}
+
+// NewMockEventOutbox creates a new mock instance.
+func NewMockEventOutbox(ctrl *gomock.Controller) *MockEventOutbox {
+	mock := &MockEventOutbox{ctrl: ctrl}
+	mock.recorder = &MockEventOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventOutbox) EXPECT() *MockEventOutboxMockRecorder {
+	return m.recorder
+}
+
+// MarkFailed mocks base method.
+func (m *MockEventOutbox) MarkFailed(arg0 context.Context, arg1 uuid.UUID, arg2 string, arg3 int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockEventOutboxMockRecorder) MarkFailed(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockEventOutbox)(nil).MarkFailed), arg0, arg1, arg2, arg3)
+}
+
+// MarkPublished mocks base method.
+func (m *MockEventOutbox) MarkPublished(arg0 context.Context, arg1 ...uuid.UUID) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MarkPublished", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkPublished indicates an expected call of MarkPublished.
+func (mr *MockEventOutboxMockRecorder) MarkPublished(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPublished", reflect.TypeOf((*MockEventOutbox)(nil).MarkPublished), varargs...)
+}
+
+// Pending mocks base method.
+func (m *MockEventOutbox) Pending(arg0 context.Context, arg1 int) ([]domain.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pending", arg0, arg1)
+	ret0, _ := ret[0].([]domain.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pending indicates an expected call of Pending.
+func (mr *MockEventOutboxMockRecorder) Pending(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pending", reflect.TypeOf((*MockEventOutbox)(nil).Pending), arg0, arg1)
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-playground/validator"
+	uuid "github.com/google/uuid"
+)
+
+type CmdPublishEvents struct {
+	// BatchSize is the maximum number of events read from the outbox at once
+	BatchSize int `validate:"min=1,max=1000"`
+	// MaxAttempts is the number of failed publications after which an event is dead
+	MaxAttempts int `validate:"min=1"`
+}
+
+// PublishEventsHandler relays the outbox events to the publisher in order. An event is marked published
+// once the publisher accepted it, so events are delivered at least once. An event the publisher fails stays in the
+// outbox and is retried on the next run, it does not hold the later events back. Once it failed MaxAttempts times
+// the event is dead: it is kept in the outbox but no longer published, and the publisher forgets it.
+type PublishEventsHandler struct {
+	outbox    EventOutbox
+	publisher EventPublisher
+	validator *validator.Validate
+}
+
+func NewPublishEvents(outbox EventOutbox, publisher EventPublisher) PublishEventsHandler {
+	return PublishEventsHandler{
+		outbox:    outbox,
+		publisher: publisher,
+		validator: validator.New(),
+	}
+}
+
+// Publish empties the outbox and returns the number of published events. Each event is tried once per run, the
+// failed ones are left pending and reported once the other events are published.
+func (h PublishEventsHandler) Publish(ctx context.Context, cmd CmdPublishEvents) (int, error) {
+	err := h.validator.Struct(cmd)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	published := 0
+	failed := make(map[uuid.UUID]bool)
+	var errs []error
+	for {
+		// the failed events are still pending, the batch is read beyond them
+		limit := cmd.BatchSize + len(failed)
+		events, err := h.outbox.Pending(ctx, limit)
+		if err != nil {
+			return published, fmt.Errorf("%w: %s", ErrInternal, err)
+		}
+
+		for _, event := range events {
+			if failed[event.EventId()] {
+				continue
+			}
+
+			err = h.publisher.Publish(ctx, event)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to publish event %s: %w", event.EventId(), err))
+				dead, err := h.outbox.MarkFailed(ctx, event.EventId(), err.Error(), cmd.MaxAttempts)
+				if err != nil {
+					return published, fmt.Errorf("%w: %s", ErrInternal, err)
+				}
+				if dead {
+					h.publisher.Forget(ctx, event.EventId())
+				} else {
+					failed[event.EventId()] = true
+				}
+				continue
+			}
+
+			err = h.outbox.MarkPublished(ctx, event.EventId())
+			if err != nil {
+				return published, fmt.Errorf("%w: %s", ErrInternal, err)
+			}
+			published++
+		}
+
+		if len(events) < limit {
+			break
+		}
+	}
+
+	if len(errs) > 0 {
+		return published, fmt.Errorf("%w: %s", ErrInternal, errors.Join(errs...))
+	}
+
+	return published, nil
+}
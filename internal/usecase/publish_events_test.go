@@ -0,0 +1,211 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/pkg/user"
+	"github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordedEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	owner := user.New(uuid.New(), user.UserTypeAuthenticated)
+
+	t.Run("create records a contact created event", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.expectSchema(nil)
+		container.expectAddressBooks()
+		var events []domain.Event
+		container.contactRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, c *domain.Contact) (*domain.Contact, error) {
+				events = c.PullEvents()
+				return c, nil
+			})
+
+		contact, err := NewCreateContact(container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails).
+			Create(ctx, CmdCreateContact{CreatedBy: owner, FirstName: "John", LastName: "Doe", Email: "jdoe@contact.local", Phone: "+33612345678"})
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		created, ok := events[0].(domain.ContactCreated)
+		require.True(t, ok)
+		assert.Equal(t, contact.Id, created.AggregateId())
+		assert.Equal(t, "John", created.Contact.FirstName)
+	})
+
+	t.Run("update records the changed fields", func(t *testing.T) {
+		t.Parallel()
+
+		contact := domain.New(owner.Id())
+		contact.FirstName = "John"
+		container := testContainer(t)
+		container.expectSchema(nil)
+		container.expectAddressBooks()
+		var events []domain.Event
+		container.contactRepo.EXPECT().
+			Update(ctx, contact.Id, gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, _ uuid.UUID, updateFn func(c domain.Contact) (domain.Contact, error)) (*domain.Contact, error) {
+				updated, err := updateFn(*contact)
+				events = updated.PullEvents()
+				return &updated, err
+			})
+
+		_, err := NewUpdateContact(container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails).
+			Update(ctx, CmdUpdateContact{Updater: owner, ContactId: contact.Id.String(), FirstName: "Jane"})
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		updated, ok := events[0].(domain.ContactUpdated)
+		require.True(t, ok)
+		assert.Equal(t, []domain.FieldChange{{Field: "first_name", Before: "John", After: "Jane"}}, updated.Changes)
+	})
+
+	t.Run("delete records a contact deleted event", func(t *testing.T) {
+		t.Parallel()
+
+		contact := domain.New(owner.Id())
+		container := testContainer(t)
+		container.expectSchema(nil)
+		container.expectAddressBooks()
+		container.expectNoRelatedContacts()
+		var events []domain.Event
+		container.contactRepo.EXPECT().
+			Update(ctx, contact.Id, gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, _ uuid.UUID, updateFn func(c domain.Contact) (domain.Contact, error)) (*domain.Contact, error) {
+				updated, err := updateFn(*contact)
+				events = updated.PullEvents()
+				return &updated, err
+			})
+
+		err := NewDeleteContact(container.contactRepo, container.addressBookRepo).
+			Delete(ctx, CmdDeleteContact{Deleter: owner, ContactId: contact.Id.String()})
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, domain.EventContactDeleted, events[0].EventName())
+		assert.Equal(t, contact.Id, events[0].AggregateId())
+	})
+}
+
+func TestPublishEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	contactId := uuid.New()
+	first := domain.NewContactDeleted(contactId)
+	second := domain.NewContactUpdated(contactId, nil)
+	third := domain.NewContactDeleted(contactId)
+	errPublish := errors.New("broker unavailable")
+
+	t.Run("publish the pending events in batches", func(t *testing.T) {
+		t.Parallel()
+
+		controller := gomock.NewController(t)
+		outbox := NewMockEventOutbox(controller)
+		publisher := NewMockEventPublisher(controller)
+		gomock.InOrder(
+			outbox.EXPECT().Pending(ctx, 2).Return([]domain.Event{first, second}, nil),
+			publisher.EXPECT().Publish(ctx, first).Return(nil),
+			outbox.EXPECT().MarkPublished(ctx, first.EventId()).Return(nil),
+			publisher.EXPECT().Publish(ctx, second).Return(nil),
+			outbox.EXPECT().MarkPublished(ctx, second.EventId()).Return(nil),
+			outbox.EXPECT().Pending(ctx, 2).Return([]domain.Event{third}, nil),
+			publisher.EXPECT().Publish(ctx, third).Return(nil),
+			outbox.EXPECT().MarkPublished(ctx, third.EventId()).Return(nil),
+		)
+
+		published, err := NewPublishEvents(outbox, publisher).Publish(ctx, CmdPublishEvents{BatchSize: 2, MaxAttempts: 3})
+		require.NoError(t, err)
+		assert.Equal(t, 3, published)
+	})
+
+	t.Run("a failing event does not hold the later ones back", func(t *testing.T) {
+		t.Parallel()
+
+		controller := gomock.NewController(t)
+		outbox := NewMockEventOutbox(controller)
+		publisher := NewMockEventPublisher(controller)
+		gomock.InOrder(
+			outbox.EXPECT().Pending(ctx, 10).Return([]domain.Event{first, second, third}, nil),
+			publisher.EXPECT().Publish(ctx, first).Return(nil),
+			outbox.EXPECT().MarkPublished(ctx, first.EventId()).Return(nil),
+			publisher.EXPECT().Publish(ctx, second).Return(errPublish),
+			outbox.EXPECT().MarkFailed(ctx, second.EventId(), "broker unavailable", 3).Return(false, nil),
+			publisher.EXPECT().Publish(ctx, third).Return(nil),
+			outbox.EXPECT().MarkPublished(ctx, third.EventId()).Return(nil),
+		)
+
+		published, err := NewPublishEvents(outbox, publisher).Publish(ctx, CmdPublishEvents{BatchSize: 10, MaxAttempts: 3})
+		assert.ErrorIs(t, err, ErrInternal)
+		assert.ErrorContains(t, err, second.EventId().String())
+		assert.Equal(t, 2, published)
+	})
+
+	t.Run("read the batches beyond the failed events", func(t *testing.T) {
+		t.Parallel()
+
+		controller := gomock.NewController(t)
+		outbox := NewMockEventOutbox(controller)
+		publisher := NewMockEventPublisher(controller)
+		gomock.InOrder(
+			outbox.EXPECT().Pending(ctx, 2).Return([]domain.Event{first, second}, nil),
+			publisher.EXPECT().Publish(ctx, first).Return(errPublish),
+			outbox.EXPECT().MarkFailed(ctx, first.EventId(), "broker unavailable", 3).Return(false, nil),
+			publisher.EXPECT().Publish(ctx, second).Return(nil),
+			outbox.EXPECT().MarkPublished(ctx, second.EventId()).Return(nil),
+			// the failed event is still pending, it is not tried twice in a run
+			outbox.EXPECT().Pending(ctx, 3).Return([]domain.Event{first, third}, nil),
+			publisher.EXPECT().Publish(ctx, third).Return(nil),
+			outbox.EXPECT().MarkPublished(ctx, third.EventId()).Return(nil),
+		)
+
+		published, err := NewPublishEvents(outbox, publisher).Publish(ctx, CmdPublishEvents{BatchSize: 2, MaxAttempts: 3})
+		assert.ErrorIs(t, err, ErrInternal)
+		assert.Equal(t, 2, published)
+	})
+
+	t.Run("give up on an event failing too many times", func(t *testing.T) {
+		t.Parallel()
+
+		controller := gomock.NewController(t)
+		outbox := NewMockEventOutbox(controller)
+		publisher := NewMockEventPublisher(controller)
+		gomock.InOrder(
+			outbox.EXPECT().Pending(ctx, 2).Return([]domain.Event{first, second}, nil),
+			publisher.EXPECT().Publish(ctx, first).Return(errPublish),
+			outbox.EXPECT().MarkFailed(ctx, first.EventId(), "broker unavailable", 3).Return(true, nil),
+			publisher.EXPECT().Forget(ctx, first.EventId()),
+			publisher.EXPECT().Publish(ctx, second).Return(nil),
+			outbox.EXPECT().MarkPublished(ctx, second.EventId()).Return(nil),
+			// the dead event is no longer pending, the batch is not read beyond it
+			outbox.EXPECT().Pending(ctx, 2).Return([]domain.Event{third}, nil),
+			publisher.EXPECT().Publish(ctx, third).Return(nil),
+			outbox.EXPECT().MarkPublished(ctx, third.EventId()).Return(nil),
+		)
+
+		published, err := NewPublishEvents(outbox, publisher).Publish(ctx, CmdPublishEvents{BatchSize: 2, MaxAttempts: 3})
+		assert.ErrorIs(t, err, ErrInternal)
+		assert.ErrorContains(t, err, first.EventId().String())
+		assert.Equal(t, 2, published)
+	})
+
+	t.Run("invalid batch size", func(t *testing.T) {
+		t.Parallel()
+
+		controller := gomock.NewController(t)
+		_, err := NewPublishEvents(NewMockEventOutbox(controller), NewMockEventPublisher(controller)).
+			Publish(ctx, CmdPublishEvents{})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator"
+)
+
+type CmdPurgeTrash struct {
+	// Retention is how long deleted contacts are kept in the trash
+	Retention time.Duration `validate:"min=0"`
+}
+
+// PurgeTrashHandler permanently removes the contacts that have been in the trash for longer than the retention period
+type PurgeTrashHandler struct {
+	repo      ContactRepository
+	validator *validator.Validate
+}
+
+func NewPurgeTrash(repo ContactRepository) PurgeTrashHandler {
+	return PurgeTrashHandler{
+		repo:      repo,
+		validator: validator.New(),
+	}
+}
+
+func (h PurgeTrashHandler) Purge(ctx context.Context, cmd CmdPurgeTrash) (int, error) {
+	err := h.validator.Struct(cmd)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	purged, err := h.repo.Purge(ctx, time.Now().UTC().Add(-cmd.Retention))
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrInternal, err)
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/davidterranova/contacts/pkg/user"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/go-playground/validator"
+	uuid "github.com/google/uuid"
+)
+
+type CmdRestoreContact struct {
+	Restorer  user.User `validate:"required"`
+	ContactId string    `validate:"required,uuid"`
+}
+
+// RestoreContactHandler takes a contact out of the trash, it requires the same access as deleting it
+type RestoreContactHandler struct {
+	repo      ContactRepository
+	books     AddressBookRepository
+	validator *validator.Validate
+}
+
+func NewRestoreContact(repo ContactRepository, books AddressBookRepository) RestoreContactHandler {
+	return RestoreContactHandler{
+		repo:      repo,
+		books:     books,
+		validator: validator.New(),
+	}
+}
+
+func (h RestoreContactHandler) Restore(ctx context.Context, cmd CmdRestoreContact) (*domain.Contact, error) {
+	err := h.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	contactUUID, err := uuid.Parse(cmd.ContactId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	access, err := loadContactAccess(ctx, h.books, cmd.Restorer)
+	if err != nil {
+		return nil, err
+	}
+
+	return handleRepositoryError(h.repo.Update(ctx, contactUUID, func(c domain.Contact) (domain.Contact, error) {
+		if !access.canWrite(c) {
+			return c, fmt.Errorf("%w: %s", ErrForbidden, "contact can only be restored by its creator, users it is shared with for writing and its address book editors")
+		}
+		if !c.IsDeleted() {
+			return c, fmt.Errorf("%w: %s", ErrInvalidCommand, "contact is not in the trash")
+		}
+
+		restored := c
+		restored.Restore()
+		restored.Record(domain.NewContactUpdated(c.Id, domain.Diff(c, restored)))
+		restored.Audit(domain.NewAuditEntry(cmd.Restorer, domain.AuditActionRestore, c, restored))
+		return restored, nil
+	}))
+}
@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/pkg/user"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrashContact(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	owner := user.New(uuid.New(), user.UserTypeAuthenticated)
+	other := user.New(uuid.New(), user.UserTypeAuthenticated)
+
+	trashedContact := func() *domain.Contact {
+		contact := domain.New(owner.Id())
+		contact.Trash()
+		return contact
+	}
+
+	testCases := []struct {
+		name          string
+		contact       *domain.Contact
+		run           func(container *container, contactId string) error
+		expectedError error
+		// expectDeleted tells whether the contact saved by the repository is in the trash
+		expectDeleted bool
+	}{
+		{
+			name:    "delete moves the contact to the trash",
+			contact: domain.New(owner.Id()),
+			run: func(container *container, contactId string) error {
+				return NewDeleteContact(container.contactRepo, container.addressBookRepo).
+					Delete(ctx, CmdDeleteContact{Deleter: owner, ContactId: contactId})
+			},
+			expectDeleted: true,
+		},
+		{
+			name:    "delete at a stale version",
+			contact: domain.New(owner.Id()),
+			run: func(container *container, contactId string) error {
+				return NewDeleteContact(container.contactRepo, container.addressBookRepo).
+					Delete(ctx, CmdDeleteContact{Deleter: owner, ContactId: contactId, Version: 2})
+			},
+			expectedError: ErrConflict,
+		},
+		{
+			name:    "delete a contact already in the trash",
+			contact: trashedContact(),
+			run: func(container *container, contactId string) error {
+				return NewDeleteContact(container.contactRepo, container.addressBookRepo).
+					Delete(ctx, CmdDeleteContact{Deleter: owner, ContactId: contactId})
+			},
+			expectedError: ErrNotFound,
+		},
+		{
+			name:    "restore",
+			contact: trashedContact(),
+			run: func(container *container, contactId string) error {
+				_, err := NewRestoreContact(container.contactRepo, container.addressBookRepo).
+					Restore(ctx, CmdRestoreContact{Restorer: owner, ContactId: contactId})
+				return err
+			},
+		},
+		{
+			name:    "restore a contact which is not in the trash",
+			contact: domain.New(owner.Id()),
+			run: func(container *container, contactId string) error {
+				_, err := NewRestoreContact(container.contactRepo, container.addressBookRepo).
+					Restore(ctx, CmdRestoreContact{Restorer: owner, ContactId: contactId})
+				return err
+			},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name:    "restore by an unauthorized user",
+			contact: trashedContact(),
+			run: func(container *container, contactId string) error {
+				_, err := NewRestoreContact(container.contactRepo, container.addressBookRepo).
+					Restore(ctx, CmdRestoreContact{Restorer: other, ContactId: contactId})
+				return err
+			},
+			expectedError: ErrForbidden,
+		},
+		{
+			name:    "update a contact in the trash",
+			contact: trashedContact(),
+			run: func(container *container, contactId string) error {
+				_, err := NewUpdateContact(container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails).
+					Update(ctx, CmdUpdateContact{Updater: owner, ContactId: contactId, FirstName: "Jane"})
+				return err
+			},
+			expectedError: ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var saved *domain.Contact
+			container := testContainer(t)
+			container.expectAddressBooks()
+			container.expectNoRelatedContacts()
+			container.contactRepo.EXPECT().
+				Update(ctx, tc.contact.Id, gomock.Any()).
+				MaxTimes(1).
+				DoAndReturn(func(_ context.Context, _ uuid.UUID, updateFn func(c domain.Contact) (domain.Contact, error)) (*domain.Contact, error) {
+					updated, err := updateFn(*tc.contact)
+					if err != nil {
+						return nil, err
+					}
+					saved = &updated
+					return saved, nil
+				})
+
+			err := tc.run(container, tc.contact.Id.String())
+			assert.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError != nil {
+				return
+			}
+
+			require.NotNil(t, saved)
+			assert.Equal(t, tc.expectDeleted, saved.IsDeleted())
+		})
+	}
+}
+
+func TestGetTrashedContact(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	owner := user.New(uuid.New(), user.UserTypeAuthenticated)
+	contact := domain.New(owner.Id())
+	contact.Trash()
+
+	container := testContainer(t)
+	container.expectAddressBooks()
+	container.contactRepo.EXPECT().
+		Get(ctx, contact.Id).
+		Return(contact, nil)
+
+	_, err := NewGetContact(container.contactRepo, container.addressBookRepo).
+		Get(ctx, QueryGetContact{Requester: owner, ContactId: contact.Id.String()})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestPurgeTrash(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	container := testContainer(t)
+	container.contactRepo.EXPECT().
+		Purge(ctx, gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, deletedBefore time.Time) (int, error) {
+			assert.WithinDuration(t, time.Now().Add(-24*time.Hour), deletedBefore, time.Minute)
+			return 3, nil
+		})
+
+	purged, err := NewPurgeTrash(container.contactRepo).Purge(ctx, CmdPurgeTrash{Retention: 24 * time.Hour})
+	require.NoError(t, err)
+	assert.Equal(t, 3, purged)
+
+	_, err = NewPurgeTrash(container.contactRepo).Purge(ctx, CmdPurgeTrash{Retention: -time.Hour})
+	assert.ErrorIs(t, err, ErrInvalidCommand)
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/davidterranova/contacts/pkg/user"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/go-playground/validator"
+	uuid "github.com/google/uuid"
+)
+
+type CmdShareContact struct {
+	Sharer     user.User `validate:"required"`
+	ContactId  string    `validate:"required,uuid"`
+	UserId     string    `validate:"required,uuid"`
+	Permission string    `validate:"required,oneof=read write"`
+}
+
+type CmdUnshareContact struct {
+	Sharer    user.User `validate:"required"`
+	ContactId string    `validate:"required,uuid"`
+	UserId    string    `validate:"required,uuid"`
+}
+
+// ShareContactHandler grants and revokes access to a contact, only its creator may share it
+type ShareContactHandler struct {
+	repo      ContactRepository
+	validator *validator.Validate
+}
+
+func NewShareContact(repo ContactRepository) ShareContactHandler {
+	return ShareContactHandler{
+		repo:      repo,
+		validator: validator.New(),
+	}
+}
+
+func (h ShareContactHandler) Share(ctx context.Context, cmd CmdShareContact) (*domain.Contact, error) {
+	err := h.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	contactUUID, userUUID, err := parseShareIds(cmd.ContactId, cmd.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	contact, err := h.repo.Update(ctx, contactUUID, func(c domain.Contact) (domain.Contact, error) {
+		if c.IsDeleted() {
+			return c, errTrashed(c)
+		}
+		if c.CreatedBy != cmd.Sharer.Id() {
+			return c, fmt.Errorf("%w: %s", ErrForbidden, "contact can only be shared by its creator")
+		}
+		if c.CreatedBy == userUUID {
+			return c, fmt.Errorf("%w: %s", ErrInvalidCommand, "contact cannot be shared with its creator")
+		}
+
+		shared := c
+		shared.Share(userUUID, domain.Permission(cmd.Permission))
+		shared.Audit(domain.NewAuditEntry(cmd.Sharer, domain.AuditActionShare, c, shared))
+		return shared, nil
+	})
+
+	return handleRepositoryError(contact, err)
+}
+
+func (h ShareContactHandler) Unshare(ctx context.Context, cmd CmdUnshareContact) (*domain.Contact, error) {
+	err := h.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	contactUUID, userUUID, err := parseShareIds(cmd.ContactId, cmd.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	contact, err := h.repo.Update(ctx, contactUUID, func(c domain.Contact) (domain.Contact, error) {
+		if c.IsDeleted() {
+			return c, errTrashed(c)
+		}
+		if c.CreatedBy != cmd.Sharer.Id() {
+			return c, fmt.Errorf("%w: %s", ErrForbidden, "contact can only be unshared by its creator")
+		}
+		unshared := c
+		if !unshared.Unshare(userUUID) {
+			return c, fmt.Errorf("%w: contact is not shared with user %s", ErrNotFound, userUUID)
+		}
+
+		unshared.Audit(domain.NewAuditEntry(cmd.Sharer, domain.AuditActionUnshare, c, unshared))
+		return unshared, nil
+	})
+
+	return handleRepositoryError(contact, err)
+}
+
+func parseShareIds(contactId string, userId string) (uuid.UUID, uuid.UUID, error) {
+	contactUUID, err := uuid.Parse(contactId)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	userUUID, err := uuid.Parse(userId)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	return contactUUID, userUUID, nil
+}
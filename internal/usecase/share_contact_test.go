@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/pkg/user"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareContact(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	owner := user.New(uuid.New(), user.UserTypeAuthenticated)
+	other := user.New(uuid.New(), user.UserTypeAuthenticated)
+
+	testCases := []struct {
+		name          string
+		cmd           CmdShareContact
+		expectedError error
+	}{
+		{
+			name: "share for reading",
+			cmd:  CmdShareContact{Sharer: owner, UserId: other.Id().String(), Permission: "read"},
+		},
+		{
+			name: "share for writing",
+			cmd:  CmdShareContact{Sharer: owner, UserId: other.Id().String(), Permission: "write"},
+		},
+		{
+			name:          "invalid command: unknown permission",
+			cmd:           CmdShareContact{Sharer: owner, UserId: other.Id().String(), Permission: "admin"},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name:          "invalid command: share with the creator",
+			cmd:           CmdShareContact{Sharer: owner, UserId: owner.Id().String(), Permission: "read"},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name:          "only the creator can share",
+			cmd:           CmdShareContact{Sharer: other, UserId: other.Id().String(), Permission: "write"},
+			expectedError: ErrForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			contact := domain.New(owner.Id())
+			tc.cmd.ContactId = contact.Id.String()
+			container := testContainer(t)
+			sharer := NewShareContact(container.contactRepo)
+
+			container.contactRepo.EXPECT().
+				Update(ctx, contact.Id, gomock.Any()).
+				MaxTimes(1).
+				DoAndReturn(func(_ context.Context, _ uuid.UUID, updateFn func(c domain.Contact) (domain.Contact, error)) (*domain.Contact, error) {
+					updated, err := updateFn(*contact)
+					if err != nil {
+						return nil, err
+					}
+					return &updated, nil
+				})
+
+			shared, err := sharer.Share(ctx, tc.cmd)
+			assert.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError != nil {
+				return
+			}
+
+			require.Len(t, shared.Grants, 1)
+			assert.True(t, shared.CanRead(other.Id()))
+			assert.Equal(t, tc.cmd.Permission == "write", shared.CanWrite(other.Id()))
+			assert.Empty(t, contact.Grants)
+		})
+	}
+}
+
+func TestUnshareContact(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	owner := user.New(uuid.New(), user.UserTypeAuthenticated)
+	other := user.New(uuid.New(), user.UserTypeAuthenticated)
+
+	testCases := []struct {
+		name          string
+		cmd           CmdUnshareContact
+		expectedError error
+	}{
+		{
+			name: "unshare",
+			cmd:  CmdUnshareContact{Sharer: owner, UserId: other.Id().String()},
+		},
+		{
+			name:          "contact not shared with user",
+			cmd:           CmdUnshareContact{Sharer: owner, UserId: uuid.NewString()},
+			expectedError: ErrNotFound,
+		},
+		{
+			name:          "only the creator can unshare",
+			cmd:           CmdUnshareContact{Sharer: other, UserId: other.Id().String()},
+			expectedError: ErrForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			contact := domain.New(owner.Id())
+			contact.Share(other.Id(), domain.PermissionWrite)
+			tc.cmd.ContactId = contact.Id.String()
+			container := testContainer(t)
+			sharer := NewShareContact(container.contactRepo)
+
+			container.contactRepo.EXPECT().
+				Update(ctx, contact.Id, gomock.Any()).
+				Times(1).
+				DoAndReturn(func(_ context.Context, _ uuid.UUID, updateFn func(c domain.Contact) (domain.Contact, error)) (*domain.Contact, error) {
+					updated, err := updateFn(*contact)
+					if err != nil {
+						return nil, err
+					}
+					return &updated, nil
+				})
+
+			unshared, err := sharer.Unshare(ctx, tc.cmd)
+			assert.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError == nil {
+				assert.False(t, unshared.CanRead(other.Id()))
+			}
+		})
+	}
+}
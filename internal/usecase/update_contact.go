@@ -18,44 +18,125 @@ type CmdUpdateContact struct {
 	FirstName string    `validate:"omitempty,min=2,max=255"`
 	LastName  string    `validate:"omitempty,min=2,max=255"`
 	Email     string    `validate:"omitempty,email"`
-	Phone     string    `validate:"omitempty,e164"` // https://en.wikipedia.org/wiki/E.164
+	Phone     string    `validate:"omitempty,max=32"`
+	// PhoneRegion is the region phones without international prefix are read in, the default phone region of the
+	// contact creator when empty
+	PhoneRegion string `validate:"omitempty,len=2,alpha"`
+	// Emails, Phones and Addresses replace the lists of the contact when they are not empty, Email and Phone are
+	// then made primary
+	Emails    []ContactEmail   `validate:"max=20,dive"`
+	Phones    []ContactPhone   `validate:"max=20,dive"`
+	Addresses []ContactAddress `validate:"max=20,dive"`
+	// CustomFields are set on the contact, a nil value removes the field, they are checked against the schema of the
+	// contact creator
+	CustomFields map[string]any `validate:"max=50"`
+	// Version is the expected contact version, 0 updates whatever the current version
+	Version int `validate:"min=0"`
 }
 
 type UpdateContact struct {
 	repo      ContactRepository
+	books     AddressBookRepository
+	schemas   ContactSchemaRepository
+	emails    EmailVerifier
 	validator *validator.Validate
 }
 
-func NewUpdateContact(repo ContactRepository) UpdateContact {
+func NewUpdateContact(repo ContactRepository, books AddressBookRepository, schemas ContactSchemaRepository, emails EmailVerifier) UpdateContact {
 	return UpdateContact{
 		repo:      repo,
+		books:     books,
+		schemas:   schemas,
+		emails:    emails,
 		validator: validator.New(),
 	}
 }
 
 func (h UpdateContact) Update(ctx context.Context, cmd CmdUpdateContact) (*domain.Contact, error) {
+	contactUUID, err := h.contactId(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	access, err := loadContactAccess(ctx, h.books, cmd.Updater)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := h.schema(ctx, contactUUID, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := h.emails.statuses(ctx, commandEmails(cmd.Email, cmd.Emails)...)
+	return handleRepositoryError(h.repo.Update(ctx, contactUUID, updater(cmd, access, schema, statuses)))
+}
+
+// contactId validates the command and returns the id of the contact to update
+func (h UpdateContact) contactId(cmd CmdUpdateContact) (uuid.UUID, error) {
 	err := h.validator.Struct(cmd)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+		return uuid.Nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+	err = h.emails.check(commandEmails(cmd.Email, cmd.Emails)...)
+	if err != nil {
+		return uuid.Nil, err
 	}
 
 	contactUUID, err := uuid.Parse(cmd.ContactId)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+		return uuid.Nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
 	}
 
-	contact, err := h.repo.Update(ctx, contactUUID, func(c domain.Contact) (domain.Contact, error) {
-		return updateContactFn(c, cmd)
-	})
+	return contactUUID, nil
+}
+
+// schema returns the contact schema of the contact creator when the command sets custom fields or national phones
+// without region, nil otherwise. The creator of a contact never changes, it is read before the update.
+func (h UpdateContact) schema(ctx context.Context, contactId uuid.UUID, cmd CmdUpdateContact) (*domain.ContactSchema, error) {
+	if len(cmd.CustomFields) == 0 && (cmd.PhoneRegion != "" || !hasNationalPhone(cmd.Phone, cmd.Phones)) {
+		return nil, nil
+	}
 
-	return handleRepositoryError(contact, err)
+	contact, err := handleRepositoryError(h.repo.Get(ctx, contactId))
+	if err != nil {
+		return nil, err
+	}
+
+	return loadSchema(ctx, h.schemas, contact.CreatedBy)
 }
 
-func updateContactFn(c domain.Contact, cmd CmdUpdateContact) (domain.Contact, error) {
+// updater returns the repository update function of the command, the audit entry is saved along with the contact.
+// The statuses of the command emails are checked beforehand, not to resolve their domains within the update.
+func updater(cmd CmdUpdateContact, access contactAccess, schema *domain.ContactSchema, statuses map[string]domain.EmailStatus) func(c domain.Contact) (domain.Contact, error) {
+	return func(c domain.Contact) (domain.Contact, error) {
+		updated, err := updateContactFn(c, cmd, access, schema)
+		if err != nil {
+			return updated, err
+		}
+		setEmailStatus(&updated, c.Email, statuses)
+
+		if changes := domain.Diff(c, updated); len(changes) > 0 {
+			updated.Record(domain.NewContactUpdated(updated.Id, changes))
+		}
+		updated.Audit(domain.NewAuditEntry(cmd.Updater, domain.AuditActionUpdate, c, updated))
+		return updated, nil
+	}
+}
+
+func updateContactFn(c domain.Contact, cmd CmdUpdateContact, access contactAccess, schema *domain.ContactSchema) (domain.Contact, error) {
 	updated := false
 
-	if c.CreatedBy != cmd.Updater.Id() {
-		return c, fmt.Errorf("%w: %s", ErrForbidden, "contact can only be updated by its creator")
+	if c.IsDeleted() {
+		return c, errTrashed(c)
+	}
+
+	if !access.canWrite(c) {
+		return c, fmt.Errorf("%w: %s", ErrForbidden, "contact can only be updated by its creator, users it is shared with for writing and its address book editors")
+	}
+
+	if err := checkVersion(c, cmd.Version); err != nil {
+		return c, err
 	}
 
 	if cmd.FirstName != "" {
@@ -68,14 +149,20 @@ func updateContactFn(c domain.Contact, cmd CmdUpdateContact) (domain.Contact, er
 		c.LastName = cmd.LastName
 	}
 
-	if cmd.Email != "" {
+	if cmd.Email != "" || cmd.Phone != "" || len(cmd.Emails) > 0 || len(cmd.Phones) > 0 || len(cmd.Addresses) > 0 {
 		updated = true
-		c.Email = cmd.Email
+		err := setContactDetails(&c, cmd.Email, cmd.Phone, cmd.Emails, cmd.Phones, cmd.Addresses, phoneRegion(cmd.PhoneRegion, schema))
+		if err != nil {
+			return c, err
+		}
 	}
 
-	if cmd.Phone != "" {
+	if len(cmd.CustomFields) > 0 {
 		updated = true
-		c.Phone = cmd.Phone
+		err := setCustomFields(&c, schema, cmd.CustomFields)
+		if err != nil {
+			return c, err
+		}
 	}
 
 	if updated {
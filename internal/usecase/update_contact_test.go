@@ -11,6 +11,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUpdateContac(t *testing.T) {
@@ -24,7 +25,8 @@ func TestUpdateContac(t *testing.T) {
 func testUpdateContactValidation(t *testing.T) {
 	ctx := context.Background()
 	container := testContainer(t)
-	contactUpdater := NewUpdateContact(container.contactRepo)
+	container.expectAddressBooks()
+	contactUpdater := NewUpdateContact(container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails)
 
 	testCases := []struct {
 		name          string
@@ -51,6 +53,15 @@ func testUpdateContactValidation(t *testing.T) {
 			},
 			expectedError: ErrInvalidCommand,
 		},
+		{
+			name: "invalid command: negative version",
+			command: CmdUpdateContact{
+				ContactId: uuid.NewString(),
+				FirstName: "John",
+				Version:   -1,
+			},
+			expectedError: ErrInvalidCommand,
+		},
 		{
 			name: "invalid command: missing contact id",
 			command: CmdUpdateContact{
@@ -69,7 +80,7 @@ func testUpdateContactValidation(t *testing.T) {
 				container.contactRepo.EXPECT().
 					Update(ctx, gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(nil, nil)
+					Return(&domain.Contact{}, nil)
 			}
 
 			_, err := contactUpdater.Update(ctx, tc.command)
@@ -81,7 +92,8 @@ func testUpdateContactValidation(t *testing.T) {
 func testUpdateContact(t *testing.T) {
 	ctx := context.Background()
 	container := testContainer(t)
-	contactUpdater := NewUpdateContact(container.contactRepo)
+	container.expectAddressBooks()
+	contactUpdater := NewUpdateContact(container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails)
 
 	t.Run("successfully update contact", func(t *testing.T) {
 		uuid := uuid.New()
@@ -108,6 +120,65 @@ func testUpdateContact(t *testing.T) {
 		assert.Equal(t, cmd.FirstName, updatedContact.FirstName)
 	})
 
+	t.Run("national phone read in the default region of the contact creator", func(t *testing.T) {
+		contact := domain.New(uuid.New())
+		container := testContainer(t)
+		container.expectAddressBooks()
+		container.expectSchema(&domain.ContactSchema{OwnerId: contact.CreatedBy, DefaultPhoneRegion: "FR"})
+		container.contactRepo.EXPECT().Get(ctx, contact.Id).Times(1).Return(contact, nil)
+		container.contactRepo.EXPECT().
+			Update(ctx, contact.Id, gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, _ uuid.UUID, updateFn func(c domain.Contact) (domain.Contact, error)) (*domain.Contact, error) {
+				updated, err := updateFn(*contact)
+				return &updated, err
+			})
+		contactUpdater := NewUpdateContact(container.contactRepo, container.addressBookRepo, container.schemaRepo, container.emails)
+
+		updatedContact, err := contactUpdater.Update(ctx, CmdUpdateContact{
+			Updater:   user.New(contact.CreatedBy, user.UserTypeAuthenticated),
+			ContactId: contact.Id.String(),
+			Phone:     "07.00.00.00.00",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "+33700000000", updatedContact.Phone)
+	})
+
+	t.Run("email status checked when the primary email changes", func(t *testing.T) {
+		contact := domain.New(uuid.New())
+		contact.SetEmail("jdoe@contact.local")
+		contact.EmailStatus = domain.EmailStatusDeliverable
+		container := testContainer(t)
+		container.expectAddressBooks()
+		container.contactRepo.EXPECT().
+			Update(ctx, contact.Id, gomock.Any()).
+			Times(2).
+			DoAndReturn(func(_ context.Context, _ uuid.UUID, updateFn func(c domain.Contact) (domain.Contact, error)) (*domain.Contact, error) {
+				updated, err := updateFn(*contact)
+				return &updated, err
+			})
+		container.resolver.EXPECT().Resolve(ctx, "contact.local").Return(true, nil)
+		container.resolver.EXPECT().Resolve(ctx, "unreachable.local").Return(false, errors.New("timeout"))
+		contactUpdater := NewUpdateContact(container.contactRepo, container.addressBookRepo, container.schemaRepo, NewEmailVerifier(container.resolver, nil))
+
+		updatedContact, err := contactUpdater.Update(ctx, CmdUpdateContact{
+			Updater:   user.New(contact.CreatedBy, user.UserTypeAuthenticated),
+			ContactId: contact.Id.String(),
+			Email:     "JDoe@Contact.local",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "jdoe@contact.local", updatedContact.Email)
+		assert.Equal(t, domain.EmailStatusDeliverable, updatedContact.EmailStatus)
+
+		updatedContact, err = contactUpdater.Update(ctx, CmdUpdateContact{
+			Updater:   user.New(contact.CreatedBy, user.UserTypeAuthenticated),
+			ContactId: contact.Id.String(),
+			Email:     "jdoe@unreachable.local",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, domain.EmailStatusUnknown, updatedContact.EmailStatus)
+	})
+
 	t.Run("contact not found", func(t *testing.T) {
 		cmd := CmdUpdateContact{
 			Updater:   user.New(uuid.New(), user.UserTypeAuthenticated),
@@ -157,11 +228,22 @@ func testUpdateContactFn(t *testing.T) {
 		LastName:  "Doe",
 		Email:     "jdoe@contact.local",
 		Phone:     "+33612345678",
+		Version:   3,
+	}
+	sharedWith := uuid.New()
+	sharedContact := func(c domain.Contact, permission domain.Permission) domain.Contact {
+		c.Share(sharedWith, permission)
+		return c
 	}
+	addressBookId := uuid.New()
+	bookContact := contact
+	bookContact.AddressBookId = addressBookId
+	bookMember := uuid.New()
 
 	tests := []struct {
 		name          string
 		contact       domain.Contact
+		roles         map[uuid.UUID]domain.Role
 		cmd           CmdUpdateContact
 		expectedError error
 	}{
@@ -174,6 +256,64 @@ func testUpdateContactFn(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			name:    "updater with write access",
+			contact: sharedContact(contact, domain.PermissionWrite),
+			cmd: CmdUpdateContact{
+				Updater:   user.New(sharedWith, user.UserTypeAuthenticated),
+				FirstName: "Jane",
+			},
+			expectedError: nil,
+		},
+		{
+			name:    "updater with read access",
+			contact: sharedContact(contact, domain.PermissionRead),
+			cmd: CmdUpdateContact{
+				Updater:   user.New(sharedWith, user.UserTypeAuthenticated),
+				FirstName: "Jane",
+			},
+			expectedError: ErrForbidden,
+		},
+		{
+			name:    "address book editor",
+			contact: bookContact,
+			roles:   map[uuid.UUID]domain.Role{addressBookId: domain.RoleEditor},
+			cmd: CmdUpdateContact{
+				Updater:   user.New(bookMember, user.UserTypeAuthenticated),
+				FirstName: "Jane",
+			},
+			expectedError: nil,
+		},
+		{
+			name:    "address book viewer",
+			contact: bookContact,
+			roles:   map[uuid.UUID]domain.Role{addressBookId: domain.RoleViewer},
+			cmd: CmdUpdateContact{
+				Updater:   user.New(bookMember, user.UserTypeAuthenticated),
+				FirstName: "Jane",
+			},
+			expectedError: ErrForbidden,
+		},
+		{
+			name:    "expected version",
+			contact: contact,
+			cmd: CmdUpdateContact{
+				Updater:   user.New(contact.CreatedBy, user.UserTypeAuthenticated),
+				FirstName: "Jane",
+				Version:   3,
+			},
+			expectedError: nil,
+		},
+		{
+			name:    "stale version",
+			contact: contact,
+			cmd: CmdUpdateContact{
+				Updater:   user.New(contact.CreatedBy, user.UserTypeAuthenticated),
+				FirstName: "Jane",
+				Version:   2,
+			},
+			expectedError: ErrConflict,
+		},
 		{
 			name:    "unauthorized updater",
 			contact: contact,
@@ -190,8 +330,42 @@ func testUpdateContactFn(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			t.Parallel()
 
-			_, err := updateContactFn(test.contact, test.cmd)
+			access := contactAccess{userId: test.cmd.Updater.Id(), roles: test.roles}
+			_, err := updateContactFn(test.contact, test.cmd, access, nil)
 			assert.ErrorIs(t, err, test.expectedError)
 		})
 	}
+
+	t.Run("update the email of a contact with several emails", func(t *testing.T) {
+		t.Parallel()
+
+		access := contactAccess{userId: contact.CreatedBy}
+		updater := user.New(contact.CreatedBy, user.UserTypeAuthenticated)
+		updated, err := updateContactFn(contact, CmdUpdateContact{
+			Updater: updater,
+			Emails: []ContactEmail{
+				{Label: "work", Address: "jdoe@work.local", Primary: true},
+				{Label: "home", Address: "jdoe@home.local"},
+			},
+		}, access, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "jdoe@work.local", updated.Email)
+		// the phone saved before the lists is kept as the single phone
+		assert.Equal(t, []domain.PhoneNumber{{Label: domain.LabelOther, Number: "+33612345678", Primary: true}}, updated.PhoneNumbers())
+
+		// the single email replaces the primary one, the other emails are kept
+		updated, err = updateContactFn(updated, CmdUpdateContact{Updater: updater, Email: "john@work.local"}, access, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "john@work.local", updated.Email)
+		assert.Equal(t, []domain.EmailAddress{
+			{Label: domain.LabelWork, Address: "john@work.local", Primary: true},
+			{Label: domain.LabelHome, Address: "jdoe@home.local"},
+		}, updated.Emails)
+
+		_, err = updateContactFn(updated, CmdUpdateContact{
+			Updater: updater,
+			Phones:  []ContactPhone{{Number: "+33612345678", Primary: true}, {Number: "+33112345678", Primary: true}},
+		}, access, nil)
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
 }
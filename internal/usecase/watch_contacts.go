@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/davidterranova/contacts/pkg/user"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/go-playground/validator"
+	uuid "github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// watchBufferSize is the number of changes a watcher may lag behind before being disconnected
+const watchBufferSize = 64
+
+// replayBufferSize is the number of recent changes kept to resume a watch after a disconnection
+const replayBufferSize = 256
+
+type QueryWatchContacts struct {
+	Watcher user.User `validate:"required"`
+	// After resumes the watch after the change with this event id, the missed changes are replayed first
+	After string `validate:"omitempty,uuid"`
+}
+
+// ContactChange notifies a watcher of a contact event, along with the contact as it is when notified
+type ContactChange struct {
+	EventId    uuid.UUID
+	Event      string
+	OccurredAt time.Time
+	Contact    *domain.Contact
+}
+
+// WatchContactsHandler is an event bus subscriber notifying the watchers of the changes of the contacts they can read.
+// A watcher not keeping up with the changes is disconnected, its channel is closed, so that it can resume or reload and watch again.
+type WatchContactsHandler struct {
+	repo      ContactRepository
+	books     AddressBookRepository
+	validator *validator.Validate
+
+	mtx      sync.Mutex
+	watchers map[*contactWatcher]struct{}
+	// recent holds the last changes, oldest first, to replay them to the resuming watchers
+	recent []ContactChange
+}
+
+type contactWatcher struct {
+	user    user.User
+	changes chan ContactChange
+}
+
+func NewWatchContacts(repo ContactRepository, books AddressBookRepository) *WatchContactsHandler {
+	return &WatchContactsHandler{
+		repo:      repo,
+		books:     books,
+		validator: validator.New(),
+		watchers:  map[*contactWatcher]struct{}{},
+	}
+}
+
+// Watch returns the changes of the contacts the watcher can read until the context is done.
+// When resuming after a change no longer buffered, it fails with ErrNotFound and the watcher should reload.
+func (h *WatchContactsHandler) Watch(ctx context.Context, query QueryWatchContacts) (<-chan ContactChange, error) {
+	err := h.validator.Struct(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	var access contactAccess
+	if query.After != "" {
+		access, err = loadContactAccess(ctx, h.books, query.Watcher)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	h.mtx.Lock()
+	var missed []ContactChange
+	if query.After != "" {
+		missed, err = h.replay(uuid.MustParse(query.After), access)
+		if err != nil {
+			h.mtx.Unlock()
+			return nil, err
+		}
+	}
+
+	watcher := &contactWatcher{
+		user:    query.Watcher,
+		changes: make(chan ContactChange, watchBufferSize+len(missed)),
+	}
+	for _, change := range missed {
+		watcher.changes <- change
+	}
+	h.watchers[watcher] = struct{}{}
+	h.mtx.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		h.mtx.Lock()
+		defer h.mtx.Unlock()
+		h.remove(watcher)
+	}()
+
+	return watcher.changes, nil
+}
+
+// replay returns the buffered changes following the given event which the watcher can read,
+// it must be called while holding the lock
+func (h *WatchContactsHandler) replay(after uuid.UUID, access contactAccess) ([]ContactChange, error) {
+	for i, change := range h.recent {
+		if change.EventId != after {
+			continue
+		}
+
+		var missed []ContactChange
+		for _, change := range h.recent[i+1:] {
+			if access.canRead(*change.Contact) {
+				missed = append(missed, change)
+			}
+		}
+		return missed, nil
+	}
+
+	return nil, fmt.Errorf("%w: change %s is no longer available", ErrNotFound, after)
+}
+
+func (h *WatchContactsHandler) Notify(ctx context.Context, event domain.Event) error {
+	contact, err := handleRepositoryError(h.repo.Get(ctx, event.AggregateId()))
+	if errors.Is(err, ErrNotFound) {
+		// the contact was purged before its events were published
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	change := ContactChange{
+		EventId:    event.EventId(),
+		Event:      event.EventName(),
+		OccurredAt: event.OccurredAt(),
+		Contact:    contact,
+	}
+
+	// buffering the change and listing the watchers at once ensures a resuming watcher
+	// receives the change either from the replay or from the notification, never both
+	h.mtx.Lock()
+	h.recent = append(h.recent, change)
+	if len(h.recent) > replayBufferSize {
+		h.recent = h.recent[len(h.recent)-replayBufferSize:]
+	}
+	watchers := make([]*contactWatcher, 0, len(h.watchers))
+	for watcher := range h.watchers {
+		watchers = append(watchers, watcher)
+	}
+	h.mtx.Unlock()
+
+	// the change is dropped for a watcher failing to receive it rather than failing the notification,
+	// since the event would be published again to the watchers which already received it
+	for _, watcher := range watchers {
+		access, err := loadContactAccess(ctx, h.books, watcher.user)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Str("watcher", watcher.user.Id().String()).Msg("watch_contacts:notify failed to load the watcher access, change dropped")
+			continue
+		}
+		if access.canRead(*contact) {
+			h.send(ctx, watcher, change)
+		}
+	}
+
+	return nil
+}
+
+func (h *WatchContactsHandler) send(ctx context.Context, watcher *contactWatcher, change ContactChange) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	if _, ok := h.watchers[watcher]; !ok {
+		return
+	}
+
+	select {
+	case watcher.changes <- change:
+	default:
+		log.Ctx(ctx).Warn().Str("watcher", watcher.user.Id().String()).Msg("watch_contacts:notify watcher not keeping up, disconnected")
+		h.remove(watcher)
+	}
+}
+
+// remove closes the watcher channel once, it must be called while holding the lock
+func (h *WatchContactsHandler) remove(watcher *contactWatcher) {
+	if _, ok := h.watchers[watcher]; ok {
+		delete(h.watchers, watcher)
+		close(watcher.changes)
+	}
+}
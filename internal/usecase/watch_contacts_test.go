@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/pkg/user"
+	"github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchContacts(t *testing.T) {
+	t.Parallel()
+
+	owner := user.New(uuid.New(), user.UserTypeAuthenticated)
+	stranger := user.New(uuid.New(), user.UserTypeAuthenticated)
+
+	t.Run("notify the watchers who can read the contact", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		contact := domain.New(owner.Id())
+		container := testContainer(t)
+		container.expectAddressBooks()
+		container.contactRepo.EXPECT().Get(ctx, contact.Id).Times(1).Return(contact, nil)
+
+		handler := NewWatchContacts(container.contactRepo, container.addressBookRepo)
+		ownerChanges, err := handler.Watch(ctx, QueryWatchContacts{Watcher: owner})
+		require.NoError(t, err)
+		strangerChanges, err := handler.Watch(ctx, QueryWatchContacts{Watcher: stranger})
+		require.NoError(t, err)
+
+		event := domain.NewContactDeleted(contact.Id)
+		require.NoError(t, handler.Notify(ctx, event))
+
+		select {
+		case change := <-ownerChanges:
+			assert.Equal(t, event.EventId(), change.EventId)
+			assert.Equal(t, domain.EventContactDeleted, change.Event)
+			assert.Equal(t, event.OccurredAt(), change.OccurredAt)
+			assert.Equal(t, contact, change.Contact)
+		default:
+			t.Fatal("owner was not notified")
+		}
+		assert.Empty(t, strangerChanges)
+	})
+
+	t.Run("drop the change of a watcher failing to receive it", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		contact := domain.New(owner.Id())
+		container := testContainer(t)
+		container.addressBookRepo.EXPECT().ListByMember(gomock.Any(), stranger.Id()).Times(1).Return(nil, errors.New("connection lost"))
+		container.addressBookRepo.EXPECT().ListByMember(gomock.Any(), owner.Id()).Times(1).Return(nil, nil)
+		container.contactRepo.EXPECT().Get(ctx, contact.Id).Times(1).Return(contact, nil)
+
+		handler := NewWatchContacts(container.contactRepo, container.addressBookRepo)
+		strangerChanges, err := handler.Watch(ctx, QueryWatchContacts{Watcher: stranger})
+		require.NoError(t, err)
+		ownerChanges, err := handler.Watch(ctx, QueryWatchContacts{Watcher: owner})
+		require.NoError(t, err)
+
+		event := domain.NewContactUpdated(contact.Id, nil)
+		require.NoError(t, handler.Notify(ctx, event))
+
+		select {
+		case change := <-ownerChanges:
+			assert.Equal(t, event.EventId(), change.EventId)
+		default:
+			t.Fatal("owner was not notified")
+		}
+		assert.Empty(t, strangerChanges)
+	})
+
+	t.Run("stop watching when the context is done", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		container := testContainer(t)
+
+		changes, err := NewWatchContacts(container.contactRepo, container.addressBookRepo).Watch(ctx, QueryWatchContacts{Watcher: owner})
+		require.NoError(t, err)
+		cancel()
+
+		select {
+		case _, ok := <-changes:
+			assert.False(t, ok)
+		case <-time.After(time.Second):
+			t.Fatal("changes were not closed")
+		}
+	})
+
+	t.Run("disconnect a watcher lagging behind", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		contact := domain.New(owner.Id())
+		container := testContainer(t)
+		container.expectAddressBooks()
+		container.contactRepo.EXPECT().Get(ctx, contact.Id).Times(watchBufferSize+1).Return(contact, nil)
+
+		handler := NewWatchContacts(container.contactRepo, container.addressBookRepo)
+		changes, err := handler.Watch(ctx, QueryWatchContacts{Watcher: owner})
+		require.NoError(t, err)
+		for i := 0; i <= watchBufferSize; i++ {
+			require.NoError(t, handler.Notify(ctx, domain.NewContactUpdated(contact.Id, nil)))
+		}
+
+		received := 0
+		for range changes {
+			received++
+		}
+		assert.Equal(t, watchBufferSize, received)
+	})
+
+	t.Run("resume replays the missed changes the watcher can read", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		owned := domain.New(owner.Id())
+		other := domain.New(stranger.Id())
+		container := testContainer(t)
+		container.expectAddressBooks()
+		container.contactRepo.EXPECT().Get(ctx, owned.Id).Times(2).Return(owned, nil)
+		container.contactRepo.EXPECT().Get(ctx, other.Id).Times(1).Return(other, nil)
+
+		handler := NewWatchContacts(container.contactRepo, container.addressBookRepo)
+		seen := domain.NewContactUpdated(owned.Id, nil)
+		missed := domain.NewContactDeleted(owned.Id)
+		require.NoError(t, handler.Notify(ctx, seen))
+		require.NoError(t, handler.Notify(ctx, domain.NewContactCreated(*other)))
+		require.NoError(t, handler.Notify(ctx, missed))
+
+		changes, err := handler.Watch(ctx, QueryWatchContacts{Watcher: owner, After: seen.EventId().String()})
+		require.NoError(t, err)
+
+		select {
+		case change := <-changes:
+			assert.Equal(t, missed.EventId(), change.EventId)
+		default:
+			t.Fatal("missed change was not replayed")
+		}
+		assert.Empty(t, changes)
+	})
+
+	t.Run("resume after a change no longer buffered", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		container.expectAddressBooks()
+
+		_, err := NewWatchContacts(container.contactRepo, container.addressBookRepo).
+			Watch(context.Background(), QueryWatchContacts{Watcher: owner, After: uuid.NewString()})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		t.Parallel()
+
+		container := testContainer(t)
+		_, err := NewWatchContacts(container.contactRepo, container.addressBookRepo).Watch(context.Background(), QueryWatchContacts{})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
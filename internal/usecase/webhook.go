@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/davidterranova/contacts/pkg/user"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/go-playground/validator"
+	uuid "github.com/google/uuid"
+)
+
+type CmdCreateWebhook struct {
+	Creator user.User `validate:"required"`
+	URL     string    `validate:"required,url,max=2048"`
+	Events  []string  `validate:"required,min=1,dive,oneof=contact.created contact.updated contact.deleted"`
+	Secret  string    `validate:"required,min=16,max=255"`
+}
+
+type QueryGetWebhook struct {
+	Requester user.User `validate:"required"`
+	WebhookId string    `validate:"required,uuid"`
+}
+
+type QueryListWebhooks struct {
+	Owner user.User `validate:"required"`
+}
+
+type CmdUpdateWebhook struct {
+	Updater   user.User `validate:"required"`
+	WebhookId string    `validate:"required,uuid"`
+	URL       string    `validate:"required,url,max=2048"`
+	Events    []string  `validate:"required,min=1,dive,oneof=contact.created contact.updated contact.deleted"`
+	// Secret is kept unchanged when empty
+	Secret string `validate:"omitempty,min=16,max=255"`
+}
+
+type CmdDeleteWebhook struct {
+	Deleter   user.User `validate:"required"`
+	WebhookId string    `validate:"required,uuid"`
+}
+
+type QueryListWebhookDeliveries struct {
+	Requester user.User `validate:"required"`
+	WebhookId string    `validate:"required,uuid"`
+}
+
+// WebhookHandler manages webhooks and their delivery history, a webhook is only visible to its creator
+type WebhookHandler struct {
+	webhooks  WebhookRepository
+	validator *validator.Validate
+}
+
+func NewWebhookHandler(webhooks WebhookRepository) WebhookHandler {
+	return WebhookHandler{
+		webhooks:  webhooks,
+		validator: validator.New(),
+	}
+}
+
+func (h WebhookHandler) Create(ctx context.Context, cmd CmdCreateWebhook) (*domain.Webhook, error) {
+	err := h.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	return handleRepositoryError(h.webhooks.Create(ctx, domain.NewWebhook(cmd.Creator.Id(), cmd.URL, cmd.Events, cmd.Secret)))
+}
+
+func (h WebhookHandler) Get(ctx context.Context, query QueryGetWebhook) (*domain.Webhook, error) {
+	err := h.validator.Struct(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	return h.get(ctx, query.Requester, query.WebhookId)
+}
+
+func (h WebhookHandler) get(ctx context.Context, requester user.User, webhookId string) (*domain.Webhook, error) {
+	webhookUUID, err := uuid.Parse(webhookId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	webhook, err := handleRepositoryError(h.webhooks.Get(ctx, webhookUUID))
+	if err != nil {
+		return nil, err
+	}
+
+	if webhook.CreatedBy != requester.Id() {
+		return nil, fmt.Errorf("%w: %s", ErrForbidden, "webhook can only be read by its creator")
+	}
+
+	return webhook, nil
+}
+
+func (h WebhookHandler) List(ctx context.Context, query QueryListWebhooks) ([]*domain.Webhook, error) {
+	err := h.validator.Struct(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	return handleRepositoryError(h.webhooks.ListByOwner(ctx, query.Owner.Id()))
+}
+
+func (h WebhookHandler) Update(ctx context.Context, cmd CmdUpdateWebhook) (*domain.Webhook, error) {
+	err := h.validator.Struct(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	webhookUUID, err := uuid.Parse(cmd.WebhookId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	webhook, err := h.webhooks.Update(ctx, webhookUUID, func(w domain.Webhook) (domain.Webhook, error) {
+		if w.CreatedBy != cmd.Updater.Id() {
+			return w, fmt.Errorf("%w: %s", ErrForbidden, "webhook can only be updated by its creator")
+		}
+
+		w.URL = cmd.URL
+		w.Events = cmd.Events
+		if cmd.Secret != "" {
+			w.Secret = cmd.Secret
+		}
+		w.UpdatedAt = time.Now().UTC()
+		return w, nil
+	})
+
+	return handleRepositoryError(webhook, err)
+}
+
+func (h WebhookHandler) Delete(ctx context.Context, cmd CmdDeleteWebhook) error {
+	err := h.validator.Struct(cmd)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	webhookUUID, err := uuid.Parse(cmd.WebhookId)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	_, err = handleRepositoryError[*domain.Webhook](nil, h.webhooks.Delete(ctx, webhookUUID, func(w domain.Webhook) error {
+		if w.CreatedBy != cmd.Deleter.Id() {
+			return fmt.Errorf("%w: %s", ErrForbidden, "webhook can only be deleted by its creator")
+		}
+
+		return nil
+	}))
+	return err
+}
+
+func (h WebhookHandler) Deliveries(ctx context.Context, query QueryListWebhookDeliveries) ([]*domain.Delivery, error) {
+	err := h.validator.Struct(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	webhook, err := h.get(ctx, query.Requester, query.WebhookId)
+	if err != nil {
+		return nil, err
+	}
+
+	return handleRepositoryError(h.webhooks.ListDeliveries(ctx, webhook.Id))
+}
@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/davidterranova/contacts/pkg/user"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/go-playground/validator"
+	uuid "github.com/google/uuid"
+)
+
+// webhookPayload is the body posted to the webhooks, the changes of a created contact are its set fields
+type webhookPayload struct {
+	Id         uuid.UUID            `json:"id"`
+	Event      string               `json:"event"`
+	ContactId  uuid.UUID            `json:"contact_id"`
+	OccurredAt time.Time            `json:"occurred_at"`
+	Changes    []webhookFieldChange `json:"changes"`
+}
+
+type webhookFieldChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+func newWebhookPayload(event domain.Event) ([]byte, error) {
+	var changes []domain.FieldChange
+	switch e := event.(type) {
+	case domain.ContactCreated:
+		changes = domain.Diff(domain.Contact{}, e.Contact)
+	case domain.ContactUpdated:
+		changes = e.Changes
+	}
+
+	payload := webhookPayload{
+		Id:         event.EventId(),
+		Event:      event.EventName(),
+		ContactId:  event.AggregateId(),
+		OccurredAt: event.OccurredAt().UTC(),
+		Changes:    make([]webhookFieldChange, 0, len(changes)),
+	}
+	for _, change := range changes {
+		payload.Changes = append(payload.Changes, webhookFieldChange(change))
+	}
+
+	return json.Marshal(payload)
+}
+
+// EnqueueWebhookDeliveriesHandler is an event bus subscriber creating the deliveries of an event
+// to the webhooks subscribed to it, provided their creator can read the contact
+type EnqueueWebhookDeliveriesHandler struct {
+	webhooks WebhookRepository
+	contacts ContactRepository
+	books    AddressBookRepository
+}
+
+func NewEnqueueWebhookDeliveries(webhooks WebhookRepository, contacts ContactRepository, books AddressBookRepository) EnqueueWebhookDeliveriesHandler {
+	return EnqueueWebhookDeliveriesHandler{
+		webhooks: webhooks,
+		contacts: contacts,
+		books:    books,
+	}
+}
+
+func (h EnqueueWebhookDeliveriesHandler) Enqueue(ctx context.Context, event domain.Event) error {
+	webhooks, err := handleRepositoryError(h.webhooks.ListByEvent(ctx, event.EventName()))
+	if err != nil || len(webhooks) == 0 {
+		return err
+	}
+
+	contact, err := handleRepositoryError(h.contacts.Get(ctx, event.AggregateId()))
+	if errors.Is(err, ErrNotFound) {
+		// the contact was purged before its events were published
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	payload, err := newWebhookPayload(event)
+	if err != nil {
+		return fmt.Errorf("%w: failed to encode webhook payload: %s", ErrInternal, err)
+	}
+
+	for _, webhook := range webhooks {
+		access, err := loadContactAccess(ctx, h.books, user.New(webhook.CreatedBy, user.UserTypeAuthenticated))
+		if err != nil {
+			return err
+		}
+		if !access.canRead(*contact) {
+			continue
+		}
+
+		_, err = handleRepositoryError(h.webhooks.CreateDelivery(ctx, domain.NewDelivery(webhook.Id, event, payload)))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type CmdDeliverWebhooks struct {
+	// BatchSize is the maximum number of deliveries attempted at once
+	BatchSize int `validate:"min=1,max=1000"`
+	// MaxAttempts is the number of failed attempts after which a delivery is dead
+	MaxAttempts int `validate:"min=1"`
+	// Backoff is the delay before the second attempt, doubled on every attempt up to MaxBackoff
+	Backoff    time.Duration `validate:"min=1"`
+	MaxBackoff time.Duration `validate:"gtefield=Backoff"`
+}
+
+// DeliverWebhooksHandler attempts the due deliveries, failing deliveries are retried until they are dead
+type DeliverWebhooksHandler struct {
+	webhooks  WebhookRepository
+	sender    WebhookSender
+	validator *validator.Validate
+}
+
+func NewDeliverWebhooks(webhooks WebhookRepository, sender WebhookSender) DeliverWebhooksHandler {
+	return DeliverWebhooksHandler{
+		webhooks:  webhooks,
+		sender:    sender,
+		validator: validator.New(),
+	}
+}
+
+// Deliver attempts a batch of due deliveries and returns the number of attempts
+func (h DeliverWebhooksHandler) Deliver(ctx context.Context, cmd CmdDeliverWebhooks) (int, error) {
+	err := h.validator.Struct(cmd)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidCommand, err)
+	}
+
+	policy := domain.RetryPolicy{MaxAttempts: cmd.MaxAttempts, Backoff: cmd.Backoff, MaxBackoff: cmd.MaxBackoff}
+	deliveries, err := handleRepositoryError(h.webhooks.ListDueDeliveries(ctx, time.Now().UTC(), cmd.BatchSize))
+	if err != nil {
+		return 0, err
+	}
+
+	for i, delivery := range deliveries {
+		webhook, err := handleRepositoryError(h.webhooks.Get(ctx, delivery.WebhookId))
+		if err != nil {
+			return i, err
+		}
+
+		status, err := h.sender.Send(ctx, *webhook, *delivery)
+		if err != nil {
+			delivery.Fail(time.Now().UTC(), status, err.Error(), policy)
+		} else {
+			delivery.Succeed(time.Now().UTC(), status)
+		}
+
+		_, err = handleRepositoryError(h.webhooks.SaveDelivery(ctx, delivery))
+		if err != nil {
+			return i, err
+		}
+	}
+
+	return len(deliveries), nil
+}
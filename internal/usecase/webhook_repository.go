@@ -0,0 +1,36 @@
+//go:generate mockgen -destination=mock_webhook_repository.go -package=usecase . WebhookRepository,WebhookSender
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	uuid "github.com/google/uuid"
+)
+
+// WebhookRepository stores the webhooks along with their deliveries, deleting a webhook deletes its deliveries
+type WebhookRepository interface {
+	Get(ctx context.Context, id uuid.UUID) (*domain.Webhook, error)
+	ListByOwner(ctx context.Context, userId uuid.UUID) ([]*domain.Webhook, error)
+	// ListByEvent returns the webhooks subscribed to the given event
+	ListByEvent(ctx context.Context, eventName string) ([]*domain.Webhook, error)
+	Create(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error)
+	Update(ctx context.Context, id uuid.UUID, updateFn func(w domain.Webhook) (domain.Webhook, error)) (*domain.Webhook, error)
+	Delete(ctx context.Context, id uuid.UUID, deleterFn func(w domain.Webhook) error) error
+
+	// CreateDelivery creates the delivery unless the webhook already has one of the same event, which is returned
+	// instead, so that an event published again is delivered once
+	CreateDelivery(ctx context.Context, delivery *domain.Delivery) (*domain.Delivery, error)
+	// ListDueDeliveries returns up to limit pending deliveries whose next attempt is due at the given time, the oldest due first
+	ListDueDeliveries(ctx context.Context, at time.Time, limit int) ([]*domain.Delivery, error)
+	// ListDeliveries returns the deliveries of a webhook from the most recent to the oldest
+	ListDeliveries(ctx context.Context, webhookId uuid.UUID) ([]*domain.Delivery, error)
+	SaveDelivery(ctx context.Context, delivery *domain.Delivery) (*domain.Delivery, error)
+}
+
+// WebhookSender posts a delivery to its webhook URL, it returns the response status along with an error
+// when the receiver could not be reached or did not acknowledge the delivery
+type WebhookSender interface {
+	Send(ctx context.Context, webhook domain.Webhook, delivery domain.Delivery) (int, error)
+}
@@ -0,0 +1,303 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/davidterranova/contacts/internal/domain"
+	"github.com/davidterranova/contacts/internal/ports"
+	"github.com/davidterranova/contacts/pkg/user"
+	"github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testWebhookSecret = "0123456789abcdef"
+
+func TestWebhook(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	owner := user.New(uuid.New(), user.UserTypeAuthenticated)
+	stranger := user.New(uuid.New(), user.UserTypeAuthenticated)
+
+	createCases := []struct {
+		name          string
+		command       CmdCreateWebhook
+		expectedError error
+	}{
+		{
+			name:    "valid command",
+			command: CmdCreateWebhook{Creator: owner, URL: "https://crm.local/hooks", Events: []string{domain.EventContactCreated}, Secret: testWebhookSecret},
+		},
+		{
+			name:          "invalid command: invalid url",
+			command:       CmdCreateWebhook{Creator: owner, URL: "crm", Events: []string{domain.EventContactCreated}, Secret: testWebhookSecret},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name:          "invalid command: missing events",
+			command:       CmdCreateWebhook{Creator: owner, URL: "https://crm.local/hooks", Secret: testWebhookSecret},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name:          "invalid command: unknown event",
+			command:       CmdCreateWebhook{Creator: owner, URL: "https://crm.local/hooks", Events: []string{"contact.merged"}, Secret: testWebhookSecret},
+			expectedError: ErrInvalidCommand,
+		},
+		{
+			name:          "invalid command: short secret",
+			command:       CmdCreateWebhook{Creator: owner, URL: "https://crm.local/hooks", Events: []string{domain.EventContactCreated}, Secret: "secret"},
+			expectedError: ErrInvalidCommand,
+		},
+	}
+
+	for _, tc := range createCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := NewMockWebhookRepository(gomock.NewController(t))
+			if tc.expectedError == nil {
+				repo.EXPECT().
+					Create(ctx, gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, w *domain.Webhook) (*domain.Webhook, error) {
+						return w, nil
+					})
+			}
+
+			webhook, err := NewWebhookHandler(repo).Create(ctx, tc.command)
+			assert.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError == nil {
+				assert.Equal(t, owner.Id(), webhook.CreatedBy)
+				assert.Equal(t, tc.command.Events, webhook.Events)
+			}
+		})
+	}
+
+	accessCases := []struct {
+		name          string
+		requester     user.User
+		expectedError error
+	}{
+		{
+			name:      "creator",
+			requester: owner,
+		},
+		{
+			name:          "another user",
+			requester:     stranger,
+			expectedError: ErrForbidden,
+		},
+	}
+
+	for _, tc := range accessCases {
+		tc := tc
+		t.Run("update by "+tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			webhook := domain.NewWebhook(owner.Id(), "https://crm.local/hooks", []string{domain.EventContactCreated}, testWebhookSecret)
+			repo := NewMockWebhookRepository(gomock.NewController(t))
+			repo.EXPECT().
+				Update(ctx, webhook.Id, gomock.Any()).
+				Times(1).
+				DoAndReturn(func(_ context.Context, _ uuid.UUID, updateFn func(w domain.Webhook) (domain.Webhook, error)) (*domain.Webhook, error) {
+					updated, err := updateFn(*webhook)
+					return &updated, err
+				})
+
+			updated, err := NewWebhookHandler(repo).Update(ctx, CmdUpdateWebhook{
+				Updater:   tc.requester,
+				WebhookId: webhook.Id.String(),
+				URL:       "https://crm.local/v2/hooks",
+				Events:    []string{domain.EventContactDeleted},
+			})
+			assert.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError == nil {
+				assert.Equal(t, "https://crm.local/v2/hooks", updated.URL)
+				assert.Equal(t, []string{domain.EventContactDeleted}, updated.Events)
+				assert.Equal(t, testWebhookSecret, updated.Secret)
+			}
+		})
+
+		t.Run("list deliveries by "+tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			webhook := domain.NewWebhook(owner.Id(), "https://crm.local/hooks", []string{domain.EventContactCreated}, testWebhookSecret)
+			deliveries := []*domain.Delivery{domain.NewDelivery(webhook.Id, domain.NewContactDeleted(uuid.New()), []byte(`{}`))}
+			repo := NewMockWebhookRepository(gomock.NewController(t))
+			repo.EXPECT().Get(ctx, webhook.Id).Times(1).Return(webhook, nil)
+			if tc.expectedError == nil {
+				repo.EXPECT().ListDeliveries(ctx, webhook.Id).Times(1).Return(deliveries, nil)
+			}
+
+			listed, err := NewWebhookHandler(repo).Deliveries(ctx, QueryListWebhookDeliveries{Requester: tc.requester, WebhookId: webhook.Id.String()})
+			assert.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError == nil {
+				assert.Equal(t, deliveries, listed)
+			}
+		})
+	}
+
+	t.Run("get an unknown webhook", func(t *testing.T) {
+		t.Parallel()
+
+		id := uuid.New()
+		repo := NewMockWebhookRepository(gomock.NewController(t))
+		repo.EXPECT().Get(ctx, id).Times(1).Return(nil, ports.ErrNotFound)
+
+		_, err := NewWebhookHandler(repo).Get(ctx, QueryGetWebhook{Requester: owner, WebhookId: id.String()})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestEnqueueWebhookDeliveries(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	owner := user.New(uuid.New(), user.UserTypeAuthenticated)
+	contact := domain.New(owner.Id())
+	contact.FirstName = "John"
+	event := domain.NewContactCreated(*contact)
+
+	subscribed := domain.NewWebhook(owner.Id(), "https://crm.local/hooks", []string{domain.EventContactCreated}, testWebhookSecret)
+	stranger := domain.NewWebhook(uuid.New(), "https://spy.local/hooks", []string{domain.EventContactCreated}, testWebhookSecret)
+
+	container := testContainer(t)
+	container.expectAddressBooks()
+	webhooks := NewMockWebhookRepository(gomock.NewController(t))
+	webhooks.EXPECT().
+		ListByEvent(ctx, domain.EventContactCreated).
+		Times(1).
+		Return([]*domain.Webhook{subscribed, stranger}, nil)
+	container.contactRepo.EXPECT().Get(ctx, contact.Id).Times(1).Return(contact, nil)
+
+	var delivery *domain.Delivery
+	webhooks.EXPECT().
+		CreateDelivery(ctx, gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, d *domain.Delivery) (*domain.Delivery, error) {
+			delivery = d
+			return d, nil
+		})
+
+	err := NewEnqueueWebhookDeliveries(webhooks, container.contactRepo, container.addressBookRepo).Enqueue(ctx, event)
+	require.NoError(t, err)
+	require.NotNil(t, delivery)
+	assert.Equal(t, subscribed.Id, delivery.WebhookId)
+	assert.Equal(t, event.EventId(), delivery.EventId)
+	assert.Equal(t, domain.DeliveryStatusPending, delivery.Status)
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(delivery.Payload, &payload))
+	assert.Equal(t, event.EventId().String(), payload["id"])
+	assert.Equal(t, domain.EventContactCreated, payload["event"])
+	assert.Equal(t, contact.Id.String(), payload["contact_id"])
+	assert.Equal(t, []any{map[string]any{"field": "first_name", "before": "", "after": "John"}}, payload["changes"])
+}
+
+func TestDeliverWebhooks(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cmd := CmdDeliverWebhooks{BatchSize: 10, MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: 90 * time.Second}
+	webhook := domain.NewWebhook(uuid.New(), "https://crm.local/hooks", []string{domain.EventContactDeleted}, testWebhookSecret)
+	errUnavailable := errors.New("webhook responded with status 503")
+
+	testCases := []struct {
+		name             string
+		attempts         int
+		sendStatus       int
+		sendErr          error
+		expectedStatus   domain.DeliveryStatus
+		expectedAttempts int
+		expectedBackoff  time.Duration
+	}{
+		{
+			name:             "acknowledged delivery",
+			sendStatus:       http.StatusOK,
+			expectedStatus:   domain.DeliveryStatusSucceeded,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "first failure is retried after the backoff",
+			sendStatus:       http.StatusServiceUnavailable,
+			sendErr:          errUnavailable,
+			expectedStatus:   domain.DeliveryStatusPending,
+			expectedAttempts: 1,
+			expectedBackoff:  time.Minute,
+		},
+		{
+			name:             "backoff doubles up to its maximum",
+			attempts:         1,
+			sendStatus:       http.StatusServiceUnavailable,
+			sendErr:          errUnavailable,
+			expectedStatus:   domain.DeliveryStatusPending,
+			expectedAttempts: 2,
+			expectedBackoff:  90 * time.Second,
+		},
+		{
+			name:             "last failure is dead lettered",
+			attempts:         2,
+			sendErr:          errors.New("connection refused"),
+			expectedStatus:   domain.DeliveryStatusDead,
+			expectedAttempts: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			delivery := domain.NewDelivery(webhook.Id, domain.NewContactDeleted(uuid.New()), []byte(`{}`))
+			delivery.Attempts = tc.attempts
+
+			controller := gomock.NewController(t)
+			webhooks := NewMockWebhookRepository(controller)
+			sender := NewMockWebhookSender(controller)
+			webhooks.EXPECT().ListDueDeliveries(ctx, gomock.Any(), cmd.BatchSize).Times(1).Return([]*domain.Delivery{delivery}, nil)
+			webhooks.EXPECT().Get(ctx, webhook.Id).Times(1).Return(webhook, nil)
+			sender.EXPECT().Send(ctx, *webhook, *delivery).Times(1).Return(tc.sendStatus, tc.sendErr)
+
+			var saved *domain.Delivery
+			webhooks.EXPECT().
+				SaveDelivery(ctx, gomock.Any()).
+				Times(1).
+				DoAndReturn(func(_ context.Context, d *domain.Delivery) (*domain.Delivery, error) {
+					saved = d
+					return d, nil
+				})
+
+			attempted, err := NewDeliverWebhooks(webhooks, sender).Deliver(ctx, cmd)
+			require.NoError(t, err)
+			assert.Equal(t, 1, attempted)
+			require.NotNil(t, saved)
+			assert.Equal(t, tc.expectedStatus, saved.Status)
+			assert.Equal(t, tc.expectedAttempts, saved.Attempts)
+			assert.Equal(t, tc.sendStatus, saved.ResponseStatus)
+			require.NotNil(t, saved.LastAttemptAt)
+			if tc.sendErr != nil {
+				assert.Equal(t, tc.sendErr.Error(), saved.LastError)
+			}
+			if tc.expectedBackoff != 0 {
+				assert.Equal(t, saved.LastAttemptAt.Add(tc.expectedBackoff), saved.NextAttemptAt)
+			}
+		})
+	}
+
+	t.Run("invalid retry policy", func(t *testing.T) {
+		t.Parallel()
+
+		controller := gomock.NewController(t)
+		_, err := NewDeliverWebhooks(NewMockWebhookRepository(controller), NewMockWebhookSender(controller)).
+			Deliver(ctx, CmdDeliverWebhooks{BatchSize: 10, MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: time.Second})
+		assert.ErrorIs(t, err, ErrInvalidCommand)
+	})
+}
@@ -0,0 +1,166 @@
+package phone
+
+// nanpTollFree are the toll free area codes of the North American Numbering Plan
+var nanpTollFree = []string{"800", "833", "844", "855", "866", "877", "888"}
+
+// nanpFormats are the formats of the North American Numbering Plan regions
+var nanpFormats = []format{{national: "(###) ###-####", pattern: "###-###-####"}}
+
+// metadata describes the numbering plans of the supported regions. The regions of a country code are listed
+// together, the first one being its main region. Ranges are matched in order, the first one matching gives the
+// type of a number.
+var metadata = []region{
+	{
+		code:        "US",
+		countryCode: 1,
+		trunkPrefix: "1",
+		ranges: []numberRange{
+			{numberType: TypeTollFree, prefixes: nanpTollFree, lengths: []int{10}},
+			{numberType: TypeFixedOrMobile, prefixes: []string{"2", "3", "4", "5", "6", "7", "8", "9"}, lengths: []int{10}},
+		},
+		formats: nanpFormats,
+	},
+	{
+		code:        "CA",
+		countryCode: 1,
+		trunkPrefix: "1",
+		ranges: []numberRange{
+			{numberType: TypeTollFree, prefixes: nanpTollFree, lengths: []int{10}},
+			{
+				numberType: TypeFixedOrMobile,
+				prefixes: []string{
+					"204", "226", "236", "249", "250", "257", "263", "289", "306", "343", "354", "365", "367", "368",
+					"382", "387", "403", "416", "418", "428", "431", "437", "438", "450", "460", "468", "474", "506",
+					"514", "519", "548", "579", "581", "584", "587", "604", "613", "639", "647", "672", "683", "705",
+					"709", "742", "753", "778", "780", "782", "807", "819", "825", "867", "873", "879", "902", "905",
+					"942",
+				},
+				lengths: []int{10},
+			},
+		},
+		formats: nanpFormats,
+	},
+	{
+		code:        "GB",
+		countryCode: 44,
+		trunkPrefix: "0",
+		ranges: []numberRange{
+			{numberType: TypeTollFree, prefixes: []string{"800", "808"}, lengths: []int{9, 10}},
+			{numberType: TypeMobile, prefixes: []string{"71", "72", "73", "74", "75", "77", "78", "79"}, lengths: []int{10}},
+			{numberType: TypeFixed, prefixes: []string{"1"}, lengths: []int{9, 10}},
+			{numberType: TypeFixed, prefixes: []string{"2", "3"}, lengths: []int{10}},
+		},
+		formats: []format{
+			{prefix: "20", pattern: "## #### ####"},
+			{prefix: "1", pattern: "#### ######"},
+			{prefix: "1", pattern: "#### #####"},
+			{prefix: "7", pattern: "#### ######"},
+			{pattern: "### ### ####"},
+		},
+	},
+	{
+		code:        "FR",
+		countryCode: 33,
+		trunkPrefix: "0",
+		ranges: []numberRange{
+			{numberType: TypeTollFree, prefixes: []string{"800", "801", "802", "803", "804", "805"}, lengths: []int{9}},
+			{numberType: TypeMobile, prefixes: []string{"6", "7"}, lengths: []int{9}},
+			{numberType: TypeFixed, prefixes: []string{"1", "2", "3", "4", "5", "9"}, lengths: []int{9}},
+		},
+		formats: []format{{pattern: "# ## ## ## ##"}},
+	},
+	{
+		code:        "DE",
+		countryCode: 49,
+		trunkPrefix: "0",
+		ranges: []numberRange{
+			{numberType: TypeTollFree, prefixes: []string{"800"}, lengths: []int{10, 11}},
+			{numberType: TypeMobile, prefixes: []string{"15"}, lengths: []int{11}},
+			{numberType: TypeMobile, prefixes: []string{"16", "17"}, lengths: []int{10, 11}},
+			{numberType: TypeFixed, prefixes: []string{"2", "3", "4", "5", "6", "7", "8", "9"}, lengths: []int{6, 7, 8, 9, 10, 11}},
+		},
+		formats: []format{
+			{prefix: "1", pattern: "### ########"},
+			{prefix: "1", pattern: "### #######"},
+			{prefix: "800", pattern: "### #######"},
+			{prefix: "30", pattern: "## ########"},
+			{prefix: "40", pattern: "## ########"},
+			{prefix: "69", pattern: "## ########"},
+			{prefix: "89", pattern: "## ########"},
+		},
+	},
+	{
+		code:        "ES",
+		countryCode: 34,
+		ranges: []numberRange{
+			{numberType: TypeTollFree, prefixes: []string{"800", "900"}, lengths: []int{9}},
+			{numberType: TypeMobile, prefixes: []string{"6", "7"}, lengths: []int{9}},
+			{numberType: TypeFixed, prefixes: []string{"8", "9"}, lengths: []int{9}},
+		},
+		formats: []format{{pattern: "### ## ## ##"}},
+	},
+	{
+		code:        "IT",
+		countryCode: 39,
+		ranges: []numberRange{
+			{numberType: TypeTollFree, prefixes: []string{"800", "803"}, lengths: []int{6, 7, 8, 9}},
+			{numberType: TypeMobile, prefixes: []string{"3"}, lengths: []int{9, 10}},
+			{numberType: TypeFixed, prefixes: []string{"0"}, lengths: []int{6, 7, 8, 9, 10, 11}},
+		},
+		formats: []format{
+			{prefix: "3", pattern: "### ### ####"},
+			{prefix: "3", pattern: "### ######"},
+			{prefix: "0", pattern: "## #### ####"},
+		},
+	},
+	{
+		code:        "BE",
+		countryCode: 32,
+		trunkPrefix: "0",
+		ranges: []numberRange{
+			{numberType: TypeTollFree, prefixes: []string{"800"}, lengths: []int{8}},
+			{numberType: TypeMobile, prefixes: []string{"46", "47", "48", "49"}, lengths: []int{9}},
+			{numberType: TypeFixed, prefixes: []string{"1", "2", "3", "4", "5", "6", "7", "8", "9"}, lengths: []int{8}},
+		},
+		formats: []format{
+			{prefix: "4", pattern: "### ## ## ##"},
+			{prefix: "800", pattern: "### ## ###"},
+			{prefix: "2", pattern: "# ### ## ##"},
+			{prefix: "3", pattern: "# ### ## ##"},
+			{prefix: "4", pattern: "# ### ## ##"},
+			{prefix: "9", pattern: "# ### ## ##"},
+			{pattern: "## ## ## ##"},
+		},
+	},
+	{
+		code:        "CH",
+		countryCode: 41,
+		trunkPrefix: "0",
+		ranges: []numberRange{
+			{numberType: TypeTollFree, prefixes: []string{"800"}, lengths: []int{9}},
+			{numberType: TypeMobile, prefixes: []string{"75", "76", "77", "78", "79"}, lengths: []int{9}},
+			{numberType: TypeFixed, prefixes: []string{"2", "3", "4", "5", "6", "8", "9"}, lengths: []int{9}},
+		},
+		formats: []format{{pattern: "## ### ## ##"}},
+	},
+}
+
+// assignedCountryCodes are the country calling codes assigned by the ITU-T, https://www.itu.int/pub/T-SP-E.164D. No code is
+// the prefix of another one, the numbers of the codes without regions in metadata are accepted without numbering plan.
+var assignedCountryCodes = []int{
+	1, 7,
+	20, 27, 30, 31, 32, 33, 34, 36, 39, 40, 41, 43, 44, 45, 46, 47, 48, 49,
+	51, 52, 53, 54, 55, 56, 57, 58, 60, 61, 62, 63, 64, 65, 66, 81, 82, 84, 86, 90, 91, 92, 93, 94, 95, 98,
+	211, 212, 213, 216, 218, 220, 221, 222, 223, 224, 225, 226, 227, 228, 229,
+	230, 231, 232, 233, 234, 235, 236, 237, 238, 239, 240, 241, 242, 243, 244, 245, 246, 247, 248, 249,
+	250, 251, 252, 253, 254, 255, 256, 257, 258, 260, 261, 262, 263, 264, 265, 266, 267, 268, 269,
+	290, 291, 297, 298, 299,
+	350, 351, 352, 353, 354, 355, 356, 357, 358, 359,
+	370, 371, 372, 373, 374, 375, 376, 377, 378, 379, 380, 381, 382, 383, 385, 386, 387, 389,
+	420, 421, 423,
+	500, 501, 502, 503, 504, 505, 506, 507, 508, 509, 590, 591, 592, 593, 594, 595, 596, 597, 598, 599,
+	670, 672, 673, 674, 675, 676, 677, 678, 679, 680, 681, 682, 683, 685, 686, 687, 688, 689, 690, 691, 692,
+	800, 808, 850, 852, 853, 855, 856, 870, 878, 880, 881, 882, 883, 886, 888,
+	960, 961, 962, 963, 964, 965, 966, 967, 968, 970, 971, 972, 973, 974, 975, 976, 977, 979,
+	992, 993, 994, 995, 996, 998,
+}
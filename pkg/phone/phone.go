@@ -0,0 +1,306 @@
+// Package phone parses, validates and formats phone numbers against the numbering plans of a set of regions, the
+// numbers of the other country codes being accepted as they are. The numbering plans are held by an embedded metadata
+// table, no network access is needed.
+package phone
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalid       = errors.New("invalid phone number")
+	ErrUnknownRegion = errors.New("unknown phone region")
+)
+
+const (
+	// maxDigits is the maximum number of digits of an international number, https://en.wikipedia.org/wiki/E.164
+	maxDigits = 15
+	// minNationalDigits is the minimum number of digits of the national numbers without numbering plan
+	minNationalDigits = 4
+)
+
+// Type is the kind of line a number belongs to
+type Type string
+
+const (
+	TypeMobile Type = "mobile"
+	TypeFixed  Type = "fixed"
+	// TypeFixedOrMobile is the type of the numbers of the regions not telling mobile and fixed lines apart, like the
+	// North American ones
+	TypeFixedOrMobile Type = "fixed_or_mobile"
+	TypeTollFree      Type = "toll_free"
+	// TypeUnknown is the type of the numbers of the country codes without numbering plan
+	TypeUnknown Type = ""
+)
+
+// Number is a valid phone number
+type Number struct {
+	// Region is the ISO 3166-1 alpha-2 code of the region the number belongs to, empty for the numbers of the
+	// country codes without numbering plan
+	Region      string
+	CountryCode int
+	// NationalNumber is the national significant number, the digits dialed after the country code
+	NationalNumber string
+	Type           Type
+}
+
+type region struct {
+	code        string
+	countryCode int
+	// trunkPrefix is dialed before the national numbers within the region, it is not part of them
+	trunkPrefix string
+	ranges      []numberRange
+	formats     []format
+}
+
+// numberRange is a range of national significant numbers of a type, those starting with one of the prefixes and
+// having one of the lengths
+type numberRange struct {
+	numberType Type
+	prefixes   []string
+	lengths    []int
+}
+
+// format groups the digits of the national significant numbers starting with its prefix, its pattern has a # per
+// digit. The national formatted number is the pattern prefixed by the trunk prefix unless a national pattern is set.
+type format struct {
+	prefix   string
+	pattern  string
+	national string
+}
+
+var (
+	regions       = make(map[string]*region, len(metadata))
+	countryCodes  = make(map[int][]*region)
+	regionsSorted = make([]string, 0, len(metadata))
+	assigned      = make(map[int]bool, len(assignedCountryCodes))
+)
+
+func init() {
+	for _, countryCode := range assignedCountryCodes {
+		assigned[countryCode] = true
+	}
+
+	for i := range metadata {
+		r := &metadata[i]
+		regions[r.code] = r
+		countryCodes[r.countryCode] = append(countryCodes[r.countryCode], r)
+		regionsSorted = append(regionsSorted, r.code)
+	}
+	sort.Strings(regionsSorted)
+}
+
+// Regions returns the codes of the supported regions, sorted
+func Regions() []string {
+	return append([]string{}, regionsSorted...)
+}
+
+// IsRegion tells whether the region is supported, codes are case insensitive
+func IsRegion(code string) bool {
+	_, ok := regions[strings.ToUpper(code)]
+	return ok
+}
+
+// Parse reads a number written with or without separators (spaces, dots, dashes, slashes and parentheses).
+// Numbers starting with + or 00 are international ones, the others are national numbers of the default region,
+// their trunk prefix being optional. The number is checked against the numbering plan of its region, the
+// international numbers of the other assigned country codes are only checked for their length and have an unknown
+// type.
+func Parse(input string, defaultRegion string) (Number, error) {
+	digits, international, err := clean(input)
+	if err != nil {
+		return Number{}, err
+	}
+
+	if international {
+		for i := 1; i <= 3 && i < len(digits); i++ {
+			countryCode, _ := strconv.Atoi(digits[:i])
+			if rs, ok := countryCodes[countryCode]; ok {
+				return parse(input, countryCode, rs[0].trunkPrefix, digits[i:])
+			}
+			if assigned[countryCode] {
+				return parseUnknown(input, countryCode, digits[i:])
+			}
+		}
+
+		return Number{}, fmt.Errorf("%w: %s has an unknown country code", ErrInvalid, input)
+	}
+
+	if defaultRegion == "" {
+		return Number{}, fmt.Errorf("%w: %s is not international and no region is given", ErrInvalid, input)
+	}
+	r, ok := regions[strings.ToUpper(defaultRegion)]
+	if !ok {
+		return Number{}, fmt.Errorf("%w: %s", ErrUnknownRegion, defaultRegion)
+	}
+
+	return parse(input, r.countryCode, r.trunkPrefix, digits)
+}
+
+// IsInternational tells whether the input starts with an international prefix, + or 00, and so can be parsed
+// without region
+func IsInternational(input string) bool {
+	input = strings.TrimSpace(input)
+	return strings.HasPrefix(input, "+") || strings.HasPrefix(input, "00")
+}
+
+// E164 formats the number as +, the country code and the national significant number, the form numbers are stored in
+func (n Number) E164() string {
+	return "+" + strconv.Itoa(n.CountryCode) + n.NationalNumber
+}
+
+// National formats the number as it is dialed within its region, like 06 12 34 56 78 or (415) 555-0100, it is empty
+// for the numbers without region
+func (n Number) National() string {
+	r, ok := regions[n.Region]
+	if !ok {
+		return ""
+	}
+	f, ok := r.format(n.NationalNumber)
+	if !ok {
+		return r.trunkPrefix + n.NationalNumber
+	}
+	if f.national != "" {
+		return apply(f.national, n.NationalNumber)
+	}
+
+	return r.trunkPrefix + apply(f.pattern, n.NationalNumber)
+}
+
+// International formats the number as it is dialed from abroad, like +33 6 12 34 56 78 or +1 415-555-0100, the
+// national significant number of the numbers without region being left ungrouped
+func (n Number) International() string {
+	grouped := n.NationalNumber
+	if r, ok := regions[n.Region]; ok {
+		if f, ok := r.format(n.NationalNumber); ok {
+			grouped = apply(f.pattern, n.NationalNumber)
+		}
+	}
+
+	return "+" + strconv.Itoa(n.CountryCode) + " " + grouped
+}
+
+// parse finds the region of the country code the digits belong to, they are read without the trunk prefix when they
+// do not make a valid number with it
+func parse(input string, countryCode int, trunkPrefix string, digits string) (Number, error) {
+	if n, ok := resolve(countryCode, digits); ok {
+		return n, nil
+	}
+	if trunkPrefix != "" && strings.HasPrefix(digits, trunkPrefix) {
+		if n, ok := resolve(countryCode, strings.TrimPrefix(digits, trunkPrefix)); ok {
+			return n, nil
+		}
+	}
+
+	return Number{}, fmt.Errorf("%w: %s is not a number of +%d", ErrInvalid, input, countryCode)
+}
+
+// parseUnknown returns the number of a country code without numbering plan, its national significant number only
+// being checked for its length
+func parseUnknown(input string, countryCode int, nationalNumber string) (Number, error) {
+	if len(nationalNumber) < minNationalDigits {
+		return Number{}, fmt.Errorf("%w: %s is too short for +%d", ErrInvalid, input, countryCode)
+	}
+
+	return Number{CountryCode: countryCode, NationalNumber: nationalNumber, Type: TypeUnknown}, nil
+}
+
+// resolve returns the number of the national significant number. The regions sharing the country code with its main
+// region are tried first, a number belongs to them when it is in one of their ranges other than the toll free ones.
+func resolve(countryCode int, nationalNumber string) (Number, bool) {
+	rs := countryCodes[countryCode]
+	for _, r := range rs[1:] {
+		if t := r.numberType(nationalNumber); t != "" && t != TypeTollFree {
+			return Number{Region: r.code, CountryCode: countryCode, NationalNumber: nationalNumber, Type: t}, true
+		}
+	}
+
+	t := rs[0].numberType(nationalNumber)
+	return Number{Region: rs[0].code, CountryCode: countryCode, NationalNumber: nationalNumber, Type: t}, t != ""
+}
+
+// numberType returns the type of the first range of the region the number is in, an empty type when there is none
+func (r *region) numberType(nationalNumber string) Type {
+	for _, rg := range r.ranges {
+		if !containsInt(rg.lengths, len(nationalNumber)) {
+			continue
+		}
+		for _, prefix := range rg.prefixes {
+			if strings.HasPrefix(nationalNumber, prefix) {
+				return rg.numberType
+			}
+		}
+	}
+
+	return ""
+}
+
+// format returns the first format of the region whose prefix and number of digits match the number
+func (r *region) format(nationalNumber string) (format, bool) {
+	for _, f := range r.formats {
+		if strings.HasPrefix(nationalNumber, f.prefix) && strings.Count(f.pattern, "#") == len(nationalNumber) {
+			return f, true
+		}
+	}
+
+	return format{}, false
+}
+
+// clean returns the digits of the input and whether it is an international number
+func clean(input string) (string, bool, error) {
+	input = strings.TrimSpace(input)
+	international := strings.HasPrefix(input, "+")
+
+	var digits strings.Builder
+	for i, c := range input {
+		switch {
+		case c >= '0' && c <= '9':
+			digits.WriteRune(c)
+		case c == '+' && i == 0:
+		case strings.ContainsRune(" .-/()\u00a0", c):
+		default:
+			return "", false, fmt.Errorf("%w: %s has an unexpected character %q", ErrInvalid, input, c)
+		}
+	}
+
+	result := digits.String()
+	if !international && strings.HasPrefix(result, "00") {
+		international = true
+		result = result[2:]
+	}
+	if result == "" || len(result) > maxDigits {
+		return "", false, fmt.Errorf("%w: %s must have between 1 and %d digits", ErrInvalid, input, maxDigits)
+	}
+
+	return result, international, nil
+}
+
+// apply replaces the # of the pattern by the digits
+func apply(pattern string, digits string) string {
+	var result strings.Builder
+	i := 0
+	for _, c := range pattern {
+		if c == '#' {
+			result.WriteByte(digits[i])
+			i++
+			continue
+		}
+		result.WriteRune(c)
+	}
+
+	return result.String()
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,260 @@
+package phone
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		input         string
+		region        string
+		e164          string
+		numberRegion  string
+		numberType    Type
+		national      string
+		international string
+	}{
+		{
+			name:          "international french mobile",
+			input:         "+33 6 12 34 56 78",
+			e164:          "+33612345678",
+			numberRegion:  "FR",
+			numberType:    TypeMobile,
+			national:      "06 12 34 56 78",
+			international: "+33 6 12 34 56 78",
+		},
+		{
+			name:          "00 international prefix",
+			input:         "0033 1 23 45 67 89",
+			e164:          "+33123456789",
+			numberRegion:  "FR",
+			numberType:    TypeFixed,
+			national:      "01 23 45 67 89",
+			international: "+33 1 23 45 67 89",
+		},
+		{
+			name:          "national number in the default region",
+			input:         "06.12.34.56.78",
+			region:        "fr",
+			e164:          "+33612345678",
+			numberRegion:  "FR",
+			numberType:    TypeMobile,
+			national:      "06 12 34 56 78",
+			international: "+33 6 12 34 56 78",
+		},
+		{
+			name:          "national number without trunk prefix",
+			input:         "612345678",
+			region:        "FR",
+			e164:          "+33612345678",
+			numberRegion:  "FR",
+			numberType:    TypeMobile,
+			national:      "06 12 34 56 78",
+			international: "+33 6 12 34 56 78",
+		},
+		{
+			name:          "international number in another default region",
+			input:         "+44 20 7946 0018",
+			region:        "FR",
+			e164:          "+442079460018",
+			numberRegion:  "GB",
+			numberType:    TypeFixed,
+			national:      "020 7946 0018",
+			international: "+44 20 7946 0018",
+		},
+		{
+			name:          "north american number",
+			input:         "(415) 555-0100",
+			region:        "US",
+			e164:          "+14155550100",
+			numberRegion:  "US",
+			numberType:    TypeFixedOrMobile,
+			national:      "(415) 555-0100",
+			international: "+1 415-555-0100",
+		},
+		{
+			name:          "region sharing its country code",
+			input:         "+1 416 555 0100",
+			e164:          "+14165550100",
+			numberRegion:  "CA",
+			numberType:    TypeFixedOrMobile,
+			national:      "(416) 555-0100",
+			international: "+1 416-555-0100",
+		},
+		{
+			name:          "toll free number of the main region",
+			input:         "1-800-555-0100",
+			region:        "CA",
+			e164:          "+18005550100",
+			numberRegion:  "US",
+			numberType:    TypeTollFree,
+			national:      "(800) 555-0100",
+			international: "+1 800-555-0100",
+		},
+		{
+			name:          "region without trunk prefix",
+			input:         "612 34 56 78",
+			region:        "ES",
+			e164:          "+34612345678",
+			numberRegion:  "ES",
+			numberType:    TypeMobile,
+			national:      "612 34 56 78",
+			international: "+34 612 34 56 78",
+		},
+		{
+			name:          "italian fixed number keeping its leading zero",
+			input:         "06 1234 5678",
+			region:        "IT",
+			e164:          "+390612345678",
+			numberRegion:  "IT",
+			numberType:    TypeFixed,
+			national:      "06 1234 5678",
+			international: "+39 06 1234 5678",
+		},
+		{
+			name:          "german mobile number",
+			input:         "0151 23456789",
+			region:        "DE",
+			e164:          "+4915123456789",
+			numberRegion:  "DE",
+			numberType:    TypeMobile,
+			national:      "0151 23456789",
+			international: "+49 151 23456789",
+		},
+		{
+			name:          "belgian mobile number",
+			input:         "0470 12 34 56",
+			region:        "BE",
+			e164:          "+32470123456",
+			numberRegion:  "BE",
+			numberType:    TypeMobile,
+			national:      "0470 12 34 56",
+			international: "+32 470 12 34 56",
+		},
+		{
+			name:          "swiss mobile number",
+			input:         "078 123 45 67",
+			region:        "CH",
+			e164:          "+41781234567",
+			numberRegion:  "CH",
+			numberType:    TypeMobile,
+			national:      "078 123 45 67",
+			international: "+41 78 123 45 67",
+		},
+		{
+			name:          "japanese number without numbering plan",
+			input:         "+81 3-1234-5678",
+			e164:          "+81312345678",
+			numberType:    TypeUnknown,
+			international: "+81 312345678",
+		},
+		{
+			name:          "australian number without numbering plan",
+			input:         "+61 2 9876 5432",
+			region:        "FR",
+			e164:          "+61298765432",
+			numberType:    TypeUnknown,
+			international: "+61 298765432",
+		},
+		{
+			name:          "indian number without numbering plan",
+			input:         "0091 98765 43210",
+			e164:          "+919876543210",
+			numberType:    TypeUnknown,
+			international: "+91 9876543210",
+		},
+		{
+			name:          "three digits country code without numbering plan",
+			input:         "+352 621 123 456",
+			e164:          "+352621123456",
+			numberType:    TypeUnknown,
+			international: "+352 621123456",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			n, err := Parse(tc.input, tc.region)
+			require.NoError(t, err)
+			assert.Equal(t, tc.e164, n.E164())
+			assert.Equal(t, tc.numberRegion, n.Region)
+			assert.Equal(t, tc.numberType, n.Type)
+			assert.Equal(t, tc.national, n.National())
+			assert.Equal(t, tc.international, n.International())
+
+			parsed, err := Parse(n.E164(), "")
+			require.NoError(t, err)
+			assert.Equal(t, n, parsed, "the E.164 form is parsed into the same number")
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		input  string
+		region string
+		err    error
+	}{
+		{name: "national number without region", input: "06 12 34 56 78", err: ErrInvalid},
+		{name: "unsupported default region", input: "03-1234-5678", region: "JP", err: ErrUnknownRegion},
+		{name: "unexpected character", input: "+33 6 12 34 56 7x", err: ErrInvalid},
+		{name: "no digits", input: "+", err: ErrInvalid},
+		{name: "too many digits", input: "+33 6 12 34 56 78 90 12 34", err: ErrInvalid},
+		{name: "unassigned country code", input: "+999 123 456 789", err: ErrInvalid},
+		{name: "too short without numbering plan", input: "+81 123", err: ErrInvalid},
+		{name: "out of the numbering plan", input: "+33 0 12 34 56 78", err: ErrInvalid},
+		{name: "wrong length", input: "06 12 34 56", region: "FR", err: ErrInvalid},
+		{name: "north american area code outside of the regions", input: "+1 123 555 0100", err: ErrInvalid},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Parse(tc.input, tc.region)
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestRegions(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"BE", "CA", "CH", "DE", "ES", "FR", "GB", "IT", "US"}, Regions())
+	assert.True(t, IsRegion("fr"))
+	assert.False(t, IsRegion("JP"))
+	assert.True(t, IsInternational(" +81 3 1234 5678"))
+	assert.True(t, IsInternational("0081 3 1234 5678"))
+	assert.False(t, IsInternational("03 1234 5678"))
+}
+
+func TestAssignedCountryCodes(t *testing.T) {
+	t.Parallel()
+
+	for _, r := range metadata {
+		assert.True(t, assigned[r.countryCode], "country code %d of %s", r.countryCode, r.code)
+	}
+	for _, code := range assignedCountryCodes {
+		for _, other := range assignedCountryCodes {
+			if code == other {
+				continue
+			}
+			assert.False(t, strings.HasPrefix(strconv.Itoa(other), strconv.Itoa(code)), "%d is a prefix of %d", code, other)
+		}
+	}
+}
@@ -0,0 +1,379 @@
+// Package vcard reads and writes vCard 3.0 (RFC 2426) and 4.0 (RFC 6350) cards
+package vcard
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	Version3 = "3.0"
+	Version4 = "4.0"
+
+	// MediaType of the vCard files
+	MediaType = "text/vcard"
+
+	// lineLength is the number of octets after which the lines are folded
+	lineLength = 75
+)
+
+var (
+	ErrInvalid            = errors.New("invalid vcard")
+	ErrUnsupportedVersion = errors.New("unsupported vcard version")
+)
+
+// Property is a content line of a card, its name and parameter names are upper case
+type Property struct {
+	Name   string
+	Params map[string][]string
+	// Value is escaped as written in the card, see Text and Components
+	Value string
+}
+
+// NewText returns a property holding a text value
+func NewText(name string, value string) Property {
+	return Property{Name: name, Value: escape(value)}
+}
+
+// NewStructured returns a property holding a list of text components separated by semicolons, like N
+func NewStructured(name string, components ...string) Property {
+	escaped := make([]string, 0, len(components))
+	for _, component := range components {
+		escaped = append(escaped, escape(component))
+	}
+
+	return Property{Name: name, Value: strings.Join(escaped, ";")}
+}
+
+// WithParam returns a copy of the property with the given parameter
+func (p Property) WithParam(name string, values ...string) Property {
+	params := make(map[string][]string, len(p.Params)+1)
+	for k, v := range p.Params {
+		params[k] = v
+	}
+	params[strings.ToUpper(name)] = values
+	p.Params = params
+
+	return p
+}
+
+// HasParam tells whether one of the values of the parameter is the given one, ignoring case
+func (p Property) HasParam(name string, value string) bool {
+	for _, v := range p.Params[strings.ToUpper(name)] {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Text returns the unescaped value
+func (p Property) Text() string {
+	return unescape(p.Value)
+}
+
+// Components returns the unescaped components of a structured value
+func (p Property) Components() []string {
+	var (
+		components []string
+		current    strings.Builder
+		escaped    bool
+	)
+	for _, r := range p.Value {
+		switch {
+		case escaped:
+			current.WriteRune('\\')
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ';':
+			components = append(components, unescape(current.String()))
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	return append(components, unescape(current.String()))
+}
+
+// Card is a vCard, VERSION, BEGIN and END are not part of its properties
+type Card struct {
+	Version    string
+	Properties []Property
+}
+
+// Get returns the first property with the given name
+func (c Card) Get(name string) (Property, bool) {
+	name = strings.ToUpper(name)
+	for _, p := range c.Properties {
+		if p.Name == name {
+			return p, true
+		}
+	}
+
+	return Property{}, false
+}
+
+// All returns the properties with the given name, in the order of the card
+func (c Card) All(name string) []Property {
+	name = strings.ToUpper(name)
+	var properties []Property
+	for _, p := range c.Properties {
+		if p.Name == name {
+			properties = append(properties, p)
+		}
+	}
+
+	return properties
+}
+
+// Result is a card read from a file, or the error making it unreadable
+type Result struct {
+	Card Card
+	Err  error
+}
+
+// Decode reads all the cards of a vCard file, a malformed card being returned as an error in its result without
+// stopping the decoding of the next ones. Consecutive lines outside of the cards are returned as a single failed
+// result. It only fails when the file cannot be read.
+func Decode(r io.Reader) ([]Result, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		results []Result
+		card    *Card
+		// cardErr is the first error of the current card
+		cardErr error
+		// outside tells the previous line was outside of a card
+		outside bool
+	)
+	for _, line := range lines {
+		if strings.TrimSpace(line.text) == "" {
+			continue
+		}
+
+		p, err := parseLine(line.text)
+		switch {
+		case err == nil && p.Name == "BEGIN" && strings.EqualFold(p.Value, "VCARD"):
+			if card != nil {
+				results = append(results, Result{Err: fmt.Errorf("line %d: %w: card is not ended", line.number, ErrInvalid)})
+			}
+			card, cardErr, outside = &Card{}, nil, false
+		case card == nil:
+			if !outside {
+				results = append(results, Result{Err: fmt.Errorf("line %d: %w: property outside of a card", line.number, ErrInvalid)})
+				outside = true
+			}
+		case err != nil:
+			if cardErr == nil {
+				cardErr = fmt.Errorf("line %d: %w", line.number, err)
+			}
+		case p.Name == "END" && strings.EqualFold(p.Value, "VCARD"):
+			if cardErr == nil && card.Version != Version3 && card.Version != Version4 {
+				cardErr = fmt.Errorf("line %d: %w: %q", line.number, ErrUnsupportedVersion, card.Version)
+			}
+			if cardErr != nil {
+				results = append(results, Result{Err: cardErr})
+			} else {
+				results = append(results, Result{Card: *card})
+			}
+			card = nil
+		case p.Name == "VERSION":
+			card.Version = p.Value
+		default:
+			card.Properties = append(card.Properties, p)
+		}
+	}
+	if card != nil {
+		results = append(results, Result{Err: fmt.Errorf("%w: card is not ended", ErrInvalid)})
+	}
+
+	return results, nil
+}
+
+// Encoder writes cards with CRLF line endings, folding the long lines
+type Encoder struct {
+	w io.Writer
+}
+
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+func (e *Encoder) Encode(card Card) error {
+	var b strings.Builder
+	writeLine(&b, "BEGIN:VCARD")
+	writeLine(&b, "VERSION:"+card.Version)
+	for _, p := range card.Properties {
+		writeLine(&b, formatLine(p))
+	}
+	writeLine(&b, "END:VCARD")
+
+	_, err := io.WriteString(e.w, b.String())
+	return err
+}
+
+// line is an unfolded content line and the number of the line it starts on
+type line struct {
+	text   string
+	number int
+}
+
+// unfold joins the lines starting with a space or a tab to the previous one, the lines are not limited in length
+func unfold(r io.Reader) ([]line, error) {
+	var (
+		lines  []line
+		reader = bufio.NewReader(r)
+	)
+	for number := 1; ; number++ {
+		text, err := reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalid, err)
+		}
+		if text == "" && err != nil {
+			return lines, nil
+		}
+
+		text = strings.TrimSuffix(strings.TrimSuffix(text, "\n"), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) {
+			lines[len(lines)-1].text += text[1:]
+		} else {
+			lines = append(lines, line{text: text, number: number})
+		}
+		if err != nil {
+			return lines, nil
+		}
+	}
+}
+
+// parseLine reads a content line: [group.]name *(;param[=value *(,value)]) : value
+func parseLine(line string) (Property, error) {
+	colon := indexUnquoted(line, ':')
+	if colon < 0 {
+		return Property{}, fmt.Errorf("%w: missing value separator", ErrInvalid)
+	}
+
+	parts := splitUnquoted(line[:colon], ';')
+	name := parts[0]
+	if dot := strings.LastIndex(name, "."); dot >= 0 {
+		name = name[dot+1:]
+	}
+	if name == "" {
+		return Property{}, fmt.Errorf("%w: missing property name", ErrInvalid)
+	}
+
+	p := Property{Name: strings.ToUpper(name), Value: line[colon+1:]}
+	for _, param := range parts[1:] {
+		if p.Params == nil {
+			p.Params = map[string][]string{}
+		}
+
+		key, value, ok := strings.Cut(param, "=")
+		if !ok {
+			// vCard 2.1 style parameters only hold a type
+			key, value = "TYPE", param
+		}
+		key = strings.ToUpper(key)
+		for _, v := range splitUnquoted(value, ',') {
+			p.Params[key] = append(p.Params[key], strings.Trim(v, `"`))
+		}
+	}
+
+	return p, nil
+}
+
+func formatLine(p Property) string {
+	var b strings.Builder
+	b.WriteString(p.Name)
+	for _, key := range sortedKeys(p.Params) {
+		b.WriteString(";" + key + "=")
+		for i, value := range p.Params[key] {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			if strings.ContainsAny(value, ":;,") {
+				value = `"` + value + `"`
+			}
+			b.WriteString(value)
+		}
+	}
+	b.WriteString(":" + p.Value)
+
+	return b.String()
+}
+
+// writeLine folds the line every lineLength octets without splitting the UTF-8 characters
+func writeLine(b *strings.Builder, line string) {
+	limit := lineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		// the leading space of the continuation lines counts in their length
+		limit = lineLength - 1
+	}
+	b.WriteString(line + "\r\n")
+}
+
+func sortedKeys(params map[string][]string) []string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func indexUnquoted(s string, sep byte) int {
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '"':
+			quoted = !quoted
+		case s[i] == sep && !quoted:
+			return i
+		}
+	}
+
+	return -1
+}
+
+func splitUnquoted(s string, sep byte) []string {
+	var parts []string
+	for {
+		i := indexUnquoted(s, sep)
+		if i < 0 {
+			return append(parts, s)
+		}
+		parts = append(parts, s[:i])
+		s = s[i+1:]
+	}
+}
+
+var (
+	escaper   = strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\r\n", `\n`, "\n", `\n`)
+	unescaper = strings.NewReplacer(`\\`, `\`, `\,`, ",", `\;`, ";", `\n`, "\n", `\N`, "\n")
+)
+
+func escape(value string) string {
+	return escaper.Replace(value)
+}
+
+func unescape(value string) string {
+	return unescaper.Replace(value)
+}
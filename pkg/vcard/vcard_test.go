@@ -0,0 +1,217 @@
+package vcard
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unfold the continuation lines", func(t *testing.T) {
+		t.Parallel()
+
+		results, err := Decode(strings.NewReader(
+			"BEGIN:VCARD\r\nVERSION:4.0\r\nNOTE:a long\r\n  note written\r\n\t on three lines\r\nEND:VCARD\r\n",
+		))
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.NoError(t, results[0].Err)
+
+		note, ok := results[0].Card.Get("note")
+		require.True(t, ok)
+		assert.Equal(t, "a long note written on three lines", note.Text())
+	})
+
+	t.Run("read the lines longer than the scanner buffer", func(t *testing.T) {
+		t.Parallel()
+
+		photo := strings.Repeat("A", 100*1024)
+		results, err := Decode(strings.NewReader(
+			"BEGIN:VCARD\nVERSION:3.0\nPHOTO;ENCODING=b;TYPE=JPEG:" + photo + "\nEND:VCARD",
+		))
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.NoError(t, results[0].Err)
+
+		p, ok := results[0].Card.Get("PHOTO")
+		require.True(t, ok)
+		assert.Equal(t, photo, p.Value)
+	})
+
+	t.Run("read the groups, parameters and multiple values", func(t *testing.T) {
+		t.Parallel()
+
+		results, err := Decode(strings.NewReader(
+			"BEGIN:VCARD\r\n" +
+				"VERSION:3.0\r\n" +
+				"item1.EMAIL;type=INTERNET,pref:jdoe@contact.local\r\n" +
+				"TEL;HOME:+33612345678\r\n" +
+				"ADR;LABEL=\"1 rue de Paris;Paris\":;;1 rue de Paris;Paris;;75001;France\r\n" +
+				"CATEGORIES:friends,work\\,colleagues\r\n" +
+				"EMAIL:john@contact.local\r\n" +
+				"END:VCARD\r\n",
+		))
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.NoError(t, results[0].Err)
+		card := results[0].Card
+
+		email, ok := card.Get("EMAIL")
+		require.True(t, ok)
+		assert.Equal(t, "jdoe@contact.local", email.Text())
+		assert.Equal(t, map[string][]string{"TYPE": {"INTERNET", "pref"}}, email.Params)
+		assert.True(t, email.HasParam("type", "PREF"))
+		assert.False(t, email.HasParam("TYPE", "home"))
+
+		tel, ok := card.Get("TEL")
+		require.True(t, ok)
+		assert.Equal(t, map[string][]string{"TYPE": {"HOME"}}, tel.Params)
+
+		adr, ok := card.Get("ADR")
+		require.True(t, ok)
+		assert.Equal(t, []string{"1 rue de Paris;Paris"}, adr.Params["LABEL"])
+		assert.Equal(t, []string{"", "", "1 rue de Paris", "Paris", "", "75001", "France"}, adr.Components())
+
+		categories, ok := card.Get("CATEGORIES")
+		require.True(t, ok)
+		assert.Equal(t, "friends,work,colleagues", categories.Text())
+
+		var emails []string
+		for _, p := range card.All("email") {
+			emails = append(emails, p.Text())
+		}
+		assert.Equal(t, []string{"jdoe@contact.local", "john@contact.local"}, emails)
+	})
+
+	t.Run("report the malformed cards and read the others", func(t *testing.T) {
+		t.Parallel()
+
+		results, err := Decode(strings.NewReader(
+			"BEGIN:VCARD\r\nVERSION:4.0\r\nFN:John Doe\r\nEND:VCARD\r\n" +
+				"BEGIN:VCARD\r\nVERSION:2.1\r\nFN:Jane Doe\r\nEND:VCARD\r\n" +
+				"BEGIN:VCARD\r\nVERSION:4.0\r\nno separator\r\nEND:VCARD\r\n" +
+				"stray\r\nlines\r\n" +
+				"BEGIN:VCARD\r\nVERSION:3.0\r\nFN:Jack Doe\r\nEND:VCARD\r\n" +
+				"BEGIN:VCARD\r\nVERSION:4.0\r\nFN:Jim Doe\r\n",
+		))
+		require.NoError(t, err)
+		require.Len(t, results, 6)
+
+		fn, _ := results[0].Card.Get("FN")
+		assert.Equal(t, "John Doe", fn.Text())
+		assert.ErrorIs(t, results[1].Err, ErrUnsupportedVersion)
+		assert.EqualError(t, results[1].Err, `line 8: unsupported vcard version: "2.1"`)
+		assert.ErrorIs(t, results[2].Err, ErrInvalid)
+		assert.EqualError(t, results[2].Err, "line 11: invalid vcard: missing value separator")
+		assert.EqualError(t, results[3].Err, "line 13: invalid vcard: property outside of a card")
+		fn, _ = results[4].Card.Get("FN")
+		assert.Equal(t, "Jack Doe", fn.Text())
+		assert.EqualError(t, results[5].Err, "invalid vcard: card is not ended")
+	})
+
+	t.Run("report a card not ended before the next one", func(t *testing.T) {
+		t.Parallel()
+
+		results, err := Decode(strings.NewReader(
+			"BEGIN:VCARD\r\nVERSION:4.0\r\nFN:John Doe\r\nBEGIN:VCARD\r\nVERSION:4.0\r\nFN:Jane Doe\r\nEND:VCARD\r\n",
+		))
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+
+		assert.EqualError(t, results[0].Err, "line 4: invalid vcard: card is not ended")
+		fn, _ := results[1].Card.Get("FN")
+		assert.Equal(t, "Jane Doe", fn.Text())
+	})
+}
+
+func TestEncode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fold the long lines without splitting the characters", func(t *testing.T) {
+		t.Parallel()
+
+		var b bytes.Buffer
+		note := strings.Repeat("é", 100)
+		err := NewEncoder(&b).Encode(Card{Version: Version4, Properties: []Property{NewText("NOTE", note)}})
+		require.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+		require.Len(t, lines, 6)
+		for i, line := range lines {
+			assert.LessOrEqual(t, len(line), lineLength)
+			assert.True(t, strings.ToValidUTF8(line, "") == line, "line %d splits a character", i+1)
+		}
+		assert.Equal(t, " ", lines[3][:1])
+	})
+
+	t.Run("escape the text values", func(t *testing.T) {
+		t.Parallel()
+
+		var b bytes.Buffer
+		err := NewEncoder(&b).Encode(Card{
+			Version: Version3,
+			Properties: []Property{
+				NewText("NOTE", "a; b, c\\d\r\ne"),
+				NewStructured("N", "Doe;Smith", "John", "", "", ""),
+				NewText("EMAIL", "jdoe@contact.local").WithParam("type", "work", "pref:1"),
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(
+			t,
+			"BEGIN:VCARD\r\n"+
+				"VERSION:3.0\r\n"+
+				"NOTE:a\\; b\\, c\\\\d\\ne\r\n"+
+				"N:Doe\\;Smith;John;;;\r\n"+
+				"EMAIL;TYPE=work,\"pref:1\":jdoe@contact.local\r\n"+
+				"END:VCARD\r\n",
+			b.String(),
+		)
+	})
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, version := range []string{Version3, Version4} {
+		version := version
+		t.Run(version, func(t *testing.T) {
+			t.Parallel()
+
+			card := Card{
+				Version: version,
+				Properties: []Property{
+					NewText("FN", "Jöhn Doe"),
+					NewStructured("N", "Doe", "Jöhn", "", "Dr.", ""),
+					NewText("NOTE", strings.Repeat("a note; with, special\\characters\n", 10)),
+					NewText("EMAIL", "jdoe@contact.local").WithParam("TYPE", "work", "pref"),
+					NewText("EMAIL", "john@contact.local").WithParam("TYPE", "home"),
+					NewText("TEL", "tel:+33612345678").WithParam("VALUE", "uri"),
+				},
+			}
+
+			var b bytes.Buffer
+			require.NoError(t, NewEncoder(&b).Encode(card))
+			require.NoError(t, NewEncoder(&b).Encode(card))
+
+			results, err := Decode(&b)
+			require.NoError(t, err)
+			require.Len(t, results, 2)
+			for _, result := range results {
+				require.NoError(t, result.Err)
+				assert.Equal(t, card, result.Card)
+			}
+
+			n, _ := results[0].Card.Get("N")
+			assert.Equal(t, []string{"Doe", "Jöhn", "", "Dr.", ""}, n.Components())
+			note, _ := results[0].Card.Get("NOTE")
+			assert.Equal(t, strings.Repeat("a note; with, special\\characters\n", 10), note.Text())
+		})
+	}
+}
@@ -4,48 +4,77 @@ import (
 	"context"
 
 	"github.com/davidterranova/contacts/pkg/auth"
+	"github.com/davidterranova/contacts/pkg/user"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 )
 
+type authFn func(authToken string) (user.User, error)
+
 func BasicAuthMiddleware(username string, password string) grpc.UnaryServerInterceptor {
-	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		meta, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, auth.ErrUnauthorized
-		}
+	return unaryAuth(auth.BasicAuth(username, password))
+}
 
-		authMetadata := meta.Get("Authorization")
-		if len(authMetadata) == 0 {
-			return nil, auth.ErrUnauthorized
-		}
+func BasicAuthStreamMiddleware(username string, password string) grpc.StreamServerInterceptor {
+	return streamAuth(auth.BasicAuth(username, password))
+}
 
-		user, err := auth.BasicAuth(username, password)(authMetadata[0])
-		if err != nil {
-			return nil, auth.ErrUnauthorized
-		}
+func GrantAnyFn() grpc.UnaryServerInterceptor {
+	return unaryAuth(auth.GrantAnyAccess())
+}
 
-		return handler(auth.ContextWithUser(ctx, user), req)
-	}
+func GrantAnyStreamFn() grpc.StreamServerInterceptor {
+	return streamAuth(auth.GrantAnyAccess())
 }
 
-func GrantAnyFn() grpc.UnaryServerInterceptor {
+func unaryAuth(fn authFn) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		meta, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, auth.ErrUnauthorized
+		ctx, err := authenticate(ctx, fn)
+		if err != nil {
+			return nil, err
 		}
 
-		authMetadata := meta.Get("Authorization")
-		if len(authMetadata) == 0 {
-			return nil, auth.ErrUnauthorized
-		}
+		return handler(ctx, req)
+	}
+}
 
-		user, err := auth.GrantAnyAccess()(authMetadata[0])
+func streamAuth(fn authFn) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(stream.Context(), fn)
 		if err != nil {
-			return nil, auth.ErrUnauthorized
+			return err
 		}
 
-		return handler(auth.ContextWithUser(ctx, user), req)
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authenticate adds the user authenticated by the Authorization metadata to the context
+func authenticate(ctx context.Context, fn authFn) (context.Context, error) {
+	meta, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, auth.ErrUnauthorized
+	}
+
+	authMetadata := meta.Get("Authorization")
+	if len(authMetadata) == 0 {
+		return nil, auth.ErrUnauthorized
 	}
+
+	user, err := fn(authMetadata[0])
+	if err != nil {
+		return nil, auth.ErrUnauthorized
+	}
+
+	return auth.ContextWithUser(ctx, user), nil
+}
+
+// authenticatedStream overrides the stream context with the one holding the authenticated user
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
 }
@@ -0,0 +1,98 @@
+package xhttp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultHeartbeatInterval between the comments keeping an idle event stream open
+const DefaultHeartbeatInterval = 15 * time.Second
+
+// EventStream writes server-sent events. The stream is exempted from the server WriteTimeout, which is shorter than
+// the heartbeat interval, each write being given DefaultWriteTimeout to complete instead
+type EventStream struct {
+	w          http.ResponseWriter
+	controller *http.ResponseController
+}
+
+// NewEventStream starts an event stream response, the headers are flushed at once so that the client
+// knows the stream is open before the first event
+func NewEventStream(w http.ResponseWriter) (*EventStream, error) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// disables the response buffering of nginx like reverse proxies
+	w.Header().Set("X-Accel-Buffering", "no")
+
+	s := &EventStream{
+		w:          w,
+		controller: http.NewResponseController(w),
+	}
+	err := s.setWriteDeadline(time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	w.WriteHeader(http.StatusOK)
+	err = s.controller.Flush()
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Event writes an event, the id is sent back by the client in the Last-Event-ID header when it reconnects
+func (s *EventStream) Event(id string, name string, data []byte) error {
+	var b strings.Builder
+	if id != "" {
+		fmt.Fprintf(&b, "id: %s\n", id)
+	}
+	if name != "" {
+		fmt.Fprintf(&b, "event: %s\n", name)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteString("\n")
+
+	return s.write(b.String())
+}
+
+// Heartbeat writes a comment, ignored by the clients, to keep the connection open
+func (s *EventStream) Heartbeat() error {
+	return s.write(": heartbeat\n\n")
+}
+
+func (s *EventStream) write(message string) error {
+	err := s.setWriteDeadline(time.Now().Add(DefaultWriteTimeout))
+	if err != nil {
+		return err
+	}
+
+	_, err = s.w.Write([]byte(message))
+	if err != nil {
+		return err
+	}
+
+	err = s.controller.Flush()
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+
+	// the idle time until the next write does not count
+	return s.setWriteDeadline(time.Time{})
+}
+
+// setWriteDeadline sets the connection write deadline, the zero time meaning no deadline
+func (s *EventStream) setWriteDeadline(deadline time.Time) error {
+	err := s.controller.SetWriteDeadline(deadline)
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+
+	return nil
+}